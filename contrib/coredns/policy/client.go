@@ -1,11 +1,13 @@
 package policy
 
 import (
+	"context"
 	"sync/atomic"
 
 	"github.com/coredns/coredns/plugin/pkg/trace"
 	"github.com/infobloxopen/themis/pdp"
 	"github.com/infobloxopen/themis/pep"
+	"github.com/infobloxopen/themis/tracing"
 )
 
 type pepCacheHitHandler struct{}
@@ -55,7 +57,15 @@ func (p *policyPlugin) connect() error {
 		opts = append(opts, pep.WithMaxRequestSize(uint32(p.conf.maxReqSize)))
 	}
 
-	if p.trace != nil {
+	if len(p.conf.otlp) > 0 {
+		t, err := tracing.NewOTLPTracer("CoreDNS", p.conf.otlp)
+		if err != nil {
+			return err
+		}
+
+		p.tracer = t
+		opts = append(opts, pep.WithTracer(t))
+	} else if p.trace != nil {
 		if t, ok := p.trace.(trace.Trace); ok {
 			opts = append(opts, pep.WithTracer(t.Tracer()))
 		}
@@ -75,11 +85,17 @@ func (p *policyPlugin) closeConn() {
 		go func() {
 			p.wg.Wait()
 			p.pdp.Close()
+
+			if p.tracer != nil {
+				if err := p.tracer.Close(); err != nil {
+					log.Errorf("Failed to close tracer: %s", err)
+				}
+			}
 		}()
 	}
 }
 
-func (p *policyPlugin) validate(buf []pdp.AttributeAssignment, ah *attrHolder, lt int, dbg *dbgMessenger) (bool, error) {
+func (p *policyPlugin) validate(ctx context.Context, buf []pdp.AttributeAssignment, ah *attrHolder, lt int, dbg *dbgMessenger) (bool, error) {
 	req := ah.attrList(buf, lt)
 	if len(req) <= 0 {
 		return true, nil
@@ -96,7 +112,7 @@ func (p *policyPlugin) validate(buf []pdp.AttributeAssignment, ah *attrHolder, l
 	}
 
 	res := pdp.Response{Obligations: respBuffer}
-	err := p.pdp.Validate(req, &res)
+	err := p.validateContext(ctx, req, &res)
 
 	ah.resetAttribute(attrIndexPolicyAction)
 	ah.resetAttribute(attrIndexRedirectTo)
@@ -187,3 +203,11 @@ func (p *policyPlugin) connStateCb(addr string, state int, err error) {
 		}
 	}
 }
+
+func (p *policyPlugin) validateContext(ctx context.Context, in, out interface{}) error {
+	if c, ok := p.pdp.(pep.ContextClient); ok {
+		return c.ValidateContext(ctx, in, out)
+	}
+
+	return p.pdp.Validate(in, out)
+}
@@ -31,6 +31,7 @@ type config struct {
 	log           bool
 	cacheTTL      time.Duration
 	cacheLimit    int
+	otlp          string
 }
 
 func newConfig() config {
@@ -118,6 +119,9 @@ func (conf *config) parseOption(c *caddy.Controller) error {
 
 	case "own_ip_endpoint":
 		return conf.parseOwnIPEndpoint(c)
+
+	case "otlp":
+		return conf.parseOTLP(c)
 	}
 
 	return errInvalidOption
@@ -395,3 +399,14 @@ func (conf *config) parseOwnIPEndpoint(c *caddy.Controller) error {
 	conf.ownIPEndpoint = args[0]
 	return nil
 }
+
+// parseOTLP parses address of OpenTelemetry collector to send spans to.
+func (conf *config) parseOTLP(c *caddy.Controller) error {
+	args := c.RemainingArgs()
+	if len(args) != 1 {
+		return c.ArgErr()
+	}
+
+	conf.otlp = args[0]
+	return nil
+}
@@ -32,6 +32,7 @@ func TestPolicyConfigParse(t *testing.T) {
 		maxResAttrs  *int
 		cacheTTL     *time.Duration
 		cacheLimit   *int
+		otlp         *string
 	}{
 		{
 			desc: "MissingPolicySection",
@@ -693,6 +694,26 @@ func TestPolicyConfigParse(t *testing.T) {
 			cacheTTL:   newDurationPtr(15 * time.Second),
 			cacheLimit: newIntPtr(128),
 		},
+		{
+			desc: "OTLP",
+			input: `.:53 {
+						policy {
+							endpoint 10.2.4.1:5555
+							otlp 127.0.0.1:4317
+						}
+					}`,
+			otlp: newStringPtr("127.0.0.1:4317"),
+		},
+		{
+			desc: "NoOTLPArguments",
+			input: `.:53 {
+						policy {
+							endpoint 10.2.4.1:5555
+							otlp
+						}
+					}`,
+			err: errors.New("Wrong argument count or unexpected line ending"),
+		},
 		{
 			desc: "TooManyCacheArguments",
 			input: `.:53 {
@@ -892,6 +913,10 @@ func TestPolicyConfigParse(t *testing.T) {
 					if test.cacheLimit != nil && *test.cacheLimit != mw.conf.cacheLimit {
 						t.Errorf("Expected cache limit %d but got %d", *test.cacheLimit, mw.conf.cacheLimit)
 					}
+
+					if test.otlp != nil && *test.otlp != mw.conf.otlp {
+						t.Errorf("Expected OTLP endpoint %q but got %q", *test.otlp, mw.conf.otlp)
+					}
 				}
 			}
 		})
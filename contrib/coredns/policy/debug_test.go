@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"testing"
@@ -228,7 +229,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		}
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeSuccess)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -265,7 +266,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		}
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeNameError)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -302,7 +303,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		}
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeServerFailure)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -339,7 +340,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		}
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, -1)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -377,7 +378,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		}
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		ah.addAddressAttr(net.ParseIP("2001:db8::1"))
 		mpc.Out = []pdp.AttributeAssignment{
@@ -385,7 +386,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 			pdp.MakeStringAssignment(attrNameRedirectTo, "192.0.2.54"),
 		}
 
-		p.validate(nil, ah, attrListTypeVal2, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal2, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeSuccess)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -422,7 +423,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		mpc.Err = fmt.Errorf("test PDP error")
 		mpc.Effect = pdp.EffectPermit
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeSuccess)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -458,7 +459,7 @@ func TestSetDebugQueryAnswer(t *testing.T) {
 		mpc.Status = fmt.Errorf("test PDP error")
 		mpc.Effect = pdp.EffectIndeterminate
 
-		p.validate(nil, ah, attrListTypeVal1, dm)
+		p.validate(context.Background(), nil, ah, attrListTypeVal1, dm)
 
 		dm.setDebugQueryAnswer(m, dns.RcodeSuccess)
 		testutil.AssertDNSMessage(t, "setDebugQueryAnswer", 0, m, 0,
@@ -9,8 +9,10 @@ import (
 	"github.com/coredns/coredns/plugin"
 	"github.com/coredns/coredns/plugin/pkg/nonwriter"
 	"github.com/miekg/dns"
+	ot "github.com/opentracing/opentracing-go"
 
 	"github.com/infobloxopen/themis/pep"
+	"github.com/infobloxopen/themis/tracing"
 )
 
 var errInvalidAction = errors.New("invalid action")
@@ -23,6 +25,7 @@ type policyPlugin struct {
 	trace           plugin.Handler
 	next            plugin.Handler
 	pdp             pep.Client
+	tracer          *tracing.Tracer
 	attrPool        attrPool
 	attrGauges      *AttrGauge
 	connAttempts    map[string]*uint32
@@ -51,6 +54,13 @@ func (p *policyPlugin) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dn
 	p.wg.Add(1)
 	defer p.wg.Done()
 
+	if p.tracer != nil {
+		span := p.tracer.StartSpan(p.Name())
+		defer span.Finish()
+
+		ctx = ot.ContextWithSpan(ctx, span)
+	}
+
 	dbgMsgr := p.patchDebugMsg(r)
 
 	// turn off default Cq and Cr dnstap messages
@@ -116,7 +126,7 @@ func (p *policyPlugin) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dn
 	}
 
 	// validate domain name (validation #1)
-	goon, err := p.validate(tmpAttrBuffer, ah, attrListTypeVal1, dbgMsgr)
+	goon, err := p.validate(ctx, tmpAttrBuffer, ah, attrListTypeVal1, dbgMsgr)
 	if err != nil {
 		status = dns.RcodeServerFailure
 		return dns.RcodeSuccess, err
@@ -158,7 +168,7 @@ func (p *policyPlugin) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dn
 			ah.addAddressAttr(address)
 
 			// validate response IP (validation #2)
-			if _, err := p.validate(tmpAttrBuffer, ah, attrListTypeVal2, dbgMsgr); err != nil {
+			if _, err := p.validate(ctx, tmpAttrBuffer, ah, attrListTypeVal2, dbgMsgr); err != nil {
 				status = dns.RcodeServerFailure
 				return dns.RcodeSuccess, err
 			}
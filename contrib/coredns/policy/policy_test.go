@@ -55,7 +55,7 @@ func TestPolicyPluginValidate(t *testing.T) {
 	mpc.Effect = pdp.EffectPermit
 	p.pdp = mpc
 
-	goon, err := p.validate(nil, ah, attrListTypeVal1, nil)
+	goon, err := p.validate(context.Background(), nil, ah, attrListTypeVal1, nil)
 	if !goon {
 		t.Errorf("Unexpected result of validate(): expected true, but got %t", goon)
 	}
@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -142,6 +143,10 @@ func (c *erraticPep) Close() {
 }
 
 func (c *erraticPep) Validate(in, out interface{}) error {
+	return c.ValidateContext(context.Background(), in, out)
+}
+
+func (c *erraticPep) ValidateContext(ctx context.Context, in, out interface{}) error {
 	if len(c.err) > 0 {
 		n := c.counter % len(c.err)
 		c.counter++
@@ -152,6 +157,10 @@ func (c *erraticPep) Validate(in, out interface{}) error {
 		}
 	}
 
+	if cc, ok := c.client.(pep.ContextClient); ok {
+		return cc.ValidateContext(ctx, in, out)
+	}
+
 	return c.client.Validate(in, out)
 }
 
@@ -175,6 +184,10 @@ func (mpc *MockPdpClient) Connect(addr string) error {
 func (mpc *MockPdpClient) Close() {}
 
 func (mpc *MockPdpClient) Validate(in, out interface{}) error {
+	return mpc.ValidateContext(context.Background(), in, out)
+}
+
+func (mpc *MockPdpClient) ValidateContext(ctx context.Context, in, out interface{}) error {
 	if mpc.In != nil {
 		inAttrs, ok := in.([]pdp.AttributeAssignment)
 		if !ok {
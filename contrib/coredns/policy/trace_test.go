@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/infobloxopen/themis/contrib/coredns/policy/testutil"
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pdpserver/server"
+	pipserver "github.com/infobloxopen/themis/pip/server"
+	"github.com/infobloxopen/themis/tracing"
+)
+
+const pipTestPolicy = `# Policy with PIP selector for tracing tests
+attributes:
+  domain_name: domain
+  policy_action: integer
+
+policies:
+  id: "PIP Policy"
+  alg:
+    id: Mapper
+    map:
+      selector:
+        uri: "pip://127.0.0.1:5610/content/item"
+        type: string
+        path:
+        - attr: domain_name
+    default: Default
+  rules:
+  - id: Permit
+    effect: Permit
+    obligations:
+    - policy_action:
+        val:
+          type: integer
+          content: 2
+  - id: Default
+    effect: Deny
+`
+
+func TestTraceThroughPDPAndPIP(t *testing.T) {
+	col, err := tracing.NewCollector("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't start collector: %s", err)
+	}
+	defer col.Stop()
+
+	pipTracer, err := tracing.NewOTLPTracer("PIP", col.Addr())
+	if err != nil {
+		t.Fatalf("can't create PIP tracer: %s", err)
+	}
+
+	pip := pipserver.NewServer(
+		pipserver.WithAddress("127.0.0.1:5610"),
+		pipserver.WithHandler(permitPIPHandler),
+		pipserver.WithTracer(pipTracer),
+	)
+	if err := pip.Bind(); err != nil {
+		t.Fatalf("can't bind PIP server: %s", err)
+	}
+
+	go pip.Serve()
+	defer pip.Stop()
+
+	endpoint := "127.0.0.1:5555"
+	srv := testutil.NewServer(
+		server.WithServiceAt(endpoint),
+		server.WithTracingAt(col.Addr()),
+		server.WithTracingType("otlp"),
+	)
+	srv.S.InitializeSelectors()
+	if err := srv.S.ReadPolicies(strings.NewReader(pipTestPolicy)); err != nil {
+		t.Fatalf("can't read policies: %s", err)
+	}
+
+	if err := testutil.WaitForPortClosed(endpoint); err != nil {
+		t.Fatalf("port still in use: %s", err)
+	}
+
+	srvDone := make(chan error)
+	go func() {
+		srvDone <- srv.S.Serve()
+	}()
+
+	if err := testutil.WaitForPortOpened(endpoint); err != nil {
+		t.Fatalf("can't connect to PDP server: %s", err)
+	}
+
+	p := newPolicyPlugin()
+	p.conf.endpoints = []string{endpoint}
+	p.conf.connTimeout = time.Second
+	p.conf.streams = 1
+	p.conf.autoResAttrs = true
+	p.conf.otlp = col.Addr()
+	p.conf.attrs.parseAttrList(attrListTypeVal1, attrNameDomainName)
+	p.next = &testutil.MockPlugin{
+		Ip: net.ParseIP("192.0.2.53"),
+		Rc: dns.RcodeSuccess,
+	}
+
+	g := testutil.NewLogGrabber()
+	if err := p.connect(); err != nil {
+		t.Fatalf("can't connect to PDP: %s\n=== plugin logs ===\n%s--- plugin logs ---", err, g.Release())
+	}
+
+	w := testutil.NewTestAddressedNonwriter("192.0.2.1")
+	m := testutil.MakeTestDNSMsg("example.com", dns.TypeA, dns.ClassINET)
+	rc, err := p.ServeDNS(context.TODO(), w, m)
+	if rc != dns.RcodeSuccess || err != nil {
+		t.Errorf("ServeDNS failed with code: %d, error: %s, message:\n%s\n"+
+			"=== plugin logs ===\n%s--- plugin logs ---", rc, err, w.Msg, g.Release())
+	} else {
+		g.Release()
+	}
+
+	p.pdp.Close()
+	if err := p.tracer.Close(); err != nil {
+		t.Errorf("can't close plugin tracer: %s", err)
+	}
+
+	if err := srv.S.Stop(); err != nil {
+		t.Errorf("can't stop PDP server: %s", err)
+	}
+	<-srvDone
+	if logs := srv.B.String(); len(logs) > 0 {
+		t.Logf("server logs:\n%s", logs)
+	}
+
+	if err := pipTracer.Close(); err != nil {
+		t.Errorf("can't close PIP tracer: %s", err)
+	}
+
+	var root *tracing.Span
+	services := map[string]int{}
+	spans := col.Spans()
+	for i, s := range spans {
+		services[s.Service]++
+		if s.Service == "CoreDNS" && s.Name == p.Name() {
+			root = &spans[i]
+		}
+	}
+
+	if root == nil {
+		t.Fatalf("expected %q span from CoreDNS but got %#v", p.Name(), spans)
+	}
+
+	for _, service := range []string{"CoreDNS", "PDP", "PIP"} {
+		if services[service] <= 0 {
+			t.Errorf("expected spans from %s but got %#v", service, spans)
+		}
+	}
+
+	for _, s := range spans {
+		if s.TraceID != root.TraceID {
+			t.Errorf("expected all spans in trace %s but got %#v", root.TraceID, s)
+		}
+	}
+}
+
+func permitPIPHandler(b []byte) []byte {
+	n, err := pdp.MarshalInfoResponseString(b[4:cap(b)], "Permit")
+	if err != nil {
+		panic(err)
+	}
+
+	return b[:4+n]
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type config struct {
+	input  string
+	output string
+}
+
+var conf config
+
+func init() {
+	flag.StringVar(&conf.input, "i", "", "JSON content or binary content snapshot to convert")
+	flag.StringVar(&conf.output, "o", "", "file to write converted content to")
+
+	flag.Parse()
+
+	if len(conf.input) <= 0 {
+		log.Fatal("no content file to convert")
+	}
+
+	if len(conf.output) <= 0 {
+		log.Fatal("no output file")
+	}
+}
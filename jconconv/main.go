@@ -0,0 +1,50 @@
+// jconconv converts JSON content (JCON) to binary content snapshot and back.
+// Direction of conversion depends on format of input file.
+package main
+
+import (
+	"bufio"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+func main() {
+	in, err := os.Open(conf.input)
+	if err != nil {
+		log.WithError(err).Fatal("failed to open content file")
+	}
+	defer in.Close()
+
+	r := bufio.NewReader(in)
+	b, _ := r.Peek(len(pdp.ContentSnapshotMagic))
+	snapshot := pdp.IsContentSnapshot(b)
+
+	c, err := jcon.Unmarshal(r, nil)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load content")
+	}
+
+	out, err := os.Create(conf.output)
+	if err != nil {
+		log.WithError(err).Fatal("failed to create output file")
+	}
+
+	if snapshot {
+		err = pdp.WriteContentJSON(out, c)
+	} else {
+		err = pdp.WriteContentSnapshot(out, c)
+	}
+
+	if err != nil {
+		out.Close()
+		log.WithError(err).Fatal("failed to write content")
+	}
+
+	if err := out.Close(); err != nil {
+		log.WithError(err).Fatal("failed to write content")
+	}
+}
@@ -230,6 +230,130 @@ func SkipArray(d *json.Decoder, desc string) error {
 	}
 }
 
+// Writer is a destination for CopyValue. Errors of WriteByte and WriteString
+// are ignored, so a writer which fails should keep failing on next Write.
+type Writer interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+}
+
+// CopyValue copies value from JSON byte stream to given writer in compact
+// form. Unlike Decoder.Decode to json.RawMessage it doesn't require whole
+// value to be in decoder's buffer.
+func CopyValue(d *json.Decoder, b Writer, desc string) error {
+	e := json.NewEncoder(b)
+	e.SetEscapeHTML(false)
+
+	return copyValue(d, b, e, desc)
+}
+
+func copyValue(d *json.Decoder, b Writer, e *json.Encoder, desc string) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := t.(json.Delim); ok {
+		s := delim.String()
+		switch s {
+		default:
+			return newUnexpectedDelimiterError(s, desc)
+
+		case DelimObjectStart:
+			return copyObject(d, b, e, desc)
+
+		case DelimArrayStart:
+			return copyArray(d, b, e, desc)
+		}
+	}
+
+	return e.Encode(t)
+}
+
+func copyObject(d *json.Decoder, b Writer, e *json.Encoder, desc string) error {
+	b.WriteString(DelimObjectStart)
+	for i := 0; ; i++ {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := t.(type) {
+		default:
+			return newObjectTokenError(t, DelimObjectEnd, desc)
+
+		case string:
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			if err := e.Encode(t); err != nil {
+				return bindError(err, t)
+			}
+			b.WriteByte(':')
+
+			if err := copyValue(d, b, e, desc); err != nil {
+				return bindError(err, t)
+			}
+
+		case json.Delim:
+			if t.String() != DelimObjectEnd {
+				return newObjectEndDelimiterError(t, DelimObjectEnd, desc)
+			}
+
+			b.WriteString(DelimObjectEnd)
+			return nil
+		}
+	}
+}
+
+func copyArray(d *json.Decoder, b Writer, e *json.Encoder, desc string) error {
+	b.WriteString(DelimArrayStart)
+	for i := 1; ; i++ {
+		src := fmt.Sprintf("%d", i)
+
+		t, err := d.Token()
+		if err != nil {
+			return bindError(err, src)
+		}
+
+		if i > 1 {
+			if delim, ok := t.(json.Delim); !ok || delim.String() != DelimArrayEnd {
+				b.WriteByte(',')
+			}
+		}
+
+		if delim, ok := t.(json.Delim); ok {
+			s := delim.String()
+			switch s {
+			default:
+				return bindError(newUnexpectedDelimiterError(s, desc), src)
+
+			case DelimArrayEnd:
+				b.WriteString(DelimArrayEnd)
+				return nil
+
+			case DelimObjectStart:
+				if err := copyObject(d, b, e, desc); err != nil {
+					return bindError(err, src)
+				}
+
+			case DelimArrayStart:
+				if err := copyArray(d, b, e, desc); err != nil {
+					return bindError(err, src)
+				}
+			}
+
+			continue
+		}
+
+		if err := e.Encode(t); err != nil {
+			return bindError(err, src)
+		}
+	}
+}
+
 // Pair represents unmarshalled part of JSON byte stream.
 // Value is an array of Pairs or primitive value or []interface{}.
 type Pair struct {
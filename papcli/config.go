@@ -15,6 +15,10 @@ type config struct {
 	contentID string
 	fromTag   string
 	toTag     string
+	list      bool
+	rollback  string
+	dryRun    bool
+	requests  string
 }
 
 type stringSet []string
@@ -32,13 +36,17 @@ var conf config
 
 func init() {
 	flag.StringVar(&conf.policy, "p", "", "policy file to upload")
-	flag.StringVar(&conf.content, "j", "", "JSON content to upload")
+	flag.StringVar(&conf.content, "j", "", "JSON content or binary content snapshot to upload")
 	flag.Var(&conf.addresses, "s", "server(s) to upload policy to")
 	flag.DurationVar(&conf.timeout, "t", 5*time.Second, "connection timeout")
 	flag.IntVar(&conf.chunkSize, "c", 64*1024, "size of chunk for splitting uploads")
 	flag.StringVar(&conf.contentID, "id", "", "id of content to upload")
 	flag.StringVar(&conf.fromTag, "vf", "", "tag to update from (if not specified data to upload is full snapshot)")
 	flag.StringVar(&conf.toTag, "vt", "", "new tag to set (if not specified data to upload is not updateable)")
+	flag.BoolVar(&conf.list, "list", false, "list versions of policies (or content if id is specified) kept by server(s)")
+	flag.StringVar(&conf.rollback, "rollback", "", "tag of policies (or content if id is specified) version to roll back to")
+	flag.BoolVar(&conf.dryRun, "dry-run", false, "upload data and report changed decisions without applying it (run again without the option to apply)")
+	flag.StringVar(&conf.requests, "i", "", "requests for dry run as in pepcli (if not specified server uses recently recorded requests)")
 
 	flag.Parse()
 }
@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"time"
 
+	"github.com/infobloxopen/themis/pdp"
 	"github.com/infobloxopen/themis/pdpctrl-client"
+	"github.com/infobloxopen/themis/pepcli/requests"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -12,9 +16,16 @@ import (
 func main() {
 	log.SetLevel(log.InfoLevel)
 
+	if conf.list || len(conf.rollback) > 0 {
+		manageVersions()
+		return
+	}
+
 	f, policy := openFile()
 	defer f.Close()
 
+	reqs := loadRequests()
+
 	hosts := []*pdpcc.Client{}
 
 	for _, addr := range conf.addresses {
@@ -94,6 +105,11 @@ func main() {
 			continue
 		}
 
+		if conf.dryRun {
+			dryRun(conf.addresses[i], h, id, reqs)
+			continue
+		}
+
 		if err := h.Apply(id); err != nil {
 			log.Errorf("Failed to apply: %v", err)
 		} else if err := h.NotifyReady(); err != nil {
@@ -102,6 +118,116 @@ func main() {
 	}
 }
 
+func manageVersions() {
+	if conf.list && len(conf.rollback) > 0 {
+		panic(fmt.Errorf("both list and rollback are specified. Please choose only one"))
+	}
+
+	for _, addr := range conf.addresses {
+		h := pdpcc.NewClient(addr, conf.chunkSize)
+		if err := h.Connect(conf.timeout); err != nil {
+			panic(err)
+		}
+
+		if conf.list {
+			listVersions(addr, h)
+		} else {
+			rollback(addr, h)
+		}
+
+		h.Close()
+	}
+}
+
+func listVersions(addr string, h *pdpcc.Client) {
+	var (
+		vs  []pdpcc.Version
+		err error
+	)
+	if len(conf.contentID) > 0 {
+		vs, err = h.ListContentVersions(conf.contentID)
+	} else {
+		vs, err = h.ListPoliciesVersions()
+	}
+
+	if err != nil {
+		log.Errorf("Failed to list versions at %s: %v", addr, err)
+		return
+	}
+
+	fmt.Printf("%s:\n", addr)
+	for _, v := range vs {
+		mark := " "
+		if v.Current {
+			mark = "*"
+		}
+
+		fmt.Printf("%s %s %s\n", mark, v.Tag, v.Timestamp.Format(time.RFC3339))
+	}
+}
+
+func rollback(addr string, h *pdpcc.Client) {
+	var err error
+	if len(conf.contentID) > 0 {
+		err = h.RollbackContent(conf.contentID, conf.rollback)
+	} else {
+		err = h.RollbackPolicies(conf.rollback)
+	}
+
+	if err != nil {
+		log.Errorf("Failed to roll back at %s: %v", addr, err)
+	}
+}
+
+func loadRequests() [][]byte {
+	if !conf.dryRun || len(conf.requests) <= 0 {
+		return nil
+	}
+
+	msgs, err := requests.Load(conf.requests, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	out := make([][]byte, len(msgs))
+	for i := range msgs {
+		out[i] = msgs[i].Body
+	}
+
+	return out
+}
+
+func dryRun(addr string, h *pdpcc.Client, id int32, reqs [][]byte) {
+	n, diffs, err := h.DryRun(id, reqs)
+	if err != nil {
+		log.Errorf("Failed to dry run at %s: %v", addr, err)
+		return
+	}
+
+	fmt.Printf("%s: %d of %d decisions changed\n", addr, len(diffs), n)
+	for _, d := range diffs {
+		fmt.Printf("- request %d:\n", d.Index+1)
+		for _, a := range d.Request {
+			fmt.Printf("    %s\n", a)
+		}
+
+		printDecision("current", d.Current)
+		printDecision("candidate", d.Candidate)
+	}
+}
+
+func printDecision(name string, d pdpcc.Decision) {
+	if len(d.Status) > 0 {
+		fmt.Printf("  %s: %s (%s)\n", name, d.Effect, d.Status)
+	} else {
+		fmt.Printf("  %s: %s\n", name, d.Effect)
+	}
+
+	for _, o := range d.Obligations {
+		fmt.Printf("    %s\n", o)
+	}
+}
+
 func openFile() (*os.File, bool) {
 	pOk := len(conf.policy) > 0
 	cOk := len(conf.content) > 0
@@ -124,5 +250,14 @@ func openFile() (*os.File, bool) {
 		panic(err)
 	}
 
+	if cOk && len(conf.fromTag) > 0 {
+		b := make([]byte, len(pdp.ContentSnapshotMagic))
+		n, _ := io.ReadFull(f, b)
+		if pdp.IsContentSnapshot(b[:n]) {
+			f.Close()
+			panic(fmt.Errorf("content update can't be a binary snapshot. Please use JSON update"))
+		}
+	}
+
 	return f, pOk
 }
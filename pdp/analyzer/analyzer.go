@@ -0,0 +1,264 @@
+// Package analyzer implements static analysis of PDP policies. It finds
+// rules and policies which can never be selected and attributes which aren't
+// used by any expression.
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/infobloxopen/themis/pdp"
+)
+
+// Warning kinds.
+const (
+	// KindUnreachableRule marks rule shadowed by a preceding rule
+	// under FirstApplicableEffect algorithm.
+	KindUnreachableRule = "unreachable-rule"
+	// KindUnreachableMapperChild marks policy or rule which Mapper algorithm
+	// never selects.
+	KindUnreachableMapperChild = "unreachable-mapper-child"
+	// KindMissingMapperDefault marks Mapper algorithm with default id which
+	// doesn't match any child.
+	KindMissingMapperDefault = "missing-mapper-default"
+	// KindMissingMapperError marks Mapper algorithm with error id which
+	// doesn't match any child.
+	KindMissingMapperError = "missing-mapper-error"
+	// KindUnusedAttribute marks declared attribute which no target,
+	// condition, obligation or mapper refers to.
+	KindUnusedAttribute = "unused-attribute"
+)
+
+// Warning describes a problem found in policies. Path contains ids of
+// policy sets, policies and rules from the root to the node with the problem.
+// Hidden node is represented by its index in parent prefixed with "#".
+// Path is empty for problems which don't belong to any node.
+type Warning struct {
+	Kind    string   `json:"kind"`
+	Path    []string `json:"path,omitempty"`
+	Message string   `json:"message"`
+}
+
+// String implements Stringer interface.
+func (w Warning) String() string {
+	if len(w.Path) <= 0 {
+		return fmt.Sprintf("%s (%s)", w.Message, w.Kind)
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", strings.Join(w.Path, "/"), w.Message, w.Kind)
+}
+
+// Analyze returns warnings for given policy storage. It returns nil if there
+// is nothing to report.
+func Analyze(s *pdp.PolicyStorage) []Warning {
+	a := &analyzer{used: make(map[string]struct{})}
+
+	root := pdp.InspectPolicies(s.Root())
+	if root != nil {
+		a.node(root, []string{nodeName(root, 0)})
+	}
+
+	for _, id := range s.Symbols().AttributeIDs() {
+		if _, ok := a.used[id]; !ok {
+			a.warn(KindUnusedAttribute, nil, "attribute %q is declared but never used", id)
+		}
+	}
+
+	return a.warnings
+}
+
+type analyzer struct {
+	warnings []Warning
+	used     map[string]struct{}
+}
+
+func (a *analyzer) warn(kind string, path []string, format string, args ...interface{}) {
+	a.warnings = append(a.warnings, Warning{
+		Kind:    kind,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (a *analyzer) use(e pdp.Expression) {
+	for _, id := range pdp.ExpressionAttributeIDs(e) {
+		a.used[id] = struct{}{}
+	}
+}
+
+func (a *analyzer) node(n *pdp.PolicyNode, path []string) {
+	for _, anyOf := range n.Target {
+		for _, allOf := range anyOf {
+			for _, m := range allOf {
+				a.use(m)
+			}
+		}
+	}
+
+	for _, o := range n.Obligations {
+		a.used[o.GetID()] = struct{}{}
+		a.use(o.GetExpression())
+	}
+
+	a.use(n.Condition)
+
+	paths := make([][]string, len(n.Children))
+	for i, c := range n.Children {
+		paths[i] = append(path[:len(path):len(path)], nodeName(c, i))
+	}
+
+	switch {
+	case n.Mapper != nil:
+		a.mapper(n, path, paths)
+
+	case n.Algorithm == "FirstApplicableEffect" && n.Kind == pdp.PolicyNodePolicy:
+		a.firstApplicableEffect(n, paths)
+	}
+
+	for i, c := range n.Children {
+		a.node(c, paths[i])
+	}
+}
+
+// firstApplicableEffect reports rules which target is covered by target of
+// any preceding rule without condition. Such preceding rule always returns its
+// effect before the shadowed one is evaluated.
+func (a *analyzer) firstApplicableEffect(n *pdp.PolicyNode, paths [][]string) {
+	for i, r := range n.Children {
+		for j, p := range n.Children[:i] {
+			if p.Condition == nil && covers(p.Target, r.Target) {
+				a.warn(KindUnreachableRule, paths[i], "rule is shadowed by %s", strings.Join(paths[j], "/"))
+				break
+			}
+		}
+	}
+}
+
+func (a *analyzer) mapper(n *pdp.PolicyNode, path []string, paths [][]string) {
+	m := n.Mapper
+	a.use(m.Argument)
+
+	ids := make(map[string]struct{}, len(n.Children))
+	for _, c := range n.Children {
+		if !c.Hidden {
+			ids[c.ID] = struct{}{}
+		}
+	}
+
+	if _, ok := ids[m.Def]; len(m.Def) > 0 && !ok {
+		a.warn(KindMissingMapperDefault, path, "default %q doesn't match any child", m.Def)
+	}
+
+	if _, ok := ids[m.Err]; len(m.Err) > 0 && !ok {
+		a.warn(KindMissingMapperError, path, "error %q doesn't match any child", m.Err)
+	}
+
+	reachable := mapperReachable(m)
+	for i, c := range n.Children {
+		switch {
+		case c.Hidden:
+			a.warn(KindUnreachableMapperChild, paths[i], "hidden child can't be selected by Mapper")
+
+		case c.ID == m.Def || c.ID == m.Err:
+
+		case !reachable(c.ID):
+			a.warn(KindUnreachableMapperChild, paths[i], "no value of Mapper argument selects %q", c.ID)
+		}
+	}
+}
+
+// mapperReachable returns function which checks if mapper argument can
+// evaluate to given id. Flags argument can produce only names of its flags
+// and constant string argument only its own value. Any id is considered
+// reachable for other arguments.
+func mapperReachable(m *pdp.MapperNode) func(ID string) bool {
+	if v, ok := m.Argument.(pdp.AttributeValue); ok && v.GetResultType() == pdp.TypeString {
+		if s, err := v.Serialize(); err == nil {
+			return func(ID string) bool {
+				return ID == s
+			}
+		}
+	}
+
+	if t, ok := m.Argument.GetResultType().(*pdp.FlagsType); ok {
+		return func(ID string) bool {
+			return t.GetFlagBit(ID) >= 0
+		}
+	}
+
+	return func(ID string) bool {
+		return true
+	}
+}
+
+// covers checks if target a matches any request which target b matches.
+// It's true when each AnyOf of a is implied by some AnyOf of b. AnyOf implies
+// other AnyOf when each its AllOf contains all matches of some AllOf of the
+// other. Matches are compared structurally.
+func covers(a, b [][][]pdp.Expression) bool {
+	for _, anyOfA := range a {
+		implied := false
+		for _, anyOfB := range b {
+			if impliesAnyOf(anyOfB, anyOfA) {
+				implied = true
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+func impliesAnyOf(b, a [][]pdp.Expression) bool {
+	if len(b) <= 0 {
+		return false
+	}
+
+	for _, allOfB := range b {
+		implied := false
+		for _, allOfA := range a {
+			if containsAll(allOfB, allOfA) {
+				implied = true
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAll(b, a []pdp.Expression) bool {
+	for _, m := range a {
+		found := false
+		for _, e := range b {
+			if reflect.DeepEqual(m, e) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func nodeName(n *pdp.PolicyNode, i int) string {
+	if n.Hidden {
+		return "#" + strconv.Itoa(i)
+	}
+
+	return n.ID
+}
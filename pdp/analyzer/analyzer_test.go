@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pdp/ast/yast"
+)
+
+const (
+	cleanPolicy = `# Policy without problems
+attributes:
+  s: string
+  r: string
+policies:
+  id: Root
+  alg: FirstApplicableEffect
+  rules:
+  - id: First
+    target:
+    - equal:
+      - attr: s
+      - val:
+          type: string
+          content: first
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: first
+  - id: Second
+    effect: Deny
+`
+
+	problemPolicy = `# Policy with all kinds of problems
+types:
+  choice:
+    meta: flags
+    flags: [first, second]
+attributes:
+  s: string
+  f: choice
+  r: string
+  unused: string
+policies:
+  id: Root
+  alg:
+    id: Mapper
+    map:
+      attr: f
+    default: fallback
+    alg: FirstApplicableEffect
+  policies:
+  - id: first
+    alg: FirstApplicableEffect
+    rules:
+    - id: Broad
+      target:
+      - equal:
+        - attr: s
+        - val:
+            type: string
+            content: test
+      effect: Permit
+    - id: Narrow
+      target:
+      - equal:
+        - attr: s
+        - val:
+            type: string
+            content: test
+      - equal:
+        - attr: r
+        - val:
+            type: string
+            content: test
+      effect: Deny
+    - id: Other
+      target:
+      - equal:
+        - attr: s
+        - val:
+            type: string
+            content: other
+      effect: Deny
+  - id: third
+    alg:
+      id: Mapper
+      map:
+        val:
+          type: string
+          content: Permit
+      error: Gone
+    rules:
+    - id: Permit
+      effect: Permit
+    - id: Deny
+      effect: Deny
+    - effect: Deny
+    - id: Gone
+      effect: Deny
+  - id: fallback
+    alg: FirstApplicableEffect
+    rules:
+    - effect: Deny
+`
+)
+
+func TestAnalyze(t *testing.T) {
+	p, err := yast.Parser{}.Unmarshal(strings.NewReader(cleanPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if w := Analyze(p); len(w) > 0 {
+		t.Errorf("Expected no warnings but got %d: %v", len(w), w)
+	}
+
+	// Parsers don't allow mappers with missing default or error ids but
+	// an update can delete such policies or rules.
+	tag := uuid.New()
+	p, err = yast.Parser{}.Unmarshal(strings.NewReader(problemPolicy), &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	tr, err := p.NewTransaction(&tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u := pdp.NewPolicyUpdate(tag, uuid.New())
+	u.Append(pdp.UODelete, []string{"Root", "fallback"}, nil)
+	u.Append(pdp.UODelete, []string{"Root", "third", "Gone"}, nil)
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	p, err = tr.Commit()
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	assertWarnings(t, Analyze(p),
+		"Root: default \"fallback\" doesn't match any child (missing-mapper-default)",
+		"Root/third: no value of Mapper argument selects \"third\" (unreachable-mapper-child)",
+		"Root/first/Narrow: rule is shadowed by Root/first/Broad (unreachable-rule)",
+		"Root/third: error \"Gone\" doesn't match any child (missing-mapper-error)",
+		"Root/third/Deny: no value of Mapper argument selects \"Deny\" (unreachable-mapper-child)",
+		"Root/third/#2: hidden child can't be selected by Mapper (unreachable-mapper-child)",
+		"attribute \"unused\" is declared but never used (unused-attribute)",
+	)
+}
+
+func TestCovers(t *testing.T) {
+	a := pdp.MakeStringValue("a")
+	b := pdp.MakeStringValue("b")
+	c := pdp.MakeStringValue("c")
+
+	if !covers(nil, [][][]pdp.Expression{{{a}}}) {
+		t.Error("Expected empty target to cover any target")
+	}
+
+	if covers([][][]pdp.Expression{{{a}}}, nil) {
+		t.Error("Expected non-empty target not to cover empty target")
+	}
+
+	if !covers([][][]pdp.Expression{{{a}, {b}}}, [][][]pdp.Expression{{{a, c}}}) {
+		t.Error("Expected a or b to cover a and c")
+	}
+
+	if covers([][][]pdp.Expression{{{a, b}}}, [][][]pdp.Expression{{{a}, {a, b}}}) {
+		t.Error("Expected a and b not to cover a or (a and b)")
+	}
+
+	if !covers([][][]pdp.Expression{{{a}}}, [][][]pdp.Expression{{{b}}, {{a}}}) {
+		t.Error("Expected a to cover b and a")
+	}
+}
+
+func assertWarnings(t *testing.T, w []Warning, e ...string) {
+	if len(w) != len(e) {
+		t.Errorf("Expected %d warnings but got %d: %v", len(e), len(w), w)
+		return
+	}
+
+	for i, s := range e {
+		if w[i].String() != s {
+			t.Errorf("Expected warning %d:\n%q\nbut got:\n%q", i, s, w[i])
+		}
+	}
+}
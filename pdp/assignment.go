@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -138,6 +139,22 @@ func MakeListOfStringsAssignment(id string, v []string) AttributeAssignment {
 	)
 }
 
+// MakeTimeAssignment creates attribute assignment for time value.
+func MakeTimeAssignment(id string, v time.Time) AttributeAssignment {
+	return MakeAttributeAssignment(
+		MakeAttribute(id, TypeTime),
+		MakeTimeValue(v),
+	)
+}
+
+// MakeDurationAssignment creates attribute assignment for duration value.
+func MakeDurationAssignment(id string, v time.Duration) AttributeAssignment {
+	return MakeAttributeAssignment(
+		MakeAttribute(id, TypeDuration),
+		MakeDurationValue(v),
+	)
+}
+
 // MakeFlags8Assignment creates attribute assignment for flags value which fits
 // 8 bits integer.
 func MakeFlags8Assignment(id string, t Type, v uint8) AttributeAssignment {
@@ -179,6 +196,11 @@ func (a AttributeAssignment) GetID() string {
 	return a.a.id
 }
 
+// GetExpression returns expression which assignment calculates value with.
+func (a AttributeAssignment) GetExpression() Expression {
+	return a.e
+}
+
 func (a AttributeAssignment) calculate(ctx *Context) (AttributeValue, error) {
 	v, err := a.e.Calculate(ctx)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 type context struct {
 	symbols    pdp.Symbols
 	rootPolicy pdp.Evaluable
+	vars       *variables
 }
 
 func newContext() *context {
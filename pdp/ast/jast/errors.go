@@ -61,6 +61,14 @@ const (
 	unknownFlagNameErrorID              = 47
 	unknownAggregationTypeErrorID       = 48
 	invalidAggregationTypeErrorID       = 49
+	invalidTimeErrorID                  = 50
+	invalidDurationErrorID              = 51
+	unknownVariableErrorID              = 52
+	duplicateVariableErrorID            = 53
+	missingEnumValueListErrorID         = 54
+	missingStructFieldListErrorID       = 55
+	missingQuantifiedElementErrorID     = 56
+	missingQuantifiedConditionErrorID   = 57
 )
 
 type externalError struct {
@@ -821,3 +829,119 @@ func newInvalidAggregationTypeError(a string, t pdp.Type) *invalidAggregationTyp
 func (e *invalidAggregationTypeError) Error() string {
 	return e.errorf("Inappropriate aggregation type %q for selector type %q", e.a, e.t)
 }
+
+type invalidTimeError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidTimeError(s string, err error) *invalidTimeError {
+	return &invalidTimeError{
+		errorLink: errorLink{id: invalidTimeErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidTimeError) Error() string {
+	return e.errorf("Expected value of time type but got %q (%v)", e.s, e.err)
+}
+
+type invalidDurationError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidDurationError(s string, err error) *invalidDurationError {
+	return &invalidDurationError{
+		errorLink: errorLink{id: invalidDurationErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidDurationError) Error() string {
+	return e.errorf("Expected value of duration type but got %q (%v)", e.s, e.err)
+}
+
+type unknownVariableError struct {
+	errorLink
+	ID string
+}
+
+func newUnknownVariableError(ID string) *unknownVariableError {
+	return &unknownVariableError{
+		errorLink: errorLink{id: unknownVariableErrorID},
+		ID:        ID}
+}
+
+func (e *unknownVariableError) Error() string {
+	return e.errorf("Unknown variable %q", e.ID)
+}
+
+type duplicateVariableError struct {
+	errorLink
+	ID string
+}
+
+func newDuplicateVariableError(ID string) *duplicateVariableError {
+	return &duplicateVariableError{
+		errorLink: errorLink{id: duplicateVariableErrorID},
+		ID:        ID}
+}
+
+func (e *duplicateVariableError) Error() string {
+	return e.errorf("Variable %q has been already defined", e.ID)
+}
+
+type missingEnumValueListError struct {
+	errorLink
+}
+
+func newMissingEnumValueListError() *missingEnumValueListError {
+	return &missingEnumValueListError{
+		errorLink: errorLink{id: missingEnumValueListErrorID}}
+}
+
+func (e *missingEnumValueListError) Error() string {
+	return e.errorf("Missing list of enum values")
+}
+
+type missingStructFieldListError struct {
+	errorLink
+}
+
+func newMissingStructFieldListError() *missingStructFieldListError {
+	return &missingStructFieldListError{
+		errorLink: errorLink{id: missingStructFieldListErrorID}}
+}
+
+func (e *missingStructFieldListError) Error() string {
+	return e.errorf("Missing list of struct fields")
+}
+
+type missingQuantifiedElementError struct {
+	errorLink
+}
+
+func newMissingQuantifiedElementError() *missingQuantifiedElementError {
+	return &missingQuantifiedElementError{
+		errorLink: errorLink{id: missingQuantifiedElementErrorID}}
+}
+
+func (e *missingQuantifiedElementError) Error() string {
+	return e.errorf("Quantified expression 'var' or 'in' attribute is missing or placed after 'condition' attribute")
+}
+
+type missingQuantifiedConditionError struct {
+	errorLink
+}
+
+func newMissingQuantifiedConditionError() *missingQuantifiedConditionError {
+	return &missingQuantifiedConditionError{
+		errorLink: errorLink{id: missingQuantifiedConditionErrorID}}
+}
+
+func (e *missingQuantifiedConditionError) Error() string {
+	return e.errorf("Missing quantified expression condition")
+}
@@ -64,6 +64,14 @@ func (ctx context) unmarshalExpression(d *json.Decoder) (pdp.Expression, error)
 			expr, err = ctx.unmarshalSelector(d)
 			return err
 
+		case yastTagVariable:
+			expr, err = ctx.unmarshalVariableReference(d)
+			return err
+
+		case yastTagAnyOf, yastTagAllOf:
+			expr, err = ctx.unmarshalQuantifiedExpression(strings.ToLower(k), d)
+			return err
+
 		default:
 			validators, ok := pdp.FunctionArgumentValidators[k]
 			if !ok {
@@ -78,6 +86,10 @@ func (ctx context) unmarshalExpression(d *json.Decoder) (pdp.Expression, error)
 			for _, validator := range validators {
 				if maker := validator(args); maker != nil {
 					expr = maker(args)
+					if err := pdp.CheckExpression(expr); err != nil {
+						return bindError(err, k)
+					}
+
 					return nil
 				}
 			}
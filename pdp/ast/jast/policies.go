@@ -1,6 +1,7 @@
 package jast
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -38,6 +39,17 @@ func (ctx *context) unmarshalPolicies(d *json.Decoder) ([]pdp.Evaluable, error)
 	return policies, nil
 }
 
+// deferredField keeps field of policy set or policy which comes before
+// variables of the policy set or policy.
+type deferredField struct {
+	k string
+	v json.RawMessage
+}
+
+// unmarshalEvaluable parses policy set or policy. Fields which can refer
+// to variables (target, algorithm, obligations, policies and rules) are
+// buffered until variables are parsed or the object ends, so variables can be
+// placed after them.
 func (ctx *context) unmarshalEvaluable(d *json.Decoder) (pdp.Evaluable, error) {
 	var (
 		hidden      = true
@@ -50,17 +62,17 @@ func (ctx *context) unmarshalEvaluable(d *json.Decoder) (pdp.Evaluable, error) {
 		target   pdp.Target
 		obligs   []pdp.AttributeAssignment
 		alg      interface{}
+
+		varsOk   bool
+		deferred []deferredField
 	)
 
-	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
-		var err error
+	ctx = ctx.newVariableScope()
 
-		switch strings.ToLower(k) {
-		case yastTagID:
-			hidden = false
-			pid, err = jparser.GetString(d, "policy or policy set id")
-			return err
+	unmarshalField := func(k string, d *json.Decoder) error {
+		var err error
 
+		switch k {
 		case yastTagAlg:
 			alg, err = ctx.unmarshalCombiningAlg(d)
 			if err != nil {
@@ -99,11 +111,59 @@ func (ctx *context) unmarshalEvaluable(d *json.Decoder) (pdp.Evaluable, error) {
 			return nil
 		}
 
+		return newUnknownFieldError(k)
+	}
+
+	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+		var err error
+
+		switch strings.ToLower(k) {
+		case yastTagID:
+			hidden = false
+			pid, err = jparser.GetString(d, "policy or policy set id")
+			return err
+
+		case yastTagVariables:
+			err = ctx.unmarshalVariables(d)
+			if err != nil {
+				return bindError(err, makeSource("policy or policy set", pid, hidden))
+			}
+
+			varsOk = true
+			for _, f := range deferred {
+				if err := unmarshalField(f.k, json.NewDecoder(bytes.NewReader(f.v))); err != nil {
+					return err
+				}
+			}
+			deferred = nil
+
+			return nil
+
+		case yastTagAlg, yastTagTarget, yastTagObligation, yastTagPolicies, yastTagRules:
+			if varsOk {
+				return unmarshalField(strings.ToLower(k), d)
+			}
+
+			var v json.RawMessage
+			if err = d.Decode(&v); err != nil {
+				return bindError(err, makeSource("policy or policy set", pid, hidden))
+			}
+
+			deferred = append(deferred, deferredField{k: strings.ToLower(k), v: v})
+			return nil
+		}
+
 		return newUnknownFieldError(k)
 	}, "policy or policy set"); err != nil {
 		return nil, err
 	}
 
+	for _, f := range deferred {
+		if err := unmarshalField(f.k, json.NewDecoder(bytes.NewReader(f.v))); err != nil {
+			return nil, err
+		}
+	}
+
 	if isPolicy && isPolicySet {
 		return nil, newPolicyAmbiguityError()
 	}
@@ -24,6 +24,7 @@ func (ctx context) unmarshalSelector(d *json.Decoder) (pdp.Expression, error) {
 		defExp pdp.Expression
 		errExp pdp.Expression
 		aggStr string
+		field  string
 	)
 
 	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
@@ -90,6 +91,10 @@ func (ctx context) unmarshalSelector(d *json.Decoder) (pdp.Expression, error) {
 		case yastTagAggregation:
 			aggStr, err = jparser.GetString(d, "aggregation")
 			return err
+
+		case yastTagField:
+			field, err = jparser.GetString(d, "field")
+			return err
 		}
 
 		return newUnknownFieldError(k)
@@ -112,16 +117,28 @@ func (ctx context) unmarshalSelector(d *json.Decoder) (pdp.Expression, error) {
 	}
 
 	var opts []pdp.SelectorOption
+
+	rt := t
+	if len(field) > 0 {
+		ft, err := pdp.GetStructFieldType(t, field)
+		if err != nil {
+			return ret, bindErrorf(err, "selector(%s).field", uri)
+		}
+
+		rt = ft
+		opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionField, Data: field})
+	}
+
 	if defExp != nil {
-		if defExp.GetResultType() != t {
-			return ret, bindErrorf(newInvalidTypeError(t), "selector(%s).default", uri)
+		if defExp.GetResultType() != rt {
+			return ret, bindErrorf(newInvalidTypeError(rt), "selector(%s).default", uri)
 		}
 		opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionDefault, Data: defExp})
 	}
 
 	if errExp != nil {
-		if errExp.GetResultType() != t {
-			return ret, bindErrorf(newInvalidTypeError(t), "selector(%s).error", uri)
+		if errExp.GetResultType() != rt {
+			return ret, bindErrorf(newInvalidTypeError(rt), "selector(%s).error", uri)
 		}
 		opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionError, Data: errExp})
 	}
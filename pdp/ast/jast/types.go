@@ -17,6 +17,8 @@ func (ctx *context) unmarshalTypeDeclaration(ID string, d *json.Decoder) error {
 		metaOk bool
 		meta   string
 		flags  []string
+		values []string
+		fields []pdp.StructField
 	)
 
 	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
@@ -41,6 +43,22 @@ func (ctx *context) unmarshalTypeDeclaration(ID string, d *json.Decoder) error {
 			}, "list of flag names"); err != nil {
 				return err
 			}
+
+		case yastTagValues:
+			values = []string{}
+			if err := jparser.GetStringSequence(d, func(i int, s string) error {
+				values = append(values, s)
+				return nil
+			}, "list of enum values"); err != nil {
+				return err
+			}
+
+		case yastTagFields:
+			var err error
+			fields, err = ctx.unmarshalStructFields(d)
+			if err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -66,6 +84,34 @@ func (ctx *context) unmarshalTypeDeclaration(ID string, d *json.Decoder) error {
 			return err
 		}
 
+		if err := ctx.symbols.PutType(t); err != nil {
+			return err
+		}
+
+	case yastTagEnum:
+		if values == nil {
+			return newMissingEnumValueListError()
+		}
+
+		t, err := pdp.NewEnumType(ID, values...)
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.symbols.PutType(t); err != nil {
+			return err
+		}
+
+	case yastTagStruct:
+		if fields == nil {
+			return newMissingStructFieldListError()
+		}
+
+		t, err := pdp.NewStructType(ID, fields...)
+		if err != nil {
+			return err
+		}
+
 		if err := ctx.symbols.PutType(t); err != nil {
 			return err
 		}
@@ -74,6 +120,45 @@ func (ctx *context) unmarshalTypeDeclaration(ID string, d *json.Decoder) error {
 	return nil
 }
 
+// unmarshalStructFields parses list of struct fields. Each field is an object
+// with field name as a key and type name as a value. Field can have enum type
+// declared before the struct type.
+func (ctx *context) unmarshalStructFields(d *json.Decoder) ([]pdp.StructField, error) {
+	if err := jparser.CheckArrayStart(d, "list of struct fields"); err != nil {
+		return nil, err
+	}
+
+	fields := []pdp.StructField{}
+	if err := jparser.UnmarshalObjectArray(d, func(idx int, d *json.Decoder) error {
+		if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+			s, err := jparser.GetString(d, "field type")
+			if err != nil {
+				return bindError(err, k)
+			}
+
+			t := ctx.symbols.GetType(s)
+			if t == nil {
+				return bindError(newUnknownTypeError(s), k)
+			}
+
+			fields = append(fields, pdp.StructField{
+				Name: k,
+				Type: t,
+			})
+
+			return nil
+		}, "struct field"); err != nil {
+			return bindErrorf(err, "%d", idx)
+		}
+
+		return nil
+	}, "list of struct fields"); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
 func (ctx *context) unmarshalTypeDeclarations(d *json.Decoder) error {
 	if err := jparser.CheckObjectStart(d, "type declarations"); err != nil {
 		return bindError(err, yastTagTypes)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/themis/jparser"
 	"github.com/infobloxopen/themis/pdp"
@@ -87,6 +88,34 @@ func (ctx context) unmarshalDomainValue(d *json.Decoder) (pdp.AttributeValue, er
 	return pdp.MakeDomainValue(dom), nil
 }
 
+func (ctx context) unmarshalTimeValue(d *json.Decoder) (pdp.AttributeValue, error) {
+	s, err := jparser.GetString(d, "value of time type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	t, ierr := time.Parse(time.RFC3339Nano, s)
+	if ierr != nil {
+		return pdp.UndefinedValue, newInvalidTimeError(s, ierr)
+	}
+
+	return pdp.MakeTimeValue(t), nil
+}
+
+func (ctx context) unmarshalDurationValue(d *json.Decoder) (pdp.AttributeValue, error) {
+	s, err := jparser.GetString(d, "value of duration type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	dur, ierr := time.ParseDuration(s)
+	if ierr != nil {
+		return pdp.UndefinedValue, newInvalidDurationError(s, ierr)
+	}
+
+	return pdp.MakeDurationValue(dur), nil
+}
+
 func (ctx context) unmarshalSetOfStringsValue(d *json.Decoder) (pdp.AttributeValue, error) {
 	set := strtree.NewTree()
 	if err := jparser.GetStringSequence(d, func(idx int, s string) error {
@@ -251,9 +280,22 @@ func (ctx context) unmarshalFlags64Value(d *json.Decoder, t *pdp.FlagsType) (pdp
 	return pdp.MakeFlagsValue64(n, t), nil
 }
 
+func (ctx context) unmarshalEnumValue(d *json.Decoder, t *pdp.EnumType) (pdp.AttributeValue, error) {
+	s, err := jparser.GetString(d, "value of enum type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	return pdp.MakeEnumValue(s, t)
+}
+
 func (ctx context) unmarshalValueByType(t pdp.Type, d *json.Decoder) (pdp.AttributeValue, error) {
-	if t, ok := t.(*pdp.FlagsType); ok {
+	switch t := t.(type) {
+	case *pdp.FlagsType:
 		return ctx.unmarshalFlagsValue(d, t)
+
+	case *pdp.EnumType:
+		return ctx.unmarshalEnumValue(d, t)
 	}
 
 	switch t {
@@ -286,6 +328,12 @@ func (ctx context) unmarshalValueByType(t pdp.Type, d *json.Decoder) (pdp.Attrib
 
 	case pdp.TypeListOfStrings:
 		return ctx.unmarshalListOfStringsValue(d)
+
+	case pdp.TypeTime:
+		return ctx.unmarshalTimeValue(d)
+
+	case pdp.TypeDuration:
+		return ctx.unmarshalDurationValue(d)
 	}
 
 	return pdp.UndefinedValue, newNotImplementedValueTypeError(t)
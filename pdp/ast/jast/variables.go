@@ -0,0 +1,174 @@
+package jast
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/infobloxopen/themis/jparser"
+	"github.com/infobloxopen/themis/pdp"
+)
+
+// variables represents scope of variables defined by policy set or policy.
+// Descendants see variables of all enclosing scopes.
+type variables struct {
+	parent *variables
+	m      map[string]*pdp.Variable
+}
+
+func (ctx *context) newVariableScope() *context {
+	out := *ctx
+	out.vars = &variables{
+		parent: ctx.vars,
+		m:      make(map[string]*pdp.Variable),
+	}
+
+	return &out
+}
+
+func (ctx context) getVariable(ID string) (*pdp.Variable, bool) {
+	for s := ctx.vars; s != nil; s = s.parent {
+		if v, ok := s.m[ID]; ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func (ctx context) unmarshalVariableReference(d *json.Decoder) (pdp.VariableReference, error) {
+	ID, err := jparser.GetString(d, "variable ID")
+	if err != nil {
+		return pdp.VariableReference{}, err
+	}
+
+	v, ok := ctx.getVariable(ID)
+	if !ok {
+		return pdp.VariableReference{}, newUnknownVariableError(ID)
+	}
+
+	return pdp.MakeVariableReference(v), nil
+}
+
+func (ctx context) unmarshalVariablesItem(d *json.Decoder) error {
+	return jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+		if _, ok := ctx.vars.m[k]; ok {
+			return newDuplicateVariableError(k)
+		}
+
+		if err := jparser.CheckObjectStart(d, "variable"); err != nil {
+			return bindError(err, k)
+		}
+
+		e, err := ctx.unmarshalExpression(d)
+		if err != nil {
+			return bindError(err, k)
+		}
+
+		ctx.vars.m[k] = pdp.NewVariable(k, e)
+		return nil
+	}, "variable")
+}
+
+// unmarshalVariables puts variables defined in given policy set or policy
+// to current scope. A variable can refer to any variable defined before it.
+func (ctx context) unmarshalVariables(d *json.Decoder) error {
+	if err := jparser.CheckArrayStart(d, "variables"); err != nil {
+		return err
+	}
+
+	return jparser.UnmarshalObjectArray(d, func(idx int, d *json.Decoder) error {
+		if err := ctx.unmarshalVariablesItem(d); err != nil {
+			return bindErrorf(err, "%d", idx)
+		}
+
+		return nil
+	}, "variables")
+}
+
+// unmarshalQuantifiedExpression parses any-of or all-of expression. Element
+// variable is visible only within condition of the expression and hides
+// variables with the same name from enclosing scopes. As condition refers to
+// the variable it should follow "var" and "in" fields.
+func (ctx *context) unmarshalQuantifiedExpression(name string, d *json.Decoder) (pdp.Expression, error) {
+	if err := jparser.CheckObjectStart(d, name); err != nil {
+		return nil, bindError(err, name)
+	}
+
+	var (
+		ID string
+		c  pdp.Expression
+		vr *pdp.Variable
+		e  pdp.Expression
+	)
+
+	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+		var err error
+
+		switch strings.ToLower(k) {
+		case yastTagVariable:
+			ID, err = jparser.GetString(d, "element variable id")
+			return err
+
+		case yastTagIn:
+			if err = jparser.CheckObjectStart(d, "collection"); err != nil {
+				return err
+			}
+
+			c, err = ctx.unmarshalExpression(d)
+			if err != nil {
+				return bindError(err, yastTagIn)
+			}
+
+			return nil
+
+		case yastTagCondition:
+			if len(ID) <= 0 || c == nil {
+				return newMissingQuantifiedElementError()
+			}
+
+			vr, err = pdp.NewElementVariable(ID, c)
+			if err != nil {
+				return bindError(err, yastTagIn)
+			}
+
+			if err = jparser.CheckObjectStart(d, "condition"); err != nil {
+				return err
+			}
+
+			qctx := ctx.newVariableScope()
+			qctx.vars.m[ID] = vr
+
+			e, err = qctx.unmarshalExpression(d)
+			if err != nil {
+				return bindError(err, yastTagCondition)
+			}
+
+			return nil
+		}
+
+		return newUnknownFieldError(k)
+	}, name); err != nil {
+		return nil, bindError(err, name)
+	}
+
+	if e == nil {
+		return nil, bindError(newMissingQuantifiedConditionError(), name)
+	}
+
+	var (
+		q   pdp.Expression
+		err error
+	)
+
+	if name == yastTagAnyOf {
+		q, err = pdp.MakeAnyOfExpression(vr, c, e)
+	} else {
+		q, err = pdp.MakeAllOfExpression(vr, c, e)
+	}
+
+	if err != nil {
+		return nil, bindError(err, name)
+	}
+
+	return q, nil
+}
@@ -8,6 +8,7 @@ import (
 
 type context struct {
 	symbols pdp.Symbols
+	vars    *variables
 }
 
 func newContext() *context {
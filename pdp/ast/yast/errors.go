@@ -70,6 +70,10 @@ const (
 	unknownFlagNameErrorID                = 57
 	unknownAggregationTypeErrorID         = 58
 	invalidAggregationTypeErrorID         = 59
+	invalidTimeErrorID                    = 60
+	invalidDurationErrorID                = 61
+	unknownVariableErrorID                = 62
+	duplicateVariableErrorID              = 63
 )
 
 type externalError struct {
@@ -1012,3 +1016,67 @@ func newInvalidAggregationTypeError(a string, t pdp.Type) *invalidAggregationTyp
 func (e *invalidAggregationTypeError) Error() string {
 	return e.errorf("Inappropriate aggregation type %q for selector type %q", e.a, e.t)
 }
+
+type invalidTimeError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidTimeError(s string, err error) *invalidTimeError {
+	return &invalidTimeError{
+		errorLink: errorLink{id: invalidTimeErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidTimeError) Error() string {
+	return e.errorf("Expected value of time type but got %q (%v)", e.s, e.err)
+}
+
+type invalidDurationError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidDurationError(s string, err error) *invalidDurationError {
+	return &invalidDurationError{
+		errorLink: errorLink{id: invalidDurationErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidDurationError) Error() string {
+	return e.errorf("Expected value of duration type but got %q (%v)", e.s, e.err)
+}
+
+type unknownVariableError struct {
+	errorLink
+	ID string
+}
+
+func newUnknownVariableError(ID string) *unknownVariableError {
+	return &unknownVariableError{
+		errorLink: errorLink{id: unknownVariableErrorID},
+		ID:        ID}
+}
+
+func (e *unknownVariableError) Error() string {
+	return e.errorf("Unknown variable %q", e.ID)
+}
+
+type duplicateVariableError struct {
+	errorLink
+	ID string
+}
+
+func newDuplicateVariableError(ID string) *duplicateVariableError {
+	return &duplicateVariableError{
+		errorLink: errorLink{id: duplicateVariableErrorID},
+		ID:        ID}
+}
+
+func (e *duplicateVariableError) Error() string {
+	return e.errorf("Variable %q has been already defined", e.ID)
+}
@@ -60,6 +60,12 @@ func (ctx context) unmarshalExpression(v interface{}) (pdp.Expression, boundErro
 
 	case yastTagSelector:
 		return ctx.unmarshalSelector(v)
+
+	case yastTagVariable:
+		return ctx.unmarshalVariableReference(v)
+
+	case yastTagAnyOf, yastTagAllOf:
+		return ctx.unmarshalQuantifiedExpression(ID, v)
 	}
 
 	validators, ok := pdp.FunctionArgumentValidators[ID]
@@ -74,7 +80,12 @@ func (ctx context) unmarshalExpression(v interface{}) (pdp.Expression, boundErro
 
 	for _, validator := range validators {
 		if maker := validator(args); maker != nil {
-			return maker(args), nil
+			e := maker(args)
+			if err := pdp.CheckExpression(e); err != nil {
+				return nil, bindError(err, ID)
+			}
+
+			return e, nil
 		}
 	}
 
@@ -15,6 +15,11 @@ const (
 	yastTagTypes       = "types"
 	yastTagMeta        = "meta"
 	yastTagFlags       = "flags"
+	yastTagEnum        = "enum"
+	yastTagValues      = "values"
+	yastTagStruct      = "struct"
+	yastTagFields      = "fields"
+	yastTagField       = "field"
 	yastTagAttributes  = "attributes"
 	yastTagID          = "id"
 	yastTagTarget      = "target"
@@ -29,11 +34,16 @@ const (
 	yastTagOrder       = "order"
 	yastTagEffect      = "effect"
 	yastTagObligation  = "obligations"
+	yastTagVariables   = "variables"
 	yastTagAny         = "any"
 	yastTagAll         = "all"
 	yastTagAttribute   = "attr"
 	yastTagValue       = "val"
 	yastTagSelector    = "selector"
+	yastTagVariable    = "var"
+	yastTagAnyOf       = "any-of"
+	yastTagAllOf       = "all-of"
+	yastTagIn          = "in"
 	yastTagType        = "type"
 	yastTagContent     = "content"
 	yastTagURI         = "uri"
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -1268,6 +1269,561 @@ policies:
     rules:
     - effect: Deny
 `
+
+	patternFunctionsPolicy = `# Policy with pattern matching functions
+attributes:
+  s: string
+  r: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      match:
+      - attr: s
+      - val:
+          type: string
+          content: "^curl/[0-9.]+$"
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: match
+  - condition:
+      glob:
+      - attr: s
+      - val:
+          type: string
+          content: "wget/*"
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: glob
+  - condition:
+      starts-with:
+      - attr: s
+      - val:
+          type: string
+          content: "Mozilla/"
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: starts-with
+  - condition:
+      ends-with:
+      - attr: s
+      - val:
+          type: string
+          content: "(bot)"
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: ends-with
+  - effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: none
+`
+
+	badRegexpPolicy = `# Policy with invalid regular expression
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - id: Bad Pattern
+    condition:
+      match:
+      - attr: s
+      - val:
+          type: string
+          content: "^ad[0-9+$"
+    effect: Permit
+`
+
+	timeFunctionsPolicy = `# Policy with time functions
+attributes:
+  t: time
+  r: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      within-window:
+      - attr: t
+      - val:
+          type: string
+          content: "09:00"
+      - val:
+          type: string
+          content: "17:00"
+      - val:
+          type: string
+          content: America/New_York
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: business hours
+  - condition:
+      greater:
+      - subtract:
+        - attr: t
+        - val:
+            type: time
+            content: "2018-06-14T00:00:00Z"
+      - val:
+          type: duration
+          content: 24h
+    effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: expired
+  - effect: Deny
+    obligations:
+    - r:
+        day-of-week:
+        - attr: t
+        - val:
+            type: string
+            content: America/New_York
+`
+
+	customFunctionPolicy = `# Policy with user-defined function
+attributes:
+  s: string
+  r: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      yast-test-checksum:
+      - attr: s
+      - val:
+          type: integer
+          content: 7
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: valid
+  - effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: invalid
+`
+
+	badCustomFunctionPolicy = `# Policy with user-defined function and wrong arguments
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      yast-test-checksum:
+      - attr: s
+      - attr: s
+    effect: Permit
+`
+
+	combiningAlgsPolicy = `# Policy with additional combining algorithms
+attributes:
+  s: string
+  r: string
+policies:
+  alg: PermitOverrides
+  policies:
+  - id: Only One
+    alg: OnlyOneApplicable
+    policies:
+    - id: First
+      target:
+      - equal:
+        - attr: s
+        - val:
+            type: string
+            content: first
+      alg: FirstApplicableEffect
+      rules:
+      - effect: Permit
+        obligations:
+        - r:
+            val:
+              type: string
+              content: first
+    - id: Mapped
+      target:
+      - equal:
+        - attr: s
+        - val:
+            type: string
+            content: second
+      alg:
+        id: Mapper
+        map:
+          val:
+            type: set of strings
+            content: [second, third]
+        alg: PermitUnlessDeny
+      rules:
+      - id: second
+        effect: Permit
+        obligations:
+        - r:
+            val:
+              type: string
+              content: second
+      - id: third
+        effect: Deny
+        obligations:
+        - r:
+            val:
+              type: string
+              content: third
+  - id: Deny All
+    alg: DenyUnlessPermit
+    rules:
+    - effect: Deny
+      obligations:
+      - r:
+          val:
+            type: string
+            content: deny
+`
+
+	badTimeZonePolicy = `# Policy with invalid time zone
+attributes:
+  t: time
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - id: Bad Time Zone
+    condition:
+      within-window:
+      - attr: t
+      - val:
+          type: string
+          content: "09:00"
+      - val:
+          type: string
+          content: "17:00"
+      - val:
+          type: string
+          content: Nowhere/Unknown
+    effect: Permit
+`
+
+	decisionPathPolicy = `# Policy with decision path obligations
+attributes:
+  rule: string
+  path: list of strings
+policies:
+  id: Root
+  alg: FirstApplicableEffect
+  policies:
+  - id: Policy
+    alg: FirstApplicableEffect
+    rules:
+    - id: Permit
+      effect: Permit
+      obligations:
+      - rule:
+          decision-rule: []
+      - path:
+          decision-path: []
+`
+
+	variablesPolicy = `# Policy with variables
+attributes:
+  s: string
+  r: string
+policies:
+  alg: FirstApplicableEffect
+  variables:
+  - name:
+      attr: s
+  policies:
+  - alg: FirstApplicableEffect
+    target:
+    - equal:
+      - var: name
+      - val:
+          type: string
+          content: first
+    rules:
+    - effect: Permit
+      obligations:
+      - r:
+          var: name
+  - alg: FirstApplicableEffect
+    variables:
+    - second:
+        equal:
+        - var: name
+        - val:
+            type: string
+            content: second
+    rules:
+    - condition:
+        var: second
+      effect: Permit
+      obligations:
+      - r:
+          val:
+            type: string
+            content: second rule
+    - effect: Deny
+      obligations:
+      - r:
+          var: name
+`
+
+	unknownVariablePolicy = `# Policy with variable out of scope
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  policies:
+  - alg: FirstApplicableEffect
+    variables:
+    - name:
+        attr: s
+    rules:
+    - effect: Permit
+  - alg: FirstApplicableEffect
+    rules:
+    - condition:
+        equal:
+        - var: name
+        - val:
+            type: string
+            content: test
+      effect: Permit
+`
+
+	duplicateVariablePolicy = `# Policy with duplicate variable
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  variables:
+  - name:
+      attr: s
+  - name:
+      val:
+        type: string
+        content: test
+  rules:
+  - effect: Permit
+`
+
+	customTypesPolicy = `# Policy with enum and struct types
+types:
+  level:
+    meta: enum
+    values: ["low", "medium", "high"]
+  device:
+    meta: struct
+    fields:
+    - name: string
+    - level: level
+
+attributes:
+  l: level
+  r: string
+
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      equal:
+      - attr: l
+      - val:
+          type: level
+          content: high
+    effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: high level
+  - condition:
+      equal:
+      - selector:
+          uri: local:content/devices
+          type: device
+          field: level
+          path:
+          - val:
+              type: string
+              content: core
+          default:
+            val:
+              type: level
+              content: low
+      - attr: l
+    effect: Permit
+    obligations:
+    - r:
+        to-string:
+        - attr: l
+`
+
+	invalidEnumValuePolicy = `# Policy with invalid enum value
+types:
+  level:
+    meta: enum
+    values: ["low", "high"]
+
+attributes:
+  l: level
+
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      equal:
+      - attr: l
+      - val:
+          type: level
+          content: medium
+    effect: Permit
+`
+
+	unknownStructFieldPolicy = `# Policy with selector for unknown struct field
+types:
+  device:
+    meta: struct
+    fields:
+    - name: string
+
+attributes:
+  s: string
+
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      equal:
+      - selector:
+          uri: local:content/devices
+          type: device
+          field: level
+          path:
+          - attr: s
+      - attr: s
+    effect: Permit
+`
+
+	quantifiedPolicy = `# Policy with quantified expressions
+attributes:
+  s1: string
+  s2: string
+  r: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      any-of:
+        var: scope
+        in:
+          concat:
+          - attr: s1
+          - attr: s2
+        condition:
+          starts-with:
+          - var: scope
+          - val:
+              type: string
+              content: admin
+    effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: privileged
+  - condition:
+      all-of:
+        var: scope
+        in:
+          concat:
+          - attr: s1
+          - attr: s2
+        condition:
+          contains:
+          - val:
+              type: set of strings
+              content: [read, write]
+          - var: scope
+    effect: Permit
+    obligations:
+    - r:
+        val:
+          type: string
+          content: allowed
+  - effect: Deny
+    obligations:
+    - r:
+        val:
+          type: string
+          content: denied
+`
+
+	elementOutOfScopePolicy = `# Policy with element variable out of quantified expression
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      and:
+      - any-of:
+          var: element
+          in:
+            val:
+              type: list of strings
+              content: [first, second]
+          condition:
+            equal:
+            - var: element
+            - attr: s
+      - equal:
+        - var: element
+        - attr: s
+    effect: Permit
+`
+
+	invalidCollectionPolicy = `# Policy with quantified expression over string
+attributes:
+  s: string
+policies:
+  alg: FirstApplicableEffect
+  rules:
+  - condition:
+      all-of:
+        var: element
+        in:
+          attr: s
+        condition:
+          equal:
+          - var: element
+          - attr: s
+    effect: Permit
+`
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -1580,6 +2136,107 @@ func TestSelectorBadError(t *testing.T) {
 	}
 }
 
+func TestDecisionPathFunctions(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(decisionPathPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertDecisionPathPolicy(s, "Permit", `"Root","Policy","Permit"`, t)
+}
+
+func TestVariables(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(variablesPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertPolicy(s, map[string]string{"s": "first"}, "first", "variable in target", t)
+	assertPolicy(s, map[string]string{"s": "second"}, "second rule", "variable in condition", t)
+	assertPolicy(s, map[string]string{"s": "other"}, "other", "variable in obligation", t)
+
+	_, err = p.Unmarshal(strings.NewReader(unknownVariablePolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *unknownVariableError but got no error")
+	} else if _, ok := err.(*unknownVariableError); !ok {
+		t.Errorf("Expected *unknownVariableError but got %T (%s)", err, err)
+	}
+
+	_, err = p.Unmarshal(strings.NewReader(duplicateVariablePolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *duplicateVariableError but got no error")
+	} else if _, ok := err.(*duplicateVariableError); !ok {
+		t.Errorf("Expected *duplicateVariableError but got %T (%s)", err, err)
+	}
+}
+
+func TestCustomTypes(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(customTypesPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	b, err := pdp.MarshalRequestAssignments([]pdp.AttributeAssignment{
+		pdp.MakeStringAssignment("l", "high"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	ctx, err := pdp.NewContextFromBytesWithSymbols(nil, b, s.Symbols())
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	r := s.Root().Calculate(ctx)
+	if r.Effect != pdp.EffectDeny {
+		t.Errorf("Expected %q effect but got %q (%v)", pdp.EffectNameFromEnum(pdp.EffectDeny), pdp.EffectNameFromEnum(r.Effect), r.Status)
+	}
+
+	_, err = p.Unmarshal(strings.NewReader(invalidEnumValuePolicy), nil)
+	if err == nil {
+		t.Errorf("Expected error for invalid enum value but got nothing")
+	} else if !strings.Contains(err.Error(), "\"medium\"") {
+		t.Errorf("Expected error for invalid enum value but got %T (%s)", err, err)
+	}
+
+	_, err = p.Unmarshal(strings.NewReader(unknownStructFieldPolicy), nil)
+	if err == nil {
+		t.Errorf("Expected error for unknown struct field but got nothing")
+	} else if !strings.Contains(err.Error(), "\"level\"") {
+		t.Errorf("Expected error for unknown struct field but got %T (%s)", err, err)
+	}
+}
+
+func TestQuantifiedExpressions(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(quantifiedPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertPolicy(s, map[string]string{"s1": "read", "s2": "write"}, "allowed", "all-of", t)
+	assertPolicy(s, map[string]string{"s1": "read", "s2": "delete"}, "denied", "all-of mismatch", t)
+	assertPolicy(s, map[string]string{"s1": "read", "s2": "admin:write"}, "privileged", "any-of", t)
+
+	_, err = p.Unmarshal(strings.NewReader(elementOutOfScopePolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *unknownVariableError but got no error")
+	} else if _, ok := err.(*unknownVariableError); !ok {
+		t.Errorf("Expected *unknownVariableError but got %T (%s)", err, err)
+	}
+
+	_, err = p.Unmarshal(strings.NewReader(invalidCollectionPolicy), nil)
+	if err == nil {
+		t.Errorf("Expected error for invalid collection but got no error")
+	} else if !strings.Contains(err.Error(), "as collection but got \"String\"") {
+		t.Errorf("Expected error for invalid collection but got %T (%s)", err, err)
+	}
+}
+
 func assertPolicy(s *pdp.PolicyStorage, attrs map[string]string, e, desc string, t *testing.T) {
 	ctx, err := newStringContext(attrs)
 	if err != nil {
@@ -1634,3 +2291,164 @@ func newStringContext(m map[string]string) (*pdp.Context, error) {
 		return n, pdp.MakeStringValue(v), nil
 	})
 }
+
+func TestPatternFunctions(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(patternFunctionsPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertPolicy(s, map[string]string{"s": "curl/7.58.0"}, "match", "regular expression", t)
+	assertPolicy(s, map[string]string{"s": "wget/1.19.4 (linux-gnu)"}, "glob", "glob pattern", t)
+	assertPolicy(s, map[string]string{"s": "Mozilla/5.0"}, "starts-with", "prefix", t)
+	assertPolicy(s, map[string]string{"s": "Googlebot/2.1 (bot)"}, "ends-with", "suffix", t)
+	assertPolicy(s, map[string]string{"s": "curl/latest"}, "none", "no match", t)
+
+	_, err = p.Unmarshal(strings.NewReader(badRegexpPolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *externalError but got no error")
+	} else if _, ok := err.(*externalError); !ok {
+		t.Errorf("Expected *externalError but got %T (%s)", err, err)
+	} else if !strings.Contains(err.Error(), "rule \"Bad Pattern\">match") {
+		t.Errorf("Expected error with path to the pattern but got %q", err)
+	}
+}
+
+func TestTimeFunctions(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(timeFunctionsPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertTimePolicy(s, time.Date(2018, 6, 15, 14, 0, 0, 0, time.UTC), "business hours", "business hours", t)
+	assertTimePolicy(s, time.Date(2018, 6, 15, 23, 0, 0, 0, time.UTC), "expired", "expired", t)
+	assertTimePolicy(s, time.Date(2018, 6, 14, 3, 0, 0, 0, time.UTC), "Wednesday", "night", t)
+
+	_, err = p.Unmarshal(strings.NewReader(badTimeZonePolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *externalError but got no error")
+	} else if _, ok := err.(*externalError); !ok {
+		t.Errorf("Expected *externalError but got %T (%s)", err, err)
+	} else if !strings.Contains(err.Error(), "rule \"Bad Time Zone\">within-window") {
+		t.Errorf("Expected error with path to the function but got %q", err)
+	}
+}
+
+func TestCombiningAlgs(t *testing.T) {
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(combiningAlgsPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertPolicy(s, map[string]string{"s": "first"}, "first", "only one applicable", t)
+	assertPolicy(s, map[string]string{"s": "second"}, "third", "permit unless deny", t)
+	assertPolicy(s, map[string]string{"s": "other"}, "deny", "deny unless permit", t)
+}
+
+func TestCustomFunction(t *testing.T) {
+	err := pdp.RegisterFunction("yast-test-checksum", pdp.MakeSignature(pdp.TypeString, pdp.TypeInteger), pdp.TypeBoolean,
+		func(args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+			s, err := args[0].GetString()
+			if err != nil {
+				return pdp.UndefinedValue, err
+			}
+
+			n, err := args[1].GetInteger()
+			if err != nil {
+				return pdp.UndefinedValue, err
+			}
+
+			sum := 0
+			for _, c := range s {
+				sum += int(c)
+			}
+
+			return pdp.MakeBooleanValue(int64(sum)%n == 0), nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	p := Parser{}
+	s, err := p.Unmarshal(strings.NewReader(customFunctionPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	assertPolicy(s, map[string]string{"s": "F"}, "valid", "valid checksum", t)
+	assertPolicy(s, map[string]string{"s": "E"}, "invalid", "invalid checksum", t)
+
+	_, err = p.Unmarshal(strings.NewReader(badCustomFunctionPolicy), nil)
+	if err == nil {
+		t.Errorf("Expected *functionCastError but got no error")
+	} else if _, ok := err.(*functionCastError); !ok {
+		t.Errorf("Expected *functionCastError but got %T (%s)", err, err)
+	}
+}
+
+func assertTimePolicy(s *pdp.PolicyStorage, tm time.Time, e, desc string, t *testing.T) {
+	ctx, err := pdp.NewContext(nil, 1, func(i int) (string, pdp.AttributeValue, error) {
+		return "t", pdp.MakeTimeValue(tm), nil
+	})
+	if err != nil {
+		t.Errorf("Expected no error for %s but got %T (%s)", desc, err, err)
+		return
+	}
+
+	r := s.Root().Calculate(ctx)
+	if r.Status != nil {
+		t.Errorf("Expected no error for %s but got %T (%s)", desc, r.Status, r.Status)
+		return
+	}
+
+	if len(r.Obligations) < 1 {
+		t.Errorf("Expected at least one obligation for %s but got nothing", desc)
+		return
+	}
+
+	_, _, v, err := r.Obligations[0].Serialize(ctx)
+	if err != nil {
+		t.Errorf("Expected no error for %s but got %T (%s)", desc, err, err)
+		return
+	}
+
+	if v != e {
+		t.Errorf("Expected %q for %s but got %q", e, desc, v)
+	}
+}
+
+func assertDecisionPathPolicy(s *pdp.PolicyStorage, rule, path string, t *testing.T) {
+	ctx, err := newStringContext(map[string]string{})
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	if !s.UsesDecisionPath() {
+		t.Fatalf("Expected policies which use decision path")
+	}
+	ctx.EnableDecisionPath()
+
+	r := s.Root().Calculate(ctx)
+	if r.Effect != pdp.EffectPermit {
+		t.Fatalf("Expected %s but got %s (%v)",
+			pdp.EffectNameFromEnum(pdp.EffectPermit), pdp.EffectNameFromEnum(r.Effect), r.Status)
+	}
+
+	ctx.SetDecision(r)
+	for i, e := range []string{rule, path} {
+		if i >= len(r.Obligations) {
+			t.Fatalf("Expected %d obligations but got %d", 2, len(r.Obligations))
+		}
+
+		_, _, v, err := r.Obligations[i].Serialize(ctx)
+		if err != nil {
+			t.Errorf("Expected no error for obligation %d but got %T (%s)", i+1, err, err)
+		} else if v != e {
+			t.Errorf("Expected %s for obligation %d but got %s", e, i+1, v)
+		}
+	}
+}
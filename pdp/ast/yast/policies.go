@@ -153,6 +153,11 @@ func (ctx context) unmarshalRuleCombiningAlg(m map[interface{}]interface{}, rule
 func (ctx context) unmarshalPolicy(m map[interface{}]interface{}, i int, ID string, hidden bool, rules interface{}) (pdp.Evaluable, boundError) {
 	src := makeSource("policy", ID, hidden, i)
 
+	ctx = ctx.newVariableScope()
+	if err := ctx.unmarshalVariables(m); err != nil {
+		return nil, bindError(err, src)
+	}
+
 	target, err := ctx.unmarshalTarget(m)
 	if err != nil {
 		return nil, bindError(err, src)
@@ -326,6 +331,11 @@ func (ctx context) unmarshalPolicies(v interface{}) ([]pdp.Evaluable, boundError
 func (ctx context) unmarshalPolicySet(m map[interface{}]interface{}, i int, ID string, hidden bool, policies interface{}) (pdp.Evaluable, boundError) {
 	src := makeSource("policy set", ID, hidden, i)
 
+	ctx = ctx.newVariableScope()
+	if err := ctx.unmarshalVariables(m); err != nil {
+		return nil, bindError(err, src)
+	}
+
 	target, err := ctx.unmarshalTarget(m)
 	if err != nil {
 		return nil, bindError(err, src)
@@ -54,6 +54,22 @@ func (ctx context) unmarshalSelector(v interface{}) (pdp.Expression, boundError)
 
 	var opts []pdp.SelectorOption
 
+	field, ok, err := ctx.extractStringOpt(m, yastTagField, "field")
+	if err != nil {
+		return nil, bindErrorf(err, "selector(%s)", uri)
+	}
+
+	rt := t
+	if ok {
+		ft, eErr := pdp.GetStructFieldType(t, field)
+		if eErr != nil {
+			return nil, bindErrorf(newExternalError(eErr), "selector(%s).field", uri)
+		}
+
+		rt = ft
+		opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionField, Data: field})
+	}
+
 	defMap, ok, err := ctx.extractMapOpt(m, yastTagDefault, "default")
 	if err != nil {
 		return nil, bindErrorf(err, "selector(%s).default", uri)
@@ -64,8 +80,8 @@ func (ctx context) unmarshalSelector(v interface{}) (pdp.Expression, boundError)
 			return nil, bindErrorf(err, "selector(%s).default", uri)
 		}
 		if defExp != nil {
-			if defExp.GetResultType() != t {
-				return nil, bindErrorf(newInvalidTypeError(t), "selector(%s).default", uri)
+			if defExp.GetResultType() != rt {
+				return nil, bindErrorf(newInvalidTypeError(rt), "selector(%s).default", uri)
 			}
 			opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionDefault, Data: defExp})
 		}
@@ -81,8 +97,8 @@ func (ctx context) unmarshalSelector(v interface{}) (pdp.Expression, boundError)
 			return nil, bindErrorf(err, "selector(%s).error", uri)
 		}
 		if errExp != nil {
-			if errExp.GetResultType() != t {
-				return nil, bindErrorf(newInvalidTypeError(t), "selector(%s).error", uri)
+			if errExp.GetResultType() != rt {
+				return nil, bindErrorf(newInvalidTypeError(rt), "selector(%s).error", uri)
 			}
 			opts = append(opts, pdp.SelectorOption{Name: pdp.SelectorOptionError, Data: errExp})
 		}
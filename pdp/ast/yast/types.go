@@ -34,16 +34,113 @@ func (ctx *context) unmarshalFlagsTypeDeclaration(id string, m map[interface{}]i
 	return nil
 }
 
+func (ctx *context) unmarshalEnumTypeDeclaration(id string, m map[interface{}]interface{}) boundError {
+	items, err := ctx.extractList(m, yastTagValues, "list of enum values")
+	if err != nil {
+		return err
+	}
+
+	values := make([]string, len(items))
+	for i, v := range items {
+		s, err := ctx.validateString(v, "enum value")
+		if err != nil {
+			return err
+		}
+
+		values[i] = s
+	}
+
+	t, eErr := pdp.NewEnumType(id, values...)
+	if eErr != nil {
+		return newExternalError(eErr)
+	}
+
+	if eErr := ctx.symbols.PutType(t); eErr != nil {
+		return newExternalError(eErr)
+	}
+
+	return nil
+}
+
+func (ctx *context) unmarshalStructField(v interface{}) (pdp.StructField, boundError) {
+	m, err := ctx.validateMap(v, "struct field")
+	if err != nil {
+		return pdp.StructField{}, err
+	}
+
+	k, v, err := ctx.getSingleMapPair(m, "struct field")
+	if err != nil {
+		return pdp.StructField{}, err
+	}
+
+	name, err := ctx.validateString(k, "field name")
+	if err != nil {
+		return pdp.StructField{}, err
+	}
+
+	s, err := ctx.validateString(v, "field type")
+	if err != nil {
+		return pdp.StructField{}, bindError(err, name)
+	}
+
+	t := ctx.symbols.GetType(s)
+	if t == nil {
+		return pdp.StructField{}, bindError(newUnknownTypeError(s), name)
+	}
+
+	return pdp.StructField{
+		Name: name,
+		Type: t,
+	}, nil
+}
+
+func (ctx *context) unmarshalStructTypeDeclaration(id string, m map[interface{}]interface{}) boundError {
+	items, err := ctx.extractList(m, yastTagFields, "list of struct fields")
+	if err != nil {
+		return err
+	}
+
+	fields := make([]pdp.StructField, len(items))
+	for i, v := range items {
+		f, err := ctx.unmarshalStructField(v)
+		if err != nil {
+			return bindErrorf(err, "%d", i)
+		}
+
+		fields[i] = f
+	}
+
+	t, eErr := pdp.NewStructType(id, fields...)
+	if eErr != nil {
+		return newExternalError(eErr)
+	}
+
+	if eErr := ctx.symbols.PutType(t); eErr != nil {
+		return newExternalError(eErr)
+	}
+
+	return nil
+}
+
 func (ctx *context) unmarshalTypeDeclarationByMetaType(id, meta string, m map[interface{}]interface{}) boundError {
 	switch strings.ToLower(meta) {
 	case yastTagFlags:
 		return ctx.unmarshalFlagsTypeDeclaration(id, m)
+
+	case yastTagEnum:
+		return ctx.unmarshalEnumTypeDeclaration(id, m)
+
+	case yastTagStruct:
+		return ctx.unmarshalStructTypeDeclaration(id, m)
 	}
 
 	return newUnknownMetaTypeError(meta)
 }
 
-func (ctx *context) unmarshalTypeDeclaration(k, v interface{}) boundError {
+// unmarshalTypeDeclaration parses type declaration. If structs argument is
+// false it skips struct types and parses all other types otherwise it parses
+// only struct types.
+func (ctx *context) unmarshalTypeDeclaration(k, v interface{}, structs bool) boundError {
 	ID, err := ctx.validateString(k, "type id")
 	if err != nil {
 		return err
@@ -59,6 +156,10 @@ func (ctx *context) unmarshalTypeDeclaration(k, v interface{}) boundError {
 		return bindError(err, ID)
 	}
 
+	if structs != (strings.ToLower(meta) == yastTagStruct) {
+		return nil
+	}
+
 	if err := ctx.unmarshalTypeDeclarationByMetaType(ID, meta, m); err != nil {
 		return bindError(err, ID)
 	}
@@ -66,16 +167,20 @@ func (ctx *context) unmarshalTypeDeclaration(k, v interface{}) boundError {
 	return nil
 }
 
+// unmarshalTypeDeclarations parses all type declarations. As fields of struct
+// type can have enum type struct types are parsed after all other types.
 func (ctx *context) unmarshalTypeDeclarations(m map[interface{}]interface{}) boundError {
 	types, ok, err := ctx.extractMapOpt(m, yastTagTypes, "type declarations")
 	if !ok || err != nil {
 		return err
 	}
 
-	for k, v := range types {
-		err = ctx.unmarshalTypeDeclaration(k, v)
-		if err != nil {
-			return bindError(err, yastTagTypes)
+	for _, structs := range []bool{false, true} {
+		for k, v := range types {
+			err = ctx.unmarshalTypeDeclaration(k, v, structs)
+			if err != nil {
+				return bindError(err, yastTagTypes)
+			}
 		}
 	}
 
@@ -2,6 +2,7 @@ package yast
 
 import (
 	"net"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -79,6 +80,34 @@ func (ctx context) unmarshalDomainValue(v interface{}) (pdp.AttributeValue, boun
 	return pdp.MakeDomainValue(d), nil
 }
 
+func (ctx context) unmarshalTimeValue(v interface{}) (pdp.AttributeValue, boundError) {
+	s, err := ctx.validateString(v, "value of time type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	t, ierr := time.Parse(time.RFC3339Nano, s)
+	if ierr != nil {
+		return pdp.UndefinedValue, newInvalidTimeError(s, ierr)
+	}
+
+	return pdp.MakeTimeValue(t), nil
+}
+
+func (ctx context) unmarshalDurationValue(v interface{}) (pdp.AttributeValue, boundError) {
+	s, err := ctx.validateString(v, "value of duration type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	dur, ierr := time.ParseDuration(s)
+	if ierr != nil {
+		return pdp.UndefinedValue, newInvalidDurationError(s, ierr)
+	}
+
+	return pdp.MakeDurationValue(dur), nil
+}
+
 func (ctx context) unmarshalSetOfStringsValueItem(v interface{}, i int, set *strtree.Tree) boundError {
 	s, err := ctx.validateString(v, "element")
 	if err != nil {
@@ -298,9 +327,27 @@ func (ctx context) unmarshalFlags64Value(v []interface{}, t *pdp.FlagsType) (pdp
 	return pdp.MakeFlagsValue64(n, t), nil
 }
 
+func (ctx context) unmarshalEnumValue(v interface{}, t *pdp.EnumType) (pdp.AttributeValue, boundError) {
+	s, err := ctx.validateString(v, "value of enum type")
+	if err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	a, eErr := pdp.MakeEnumValue(s, t)
+	if eErr != nil {
+		return pdp.UndefinedValue, newExternalError(eErr)
+	}
+
+	return a, nil
+}
+
 func (ctx context) unmarshalValueByType(t pdp.Type, v interface{}) (pdp.AttributeValue, boundError) {
-	if t, ok := t.(*pdp.FlagsType); ok {
+	switch t := t.(type) {
+	case *pdp.FlagsType:
 		return ctx.unmarshalFlagsValue(v, t)
+
+	case *pdp.EnumType:
+		return ctx.unmarshalEnumValue(v, t)
 	}
 
 	switch t {
@@ -333,6 +380,12 @@ func (ctx context) unmarshalValueByType(t pdp.Type, v interface{}) (pdp.Attribut
 
 	case pdp.TypeListOfStrings:
 		return ctx.unmarshalListOfStringsValue(v)
+
+	case pdp.TypeTime:
+		return ctx.unmarshalTimeValue(v)
+
+	case pdp.TypeDuration:
+		return ctx.unmarshalDurationValue(v)
 	}
 
 	return pdp.UndefinedValue, newNotImplementedValueTypeError(t)
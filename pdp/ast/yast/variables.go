@@ -0,0 +1,149 @@
+package yast
+
+import "github.com/infobloxopen/themis/pdp"
+
+// variables represents scope of variables defined by policy set or policy.
+// Descendants see variables of all enclosing scopes.
+type variables struct {
+	parent *variables
+	m      map[string]*pdp.Variable
+}
+
+func (ctx context) newVariableScope() context {
+	ctx.vars = &variables{
+		parent: ctx.vars,
+		m:      make(map[string]*pdp.Variable),
+	}
+
+	return ctx
+}
+
+func (ctx context) getVariable(ID string) (*pdp.Variable, bool) {
+	for s := ctx.vars; s != nil; s = s.parent {
+		if v, ok := s.m[ID]; ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func (ctx context) unmarshalVariableReference(v interface{}) (pdp.VariableReference, boundError) {
+	ID, err := ctx.validateString(v, "variable ID")
+	if err != nil {
+		return pdp.VariableReference{}, err
+	}
+
+	vr, ok := ctx.getVariable(ID)
+	if !ok {
+		return pdp.VariableReference{}, newUnknownVariableError(ID)
+	}
+
+	return pdp.MakeVariableReference(vr), nil
+}
+
+func (ctx context) unmarshalVariablesItem(v interface{}) boundError {
+	m, err := ctx.validateMap(v, "variable")
+	if err != nil {
+		return err
+	}
+
+	k, v, err := ctx.getSingleMapPair(m, "variable")
+	if err != nil {
+		return err
+	}
+
+	ID, err := ctx.validateString(k, "variable id")
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.vars.m[ID]; ok {
+		return newDuplicateVariableError(ID)
+	}
+
+	e, err := ctx.unmarshalExpression(v)
+	if err != nil {
+		return bindError(err, ID)
+	}
+
+	ctx.vars.m[ID] = pdp.NewVariable(ID, e)
+	return nil
+}
+
+// unmarshalVariables puts variables defined in given policy set or policy
+// to current scope. A variable can refer to any variable defined before it.
+func (ctx context) unmarshalVariables(m map[interface{}]interface{}) boundError {
+	items, ok, err := ctx.extractListOpt(m, yastTagVariables, "variables")
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	for i, item := range items {
+		if err := ctx.unmarshalVariablesItem(item); err != nil {
+			return bindError(bindErrorf(err, "%d", i), "variables")
+		}
+	}
+
+	return nil
+}
+
+// unmarshalQuantifiedExpression parses any-of or all-of expression. Element
+// variable is visible only within condition of the expression and hides
+// variables with the same name from enclosing scopes.
+func (ctx context) unmarshalQuantifiedExpression(name string, v interface{}) (pdp.Expression, boundError) {
+	m, err := ctx.validateMap(v, name)
+	if err != nil {
+		return nil, bindError(err, name)
+	}
+
+	ID, err := ctx.extractString(m, yastTagVariable, "element variable id")
+	if err != nil {
+		return nil, bindError(err, name)
+	}
+
+	cm, err := ctx.extractMap(m, yastTagIn, "collection")
+	if err != nil {
+		return nil, bindError(err, name)
+	}
+
+	c, err := ctx.unmarshalExpression(cm)
+	if err != nil {
+		return nil, bindError(bindError(err, yastTagIn), name)
+	}
+
+	vr, eErr := pdp.NewElementVariable(ID, c)
+	if eErr != nil {
+		return nil, bindError(bindError(newExternalError(eErr), yastTagIn), name)
+	}
+
+	em, err := ctx.extractMap(m, yastTagCondition, "condition")
+	if err != nil {
+		return nil, bindError(err, name)
+	}
+
+	ctx = ctx.newVariableScope()
+	ctx.vars.m[ID] = vr
+
+	e, err := ctx.unmarshalExpression(em)
+	if err != nil {
+		return nil, bindError(bindError(err, yastTagCondition), name)
+	}
+
+	var q pdp.Expression
+	if name == yastTagAnyOf {
+		q, eErr = pdp.MakeAnyOfExpression(vr, c, e)
+	} else {
+		q, eErr = pdp.MakeAllOfExpression(vr, c, e)
+	}
+
+	if eErr != nil {
+		return nil, bindError(newExternalError(eErr), name)
+	}
+
+	return q, nil
+}
@@ -2,8 +2,10 @@ package pdp
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -31,6 +33,8 @@ var ContentKeyTypes = makeTypeSet(
 	TypeAddress,
 	TypeNetwork,
 	TypeDomain,
+	TypeInteger,
+	TypeFloat,
 )
 
 // LocalContentStorage is a storage of all independent local contents.
@@ -76,6 +80,18 @@ func (s *LocalContentStorage) Add(c *LocalContent) *LocalContentStorage {
 	return &LocalContentStorage{r: s.r.Insert(c.id, c)}
 }
 
+// Delete removes content with given id from storage. It returns copy
+// of existing storage without the content. Existing storage isn't affected
+// by the operation.
+func (s *LocalContentStorage) Delete(cID string) *LocalContentStorage {
+	r, ok := s.r.Delete(cID)
+	if !ok {
+		return s
+	}
+
+	return &LocalContentStorage{r: r}
+}
+
 // GetLocalContent returns content from storage by given id only if the content
 // has its own tag and the tag matches to tag argument.
 func (s *LocalContentStorage) GetLocalContent(cID string, tag *uuid.UUID) (*LocalContent, error) {
@@ -104,6 +120,18 @@ func (s *LocalContentStorage) GetLocalContent(cID string, tag *uuid.UUID) (*Loca
 	return c, nil
 }
 
+// Contents returns all contents from the storage ordered by id.
+func (s *LocalContentStorage) Contents() []*LocalContent {
+	out := []*LocalContent{}
+	for p := range s.r.Enumerate() {
+		if c, ok := p.Value.(*LocalContent); ok {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
 // NewTransaction creates new transaction for given content in the storage.
 func (s *LocalContentStorage) NewTransaction(cID string, tag *uuid.UUID) (*LocalContentStorageTransaction, error) {
 	c, err := s.GetLocalContent(cID, tag)
@@ -116,6 +144,7 @@ func (s *LocalContentStorage) NewTransaction(cID string, tag *uuid.UUID) (*Local
 		ID:      cID,
 		items:   c.items,
 		symbols: c.symbols.makeROCopy(),
+		swept:   c.swept,
 	}, nil
 }
 
@@ -195,6 +224,7 @@ type LocalContentStorageTransaction struct {
 	ID      string
 	items   *strtree.Tree
 	symbols Symbols
+	swept   sweptKeys
 	err     error
 }
 
@@ -253,7 +283,7 @@ func (t *LocalContentStorageTransaction) Commit(s *LocalContentStorage) (*LocalC
 		return nil, newFailedContentTransactionError(t.ID, t.tag, t.err)
 	}
 
-	c := &LocalContent{id: t.ID, tag: &t.tag, items: t.items, symbols: t.symbols}
+	c := &LocalContent{id: t.ID, tag: &t.tag, items: t.items, symbols: t.symbols, swept: t.swept}
 	if s == nil {
 		return NewLocalContentStorage([]*LocalContent{c}), nil
 	}
@@ -275,38 +305,84 @@ func (t *LocalContentStorageTransaction) getItem(ID string) (*ContentItem, error
 	return c, nil
 }
 
-func (t *LocalContentStorageTransaction) parsePath(c *ContentItem, rawPath []string) ([]AttributeValue, error) {
+func (t *LocalContentStorageTransaction) parsePath(c *ContentItem, rawPath []string) ([]AttributeValue, contentKeySet, error) {
 	if len(rawPath) > len(c.k) {
-		return nil, newTooLongRawPathContentModificationError(c.k, rawPath)
+		return nil, nil, newTooLongRawPathContentModificationError(c.k, rawPath)
 	}
 
-	path := make([]AttributeValue, len(rawPath))
+	path := make([]AttributeValue, 0, len(rawPath))
 	for i, s := range rawPath {
+		r, err := parseKeySet(c.k[i], s)
+		if err != nil {
+			return nil, nil, bindErrorf(err, "%d", i+2)
+		}
+
+		if r != nil {
+			if i < len(rawPath)-1 {
+				return nil, nil, bindErrorf(newKeySetNotLastError(s), "%d", i+2)
+			}
+
+			return path, r, nil
+		}
+
 		if c.k[i] == TypeAddress || c.k[i] == TypeNetwork {
 			a := net.ParseIP(s)
 			if a != nil {
-				path[i] = MakeAddressValue(a)
+				path = append(path, MakeAddressValue(a))
 				continue
 			}
 
 			_, n, err := net.ParseCIDR(s)
 			if err == nil {
-				path[i] = MakeNetworkValue(n)
+				path = append(path, MakeNetworkValue(n))
 				continue
 			}
 
-			return nil, bindErrorf(newInvalidAddressNetworkStringCastError(s, err), "%d", i+2)
+			return nil, nil, bindErrorf(newInvalidAddressNetworkStringCastError(s, err), "%d", i+2)
 		}
 
 		v, err := MakeValueFromString(c.k[i], s)
 		if err != nil {
-			return nil, bindErrorf(err, "%d", i+2)
+			return nil, nil, bindErrorf(err, "%d", i+2)
 		}
 
-		path[i] = v
+		path = append(path, v)
 	}
 
-	return path, nil
+	return path, nil, nil
+}
+
+// parseKeySet parses key of given type which covers a set of keys. It returns
+// nil if the key is a regular one.
+func parseKeySet(t Type, s string) (contentKeySet, error) {
+	switch t {
+	case TypeInteger:
+		if IsRangeKey(s) {
+			return ParseIntegerRange(s)
+		}
+
+	case TypeFloat:
+		if IsRangeKey(s) {
+			return ParseFloatRange(s)
+		}
+
+	case TypeAddress, TypeNetwork:
+		if IsRangeKey(s) {
+			nets, err := ParseAddressRange(s)
+			if err != nil {
+				return nil, err
+			}
+
+			return addressRange{s: s, nets: nets}, nil
+		}
+
+	case TypeDomain:
+		if IsDomainKeyPattern(s) {
+			return parseDomainKeyPattern(s)
+		}
+	}
+
+	return nil, nil
 }
 
 func (t *LocalContentStorageTransaction) add(rawPath []string, v interface{}) error {
@@ -319,20 +395,30 @@ func (t *LocalContentStorageTransaction) add(rawPath []string, v interface{}) er
 	if len(rawPath) > 1 {
 		c, err := t.getItem(ID)
 		if err != nil {
+			if t.swept.covers([]string{ID}) {
+				return nil
+			}
+
 			return bindError(err, t.ID)
 		}
 
-		path, err := t.parsePath(c, rawPath[1:])
+		path, r, err := t.parsePath(c, rawPath[1:])
 		if err != nil {
 			return bindError(err, t.ID)
 		}
 
-		c, err = c.add(ID, path, v)
+		swept := sweptPath(ID, c.k, path, r)
+		c, err = c.add(ID, path, r, v)
 		if err != nil {
+			if isMissingValue(err) && t.swept.covers(swept) {
+				return nil
+			}
+
 			return bindError(bindError(err, ID), t.ID)
 		}
 
 		t.items = t.items.Insert(ID, c)
+		t.swept = t.swept.forget(swept)
 		return nil
 	}
 
@@ -344,6 +430,7 @@ func (t *LocalContentStorageTransaction) add(rawPath []string, v interface{}) er
 	c.id = ID
 
 	t.items = t.items.Insert(ID, c)
+	t.swept = t.swept.forget([]string{ID})
 	return nil
 }
 
@@ -357,29 +444,46 @@ func (t *LocalContentStorageTransaction) del(rawPath []string) error {
 	if len(rawPath) > 1 {
 		c, err := t.getItem(ID)
 		if err != nil {
+			if t.swept.covers([]string{ID}) {
+				return nil
+			}
+
 			return bindError(err, t.ID)
 		}
 
-		path, err := t.parsePath(c, rawPath[1:])
+		path, r, err := t.parsePath(c, rawPath[1:])
 		if err != nil {
 			return bindError(err, t.ID)
 		}
 
-		c, err = c.del(ID, path)
+		swept := sweptPath(ID, c.k, path, r)
+		c, err = c.del(ID, path, r)
 		if err != nil {
+			if isMissingValue(err) && t.swept.covers(swept) {
+				t.skipSweptDeletion(swept)
+				return nil
+			}
+
 			return bindError(bindError(err, ID), t.ID)
 		}
 
 		t.items = t.items.Insert(ID, c)
+		t.swept = t.swept.forget(swept)
 		return nil
 	}
 
 	items, ok := t.items.Delete(ID)
 	if !ok {
+		if t.swept.removed([]string{ID}) {
+			t.skipSweptDeletion([]string{ID})
+			return nil
+		}
+
 		return bindError(newMissingContentItemError(ID), t.ID)
 	}
 
 	t.items = items
+	t.swept = t.swept.forget([]string{ID})
 	return nil
 }
 
@@ -391,6 +495,7 @@ type LocalContent struct {
 	tag     *uuid.UUID
 	items   *strtree.Tree
 	symbols Symbols
+	swept   sweptKeys
 }
 
 // NewLocalContent creates content of given id with given tag and set of content
@@ -411,6 +516,16 @@ func NewLocalContent(id string, tag *uuid.UUID, symbols Symbols, items []*Conten
 	return c
 }
 
+// GetID returns id of the content.
+func (c *LocalContent) GetID() string {
+	return c.id
+}
+
+// GetTag returns tag of the content or nil if the content is untagged.
+func (c *LocalContent) GetTag() *uuid.UUID {
+	return c.tag
+}
+
 // Get returns content item of given id.
 func (c *LocalContent) Get(ID string) (*ContentItem, error) {
 	v, ok := c.items.Get(ID)
@@ -505,7 +620,7 @@ func (c *ContentItem) GetType() Type {
 	return c.t
 }
 
-func (c *ContentItem) typeCheck(path []AttributeValue, v interface{}) (ContentSubItem, error) {
+func (c *ContentItem) typeCheck(depth int, v interface{}) (ContentSubItem, error) {
 	item, ok := v.(*ContentItem)
 	if !ok {
 		return nil, newInvalidContentUpdateDataError(v)
@@ -515,46 +630,69 @@ func (c *ContentItem) typeCheck(path []AttributeValue, v interface{}) (ContentSu
 		return nil, newInvalidContentUpdateResultTypeError(item.t, c.t)
 	}
 
-	if len(path) < len(c.k) {
-		if len(path)+len(item.k) != len(c.k) {
-			return nil, newInvalidContentUpdateKeysError(len(path), item.k, c.k)
+	r := unwrapContentSubItem(item.r)
+
+	if depth < len(c.k) {
+		if depth+len(item.k) != len(c.k) {
+			return nil, newInvalidContentUpdateKeysError(depth, item.k, c.k)
 		}
 
 		for i, k := range item.k {
-			if k != c.k[len(path)+i] {
-				return nil, newInvalidContentUpdateKeysError(len(path), item.k, c.k)
+			if k != c.k[depth+i] {
+				return nil, newInvalidContentUpdateKeysError(depth, item.k, c.k)
 			}
 		}
 
-		switch c.k[len(path)] {
+		switch c.k[depth] {
 		default:
-			return nil, newInvalidContentKeyTypeError(c.k[len(path)], ContentKeyTypes)
+			return nil, newInvalidContentKeyTypeError(c.k[depth], ContentKeyTypes)
 
 		case TypeString:
-			if _, ok := item.r.(ContentStringMap); !ok {
+			if _, ok := r.(ContentStringMap); !ok {
 				return nil, newInvalidContentStringMapError(v)
 			}
 
 		case TypeAddress, TypeNetwork:
-			if _, ok := item.r.(ContentNetworkMap); !ok {
+			if _, ok := r.(ContentNetworkMap); !ok {
 				return nil, newInvalidContentNetworkMapError(v)
 			}
 
 		case TypeDomain:
-			if _, ok := item.r.(ContentDomainMap); !ok {
+			switch r.(type) {
+			default:
 				return nil, newInvalidContentDomainMapError(v)
+
+			case ContentDomainMap, ContentDomainMatchMap:
+			}
+
+		case TypeInteger:
+			if _, ok := r.(ContentIntegerMap); !ok {
+				return nil, newInvalidContentIntegerMapError(v)
+			}
+
+		case TypeFloat:
+			if _, ok := r.(ContentFloatMap); !ok {
+				return nil, newInvalidContentFloatMapError(v)
 			}
 		}
 
 		return item.r, nil
 	}
 
-	subItem, ok := item.r.(ContentValue)
+	subItem, ok := r.(ContentValue)
 	if !ok {
 		return nil, newInvalidContentValueError(v)
 	}
 
-	if t, ok := c.t.(*FlagsType); ok {
+	if _, ok := c.t.(*EnumType); ok {
+		if _, ok := subItem.value.(string); !ok {
+			return nil, newInvalidContentValueTypeError(subItem.value, c.t)
+		}
+	} else if _, ok := c.t.(*StructType); ok {
+		if v, ok := subItem.value.(AttributeValue); !ok || v.t != c.t {
+			return nil, newInvalidContentValueTypeError(subItem.value, c.t)
+		}
+	} else if t, ok := c.t.(*FlagsType); ok {
 		switch t.Capacity() {
 		case 8:
 			if _, ok := subItem.value.(uint8); !ok {
@@ -638,84 +776,95 @@ func (c *ContentItem) typeCheck(path []AttributeValue, v interface{}) (ContentSu
 			if _, ok := subItem.value.([]string); !ok {
 				return nil, newInvalidContentValueTypeError(subItem.value, c.t)
 			}
-		}
-	}
 
-	return subItem, nil
-}
+		case TypeTime:
+			if _, ok := subItem.value.(time.Time); !ok {
+				return nil, newInvalidContentValueTypeError(subItem.value, c.t)
+			}
 
-func (c *ContentItem) add(ID string, path []AttributeValue, v interface{}) (*ContentItem, error) {
-	if len(c.k) <= 0 {
-		return c, newInvalidContentModificationError()
+		case TypeDuration:
+			if _, ok := subItem.value.(time.Duration); !ok {
+				return nil, newInvalidContentValueTypeError(subItem.value, c.t)
+			}
+		}
 	}
 
-	if len(path) <= 0 {
-		return c, newMissingPathContentModificationError()
-	}
+	return item.r, nil
+}
 
-	if len(path) > len(c.k) {
-		return c, newTooLongPathContentModificationError(c.k, path)
+func (c *ContentItem) add(ID string, path []AttributeValue, r contentKeySet, v interface{}) (*ContentItem, error) {
+	depth, err := c.checkModificationPath(path, r)
+	if err != nil {
+		return c, err
 	}
 
-	var err error
-	m := c.r
-
-	last := len(path) - 1
-	branch := make([]ContentSubItem, last)
-
-	loc := []string{""}
-
-	for i, k := range path[:last] {
-		branch[i] = m
-		loc = append(loc, k.describe())
-
-		m, err = m.next(k)
+	return c.modify(ID, path, r, func(m ContentSubItem) (ContentSubItem, error) {
+		subItem, err := c.typeCheck(depth, v)
 		if err != nil {
-			return c, bindError(err, strings.Join(loc, "/"))
+			return m, err
 		}
-	}
 
-	k := path[last]
-	loc = append(loc, k.describe())
+		if _, ok := subItem.(expiringSubItem); ok && isContentFlagsMap(m) {
+			return m, newExpiringContentFlagsValueError()
+		}
 
-	subItem, err := c.typeCheck(path, v)
-	if err != nil {
-		return c, bindError(err, strings.Join(loc, "/"))
-	}
+		if r != nil {
+			return r.put(m, subItem)
+		}
 
-	m, err = m.put(k, subItem)
-	if err != nil {
-		return c, bindError(err, strings.Join(loc, "/"))
+		return m.put(path[len(path)-1], subItem)
+	})
+}
+
+func (c *ContentItem) del(ID string, path []AttributeValue, r contentKeySet) (*ContentItem, error) {
+	if _, err := c.checkModificationPath(path, r); err != nil {
+		return c, err
 	}
 
-	for i := len(branch) - 1; i >= 0; i-- {
-		p := branch[i]
-		m, err = p.put(path[i], m)
-		if err != nil {
-			return c, bindError(err, strings.Join(loc[:i], "/"))
+	return c.modify(ID, path, r, func(m ContentSubItem) (ContentSubItem, error) {
+		if r != nil {
+			return r.del(m)
 		}
-	}
 
-	return MakeContentMappingItem(ID, c.t, c.k, m), nil
+		return m.del(path[len(path)-1])
+	})
 }
 
-func (c *ContentItem) del(ID string, path []AttributeValue) (*ContentItem, error) {
+// checkModificationPath returns depth of given path. Key set if any counts
+// as the last element of the path.
+func (c *ContentItem) checkModificationPath(path []AttributeValue, r contentKeySet) (int, error) {
 	if len(c.k) <= 0 {
-		return c, newInvalidContentModificationError()
+		return 0, newInvalidContentModificationError()
+	}
+
+	depth := len(path)
+	if r != nil {
+		depth++
 	}
 
-	if len(path) <= 0 {
-		return c, newMissingPathContentModificationError()
+	if depth <= 0 {
+		return 0, newMissingPathContentModificationError()
 	}
 
-	if len(path) > len(c.k) {
-		return c, newTooLongPathContentModificationError(c.k, path)
+	if depth > len(c.k) {
+		return 0, newTooLongPathContentModificationError(c.k, path)
 	}
 
+	return depth, nil
+}
+
+// modify walks given path down to the last map and replaces the map with
+// result of f. The last map is the one which contains key set if given
+// or the last key of the path otherwise. The walk ignores expiration of maps
+// on the path and keeps it for modified ones.
+func (c *ContentItem) modify(ID string, path []AttributeValue, r contentKeySet, f func(m ContentSubItem) (ContentSubItem, error)) (*ContentItem, error) {
 	var err error
 	m := c.r
 
-	last := len(path) - 1
+	last := len(path)
+	if r == nil {
+		last--
+	}
 	branch := make([]ContentSubItem, last)
 
 	loc := []string{""}
@@ -724,15 +873,19 @@ func (c *ContentItem) del(ID string, path []AttributeValue) (*ContentItem, error
 		branch[i] = m
 		loc = append(loc, k.describe())
 
-		m, err = m.next(k)
+		m, err = unwrapContentSubItem(m).next(k)
 		if err != nil {
 			return c, bindError(err, strings.Join(loc, "/"))
 		}
 	}
 
-	k := path[last]
-	loc = append(loc, k.describe())
-	m, err = m.del(k)
+	if r != nil {
+		loc = append(loc, r.describe())
+	} else {
+		loc = append(loc, path[last].describe())
+	}
+
+	m, err = withExpiry(m, f)
 	if err != nil {
 		return c, bindError(err, strings.Join(loc, "/"))
 	}
@@ -2075,6 +2228,210 @@ func (m ContentDomainFlags64Map) del(key AttributeValue) (ContentSubItem, error)
 	return MakeContentDomainFlags64Map(t), nil
 }
 
+// ContentIntegerMap implements ContentSubItem as map of integer or range
+// of integers to ContentSubItem. Ranges in the map don't overlap. Range put
+// later replaces part of existing range it overlaps with.
+type ContentIntegerMap struct {
+	m numRangeMap
+}
+
+// ContentIntegerMapBuilder collects integer map keys and values in any order.
+// Where ranges overlap, the one added later takes precedence.
+type ContentIntegerMapBuilder struct {
+	b numRangeMapBuilder
+}
+
+// Add puts value for given range of keys to the builder. Value should be
+// of ContentSubItem compatible type.
+func (b *ContentIntegerMapBuilder) Add(r IntegerRange, v interface{}) {
+	lo, hi := r.keys()
+	b.b.add(lo, hi, v)
+}
+
+// Map creates instance of ContentIntegerMap with all values added
+// to the builder.
+func (b *ContentIntegerMapBuilder) Map() ContentIntegerMap {
+	return ContentIntegerMap{m: b.b.build()}
+}
+
+func (m ContentIntegerMap) get(key AttributeValue) (interface{}, error) {
+	n, err := key.integer()
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := m.m.get(integerKey(n))
+	if !ok {
+		return nil, newMissingValueError()
+	}
+
+	return v, nil
+}
+
+func (m ContentIntegerMap) getValue(key AttributeValue, t Type) (AttributeValue, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return UndefinedValue, err
+	}
+
+	return MakeContentValue(v).getValue(UndefinedValue, t)
+}
+
+func (m ContentIntegerMap) next(key AttributeValue) (ContentSubItem, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := v.(ContentSubItem)
+	if !ok {
+		return nil, newMapContentSubitemError()
+	}
+
+	return item, nil
+}
+
+func (m ContentIntegerMap) put(key AttributeValue, value ContentSubItem) (ContentSubItem, error) {
+	n, err := key.integer()
+	if err != nil {
+		return m, err
+	}
+
+	k := integerKey(n)
+	return m.putRange(k, k, value), nil
+}
+
+func (m ContentIntegerMap) putRange(lo, hi uint64, value ContentSubItem) ContentSubItem {
+	if v, ok := value.(ContentValue); ok {
+		return ContentIntegerMap{m: m.m.insert(lo, hi, v.value)}
+	}
+
+	return ContentIntegerMap{m: m.m.insert(lo, hi, value)}
+}
+
+func (m ContentIntegerMap) del(key AttributeValue) (ContentSubItem, error) {
+	n, err := key.integer()
+	if err != nil {
+		return m, err
+	}
+
+	k := integerKey(n)
+	return m.delRange(k, k)
+}
+
+func (m ContentIntegerMap) delRange(lo, hi uint64) (ContentSubItem, error) {
+	r, ok := m.m.delete(lo, hi)
+	if !ok {
+		return m, newMissingValueError()
+	}
+
+	return ContentIntegerMap{m: r}, nil
+}
+
+// ContentFloatMap implements ContentSubItem as map of float or range
+// of floats to ContentSubItem. Ranges in the map don't overlap. Range put
+// later replaces part of existing range it overlaps with.
+type ContentFloatMap struct {
+	m numRangeMap
+}
+
+// ContentFloatMapBuilder collects float map keys and values in any order.
+// Where ranges overlap, the one added later takes precedence.
+type ContentFloatMapBuilder struct {
+	b numRangeMapBuilder
+}
+
+// Add puts value for given range of keys to the builder. Value should be
+// of ContentSubItem compatible type.
+func (b *ContentFloatMapBuilder) Add(r FloatRange, v interface{}) {
+	lo, hi := r.keys()
+	b.b.add(lo, hi, v)
+}
+
+// Map creates instance of ContentFloatMap with all values added
+// to the builder.
+func (b *ContentFloatMapBuilder) Map() ContentFloatMap {
+	return ContentFloatMap{m: b.b.build()}
+}
+
+func (m ContentFloatMap) get(key AttributeValue) (interface{}, error) {
+	f, err := key.float()
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := m.m.get(floatKey(f))
+	if !ok {
+		return nil, newMissingValueError()
+	}
+
+	return v, nil
+}
+
+func (m ContentFloatMap) getValue(key AttributeValue, t Type) (AttributeValue, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return UndefinedValue, err
+	}
+
+	return MakeContentValue(v).getValue(UndefinedValue, t)
+}
+
+func (m ContentFloatMap) next(key AttributeValue) (ContentSubItem, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := v.(ContentSubItem)
+	if !ok {
+		return nil, newMapContentSubitemError()
+	}
+
+	return item, nil
+}
+
+func (m ContentFloatMap) put(key AttributeValue, value ContentSubItem) (ContentSubItem, error) {
+	f, err := key.float()
+	if err != nil {
+		return m, err
+	}
+
+	if math.IsNaN(f) {
+		return m, newInvalidNaNKeyError()
+	}
+
+	k := floatKey(f)
+	return m.putRange(k, k, value), nil
+}
+
+func (m ContentFloatMap) putRange(lo, hi uint64, value ContentSubItem) ContentSubItem {
+	if v, ok := value.(ContentValue); ok {
+		return ContentFloatMap{m: m.m.insert(lo, hi, v.value)}
+	}
+
+	return ContentFloatMap{m: m.m.insert(lo, hi, value)}
+}
+
+func (m ContentFloatMap) del(key AttributeValue) (ContentSubItem, error) {
+	f, err := key.float()
+	if err != nil {
+		return m, err
+	}
+
+	k := floatKey(f)
+	return m.delRange(k, k)
+}
+
+func (m ContentFloatMap) delRange(lo, hi uint64) (ContentSubItem, error) {
+	r, ok := m.m.delete(lo, hi)
+	if !ok {
+		return m, newMissingValueError()
+	}
+
+	return ContentFloatMap{m: r}, nil
+}
+
 // ContentValue implements ContentSubItem as immediate value.
 type ContentValue struct {
 	value interface{}
@@ -2088,6 +2445,33 @@ func MakeContentValue(value interface{}) ContentValue {
 }
 
 func (v ContentValue) getValue(key AttributeValue, t Type) (AttributeValue, error) {
+	if e, ok := v.value.(expiringSubItem); ok {
+		return e.getValue(key, t)
+	}
+
+	switch t.(type) {
+	case *EnumType:
+		return AttributeValue{t: t, v: v.value.(string)}, nil
+
+	case *StructType:
+		return v.value.(AttributeValue), nil
+
+	case *FlagsType:
+		switch n := v.value.(type) {
+		case uint8:
+			return MakeFlagsValue8(n, t), nil
+
+		case uint16:
+			return MakeFlagsValue16(n, t), nil
+
+		case uint32:
+			return MakeFlagsValue32(n, t), nil
+
+		case uint64:
+			return MakeFlagsValue64(n, t), nil
+		}
+	}
+
 	switch t {
 	case TypeUndefined:
 		panic(fmt.Errorf("can't convert to value of undefined type"))
@@ -2124,6 +2508,12 @@ func (v ContentValue) getValue(key AttributeValue, t Type) (AttributeValue, erro
 
 	case TypeListOfStrings:
 		return MakeListOfStringsValue(v.value.([]string)), nil
+
+	case TypeTime:
+		return MakeTimeValue(v.value.(time.Time)), nil
+
+	case TypeDuration:
+		return MakeDurationValue(v.value.(time.Duration)), nil
 	}
 
 	panic(fmt.Errorf("can't convert to value of unknown type with index %d", t))
@@ -0,0 +1,425 @@
+package pdp
+
+import (
+	"strings"
+
+	"github.com/infobloxopen/go-trees/domain"
+	"github.com/infobloxopen/go-trees/domaintree"
+)
+
+// DomainMatch defines which domains a key of domain map matches.
+type DomainMatch int
+
+const (
+	// DomainMatchSubdomainsAndApex makes key match the domain itself and all
+	// its subdomains (default).
+	DomainMatchSubdomainsAndApex DomainMatch = iota
+	// DomainMatchSubdomains makes key match only subdomains of the domain.
+	DomainMatchSubdomains
+	// DomainMatchExact makes key match only the domain itself.
+	DomainMatchExact
+
+	domainMatchTotal
+)
+
+var (
+	// DomainMatchIDs maps domain match keys to domain match ids.
+	DomainMatchIDs = map[string]DomainMatch{
+		"subdomains and apex": DomainMatchSubdomainsAndApex,
+		"subdomains":          DomainMatchSubdomains,
+		"exact":               DomainMatchExact,
+	}
+	// DomainMatchNames maps domain match ids to domain match keys.
+	DomainMatchNames = []string{
+		"Subdomains and apex",
+		"Subdomains",
+		"Exact",
+	}
+)
+
+const (
+	domainKeyNegativePrefix   = "!"
+	domainKeySubdomainsPrefix = "*."
+)
+
+// DomainKey is a key of domain map with its match mode. Negative key
+// excludes domains it matches from the map.
+type DomainKey struct {
+	Name     domain.Name
+	Match    DomainMatch
+	Negative bool
+}
+
+// IsDomainKeyPattern checks if given string is a negative key ("!example.com")
+// or a subdomains key ("*.example.com") of domain map.
+func IsDomainKeyPattern(s string) bool {
+	return strings.HasPrefix(s, domainKeyNegativePrefix) || strings.HasPrefix(s, domainKeySubdomainsPrefix)
+}
+
+// ParseDomainKey parses key of domain map. Key "*.example.com" matches only
+// subdomains of example.com and key prefixed with "!" is negative. Other keys
+// get given match mode.
+func ParseDomainKey(s string, m DomainMatch) (DomainKey, error) {
+	p, err := parseDomainKeyPattern(s)
+	if err != nil {
+		return DomainKey{}, err
+	}
+
+	return p.key(m), nil
+}
+
+// domainKeyPattern is a key of domain map which match mode depends
+// on the map where it's used unless the key is a subdomains key.
+type domainKeyPattern struct {
+	s    string
+	name domain.Name
+	sub  bool
+	neg  bool
+}
+
+func parseDomainKeyPattern(s string) (domainKeyPattern, error) {
+	p := domainKeyPattern{s: s}
+
+	t := s
+	if strings.HasPrefix(t, domainKeyNegativePrefix) {
+		p.neg = true
+		t = t[len(domainKeyNegativePrefix):]
+	}
+
+	if strings.HasPrefix(t, domainKeySubdomainsPrefix) {
+		p.sub = true
+		t = t[len(domainKeySubdomainsPrefix):]
+	}
+
+	n, err := domain.MakeNameFromString(t)
+	if err != nil {
+		return p, newInvalidDomainNameStringCastError(s, err)
+	}
+
+	p.name = n
+	return p, nil
+}
+
+func (p domainKeyPattern) key(m DomainMatch) DomainKey {
+	if p.sub {
+		m = DomainMatchSubdomains
+	}
+
+	return DomainKey{Name: p.name, Match: m, Negative: p.neg}
+}
+
+func (p domainKeyPattern) describe() string {
+	return p.s
+}
+
+func (p domainKeyPattern) put(m ContentSubItem, v ContentSubItem) (ContentSubItem, error) {
+	mm, err := toContentDomainMatchMap(m)
+	if err != nil {
+		return m, err
+	}
+
+	return mm.insert(p.key(mm.match), v), nil
+}
+
+func (p domainKeyPattern) del(m ContentSubItem) (ContentSubItem, error) {
+	mm, ok := m.(ContentDomainMatchMap)
+	if !ok {
+		if _, err := toContentDomainMatchMap(m); err != nil {
+			return m, err
+		}
+
+		return m, newMissingValueError()
+	}
+
+	return mm.delete(p.key(mm.match))
+}
+
+// ContentDomainMatchMap implements ContentSubItem as map of domain keys with
+// match modes to ContentSubItem. Lookup gets value of the longest key which
+// matches given domain. If there are several such keys exact one takes
+// precedence over subdomains one and the latter over subdomains and apex one.
+// If the key is negative the map has no value for the domain.
+type ContentDomainMatchMap struct {
+	tree  *domaintree.Node
+	match DomainMatch
+}
+
+// domainRule holds values for all match modes of a domain.
+type domainRule struct {
+	labels int
+	parent domain.Name
+	e      [domainMatchTotal]domainRuleEntry
+}
+
+type domainRuleEntry struct {
+	ok  bool
+	neg bool
+	v   interface{}
+}
+
+// MakeContentDomainMatchMap creates empty instance of ContentDomainMatchMap.
+// Keys put to the map as plain domains get given match mode.
+func MakeContentDomainMatchMap(match DomainMatch) ContentDomainMatchMap {
+	return ContentDomainMatchMap{tree: &domaintree.Node{}, match: match}
+}
+
+// InplaceInsertDomainMap puts all keys of given domain map (including flags
+// domain maps) directly to the map with subdomains and apex match mode.
+// Use the method only for a map under construction.
+func (m ContentDomainMatchMap) InplaceInsertDomainMap(dm ContentSubItem) error {
+	var err error
+	add := func(s string, v interface{}) {
+		if err != nil {
+			return
+		}
+
+		var n domain.Name
+		n, err = domain.MakeNameFromString(s)
+		if err == nil {
+			m.InplaceInsert(DomainKey{Name: n}, v)
+		}
+	}
+
+	switch dm := dm.(type) {
+	default:
+		return newInvalidContentDomainMapError(dm)
+
+	case ContentDomainMap:
+		for p := range dm.tree.Enumerate() {
+			add(p.Key, p.Value)
+		}
+
+	case ContentDomainFlags8Map:
+		for p := range dm.tree.Enumerate() {
+			add(p.Key, p.Value)
+		}
+
+	case ContentDomainFlags16Map:
+		for p := range dm.tree.Enumerate() {
+			add(p.Key, p.Value)
+		}
+
+	case ContentDomainFlags32Map:
+		for p := range dm.tree.Enumerate() {
+			add(p.Key, p.Value)
+		}
+
+	case ContentDomainFlags64Map:
+		for p := range dm.tree.Enumerate() {
+			add(p.Key, p.Value)
+		}
+	}
+
+	return err
+}
+
+// toContentDomainMatchMap converts domain map to ContentDomainMatchMap
+// to put keys with match modes there.
+func toContentDomainMatchMap(m ContentSubItem) (ContentDomainMatchMap, error) {
+	if mm, ok := m.(ContentDomainMatchMap); ok {
+		return mm, nil
+	}
+
+	mm := MakeContentDomainMatchMap(DomainMatchSubdomainsAndApex)
+	if err := mm.InplaceInsertDomainMap(m); err != nil {
+		return mm, err
+	}
+
+	return mm, nil
+}
+
+// InplaceInsert puts value for given key directly to the map. Value of negative
+// key is ignored. Use the method only for a map under construction.
+func (m ContentDomainMatchMap) InplaceInsert(k DomainKey, v interface{}) {
+	if r := m.rule(k.Name); r != nil {
+		r.e[k.Match] = makeDomainRuleEntry(k, v)
+		return
+	}
+
+	m.tree.InplaceInsert(k.Name, newDomainRule(k, v))
+}
+
+func (m ContentDomainMatchMap) insert(k DomainKey, v ContentSubItem) ContentDomainMatchMap {
+	var value interface{} = v
+	if cv, ok := v.(ContentValue); ok {
+		value = cv.value
+	}
+
+	if r := m.rule(k.Name); r != nil {
+		c := *r
+		c.e[k.Match] = makeDomainRuleEntry(k, value)
+		return ContentDomainMatchMap{tree: m.tree.Insert(k.Name, &c), match: m.match}
+	}
+
+	return ContentDomainMatchMap{tree: m.tree.Insert(k.Name, newDomainRule(k, value)), match: m.match}
+}
+
+func (m ContentDomainMatchMap) delete(k DomainKey) (ContentSubItem, error) {
+	r := m.rule(k.Name)
+	if r == nil || !r.e[k.Match].ok || r.e[k.Match].neg != k.Negative {
+		return m, newMissingValueError()
+	}
+
+	c := *r
+	c.e[k.Match] = domainRuleEntry{}
+	for _, e := range c.e {
+		if e.ok {
+			return ContentDomainMatchMap{tree: m.tree.Insert(k.Name, &c), match: m.match}, nil
+		}
+	}
+
+	t, _ := m.tree.Delete(k.Name)
+	return ContentDomainMatchMap{tree: t, match: m.match}, nil
+}
+
+// rule returns rule for exactly given domain if any.
+func (m ContentDomainMatchMap) rule(n domain.Name) *domainRule {
+	v, ok := m.tree.Get(n)
+	if !ok {
+		return nil
+	}
+
+	r := v.(*domainRule)
+	if r.labels != countDomainLabels(n) {
+		return nil
+	}
+
+	return r
+}
+
+func (m ContentDomainMatchMap) get(key AttributeValue) (interface{}, error) {
+	d, err := key.domain()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := countDomainLabels(d)
+	n := d
+	for {
+		v, ok := m.tree.Get(n)
+		if !ok {
+			return nil, newMissingValueError()
+		}
+
+		r := v.(*domainRule)
+		if e, ok := r.match(r.labels == labels); ok {
+			if e.neg {
+				return nil, newMissingValueError()
+			}
+
+			return e.v, nil
+		}
+
+		if r.labels <= 1 {
+			return nil, newMissingValueError()
+		}
+
+		n = r.parent
+	}
+}
+
+func (m ContentDomainMatchMap) getValue(key AttributeValue, t Type) (AttributeValue, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return UndefinedValue, err
+	}
+
+	return MakeContentValue(v).getValue(UndefinedValue, t)
+}
+
+func (m ContentDomainMatchMap) next(key AttributeValue) (ContentSubItem, error) {
+	v, err := m.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := v.(ContentSubItem)
+	if !ok {
+		return nil, newMapContentSubitemError()
+	}
+
+	return item, nil
+}
+
+func (m ContentDomainMatchMap) put(key AttributeValue, value ContentSubItem) (ContentSubItem, error) {
+	d, err := key.domain()
+	if err != nil {
+		return m, err
+	}
+
+	return m.insert(DomainKey{Name: d, Match: m.match}, value), nil
+}
+
+func (m ContentDomainMatchMap) del(key AttributeValue) (ContentSubItem, error) {
+	d, err := key.domain()
+	if err != nil {
+		return m, err
+	}
+
+	return m.delete(DomainKey{Name: d, Match: m.match})
+}
+
+func newDomainRule(k DomainKey, v interface{}) *domainRule {
+	r := &domainRule{labels: countDomainLabels(k.Name)}
+	if r.labels > 1 {
+		r.parent = parentDomain(k.Name)
+	}
+
+	r.e[k.Match] = makeDomainRuleEntry(k, v)
+	return r
+}
+
+func makeDomainRuleEntry(k DomainKey, v interface{}) domainRuleEntry {
+	if k.Negative {
+		return domainRuleEntry{ok: true, neg: true}
+	}
+
+	return domainRuleEntry{ok: true, v: v}
+}
+
+// match returns entry which matches domain of the rule itself (self is true)
+// or its subdomain.
+func (r *domainRule) match(self bool) (domainRuleEntry, bool) {
+	if self {
+		if e := r.e[DomainMatchExact]; e.ok {
+			return e, true
+		}
+	} else if e := r.e[DomainMatchSubdomains]; e.ok {
+		return e, true
+	}
+
+	e := r.e[DomainMatchSubdomainsAndApex]
+	return e, e.ok
+}
+
+func countDomainLabels(n domain.Name) int {
+	labels := 0
+	n.GetLabels(func(string) error {
+		labels++
+		return nil
+	})
+
+	return labels
+}
+
+// parentDomain returns name without its first label.
+func parentDomain(n domain.Name) domain.Name {
+	var labels []string
+	n.GetLabels(func(s string) error {
+		labels = append(labels, domain.MakeHumanReadableLabel(s))
+		return nil
+	})
+
+	// GetLabels goes from top level label so reverse all but the last one.
+	labels = labels[:len(labels)-1]
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+
+	p, err := domain.MakeNameFromString(strings.Join(labels, "."))
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
@@ -0,0 +1,124 @@
+package pdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/infobloxopen/go-trees/domaintree"
+)
+
+func TestContentDomainMatchMap(t *testing.T) {
+	m := MakeContentDomainMatchMap(DomainMatchSubdomainsAndApex)
+	for _, k := range []struct {
+		s string
+		v string
+	}{
+		{s: "example.com", v: "apex"},
+		{s: "*.ads.example.com", v: "ads"},
+		{s: "!mail.example.com"},
+		{s: "*.mail.example.com", v: "mail-sub"},
+	} {
+		dk, err := ParseDomainKey(k.s, DomainMatchSubdomainsAndApex)
+		if err != nil {
+			t.Fatalf("Expected no error for %q but got %s", k.s, err)
+		}
+
+		m.InplaceInsert(dk, k.v)
+	}
+
+	em := MakeContentDomainMatchMap(DomainMatchExact)
+	em.InplaceInsert(DomainKey{Name: makeTestDomain("example.org"), Match: DomainMatchExact}, "exact")
+
+	assertDomainMatch(t, m, "example.com", "apex")
+	assertDomainMatch(t, m, "www.example.com", "apex")
+	assertDomainMatch(t, m, "ads.example.com", "apex")
+	assertDomainMatch(t, m, "x.ads.example.com", "ads")
+	assertDomainMatch(t, m, "mail.example.com", "")
+	assertDomainMatch(t, m, "smtp.mail.example.com", "mail-sub")
+	assertDomainMatch(t, m, "example.net", "")
+	assertDomainMatch(t, em, "example.org", "exact")
+	assertDomainMatch(t, em, "www.example.org", "")
+
+	if _, err := ParseDomainKey("!*.example..com", DomainMatchExact); err == nil {
+		t.Errorf("Expected error for invalid domain key but got nothing")
+	}
+}
+
+func TestLocalContentStorageDomainMatchUpdate(t *testing.T) {
+	tree := &domaintree.Node{}
+	tree.InplaceInsert(makeTestDomain("example.com"), "apex")
+
+	tag := uuid.New()
+	s := NewLocalContentStorage([]*LocalContent{
+		NewLocalContent("domains", &tag, MakeSymbols(), []*ContentItem{
+			MakeContentMappingItem("dm", TypeString, MakeSignature(TypeDomain), MakeContentDomainMap(tree)),
+		}),
+	})
+
+	newTag := uuid.New()
+	u := NewContentUpdate("domains", tag, newTag)
+	u.Append(UOAdd, []string{"dm", "!mail.example.com"}, MakeContentValueItem("", TypeString, ""))
+	u.Append(UOAdd, []string{"dm", "*.ads.example.com"}, MakeContentValueItem("", TypeString, "ads"))
+	u.Append(UODelete, []string{"dm", "*.ads.example.com"}, nil)
+	u.Append(UOAdd, []string{"dm", "*.ads.example.com"}, MakeContentValueItem("", TypeString, "ads"))
+
+	tr, err := s.NewTransaction("domains", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s, err = tr.Commit(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	c, err := s.Get("domains", "dm")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	m, ok := c.r.(ContentDomainMatchMap)
+	if !ok {
+		t.Fatalf("Expected domain match map but got %T", c.r)
+	}
+
+	assertDomainMatch(t, m, "www.example.com", "apex")
+	assertDomainMatch(t, m, "mail.example.com", "")
+	assertDomainMatch(t, m, "ads.example.com", "apex")
+	assertDomainMatch(t, m, "x.ads.example.com", "ads")
+
+	tr, err = s.NewTransaction("domains", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u = NewContentUpdate("domains", newTag, uuid.New())
+	u.Append(UODelete, []string{"dm", "*.mail.example.com"}, nil)
+	if err := tr.Apply(u); err == nil || !strings.Contains(err.Error(), "Missing value") {
+		t.Errorf("Expected missing value error but got %v", err)
+	}
+}
+
+func assertDomainMatch(t *testing.T, m ContentDomainMatchMap, s, e string) {
+	v, err := m.getValue(MakeDomainValue(makeTestDomain(s)), TypeString)
+	if len(e) <= 0 {
+		if err == nil {
+			t.Errorf("Expected no value for %q but got %s", s, v.describe())
+		}
+		return
+	}
+
+	if err != nil {
+		t.Errorf("Expected %q for %q but got error %s", e, s, err)
+		return
+	}
+
+	if r, err := v.str(); err != nil || r != e {
+		t.Errorf("Expected %q for %q but got %s", e, s, v.describe())
+	}
+}
@@ -0,0 +1,662 @@
+package pdp
+
+import (
+	"net"
+	"time"
+
+	"github.com/infobloxopen/go-trees/domain"
+)
+
+// expiringSubItem wraps content map or value which disappears at given time.
+// Lookups treat it as missing after the time while it stays in content until
+// the content is swept.
+type expiringSubItem struct {
+	v   ContentSubItem
+	exp time.Time
+}
+
+// MakeExpiringContentSubItem returns ContentSubItem which works as given one
+// until the expiration time and as missing value after it. Zero time means
+// no expiration.
+func MakeExpiringContentSubItem(v ContentSubItem, exp time.Time) ContentSubItem {
+	if exp.IsZero() {
+		return v
+	}
+
+	if e, ok := v.(expiringSubItem); ok {
+		v = e.v
+	}
+
+	return expiringSubItem{v: v, exp: exp}
+}
+
+// MakeExpiringContentItem returns copy of given content item which expires
+// as a whole at given time.
+func MakeExpiringContentItem(c *ContentItem, exp time.Time) *ContentItem {
+	return &ContentItem{
+		id: c.id,
+		r:  MakeExpiringContentSubItem(c.r, exp),
+		t:  c.t,
+		k:  c.k,
+	}
+}
+
+// GetExpiration returns time when content item expires as a whole. It returns
+// false if the item doesn't expire.
+func (c *ContentItem) GetExpiration() (time.Time, bool) {
+	if e, ok := c.r.(expiringSubItem); ok {
+		return e.exp, true
+	}
+
+	return time.Time{}, false
+}
+
+// MakeExpiringContentValue wraps value for content map (nested map or golang
+// value of content item type) to make it expire at given time. Zero time
+// means no expiration.
+func MakeExpiringContentValue(v interface{}, exp time.Time) interface{} {
+	if exp.IsZero() {
+		return v
+	}
+
+	if s, ok := v.(ContentSubItem); ok {
+		return MakeExpiringContentSubItem(s, exp)
+	}
+
+	return expiringSubItem{v: MakeContentValue(v), exp: exp}
+}
+
+// splitExpiringValue returns raw map value without expiration wrapper along
+// with its expiration time (zero if the value doesn't expire).
+func splitExpiringValue(v interface{}) (interface{}, time.Time) {
+	e, ok := v.(expiringSubItem)
+	if !ok {
+		return v, time.Time{}
+	}
+
+	if cv, ok := e.v.(ContentValue); ok {
+		return cv.value, e.exp
+	}
+
+	return e.v, e.exp
+}
+
+// unwrapContentSubItem returns given subitem without expiration wrapper.
+func unwrapContentSubItem(v ContentSubItem) ContentSubItem {
+	if e, ok := v.(expiringSubItem); ok {
+		return e.v
+	}
+
+	return v
+}
+
+// withExpiry applies f to given subitem without expiration wrapper and wraps
+// result back.
+func withExpiry(v ContentSubItem, f func(m ContentSubItem) (ContentSubItem, error)) (ContentSubItem, error) {
+	e, ok := v.(expiringSubItem)
+	if !ok {
+		return f(v)
+	}
+
+	r, err := f(e.v)
+	return expiringSubItem{v: r, exp: e.exp}, err
+}
+
+func (e expiringSubItem) expired(now time.Time) bool {
+	return !now.Before(e.exp)
+}
+
+func (e expiringSubItem) getValue(key AttributeValue, t Type) (AttributeValue, error) {
+	if e.expired(time.Now()) {
+		return UndefinedValue, newMissingValueError()
+	}
+
+	return e.v.getValue(key, t)
+}
+
+func (e expiringSubItem) next(key AttributeValue) (ContentSubItem, error) {
+	if e.expired(time.Now()) {
+		return nil, newMissingValueError()
+	}
+
+	return e.v.next(key)
+}
+
+func (e expiringSubItem) put(key AttributeValue, v ContentSubItem) (ContentSubItem, error) {
+	return withExpiry(e, func(m ContentSubItem) (ContentSubItem, error) {
+		return m.put(key, v)
+	})
+}
+
+func (e expiringSubItem) del(key AttributeValue) (ContentSubItem, error) {
+	return withExpiry(e, func(m ContentSubItem) (ContentSubItem, error) {
+		return m.del(key)
+	})
+}
+
+// Sweep returns copy of the storage where all contents are swept at given
+// time and number of removed content items and map entries. It returns
+// the same storage if nothing has expired.
+func (s *LocalContentStorage) Sweep(now time.Time) (*LocalContentStorage, int) {
+	out := s
+	total := 0
+	for _, c := range s.Contents() {
+		if sc, n := c.Sweep(now); n > 0 {
+			out = out.Add(sc)
+			total += n
+		}
+	}
+
+	return out, total
+}
+
+// Sweep returns copy of the content without items and map entries expired
+// at given time and number of removed ones. The copy keeps tag of original
+// content so it accepts the same updates. Sweep returns the same content if
+// nothing has expired. Maps of flags can't have expiring entries and aren't
+// swept. The copy remembers paths of removed items and entries so updates
+// which delete them or add something below them are treated as already
+// applied to expired data.
+func (c *LocalContent) Sweep(now time.Time) (*LocalContent, int) {
+	items := c.items
+	var swept sweptKeys
+	total := 0
+	for p := range c.items.Enumerate() {
+		item, ok := p.Value.(*ContentItem)
+		if !ok {
+			continue
+		}
+
+		if e, ok := item.r.(expiringSubItem); ok && e.expired(now) {
+			items, _ = items.Delete(p.Key)
+			swept = swept.record(p.Key, nil)
+			total++
+			continue
+		}
+
+		if r, keys, n := sweepContentSubItem(item.r, item.k, now); n > 0 {
+			items = items.Insert(p.Key, MakeContentMappingItem(item.id, item.t, item.k, r.(ContentSubItem)))
+			swept = swept.record(p.Key, keys)
+			total += n
+		}
+	}
+
+	if total <= 0 {
+		return c, 0
+	}
+
+	return &LocalContent{
+		id:      c.id,
+		tag:     c.tag,
+		items:   items,
+		symbols: c.symbols,
+		swept:   c.swept.merge(swept),
+	}, total
+}
+
+// sweptKeys is a tree of content items and map keys removed by sweep. Nil
+// value marks removed item or key while not nil one holds removed keys below
+// the key. The tree is shared between versions of content so all methods
+// return a modified copy.
+type sweptKeys map[string]sweptKeys
+
+// set returns copy of the tree with given value for given key.
+func (s sweptKeys) set(k string, v sweptKeys) sweptKeys {
+	out := make(sweptKeys, len(s)+1)
+	for k, v := range s {
+		out[k] = v
+	}
+
+	out[k] = v
+	return out
+}
+
+// merge returns copy of the tree with all keys from given one.
+func (s sweptKeys) merge(m sweptKeys) sweptKeys {
+	if len(m) <= 0 {
+		return s
+	}
+
+	out := make(sweptKeys, len(s)+len(m))
+	for k, v := range s {
+		out[k] = v
+	}
+
+	for k, v := range m {
+		if old := s[k]; old != nil && v != nil {
+			v = old.merge(v)
+		}
+
+		out[k] = v
+	}
+
+	return out
+}
+
+// record puts given key to the tree which is being built by sweep. Unlike
+// other methods it modifies the tree in place.
+func (s sweptKeys) record(k string, v sweptKeys) sweptKeys {
+	if s == nil {
+		s = sweptKeys{}
+	}
+
+	s[k] = v
+	return s
+}
+
+// covers checks if sweep has removed given path or any its prefix.
+func (s sweptKeys) covers(path []string) bool {
+	for _, k := range path {
+		v, ok := s[k]
+		if !ok {
+			return false
+		}
+
+		if v == nil {
+			return true
+		}
+
+		s = v
+	}
+
+	return false
+}
+
+// removed checks if sweep has removed exactly given path.
+func (s sweptKeys) removed(path []string) bool {
+	for i, k := range path {
+		v, ok := s[k]
+		if !ok {
+			return false
+		}
+
+		if v == nil {
+			return i == len(path)-1
+		}
+
+		s = v
+	}
+
+	return false
+}
+
+// forget returns copy of the tree without given path and all paths below it.
+func (s sweptKeys) forget(path []string) sweptKeys {
+	if len(path) <= 0 {
+		return s
+	}
+
+	k := path[0]
+	v, ok := s[k]
+	if !ok {
+		return s
+	}
+
+	if len(path) > 1 {
+		if v == nil {
+			return s
+		}
+
+		if v = v.forget(path[1:]); len(v) > 0 {
+			return s.set(k, v)
+		}
+	}
+
+	if len(s) <= 1 {
+		return nil
+	}
+
+	out := make(sweptKeys, len(s)-1)
+	for n, v := range s {
+		if n != k {
+			out[n] = v
+		}
+	}
+
+	return out
+}
+
+// skipSweptDeletion treats deletion of given path removed by sweep as applied.
+// Sweep record of the path is consumed so the next deletion of the same path
+// fails as for any missing key. Record of a parent path is kept as other
+// updates below it can still come.
+func (t *LocalContentStorageTransaction) skipSweptDeletion(path []string) {
+	if t.swept.removed(path) {
+		t.swept = t.swept.forget(path)
+	}
+}
+
+// isMissingValue checks if given error is caused by missing content map entry.
+func isMissingValue(err error) bool {
+	_, ok := err.(*MissingValueError)
+	return ok
+}
+
+// sweptPath returns path of content update in the form sweep records keys.
+// It consists of content item id and keys of content maps.
+func sweptPath(ID string, t []Type, path []AttributeValue, r contentKeySet) []string {
+	out := make([]string, 0, len(path)+2)
+	out = append(out, ID)
+	for i, k := range path {
+		out = append(out, sweptKey(t[i], k))
+	}
+
+	if r != nil {
+		if p, ok := r.(domainKeyPattern); ok {
+			out = append(out, sweptDomainPattern(p))
+		} else {
+			out = append(out, r.describe())
+		}
+	}
+
+	return out
+}
+
+// sweptKey converts key of content map of given type to the form sweep
+// records it.
+func sweptKey(t Type, k AttributeValue) string {
+	switch t {
+	case TypeString:
+		if s, err := k.str(); err == nil {
+			return s
+		}
+
+	case TypeAddress, TypeNetwork:
+		if a, err := k.address(); err == nil {
+			bits := 8 * net.IPv6len
+			if a4 := a.To4(); a4 != nil {
+				a = a4
+				bits = 8 * net.IPv4len
+			}
+
+			return (&net.IPNet{IP: a, Mask: net.CIDRMask(bits, bits)}).String()
+		}
+
+		if n, err := k.network(); err == nil {
+			return n.String()
+		}
+
+	case TypeDomain:
+		if d, err := k.domain(); err == nil {
+			return d.String()
+		}
+
+	case TypeInteger:
+		if n, err := k.integer(); err == nil {
+			return IntegerRange{Lo: n, Hi: n}.describe()
+		}
+
+	case TypeFloat:
+		if f, err := k.float(); err == nil {
+			return FloatRange{Lo: f, Hi: f}.describe()
+		}
+	}
+
+	return k.describe()
+}
+
+// sweptDomainPattern converts domain key pattern to the form sweep records
+// subdomains keys. Negative keys never expire so they are never recorded.
+func sweptDomainPattern(p domainKeyPattern) string {
+	s := p.name.String()
+	if p.sub {
+		s = domainKeySubdomainsPrefix + s
+	}
+
+	if p.neg {
+		s = domainKeyNegativePrefix + s
+	}
+
+	return s
+}
+
+// sweepContentValue removes expired entries from given raw map value. It
+// returns nil if the value itself has expired. Argument t holds key types
+// of maps below the value.
+func sweepContentValue(v interface{}, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	if e, ok := v.(expiringSubItem); ok && e.expired(now) {
+		return nil, nil, 1
+	}
+
+	s, ok := v.(ContentSubItem)
+	if !ok {
+		return v, nil, 0
+	}
+
+	return sweepContentSubItem(s, t, now)
+}
+
+func sweepContentSubItem(v ContentSubItem, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	switch m := v.(type) {
+	case expiringSubItem:
+		r, keys, n := sweepContentSubItem(m.v, t, now)
+		if n <= 0 {
+			return m, nil, 0
+		}
+
+		return expiringSubItem{v: r.(ContentSubItem), exp: m.exp}, keys, n
+
+	case ContentStringMap:
+		return sweepContentStringMap(m, t, now)
+
+	case ContentNetworkMap:
+		return sweepContentNetworkMap(m, t, now)
+
+	case ContentDomainMap:
+		return sweepContentDomainMap(m, t, now)
+
+	case ContentDomainMatchMap:
+		return sweepContentDomainMatchMap(m, t, now)
+
+	case ContentIntegerMap:
+		r, keys, n := sweepNumRangeMap(m.m, t, now, func(lo, hi uint64) string {
+			return IntegerRange{Lo: integerFromKey(lo), Hi: integerFromKey(hi)}.describe()
+		})
+		if n <= 0 {
+			return m, nil, 0
+		}
+
+		return ContentIntegerMap{m: r}, keys, n
+
+	case ContentFloatMap:
+		r, keys, n := sweepNumRangeMap(m.m, t, now, func(lo, hi uint64) string {
+			return FloatRange{Lo: floatFromKey(lo), Hi: floatFromKey(hi)}.describe()
+		})
+		if n <= 0 {
+			return m, nil, 0
+		}
+
+		return ContentFloatMap{m: r}, keys, n
+	}
+
+	return v, nil, 0
+}
+
+// nextKeyTypes returns key types of maps below map of the first type.
+func nextKeyTypes(t []Type) []Type {
+	if len(t) <= 0 {
+		return nil
+	}
+
+	return t[1:]
+}
+
+func sweepContentStringMap(m ContentStringMap, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	tree := m.tree
+	var swept sweptKeys
+	total := 0
+	for p := range m.tree.Enumerate() {
+		v, keys, n := sweepContentValue(p.Value, nextKeyTypes(t), now)
+		if n <= 0 {
+			continue
+		}
+
+		if v == nil {
+			tree, _ = tree.Delete(p.Key)
+		} else {
+			tree = tree.Insert(p.Key, v)
+		}
+		swept = swept.record(p.Key, keys)
+		total += n
+	}
+
+	if total <= 0 {
+		return m, nil, 0
+	}
+
+	return MakeContentStringMap(tree), swept, total
+}
+
+func sweepContentNetworkMap(m ContentNetworkMap, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	tree := m.tree
+	var swept sweptKeys
+	total := 0
+	for p := range m.tree.Enumerate() {
+		v, keys, n := sweepContentValue(p.Value, nextKeyTypes(t), now)
+		if n <= 0 {
+			continue
+		}
+
+		if v == nil {
+			tree, _ = tree.DeleteByNet(p.Key)
+		} else {
+			tree = tree.InsertNet(p.Key, v)
+		}
+		swept = swept.record(p.Key.String(), keys)
+		total += n
+	}
+
+	if total <= 0 {
+		return m, nil, 0
+	}
+
+	return MakeContentNetworkMap(tree), swept, total
+}
+
+func sweepContentDomainMap(m ContentDomainMap, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	tree := m.tree
+	var swept sweptKeys
+	total := 0
+	for p := range m.tree.Enumerate() {
+		v, keys, n := sweepContentValue(p.Value, nextKeyTypes(t), now)
+		if n <= 0 {
+			continue
+		}
+
+		d := makeSweptDomain(p.Key)
+		if v == nil {
+			tree, _ = tree.Delete(d)
+		} else {
+			tree = tree.Insert(d, v)
+		}
+		swept = swept.record(d.String(), keys)
+		total += n
+	}
+
+	if total <= 0 {
+		return m, nil, 0
+	}
+
+	return MakeContentDomainMap(tree), swept, total
+}
+
+// sweepContentDomainMatchMap records removed entry by plain domain if the
+// entry has match mode of the map and by subdomains key ("*.example.com")
+// if the entry matches only subdomains.
+func sweepContentDomainMatchMap(m ContentDomainMatchMap, t []Type, now time.Time) (interface{}, sweptKeys, int) {
+	tree := m.tree
+	var swept sweptKeys
+	total := 0
+	for p := range m.tree.Enumerate() {
+		r := *p.Value.(*domainRule)
+		d := makeSweptDomain(p.Key)
+		changed := 0
+		empty := true
+		for i, e := range r.e {
+			if e.ok && !e.neg {
+				v, keys, n := sweepContentValue(e.v, nextKeyTypes(t), now)
+				if n > 0 {
+					if v == nil {
+						r.e[i] = domainRuleEntry{}
+					} else {
+						r.e[i].v = v
+					}
+
+					if DomainMatch(i) == m.match {
+						swept = swept.record(d.String(), keys)
+					}
+
+					if DomainMatch(i) == DomainMatchSubdomains {
+						swept = swept.record(domainKeySubdomainsPrefix+d.String(), keys)
+					}
+					changed += n
+				}
+			}
+
+			empty = empty && !r.e[i].ok
+		}
+
+		if changed <= 0 {
+			continue
+		}
+
+		if empty {
+			tree, _ = tree.Delete(d)
+		} else {
+			tree = tree.Insert(d, &r)
+		}
+		total += changed
+	}
+
+	if total <= 0 {
+		return m, nil, 0
+	}
+
+	return ContentDomainMatchMap{tree: tree, match: m.match}, swept, total
+}
+
+// makeSweptDomain converts key of domain tree back to domain name.
+func makeSweptDomain(s string) domain.Name {
+	d, err := domain.MakeNameFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func sweepNumRangeMap(m numRangeMap, t []Type, now time.Time, key func(lo, hi uint64) string) (numRangeMap, sweptKeys, int) {
+	var swept sweptKeys
+	total := 0
+	out := make(numRangeMap, 0, len(m))
+	for _, r := range m {
+		v, keys, n := sweepContentValue(r.v, nextKeyTypes(t), now)
+		if n > 0 {
+			swept = swept.record(key(r.lo, r.hi), keys)
+			total += n
+		}
+
+		if v != nil {
+			out = append(out, numRange{lo: r.lo, hi: r.hi, v: v})
+		}
+	}
+
+	if total <= 0 {
+		return m, nil, 0
+	}
+
+	return out, swept, total
+}
+
+// isContentFlagsMap checks if given subitem is a map of flags which holds
+// bare flags values and can't keep expiration.
+func isContentFlagsMap(v ContentSubItem) bool {
+	switch v.(type) {
+	case ContentStringFlags8Map, ContentStringFlags16Map, ContentStringFlags32Map, ContentStringFlags64Map,
+		ContentNetworkFlags8Map, ContentNetworkFlags16Map, ContentNetworkFlags32Map, ContentNetworkFlags64Map,
+		ContentDomainFlags8Map, ContentDomainFlags16Map, ContentDomainFlags32Map, ContentDomainFlags64Map:
+		return true
+	}
+
+	return false
+}
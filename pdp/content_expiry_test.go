@@ -0,0 +1,334 @@
+package pdp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/infobloxopen/go-trees/strtree"
+	"github.com/infobloxopen/go-trees/uintX/strtree8"
+)
+
+func TestLocalContentExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	sm := strtree.NewTree()
+	sm.InplaceInsert("old", MakeExpiringContentValue("stale", past))
+	sm.InplaceInsert("new", MakeExpiringContentValue("fresh", future))
+	sm.InplaceInsert("permanent", "always")
+
+	var b ContentIntegerMapBuilder
+	b.Add(IntegerRange{Lo: 0, Hi: 1023}, MakeExpiringContentValue("system", past))
+	b.Add(IntegerRange{Lo: 1024, Hi: 49151}, "registered")
+
+	tag := uuid.New()
+	c := NewLocalContent("expiry", &tag, MakeSymbols(), []*ContentItem{
+		MakeContentMappingItem("threats", TypeString, MakeSignature(TypeString), MakeContentStringMap(sm)),
+		MakeContentMappingItem("ports", TypeString, MakeSignature(TypeInteger), b.Map()),
+		MakeExpiringContentItem(MakeContentValueItem("campaign", TypeString, "autumn"), past),
+		MakeContentValueItem("level", TypeString, "high"),
+	})
+
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("old")}, "#03 (/\"old\"): Missing value")
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("new")}, "fresh")
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("permanent")}, "always")
+	assertExpiryLookup(t, c, "ports", []AttributeValue{MakeIntegerValue(80)}, "#03 (/80): Missing value")
+	assertExpiryLookup(t, c, "ports", []AttributeValue{MakeIntegerValue(8080)}, "registered")
+	assertExpiryLookup(t, c, "campaign", nil, "Missing value")
+	assertExpiryLookup(t, c, "level", nil, "high")
+
+	item, err := c.Get("campaign")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if exp, ok := item.GetExpiration(); !ok || !exp.Equal(past) {
+		t.Errorf("Expected %s expiration for \"campaign\" but got %s (%v)", past, exp, ok)
+	}
+
+	w := new(bytes.Buffer)
+	if err := WriteContentSnapshot(w, c); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	snapshot := w.Bytes()
+	rc, err := ReadContentSnapshot(bytes.NewReader(snapshot), &tag, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	assertExpiryLookup(t, rc, "threats", []AttributeValue{MakeStringValue("old")}, "#03 (/\"old\"): Missing value")
+	assertExpiryLookup(t, rc, "threats", []AttributeValue{MakeStringValue("new")}, "fresh")
+	assertExpiryLookup(t, rc, "campaign", nil, "Missing value")
+
+	w = new(bytes.Buffer)
+	if err := WriteContentSnapshot(w, rc); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if !bytes.Equal(w.Bytes(), snapshot) {
+		t.Errorf("Expected the same snapshot after reading and writing back")
+	}
+
+	sc, n := c.Sweep(time.Now())
+	if n != 3 {
+		t.Errorf("Expected 3 swept entries but got %d", n)
+	}
+
+	if sc == c {
+		t.Errorf("Expected new content after sweep but got the same")
+	}
+
+	if sc.GetTag() != c.GetTag() {
+		t.Errorf("Expected tag %s after sweep but got %s", c.GetTag(), sc.GetTag())
+	}
+
+	if _, err := sc.Get("campaign"); err == nil {
+		t.Errorf("Expected no \"campaign\" item after sweep")
+	}
+
+	assertExpiryLookup(t, sc, "threats", []AttributeValue{MakeStringValue("new")}, "fresh")
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("new")}, "fresh")
+
+	if rc, n := sc.Sweep(time.Now()); n != 0 || rc != sc {
+		t.Errorf("Expected the same content and nothing swept but got %p and %d (%p)", rc, n, sc)
+	}
+
+	if _, n := sc.Sweep(future); n != 1 {
+		t.Errorf("Expected 1 swept entry in future but got %d", n)
+	}
+}
+
+func TestLocalContentStorageExpiringUpdate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tag := uuid.New()
+	s := NewLocalContentStorage([]*LocalContent{
+		NewLocalContent("expiry", &tag, MakeSymbols(), []*ContentItem{
+			MakeContentMappingItem("threats", TypeString, MakeSignature(TypeString, TypeString),
+				MakeContentStringMap(strtree.NewTree())),
+			MakeContentMappingItem("flags", TypeString, MakeSignature(TypeString),
+				MakeContentStringFlags8Map(strtree8.NewTree())),
+		}),
+	})
+
+	newTag := uuid.New()
+	u := NewContentUpdate("expiry", tag, newTag)
+	u.Append(UOAdd, []string{"threats", "a"}, MakeExpiringContentItem(
+		MakeContentMappingItem("", TypeString, MakeSignature(TypeString), MakeContentStringMap(strtree.NewTree())),
+		future,
+	))
+	u.Append(UOAdd, []string{"threats", "a", "x"}, MakeContentValueItem("", TypeString, "1"))
+	u.Append(UOAdd, []string{"threats", "a", "y"}, MakeExpiringContentItem(MakeContentValueItem("", TypeString, "2"), past))
+
+	tr, err := s.NewTransaction("expiry", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s, err = tr.Commit(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	c, err := s.GetLocalContent("expiry", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("a"), MakeStringValue("x")}, "1")
+	assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("a"), MakeStringValue("y")},
+		"#03 (/\"a\"/\"y\"): Missing value")
+
+	if _, n := c.Sweep(future); n != 1 {
+		t.Errorf("Expected 1 swept entry in future but got %d", n)
+	}
+
+	ns, n := s.Sweep(time.Now())
+	if n != 1 {
+		t.Errorf("Expected 1 swept entry but got %d", n)
+	}
+
+	if _, err := ns.GetLocalContent("expiry", &newTag); err != nil {
+		t.Errorf("Expected swept content with the same tag but got %s", err)
+	}
+
+	tr, err = s.NewTransaction("expiry", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u = NewContentUpdate("expiry", newTag, uuid.New())
+	u.Append(UOAdd, []string{"flags", "a"}, MakeExpiringContentItem(MakeContentValueItem("", TypeString, "1"), future))
+	if err := tr.Apply(u); err == nil || !strings.Contains(err.Error(), "Can't put expiring value to map of flags") {
+		t.Errorf("Expected expiring flags error but got %v", err)
+	}
+}
+
+func TestLocalContentStorageUpdateAfterSweep(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	a := strtree.NewTree()
+	a.InplaceInsert("x", "1")
+
+	sm := strtree.NewTree()
+	sm.InplaceInsert("a", MakeExpiringContentValue(MakeContentStringMap(a), past))
+	sm.InplaceInsert("b", MakeExpiringContentValue(MakeContentStringMap(strtree.NewTree()), past))
+
+	tag := uuid.New()
+	s := NewLocalContentStorage([]*LocalContent{
+		NewLocalContent("expiry", &tag, MakeSymbols(), []*ContentItem{
+			MakeContentMappingItem("threats", TypeString, MakeSignature(TypeString, TypeString),
+				MakeContentStringMap(sm)),
+			MakeExpiringContentItem(MakeContentValueItem("campaign", TypeString, "autumn"), past),
+		}),
+	})
+
+	ss, n := s.Sweep(time.Now())
+	if n != 3 {
+		t.Errorf("Expected 3 swept entries but got %d", n)
+	}
+
+	newTag := uuid.New()
+	u := NewContentUpdate("expiry", tag, newTag)
+	u.Append(UODelete, []string{"threats", "a"}, nil)
+	u.Append(UOAdd, []string{"threats", "b", "x"}, MakeContentValueItem("", TypeString, "1"))
+	u.Append(UODelete, []string{"campaign"}, nil)
+
+	for i, cs := range []*LocalContentStorage{s, ss} {
+		tr, err := cs.NewTransaction("expiry", &tag)
+		if err != nil {
+			t.Fatalf("Expected no error for storage %d but got %s", i, err)
+		}
+
+		if err := tr.Apply(u); err != nil {
+			t.Errorf("Expected no error for storage %d but got %s", i, err)
+			continue
+		}
+
+		cs, err = tr.Commit(cs)
+		if err != nil {
+			t.Fatalf("Expected no error for storage %d but got %s", i, err)
+		}
+
+		c, err := cs.GetLocalContent("expiry", &newTag)
+		if err != nil {
+			t.Fatalf("Expected no error for storage %d but got %s", i, err)
+		}
+
+		assertExpiryLookup(t, c, "threats", []AttributeValue{MakeStringValue("b"), MakeStringValue("x")}, "Missing value")
+
+		if _, err := c.Get("campaign"); err == nil {
+			t.Errorf("Expected no \"campaign\" item for storage %d after update", i)
+		}
+	}
+
+	tr, err := s.NewTransaction("expiry", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u = NewContentUpdate("expiry", tag, newTag)
+	u.Append(UODelete, []string{"threats", "c"}, nil)
+	if err := tr.Apply(u); err == nil {
+		t.Errorf("Expected error on deleting missing key from content which hasn't been swept")
+	}
+}
+
+func TestLocalContentStorageMissingKeysAfterSweep(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	sm := strtree.NewTree()
+	sm.InplaceInsert("a", MakeExpiringContentValue(MakeContentStringMap(strtree.NewTree()), past))
+	sm.InplaceInsert("b", MakeContentStringMap(strtree.NewTree()))
+
+	tag := uuid.New()
+	s := NewLocalContentStorage([]*LocalContent{
+		NewLocalContent("expiry", &tag, MakeSymbols(), []*ContentItem{
+			MakeContentMappingItem("threats", TypeString, MakeSignature(TypeString, TypeString),
+				MakeContentStringMap(sm)),
+			MakeExpiringContentItem(MakeContentValueItem("campaign", TypeString, "autumn"), past),
+		}),
+	})
+
+	s, n := s.Sweep(time.Now())
+	if n != 2 {
+		t.Errorf("Expected 2 swept entries but got %d", n)
+	}
+
+	for i, cmd := range []struct {
+		op   int
+		path []string
+	}{
+		{UODelete, []string{"threats", "c"}},
+		{UODelete, []string{"threats", "b", "x"}},
+		{UODelete, []string{"level"}},
+		{UOAdd, []string{"threats", "c", "x"}},
+		{UOAdd, []string{"level", "x"}},
+	} {
+		tr, err := s.NewTransaction("expiry", &tag)
+		if err != nil {
+			t.Fatalf("Expected no error but got %s", err)
+		}
+
+		u := NewContentUpdate("expiry", tag, uuid.New())
+		var v *ContentItem
+		if cmd.op == UOAdd {
+			v = MakeContentValueItem("", TypeString, "1")
+		}
+		u.Append(cmd.op, cmd.path, v)
+
+		if err := tr.Apply(u); err == nil {
+			t.Errorf("Expected error for command %d on missing path %q after sweep", i, cmd.path)
+		}
+	}
+
+	tr, err := s.NewTransaction("expiry", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u := NewContentUpdate("expiry", tag, uuid.New())
+	u.Append(UODelete, []string{"threats", "a"}, nil)
+	u.Append(UODelete, []string{"campaign"}, nil)
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error on deleting swept paths but got %s", err)
+	}
+
+	u = NewContentUpdate("expiry", u.newTag, uuid.New())
+	u.Append(UODelete, []string{"threats", "a"}, nil)
+	if err := tr.Apply(u); err == nil {
+		t.Errorf("Expected error on deleting swept key for the second time")
+	}
+}
+
+func assertExpiryLookup(t *testing.T, c *LocalContent, id string, path []AttributeValue, e string) {
+	item, err := c.Get(id)
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+		return
+	}
+
+	v, err := item.GetByValues(path, AggTypeDisable)
+	if err != nil {
+		if !strings.HasSuffix(err.Error(), e) {
+			t.Errorf("Expected %s for %s but got error %s", e, id, err)
+		}
+		return
+	}
+
+	r, err := v.Serialize()
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if r != e {
+		t.Errorf("Expected %s for %s but got %s", e, id, r)
+	}
+}
@@ -0,0 +1,828 @@
+package pdp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/infobloxopen/go-trees/domain"
+	"github.com/infobloxopen/go-trees/domaintree"
+	"github.com/infobloxopen/go-trees/iptree"
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+// WriteContentJSON writes given content in JCON format. A custom type is
+// declared by the first content item of the type. Items of struct types go
+// after other items so enums used by struct fields are declared before.
+// Expiration of items and keys goes to "expires" field ahead of data.
+func WriteContentJSON(w io.Writer, c *LocalContent) error {
+	jw := &jsonWriter{
+		w:        bufio.NewWriter(w),
+		c:        c,
+		declared: make(map[Type]bool),
+	}
+
+	if err := jw.putContent(); err != nil {
+		return err
+	}
+
+	return jw.w.Flush()
+}
+
+// jsonWriter relies on sticky error of bufio.Writer so only final flush
+// reports write errors.
+type jsonWriter struct {
+	w        *bufio.Writer
+	c        *LocalContent
+	declared map[Type]bool
+	exp      *jsonExpiry
+}
+
+// jsonExpiry collects expiration of keys while content item data is written.
+type jsonExpiry struct {
+	t    time.Time
+	keys []string
+	sub  map[string]*jsonExpiry
+}
+
+func (e *jsonExpiry) child(k string, t time.Time) *jsonExpiry {
+	if e.sub == nil {
+		e.sub = make(map[string]*jsonExpiry)
+	}
+
+	c := &jsonExpiry{t: t}
+	e.keys = append(e.keys, k)
+	e.sub[k] = c
+	return c
+}
+
+// used checks if the key or any of its nested keys expires.
+func (e *jsonExpiry) used() bool {
+	if !e.t.IsZero() {
+		return true
+	}
+
+	for _, c := range e.sub {
+		if c.used() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *jsonWriter) putContent() error {
+	w.w.WriteString("{\n\"id\": ")
+	w.putString(w.c.id)
+	w.w.WriteString(",\n\"items\": {")
+
+	items := w.c.sortedItems()
+	var structs []*ContentItem
+	first := true
+	for _, item := range items {
+		if _, ok := item.t.(*StructType); ok {
+			structs = append(structs, item)
+			continue
+		}
+
+		if err := w.putItem(item, first); err != nil {
+			return bindError(err, item.id)
+		}
+
+		first = false
+	}
+
+	for _, item := range structs {
+		if err := w.putItem(item, first); err != nil {
+			return bindError(err, item.id)
+		}
+
+		first = false
+	}
+
+	w.w.WriteString("\n}\n}\n")
+	return nil
+}
+
+func (w *jsonWriter) putItem(item *ContentItem, first bool) error {
+	if !first {
+		w.w.WriteByte(',')
+	}
+
+	w.w.WriteString("\n")
+	w.putString(item.id)
+	w.w.WriteString(": {\"type\": ")
+	if err := w.putType(item.t); err != nil {
+		return err
+	}
+
+	match := DomainMatchSubdomainsAndApex
+	if len(item.k) > 0 {
+		w.w.WriteString(", \"keys\": [")
+		for i, k := range item.k {
+			if i > 0 {
+				w.w.WriteString(", ")
+			}
+
+			w.putString(k.GetKey())
+		}
+		w.w.WriteByte(']')
+
+		if m, ok := findContentDomainMatch(item.k, 0, item.r); ok && m != DomainMatchSubdomainsAndApex {
+			match = m
+			w.w.WriteString(", \"match\": ")
+			w.putString(strings.ToLower(DomainMatchNames[m]))
+		}
+	}
+
+	r, exp := splitExpiringValue(item.r)
+	if !exp.IsZero() {
+		w.w.WriteString(", \"expires\": ")
+		w.putString(exp.UTC().Format(time.RFC3339Nano))
+	}
+
+	if len(item.k) > 0 && hasContentExpiry(r) {
+		if !exp.IsZero() {
+			return newNestedContentJSONExpiryError()
+		}
+
+		e, err := w.collectExpiry(item, r, match)
+		if err != nil {
+			return err
+		}
+
+		w.w.WriteString(", \"expires\": ")
+		if err := w.putExpiry(e); err != nil {
+			return err
+		}
+	}
+
+	w.w.WriteString(", \"data\": ")
+	if err := w.putNode(item, 0, r, match); err != nil {
+		return err
+	}
+
+	w.w.WriteByte('}')
+	return nil
+}
+
+// collectExpiry makes dry run of writing item data to get expiration of keys.
+func (w *jsonWriter) collectExpiry(item *ContentItem, r interface{}, match DomainMatch) (*jsonExpiry, error) {
+	out := w.w
+	defer func() {
+		w.w = out
+		w.exp = nil
+	}()
+
+	w.w = bufio.NewWriter(ioutil.Discard)
+	w.exp = &jsonExpiry{}
+	e := w.exp
+	if err := w.putNode(item, 0, r, match); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (w *jsonWriter) putExpiry(e *jsonExpiry) error {
+	w.w.WriteByte('{')
+	first := true
+	for _, k := range e.keys {
+		c := e.sub[k]
+		if !c.used() {
+			continue
+		}
+
+		if !first {
+			w.w.WriteString(", ")
+		}
+		first = false
+
+		w.putString(k)
+		w.w.WriteString(": ")
+		if c.t.IsZero() {
+			if err := w.putExpiry(c); err != nil {
+				return bindError(err, k)
+			}
+
+			continue
+		}
+
+		for _, n := range c.sub {
+			if n.used() {
+				return bindError(newNestedContentJSONExpiryError(), k)
+			}
+		}
+
+		w.putString(c.t.UTC().Format(time.RFC3339Nano))
+	}
+	w.w.WriteByte('}')
+
+	return nil
+}
+
+// hasContentExpiry checks if content map or any nested map has expiring
+// values.
+func hasContentExpiry(v interface{}) bool {
+	if _, ok := v.(expiringSubItem); ok {
+		return true
+	}
+
+	found := false
+	forEachContentMapValue(v, func(v interface{}) {
+		if !found {
+			found = hasContentExpiry(v)
+		}
+	})
+
+	return found
+}
+
+func (w *jsonWriter) isNamed(t Type) bool {
+	nt, ok := w.c.symbols.types[t.GetKey()]
+	return ok && nt == t
+}
+
+func (w *jsonWriter) putType(t Type) error {
+	if _, ok := t.(*builtinType); ok {
+		w.putString(t.GetKey())
+		return nil
+	}
+
+	named := w.isNamed(t)
+	if named && w.declared[t] {
+		w.putString(t.String())
+		return nil
+	}
+
+	var fields []StructField
+	if st, ok := t.(*StructType); ok {
+		fields = st.b
+		for _, f := range fields {
+			if _, ok := f.Type.(*builtinType); !ok && (!w.isNamed(f.Type) || !w.declared[f.Type]) {
+				return newUndeclaredContentJSONTypeError(f.Type)
+			}
+		}
+	}
+
+	w.w.WriteString("{\"meta\": ")
+	switch t := t.(type) {
+	default:
+		return newInvalidContentJSONValueError(t)
+
+	case *FlagsType:
+		w.putString("flags")
+		w.w.WriteString(", \"flags\": ")
+		w.putStrings(t.b)
+
+	case *EnumType:
+		w.putString("enum")
+		w.w.WriteString(", \"values\": ")
+		w.putStrings(t.b)
+
+	case *StructType:
+		w.putString("struct")
+		w.w.WriteString(", \"fields\": [")
+		for i, f := range fields {
+			if i > 0 {
+				w.w.WriteString(", ")
+			}
+
+			w.w.WriteByte('{')
+			w.putString(f.Name)
+			w.w.WriteString(": ")
+			if _, ok := f.Type.(*builtinType); ok {
+				w.putString(f.Type.GetKey())
+			} else {
+				w.putString(f.Type.String())
+			}
+			w.w.WriteByte('}')
+		}
+		w.w.WriteByte(']')
+	}
+
+	if named {
+		w.w.WriteString(", \"name\": ")
+		w.putString(t.String())
+		w.declared[t] = true
+	}
+
+	w.w.WriteByte('}')
+	return nil
+}
+
+// findContentDomainMatch returns match mode of the first domain map of the
+// item. Plain domain maps match subdomains and apex.
+func findContentDomainMatch(k []Type, level int, v interface{}) (DomainMatch, bool) {
+	deeper := false
+	for _, t := range k[level:] {
+		if t == TypeDomain {
+			deeper = true
+			break
+		}
+	}
+
+	if !deeper {
+		return DomainMatchSubdomainsAndApex, false
+	}
+
+	v, _ = splitExpiringValue(v)
+	if k[level] == TypeDomain {
+		switch m := v.(type) {
+		case ContentDomainMatchMap:
+			return m.match, true
+
+		case ContentDomainMap, ContentDomainFlags8Map, ContentDomainFlags16Map,
+			ContentDomainFlags32Map, ContentDomainFlags64Map:
+			return DomainMatchSubdomainsAndApex, true
+		}
+	}
+
+	var (
+		match DomainMatch
+		found bool
+	)
+	forEachContentMapValue(v, func(v interface{}) {
+		if !found {
+			match, found = findContentDomainMatch(k, level+1, v)
+		}
+	})
+
+	return match, found
+}
+
+// forEachContentMapValue calls f for each value of content map which can hold
+// nested maps.
+func forEachContentMapValue(v interface{}, f func(v interface{})) {
+	v, _ = splitExpiringValue(v)
+	switch m := v.(type) {
+	case ContentStringMap:
+		for p := range m.tree.Enumerate() {
+			f(p.Value)
+		}
+
+	case ContentNetworkMap:
+		for p := range m.tree.Enumerate() {
+			f(p.Value)
+		}
+
+	case ContentDomainMap:
+		for p := range m.tree.Enumerate() {
+			f(p.Value)
+		}
+
+	case ContentDomainMatchMap:
+		for p := range m.tree.Enumerate() {
+			for _, e := range p.Value.(*domainRule).e {
+				if e.ok && !e.neg {
+					f(e.v)
+				}
+			}
+		}
+
+	case ContentIntegerMap:
+		for _, r := range m.m {
+			f(r.v)
+		}
+
+	case ContentFloatMap:
+		for _, r := range m.m {
+			f(r.v)
+		}
+	}
+}
+
+// jsonMapWriter writes object of content map and keeps the first error
+// as enumeration of a tree needs to go till its end.
+type jsonMapWriter struct {
+	w     *jsonWriter
+	first bool
+	err   error
+}
+
+func (w *jsonWriter) newMapWriter() *jsonMapWriter {
+	w.w.WriteByte('{')
+	return &jsonMapWriter{w: w, first: true}
+}
+
+func (m *jsonMapWriter) key(s string) bool {
+	if m.err != nil {
+		return false
+	}
+
+	if !m.first {
+		m.w.w.WriteString(", ")
+	}
+
+	m.first = false
+	m.w.putString(s)
+	m.w.w.WriteString(": ")
+	return true
+}
+
+func (m *jsonMapWriter) node(item *ContentItem, level int, s string, v interface{}, match DomainMatch) {
+	if m.key(s) {
+		v, exp := splitExpiringValue(v)
+		parent := m.w.exp
+		if parent != nil {
+			m.w.exp = parent.child(s, exp)
+		}
+
+		err := m.w.putNode(item, level, v, match)
+		m.w.exp = parent
+		if err != nil {
+			m.err = bindError(err, s)
+		}
+	}
+}
+
+func (m *jsonMapWriter) flags(t Type, s string, n uint64) {
+	if m.key(s) {
+		if err := m.w.putFlags(t, n); err != nil {
+			m.err = bindError(err, s)
+		}
+	}
+}
+
+func (m *jsonMapWriter) domain(s string, e, match DomainMatch, neg bool) (string, bool) {
+	if m.err != nil {
+		return "", false
+	}
+
+	prefix := ""
+	if neg {
+		prefix = domainKeyNegativePrefix
+	}
+
+	switch e {
+	case DomainMatchSubdomains:
+		return prefix + domainKeySubdomainsPrefix + s, true
+
+	case match:
+		return prefix + s, true
+	}
+
+	m.err = newInvalidContentJSONDomainMatchError(s, DomainMatchNames[e], DomainMatchNames[match])
+	return "", false
+}
+
+func (m *jsonMapWriter) end() error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.w.w.WriteByte('}')
+	return nil
+}
+
+func (w *jsonWriter) putNode(item *ContentItem, level int, v interface{}, match DomainMatch) error {
+	if level >= len(item.k) {
+		if cv, ok := v.(ContentValue); ok {
+			v = cv.value
+		}
+
+		return w.putValue(item.t, v)
+	}
+
+	next := level + 1
+	switch m := v.(type) {
+	case ContentStringMap:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.node(item, next, p.Key, p.Value, match)
+		}
+
+		return mw.end()
+
+	case ContentStringFlags8Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key, uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentStringFlags16Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key, uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentStringFlags32Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key, uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentStringFlags64Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key, p.Value)
+		}
+
+		return mw.end()
+
+	case ContentNetworkMap:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.node(item, next, p.Key.String(), p.Value, match)
+		}
+
+		return mw.end()
+
+	case ContentNetworkFlags8Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key.String(), uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentNetworkFlags16Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key.String(), uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentNetworkFlags32Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key.String(), uint64(p.Value))
+		}
+
+		return mw.end()
+
+	case ContentNetworkFlags64Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			mw.flags(item.t, p.Key.String(), p.Value)
+		}
+
+		return mw.end()
+
+	case ContentDomainMap:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			if s, ok := mw.domain(p.Key, DomainMatchSubdomainsAndApex, match, false); ok {
+				mw.node(item, next, s, p.Value, match)
+			}
+		}
+
+		return mw.end()
+
+	case ContentDomainFlags8Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			if s, ok := mw.domain(p.Key, DomainMatchSubdomainsAndApex, match, false); ok {
+				mw.flags(item.t, s, uint64(p.Value))
+			}
+		}
+
+		return mw.end()
+
+	case ContentDomainFlags16Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			if s, ok := mw.domain(p.Key, DomainMatchSubdomainsAndApex, match, false); ok {
+				mw.flags(item.t, s, uint64(p.Value))
+			}
+		}
+
+		return mw.end()
+
+	case ContentDomainFlags32Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			if s, ok := mw.domain(p.Key, DomainMatchSubdomainsAndApex, match, false); ok {
+				mw.flags(item.t, s, uint64(p.Value))
+			}
+		}
+
+		return mw.end()
+
+	case ContentDomainFlags64Map:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			if s, ok := mw.domain(p.Key, DomainMatchSubdomainsAndApex, match, false); ok {
+				mw.flags(item.t, s, p.Value)
+			}
+		}
+
+		return mw.end()
+
+	case ContentDomainMatchMap:
+		mw := w.newMapWriter()
+		for p := range m.tree.Enumerate() {
+			for i, e := range p.Value.(*domainRule).e {
+				if !e.ok {
+					continue
+				}
+
+				s, ok := mw.domain(p.Key, DomainMatch(i), match, e.neg)
+				if !ok {
+					continue
+				}
+
+				if e.neg {
+					if mw.key(s) {
+						w.w.WriteString("null")
+					}
+					continue
+				}
+
+				mw.node(item, next, s, e.v, match)
+			}
+		}
+
+		return mw.end()
+
+	case ContentIntegerMap:
+		mw := w.newMapWriter()
+		for _, r := range m.m {
+			lo, hi := integerFromKey(r.lo), integerFromKey(r.hi)
+			s := strconv.FormatInt(lo, 10)
+			if lo != hi {
+				s = IntegerRange{Lo: lo, Hi: hi}.describe()
+			}
+
+			mw.node(item, next, s, r.v, match)
+		}
+
+		return mw.end()
+
+	case ContentFloatMap:
+		mw := w.newMapWriter()
+		for _, r := range m.m {
+			lo, hi := floatFromKey(r.lo), floatFromKey(r.hi)
+			s := strconv.FormatFloat(lo, 'g', -1, 64)
+			if r.lo != r.hi {
+				s = FloatRange{Lo: lo, Hi: hi}.describe()
+			}
+
+			mw.node(item, next, s, r.v, match)
+		}
+
+		return mw.end()
+	}
+
+	return newInvalidContentJSONValueError(v)
+}
+
+func (w *jsonWriter) putFlags(t Type, n uint64) error {
+	ft, ok := t.(*FlagsType)
+	if !ok {
+		return newInvalidContentJSONValueError(n)
+	}
+
+	var flags []string
+	for i, f := range ft.b {
+		if n&(1<<uint(i)) != 0 {
+			flags = append(flags, f)
+		}
+	}
+
+	w.putStrings(flags)
+	return nil
+}
+
+func (w *jsonWriter) putValue(t Type, v interface{}) error {
+	switch t := t.(type) {
+	case *FlagsType:
+		switch n := v.(type) {
+		case uint8:
+			return w.putFlags(t, uint64(n))
+
+		case uint16:
+			return w.putFlags(t, uint64(n))
+
+		case uint32:
+			return w.putFlags(t, uint64(n))
+
+		case uint64:
+			return w.putFlags(t, n)
+		}
+
+		return newInvalidContentJSONValueError(v)
+
+	case *EnumType:
+		s, ok := v.(string)
+		if !ok {
+			return newInvalidContentJSONValueError(v)
+		}
+
+		w.putString(s)
+		return nil
+
+	case *StructType:
+		av, ok := v.(AttributeValue)
+		if !ok {
+			return newInvalidContentJSONValueError(v)
+		}
+
+		fields, ok := av.v.([]AttributeValue)
+		if !ok || len(fields) != len(t.b) {
+			return newInvalidContentJSONValueError(av.v)
+		}
+
+		w.w.WriteByte('{')
+		for i, f := range t.b {
+			if i > 0 {
+				w.w.WriteString(", ")
+			}
+
+			w.putString(f.Name)
+			w.w.WriteString(": ")
+			if err := w.putValue(f.Type, fields[i].v); err != nil {
+				return bindError(err, f.Name)
+			}
+		}
+		w.w.WriteByte('}')
+
+		return nil
+	}
+
+	switch v := v.(type) {
+	case bool:
+		w.w.WriteString(strconv.FormatBool(v))
+
+	case string:
+		w.putString(v)
+
+	case int64:
+		w.w.WriteString(strconv.FormatInt(v, 10))
+
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return newInvalidContentJSONValueError(v)
+		}
+
+		w.w.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+
+	case net.IP:
+		w.putString(v.String())
+
+	case *net.IPNet:
+		w.putString(v.String())
+
+	case domain.Name:
+		w.putString(v.String())
+
+	case *strtree.Tree:
+		w.putStrings(SortSetOfStrings(v))
+
+	case *iptree.Tree:
+		nets := SortSetOfNetworks(v)
+		s := make([]string, len(nets))
+		for i, n := range nets {
+			s[i] = n.String()
+		}
+
+		w.putStrings(s)
+
+	case *domaintree.Node:
+		w.putStrings(SortSetOfDomains(v))
+
+	case []string:
+		w.putStrings(v)
+
+	case time.Time:
+		w.putString(v.Format(time.RFC3339Nano))
+
+	case time.Duration:
+		w.putString(v.String())
+
+	default:
+		return newInvalidContentJSONValueError(v)
+	}
+
+	return nil
+}
+
+func (w *jsonWriter) putString(s string) {
+	b, _ := json.Marshal(s)
+	w.w.Write(b)
+}
+
+func (w *jsonWriter) putStrings(s []string) {
+	w.w.WriteByte('[')
+	for i, s := range s {
+		if i > 0 {
+			w.w.WriteString(", ")
+		}
+
+		w.putString(s)
+	}
+	w.w.WriteByte(']')
+}
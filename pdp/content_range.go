@@ -0,0 +1,425 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IntegerRange represents closed range of integers. Single integer key
+// of integer map is a range with equal low and high bounds.
+type IntegerRange struct {
+	Lo int64
+	Hi int64
+}
+
+// FloatRange represents closed range of floats. Single float key of float map
+// is a range with equal low and high bounds.
+type FloatRange struct {
+	Lo float64
+	Hi float64
+}
+
+// IsRangeKey checks if given string looks like range key "[low, high]".
+func IsRangeKey(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), "[")
+}
+
+func splitRangeKey(s string) (string, string, error) {
+	t := strings.TrimSpace(s)
+	if !strings.HasPrefix(t, "[") || !strings.HasSuffix(t, "]") {
+		return "", "", newInvalidRangeStringError(s)
+	}
+
+	b := strings.Split(t[1:len(t)-1], ",")
+	if len(b) != 2 {
+		return "", "", newInvalidRangeStringError(s)
+	}
+
+	return strings.TrimSpace(b[0]), strings.TrimSpace(b[1]), nil
+}
+
+// ParseIntegerRange parses integer ("42") or range of integers ("[10, 20]").
+func ParseIntegerRange(s string) (IntegerRange, error) {
+	if !IsRangeKey(s) {
+		n, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return IntegerRange{}, newInvalidIntegerStringCastError(s, err)
+		}
+
+		return IntegerRange{Lo: n, Hi: n}, nil
+	}
+
+	lo, hi, err := splitRangeKey(s)
+	if err != nil {
+		return IntegerRange{}, err
+	}
+
+	r := IntegerRange{}
+	r.Lo, err = strconv.ParseInt(lo, 0, 64)
+	if err != nil {
+		return IntegerRange{}, newInvalidIntegerStringCastError(lo, err)
+	}
+
+	r.Hi, err = strconv.ParseInt(hi, 0, 64)
+	if err != nil {
+		return IntegerRange{}, newInvalidIntegerStringCastError(hi, err)
+	}
+
+	if r.Lo > r.Hi {
+		return IntegerRange{}, newInvalidRangeBoundsError(s)
+	}
+
+	return r, nil
+}
+
+// ParseFloatRange parses float ("0.5") or range of floats ("[0.5, 1]").
+func ParseFloatRange(s string) (FloatRange, error) {
+	if !IsRangeKey(s) {
+		f, err := parseFloatKey(s)
+		if err != nil {
+			return FloatRange{}, err
+		}
+
+		return FloatRange{Lo: f, Hi: f}, nil
+	}
+
+	lo, hi, err := splitRangeKey(s)
+	if err != nil {
+		return FloatRange{}, err
+	}
+
+	r := FloatRange{}
+	r.Lo, err = parseFloatKey(lo)
+	if err != nil {
+		return FloatRange{}, err
+	}
+
+	r.Hi, err = parseFloatKey(hi)
+	if err != nil {
+		return FloatRange{}, err
+	}
+
+	if r.Lo > r.Hi {
+		return FloatRange{}, newInvalidRangeBoundsError(s)
+	}
+
+	return r, nil
+}
+
+func parseFloatKey(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, newInvalidFloatStringCastError(s, err)
+	}
+
+	if math.IsNaN(f) {
+		return 0, newInvalidNaNKeyError()
+	}
+
+	return f, nil
+}
+
+// ParseAddressRange parses range of addresses ("[192.0.2.10, 192.0.2.20]")
+// and returns minimal list of networks which covers the range.
+func ParseAddressRange(s string) ([]*net.IPNet, error) {
+	lo, hi, err := splitRangeKey(s)
+	if err != nil {
+		return nil, err
+	}
+
+	a := net.ParseIP(lo)
+	if a == nil {
+		return nil, newInvalidAddressStringCastError(lo)
+	}
+
+	b := net.ParseIP(hi)
+	if b == nil {
+		return nil, newInvalidAddressStringCastError(hi)
+	}
+
+	bits := 8 * net.IPv6len
+	if a4, b4 := a.To4(), b.To4(); a4 != nil || b4 != nil {
+		if a4 == nil || b4 == nil {
+			return nil, newInvalidAddressRangeError(s)
+		}
+
+		a, b = a4, b4
+		bits = 8 * net.IPv4len
+	} else {
+		a, b = a.To16(), b.To16()
+	}
+
+	x := new(big.Int).SetBytes(a)
+	y := new(big.Int).SetBytes(b)
+	if x.Cmp(y) > 0 {
+		return nil, newInvalidRangeBoundsError(s)
+	}
+
+	one := big.NewInt(1)
+	var out []*net.IPNet
+	for {
+		// Take the largest block aligned at x which doesn't go beyond y.
+		k := bits
+		if x.Sign() != 0 {
+			k = int(x.TrailingZeroBits())
+		}
+
+		last := new(big.Int)
+		for {
+			mask := new(big.Int).Sub(new(big.Int).Lsh(one, uint(k)), one)
+			if last.Or(x, mask).Cmp(y) <= 0 {
+				break
+			}
+
+			k--
+		}
+
+		ip := make(net.IP, bits/8)
+		x.FillBytes(ip)
+		out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits-k, bits)})
+
+		if last.Cmp(y) >= 0 {
+			return out, nil
+		}
+
+		x.Add(last, one)
+	}
+}
+
+// contentKeySet represents key of content map which covers a set of keys
+// like a range of numbers or a domain with its subdomains.
+type contentKeySet interface {
+	describe() string
+	put(m ContentSubItem, v ContentSubItem) (ContentSubItem, error)
+	del(m ContentSubItem) (ContentSubItem, error)
+}
+
+func (r IntegerRange) describe() string {
+	return fmt.Sprintf("[%d, %d]", r.Lo, r.Hi)
+}
+
+func (r IntegerRange) keys() (uint64, uint64) {
+	return integerKey(r.Lo), integerKey(r.Hi)
+}
+
+func (r IntegerRange) put(m ContentSubItem, v ContentSubItem) (ContentSubItem, error) {
+	im, ok := m.(ContentIntegerMap)
+	if !ok {
+		return m, newInvalidContentIntegerMapError(m)
+	}
+
+	lo, hi := r.keys()
+	return im.putRange(lo, hi, v), nil
+}
+
+func (r IntegerRange) del(m ContentSubItem) (ContentSubItem, error) {
+	im, ok := m.(ContentIntegerMap)
+	if !ok {
+		return m, newInvalidContentIntegerMapError(m)
+	}
+
+	lo, hi := r.keys()
+	return im.delRange(lo, hi)
+}
+
+func (r FloatRange) describe() string {
+	return fmt.Sprintf("[%s, %s]",
+		strconv.FormatFloat(r.Lo, 'g', -1, 64),
+		strconv.FormatFloat(r.Hi, 'g', -1, 64),
+	)
+}
+
+func (r FloatRange) keys() (uint64, uint64) {
+	return floatKey(r.Lo), floatKey(r.Hi)
+}
+
+func (r FloatRange) put(m ContentSubItem, v ContentSubItem) (ContentSubItem, error) {
+	fm, ok := m.(ContentFloatMap)
+	if !ok {
+		return m, newInvalidContentFloatMapError(m)
+	}
+
+	lo, hi := r.keys()
+	return fm.putRange(lo, hi, v), nil
+}
+
+func (r FloatRange) del(m ContentSubItem) (ContentSubItem, error) {
+	fm, ok := m.(ContentFloatMap)
+	if !ok {
+		return m, newInvalidContentFloatMapError(m)
+	}
+
+	lo, hi := r.keys()
+	return fm.delRange(lo, hi)
+}
+
+// addressRange is a range of addresses represented by networks which cover it.
+type addressRange struct {
+	s    string
+	nets []*net.IPNet
+}
+
+func (r addressRange) describe() string {
+	return r.s
+}
+
+func (r addressRange) put(m ContentSubItem, v ContentSubItem) (ContentSubItem, error) {
+	out := m
+	for _, n := range r.nets {
+		var err error
+		out, err = out.put(MakeNetworkValue(n), v)
+		if err != nil {
+			return m, err
+		}
+	}
+
+	return out, nil
+}
+
+func (r addressRange) del(m ContentSubItem) (ContentSubItem, error) {
+	out := m
+	for _, n := range r.nets {
+		var err error
+		out, err = out.del(MakeNetworkValue(n))
+		if err != nil {
+			return m, err
+		}
+	}
+
+	return out, nil
+}
+
+// integerKey maps integer to unsigned key preserving order.
+func integerKey(n int64) uint64 {
+	return uint64(n) ^ (1 << 63)
+}
+
+// floatKey maps float to unsigned key preserving order. Both zeroes get
+// the same key.
+func floatKey(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+
+	b := math.Float64bits(f)
+	if b&(1<<63) != 0 {
+		return ^b
+	}
+
+	return b | (1 << 63)
+}
+
+// integerFromKey is an inverse of integerKey.
+func integerFromKey(k uint64) int64 {
+	return int64(k ^ (1 << 63))
+}
+
+// floatFromKey is an inverse of floatKey.
+func floatFromKey(k uint64) float64 {
+	if k&(1<<63) != 0 {
+		return math.Float64frombits(k &^ (1 << 63))
+	}
+
+	return math.Float64frombits(^k)
+}
+
+// numRange binds value to closed range of keys.
+type numRange struct {
+	lo uint64
+	hi uint64
+	v  interface{}
+}
+
+// numRangeMap is a sorted list of disjoint ranges. Where new range overlaps
+// existing ones it replaces overlapped parts. Any modification of the map
+// creates a copy so existing map isn't affected.
+type numRangeMap []numRange
+
+func (m numRangeMap) get(k uint64) (interface{}, bool) {
+	i := sort.Search(len(m), func(i int) bool { return m[i].hi >= k })
+	if i < len(m) && m[i].lo <= k {
+		return m[i].v, true
+	}
+
+	return nil, false
+}
+
+// overlap returns bounds of subslice of ranges which overlap with given range.
+func (m numRangeMap) overlap(lo, hi uint64) (int, int) {
+	i := sort.Search(len(m), func(i int) bool { return m[i].hi >= lo })
+	j := i + sort.Search(len(m)-i, func(j int) bool { return m[i+j].lo > hi })
+	return i, j
+}
+
+func (m numRangeMap) insert(lo, hi uint64, v interface{}) numRangeMap {
+	i, j := m.overlap(lo, hi)
+
+	out := make(numRangeMap, 0, len(m)-(j-i)+3)
+	out = append(out, m[:i]...)
+	if i < j && m[i].lo < lo {
+		out = append(out, numRange{lo: m[i].lo, hi: lo - 1, v: m[i].v})
+	}
+
+	out = append(out, numRange{lo: lo, hi: hi, v: v})
+	if i < j && m[j-1].hi > hi {
+		out = append(out, numRange{lo: hi + 1, hi: m[j-1].hi, v: m[j-1].v})
+	}
+
+	return append(out, m[j:]...)
+}
+
+func (m numRangeMap) delete(lo, hi uint64) (numRangeMap, bool) {
+	i, j := m.overlap(lo, hi)
+	if i >= j {
+		return m, false
+	}
+
+	out := make(numRangeMap, 0, len(m)-(j-i)+2)
+	out = append(out, m[:i]...)
+	if m[i].lo < lo {
+		out = append(out, numRange{lo: m[i].lo, hi: lo - 1, v: m[i].v})
+	}
+
+	if m[j-1].hi > hi {
+		out = append(out, numRange{lo: hi + 1, hi: m[j-1].hi, v: m[j-1].v})
+	}
+
+	return append(out, m[j:]...), true
+}
+
+// numRangeMapBuilder collects ranges in any order and builds numRangeMap
+// as if the ranges were inserted one by one.
+type numRangeMapBuilder []numRange
+
+func (b *numRangeMapBuilder) add(lo, hi uint64, v interface{}) {
+	*b = append(*b, numRange{lo: lo, hi: hi, v: v})
+}
+
+func (b numRangeMapBuilder) build() numRangeMap {
+	if len(b) <= 0 {
+		return nil
+	}
+
+	m := make(numRangeMap, len(b))
+	copy(m, b)
+	sort.SliceStable(m, func(i, j int) bool { return m[i].lo < m[j].lo })
+
+	for i := 1; i < len(m); i++ {
+		if m[i].lo <= m[i-1].hi {
+			// Ranges overlap so the order they were added in matters.
+			var out numRangeMap
+			for _, r := range b {
+				out = out.insert(r.lo, r.hi, r.v)
+			}
+
+			return out
+		}
+	}
+
+	return m
+}
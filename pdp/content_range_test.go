@@ -0,0 +1,288 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+func TestParseIntegerRange(t *testing.T) {
+	cases := []struct {
+		s   string
+		r   IntegerRange
+		err string
+	}{
+		{s: "42", r: IntegerRange{Lo: 42, Hi: 42}},
+		{s: "[-10, 20]", r: IntegerRange{Lo: -10, Hi: 20}},
+		{s: " [ 0x10 ,0x20 ] ", r: IntegerRange{Lo: 16, Hi: 32}},
+		{s: "x", err: "Can't treat \"x\" as integer"},
+		{s: "[1, 2", err: "Expected range in form"},
+		{s: "[1, 2, 3]", err: "Expected range in form"},
+		{s: "[1, x]", err: "Can't treat \"x\" as integer"},
+		{s: "[2, 1]", err: "Low bound of range \"[2, 1]\" is greater than its high bound"},
+	}
+
+	for _, c := range cases {
+		r, err := ParseIntegerRange(c.s)
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("Expected error containing %q for %q but got %v", c.err, c.s, err)
+			}
+		} else if err != nil {
+			t.Errorf("Expected no error for %q but got %s", c.s, err)
+		} else if r != c.r {
+			t.Errorf("Expected %#v for %q but got %#v", c.r, c.s, r)
+		}
+	}
+}
+
+func TestParseFloatRange(t *testing.T) {
+	r, err := ParseFloatRange("[-0.5, 1e3]")
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if r.Lo != -0.5 || r.Hi != 1000 {
+		t.Errorf("Expected [-0.5, 1000] but got %s", r.describe())
+	}
+
+	if _, err := ParseFloatRange("NaN"); err == nil {
+		t.Errorf("Expected error for NaN key but got nothing")
+	}
+
+	if _, err := ParseFloatRange("[1, 0.5]"); err == nil {
+		t.Errorf("Expected error for reversed range but got nothing")
+	}
+}
+
+func TestParseAddressRange(t *testing.T) {
+	cases := []struct {
+		s    string
+		nets string
+		err  string
+	}{
+		{s: "[192.0.2.0, 192.0.2.255]", nets: "192.0.2.0/24"},
+		{s: "[192.0.2.10, 192.0.2.10]", nets: "192.0.2.10/32"},
+		{
+			s:    "[192.0.2.10, 192.0.2.20]",
+			nets: "192.0.2.10/31,192.0.2.12/30,192.0.2.16/30,192.0.2.20/32",
+		},
+		{s: "[0.0.0.0, 255.255.255.255]", nets: "0.0.0.0/0"},
+		{s: "[2001:db8::ffff, 2001:db8::1:0]", nets: "2001:db8::ffff/128,2001:db8::1:0/128"},
+		{s: "[192.0.2.20, 192.0.2.10]", err: "greater than its high bound"},
+		{s: "[192.0.2.1, 2001:db8::1]", err: "same family"},
+		{s: "[192.0.2.1, x]", err: "Can't treat \"x\" as IP address"},
+	}
+
+	for _, c := range cases {
+		nets, err := ParseAddressRange(c.s)
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("Expected error containing %q for %q but got %v", c.err, c.s, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Expected no error for %q but got %s", c.s, err)
+			continue
+		}
+
+		s := make([]string, len(nets))
+		for i, n := range nets {
+			s[i] = n.String()
+		}
+
+		if strings.Join(s, ",") != c.nets {
+			t.Errorf("Expected %s for %q but got %s", c.nets, c.s, strings.Join(s, ","))
+		}
+	}
+}
+
+func TestFloatKeyOrder(t *testing.T) {
+	f := []float64{math.Inf(-1), -1e10, -1, -1e-10, 0, 1e-10, 1, 1e10, math.Inf(1)}
+	for i := 1; i < len(f); i++ {
+		if floatKey(f[i-1]) >= floatKey(f[i]) {
+			t.Errorf("Expected key of %g less than key of %g", f[i-1], f[i])
+		}
+	}
+
+	if floatKey(math.Copysign(0, -1)) != floatKey(0) {
+		t.Errorf("Expected the same key for both zeroes")
+	}
+
+	if integerKey(-1) >= integerKey(0) || integerKey(math.MaxInt64) <= integerKey(math.MinInt64) {
+		t.Errorf("Expected integer keys to preserve order")
+	}
+}
+
+func TestNumRangeMap(t *testing.T) {
+	var m numRangeMap
+	m = m.insert(10, 20, "a")
+	m = m.insert(30, 40, "b")
+	m1 := m.insert(15, 35, "c")
+
+	assertNumRangeMap(t, "original", m, "10-20:a,30-40:b")
+	assertNumRangeMap(t, "overlapping insert", m1, "10-14:a,15-35:c,36-40:b")
+	assertNumRangeMap(t, "nested insert", m.insert(12, 14, "d"), "10-11:a,12-14:d,15-20:a,30-40:b")
+	assertNumRangeMap(t, "full key range", m.insert(0, math.MaxUint64, "e"), fmt.Sprintf("0-%d:e", uint64(math.MaxUint64)))
+
+	m2, ok := m1.delete(12, 36)
+	if !ok {
+		t.Errorf("Expected deletion of [12, 36] but got nothing")
+	}
+	assertNumRangeMap(t, "delete", m2, "10-11:a,37-40:b")
+
+	if _, ok := m1.delete(0, 9); ok {
+		t.Errorf("Expected nothing to delete at [0, 9]")
+	}
+
+	if v, ok := m1.get(35); !ok || v != "c" {
+		t.Errorf("Expected \"c\" at 35 but got %v (%v)", v, ok)
+	}
+
+	if v, ok := m1.get(41); ok {
+		t.Errorf("Expected nothing at 41 but got %v", v)
+	}
+
+	var b numRangeMapBuilder
+	b.add(30, 40, "b")
+	b.add(10, 20, "a")
+	assertNumRangeMap(t, "builder", b.build(), "10-20:a,30-40:b")
+
+	b.add(15, 35, "c")
+	assertNumRangeMap(t, "builder with overlaps", b.build(), "10-14:a,15-35:c,36-40:b")
+}
+
+func assertNumRangeMap(t *testing.T, desc string, m numRangeMap, e string) {
+	s := make([]string, len(m))
+	for i, r := range m {
+		s[i] = fmt.Sprintf("%d-%d:%v", r.lo, r.hi, r.v)
+	}
+
+	if strings.Join(s, ",") != e {
+		t.Errorf("Expected %s for %s but got %s", e, desc, strings.Join(s, ","))
+	}
+}
+
+func TestLocalContentStorageRangeKeys(t *testing.T) {
+	var ib ContentIntegerMapBuilder
+	ib.Add(IntegerRange{Lo: 0, Hi: 1023}, "system")
+	ib.Add(IntegerRange{Lo: 1024, Hi: 49151}, "registered")
+	ib.Add(IntegerRange{Lo: 53, Hi: 53}, "dns")
+
+	var fb ContentFloatMapBuilder
+	fb.Add(FloatRange{Lo: 0, Hi: 0.5}, "low")
+	fb.Add(FloatRange{Lo: 0.5, Hi: 1}, "high")
+
+	sm := strtree.NewTree()
+	sm.InplaceInsert("tcp", ib.Map())
+
+	tag := uuid.New()
+	s := NewLocalContentStorage([]*LocalContent{
+		NewLocalContent("ranges", &tag, MakeSymbols(), []*ContentItem{
+			MakeContentMappingItem("ports", TypeString, MakeSignature(TypeString, TypeInteger), MakeContentStringMap(sm)),
+			MakeContentMappingItem("scores", TypeString, MakeSignature(TypeFloat), fb.Map()),
+			MakeContentMappingItem("services", TypeString, MakeSignature(TypeInteger, TypeString),
+				new(ContentIntegerMapBuilder).Map()),
+		}),
+	})
+
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(53)}, "dns")
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(80)}, "system")
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(8080)}, "registered")
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(50000)}, `#03 (/"tcp"/50000): Missing value`)
+	assertRangeKeyLookup(t, s, "scores", []AttributeValue{MakeFloatValue(0.5)}, "high")
+	assertRangeKeyLookup(t, s, "scores", []AttributeValue{MakeFloatValue(0.25)}, "low")
+
+	newTag := uuid.New()
+	u := NewContentUpdate("ranges", tag, newTag)
+	u.Append(UOAdd, []string{"ports", "tcp", "[8000, 8999]"}, MakeContentValueItem("", TypeString, "web"))
+	u.Append(UODelete, []string{"ports", "tcp", "[1024, 7999]"}, nil)
+	u.Append(UOAdd, []string{"ports", "udp"}, MakeContentMappingItem("", TypeString, MakeSignature(TypeInteger),
+		new(ContentIntegerMapBuilder).Map()))
+	u.Append(UOAdd, []string{"ports", "udp", "53"}, MakeContentValueItem("", TypeString, "dns"))
+
+	tr, err := s.NewTransaction("ranges", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s, err = tr.Commit(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(8080)}, "web")
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(9000)}, "registered")
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("tcp"), MakeIntegerValue(2000)}, `#03 (/"tcp"/2000): Missing value`)
+	assertRangeKeyLookup(t, s, "ports", []AttributeValue{MakeStringValue("udp"), MakeIntegerValue(53)}, "dns")
+
+	tr, err = s.NewTransaction("ranges", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u = NewContentUpdate("ranges", newTag, uuid.New())
+	u.Append(UODelete, []string{"services", "[1, 2]", "tcp"}, nil)
+	if err := tr.Apply(u); err == nil || !strings.Contains(err.Error(), "Key \"[1, 2]\" can be used only as the last key") {
+		t.Errorf("Expected range position error but got %v", err)
+	}
+}
+
+func TestLocalContentStorageGetAggregatedByIntegerKey(t *testing.T) {
+	var b ContentIntegerMapBuilder
+	b.Add(IntegerRange{Lo: 1, Hi: 10}, []string{"small"})
+	b.Add(IntegerRange{Lo: 11, Hi: 100}, []string{"big"})
+
+	sm := strtree.NewTree()
+	sm.InplaceInsert("first", b.Map())
+	sm.InplaceInsert("second", b.Map())
+
+	c := MakeContentMappingItem("str-int", TypeListOfStrings, MakeSignature(TypeString, TypeInteger),
+		MakeContentStringMap(sm))
+
+	v, err := c.GetAggregated([]Expression{
+		MakeListOfStringsValue([]string{"first", "second"}),
+		MakeIntegerValue(5),
+	}, nil, AggTypeAppend)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s, err := v.Serialize()
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s != `"small","small"` {
+		t.Errorf("Expected \"small\",\"small\" but got %s", s)
+	}
+}
+
+func assertRangeKeyLookup(t *testing.T, s *LocalContentStorage, id string, path []AttributeValue, e string) {
+	c, err := s.Get("ranges", id)
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+		return
+	}
+
+	v, err := c.GetByValues(path, AggTypeDisable)
+	if err != nil {
+		if err.Error() != e {
+			t.Errorf("Expected %s for %s but got error %s", e, id, err)
+		}
+		return
+	}
+
+	r, err := v.Serialize()
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if r != e {
+		t.Errorf("Expected %s for %s but got %s", e, id, r)
+	}
+}
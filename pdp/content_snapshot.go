@@ -0,0 +1,1713 @@
+package pdp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/infobloxopen/go-trees/domain"
+	"github.com/infobloxopen/go-trees/domaintree"
+	"github.com/infobloxopen/go-trees/iptree"
+	"github.com/infobloxopen/go-trees/strtree"
+	"github.com/infobloxopen/go-trees/uintX/domaintree16"
+	"github.com/infobloxopen/go-trees/uintX/domaintree32"
+	"github.com/infobloxopen/go-trees/uintX/domaintree64"
+	"github.com/infobloxopen/go-trees/uintX/domaintree8"
+	"github.com/infobloxopen/go-trees/uintX/iptree16"
+	"github.com/infobloxopen/go-trees/uintX/iptree32"
+	"github.com/infobloxopen/go-trees/uintX/iptree64"
+	"github.com/infobloxopen/go-trees/uintX/iptree8"
+	"github.com/infobloxopen/go-trees/uintX/strtree16"
+	"github.com/infobloxopen/go-trees/uintX/strtree32"
+	"github.com/infobloxopen/go-trees/uintX/strtree64"
+	"github.com/infobloxopen/go-trees/uintX/strtree8"
+)
+
+// ContentSnapshotMagic is a header of binary content snapshot. Its first byte
+// can't start JSON document so snapshot can't be confused with JCON.
+var ContentSnapshotMagic = []byte{0xff, 'T', 'C', 'S'}
+
+// ContentSnapshotVersion is a version of binary content snapshot format
+// the package writes and reads.
+const ContentSnapshotVersion = 1
+
+// IsContentSnapshot checks if given data starts with content snapshot header.
+func IsContentSnapshot(b []byte) bool {
+	return bytes.HasPrefix(b, ContentSnapshotMagic)
+}
+
+// ContentSnapshotObserver gets notified on progress of snapshot reading.
+// An error returned by any of its methods stops reading.
+type ContentSnapshotObserver interface {
+	// OnKey is called after each key of content map at any level.
+	OnKey() error
+	// OnItem is called after each content item.
+	OnItem() error
+}
+
+// Snapshot format (all integers are unsigned varints unless noted otherwise):
+//
+//	header:  magic, version (byte)
+//	content: id (string), types, items
+//	types:   count, type declarations
+//	items:   entries of id (string), type, key types, data
+//
+// A string is a length followed by bytes. An entry is preceded by
+// snapshotEntry byte or by snapshotExpiringEntry byte and expiration time
+// (signed varint of nanoseconds since Unix epoch) if its value expires. A list
+// of entries ends with snapshotEnd byte. A type
+// is zero followed by key of built-in type or index of declaration plus one.
+// A map is its tag byte followed by map specific fields and entries of keys
+// and values.
+const (
+	snapshotEnd byte = iota
+	snapshotEntry
+	snapshotExpiringEntry
+)
+
+const (
+	snapshotTypeFlags byte = iota + 1
+	snapshotTypeEnum
+	snapshotTypeStruct
+)
+
+const (
+	snapshotMapString byte = iota + 1
+	snapshotMapStringFlags8
+	snapshotMapStringFlags16
+	snapshotMapStringFlags32
+	snapshotMapStringFlags64
+	snapshotMapNetwork
+	snapshotMapNetworkFlags8
+	snapshotMapNetworkFlags16
+	snapshotMapNetworkFlags32
+	snapshotMapNetworkFlags64
+	snapshotMapDomain
+	snapshotMapDomainFlags8
+	snapshotMapDomainFlags16
+	snapshotMapDomainFlags32
+	snapshotMapDomainFlags64
+	snapshotMapDomainMatch
+	snapshotMapInteger
+	snapshotMapFloat
+)
+
+const (
+	snapshotRuleEntryNone byte = iota
+	snapshotRuleEntryValue
+	snapshotRuleEntryNegative
+	snapshotRuleEntryExpiringValue
+)
+
+// WriteContentSnapshot writes given content to binary snapshot. Snapshot
+// doesn't keep content tag.
+func WriteContentSnapshot(w io.Writer, c *LocalContent) error {
+	sw := &snapshotWriter{
+		w:     bufio.NewWriter(w),
+		types: make(map[Type]int),
+	}
+
+	if err := sw.putContent(c); err != nil {
+		return err
+	}
+
+	return sw.w.Flush()
+}
+
+// snapshotWriter relies on sticky error of bufio.Writer so only final flush
+// reports write errors.
+type snapshotWriter struct {
+	w     *bufio.Writer
+	b     [binary.MaxVarintLen64]byte
+	types map[Type]int
+}
+
+func (w *snapshotWriter) putContent(c *LocalContent) error {
+	w.w.Write(ContentSnapshotMagic)
+	w.w.WriteByte(ContentSnapshotVersion)
+	w.putString(c.id)
+
+	items := c.sortedItems()
+	types := w.collectTypes(c, items)
+	w.putUvarint(uint64(len(types)))
+	for _, t := range types {
+		_, named := c.symbols.types[t.GetKey()]
+		if err := w.putTypeDeclaration(t, named); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		r := w.putEntry(item.r)
+		if err := w.putItem(item, r); err != nil {
+			return bindError(err, item.id)
+		}
+	}
+
+	w.w.WriteByte(snapshotEnd)
+	return nil
+}
+
+// collectTypes makes list of custom types of the content where struct types
+// go after types of their fields.
+func (w *snapshotWriter) collectTypes(c *LocalContent, items []*ContentItem) []Type {
+	var out []Type
+
+	var add func(t Type)
+	add = func(t Type) {
+		if _, ok := t.(*builtinType); ok {
+			return
+		}
+
+		if _, ok := w.types[t]; ok {
+			return
+		}
+
+		if st, ok := t.(*StructType); ok {
+			for _, f := range st.b {
+				add(f.Type)
+			}
+		}
+
+		w.types[t] = len(out)
+		out = append(out, t)
+	}
+
+	keys := make([]string, 0, len(c.symbols.types))
+	for k := range c.symbols.types {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		add(c.symbols.types[k])
+	}
+
+	for _, item := range items {
+		add(item.t)
+	}
+
+	return out
+}
+
+func (w *snapshotWriter) putTypeDeclaration(t Type, named bool) error {
+	var meta byte
+	switch t.(type) {
+	default:
+		return newInvalidContentSnapshotTypeError(t.String())
+
+	case *FlagsType:
+		meta = snapshotTypeFlags
+
+	case *EnumType:
+		meta = snapshotTypeEnum
+
+	case *StructType:
+		meta = snapshotTypeStruct
+	}
+
+	w.w.WriteByte(meta)
+	w.putString(t.String())
+	w.putBool(named)
+
+	switch t := t.(type) {
+	case *FlagsType:
+		w.putStrings(t.b)
+
+	case *EnumType:
+		w.putStrings(t.b)
+
+	case *StructType:
+		w.putUvarint(uint64(len(t.b)))
+		for _, f := range t.b {
+			w.putString(f.Name)
+			w.putType(f.Type)
+		}
+	}
+
+	return nil
+}
+
+func (w *snapshotWriter) putType(t Type) {
+	if i, ok := w.types[t]; ok {
+		w.putUvarint(uint64(i + 1))
+		return
+	}
+
+	w.putUvarint(0)
+	w.putString(t.GetKey())
+}
+
+func (w *snapshotWriter) putItem(item *ContentItem, r interface{}) error {
+	w.putString(item.id)
+	w.putType(item.t)
+	w.putUvarint(uint64(len(item.k)))
+	for _, k := range item.k {
+		w.putType(k)
+	}
+
+	return w.putNode(item, 0, r)
+}
+
+// putNode writes map if given level has a key or value otherwise.
+func (w *snapshotWriter) putNode(item *ContentItem, level int, v interface{}) error {
+	if level >= len(item.k) {
+		if cv, ok := v.(ContentValue); ok {
+			v = cv.value
+		}
+
+		return w.putValue(item.t, v)
+	}
+
+	var err error
+	put := func(v interface{}) {
+		if err == nil {
+			err = w.putNode(item, level+1, v)
+		}
+	}
+
+	switch m := v.(type) {
+	default:
+		return newInvalidContentSnapshotValueError(v)
+
+	case ContentStringMap:
+		w.w.WriteByte(snapshotMapString)
+		for p := range m.tree.Enumerate() {
+			v := w.putEntry(p.Value)
+			w.putString(p.Key)
+			put(v)
+		}
+
+	case ContentStringFlags8Map:
+		w.w.WriteByte(snapshotMapStringFlags8)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentStringFlags16Map:
+		w.w.WriteByte(snapshotMapStringFlags16)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentStringFlags32Map:
+		w.w.WriteByte(snapshotMapStringFlags32)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentStringFlags64Map:
+		w.w.WriteByte(snapshotMapStringFlags64)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(p.Value)
+		}
+
+	case ContentNetworkMap:
+		w.w.WriteByte(snapshotMapNetwork)
+		for p := range m.tree.Enumerate() {
+			v := w.putEntry(p.Value)
+			w.putNetwork(p.Key)
+			put(v)
+		}
+
+	case ContentNetworkFlags8Map:
+		w.w.WriteByte(snapshotMapNetworkFlags8)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putNetwork(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentNetworkFlags16Map:
+		w.w.WriteByte(snapshotMapNetworkFlags16)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putNetwork(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentNetworkFlags32Map:
+		w.w.WriteByte(snapshotMapNetworkFlags32)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putNetwork(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentNetworkFlags64Map:
+		w.w.WriteByte(snapshotMapNetworkFlags64)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putNetwork(p.Key)
+			w.putUvarint(p.Value)
+		}
+
+	case ContentDomainMap:
+		w.w.WriteByte(snapshotMapDomain)
+		for p := range m.tree.Enumerate() {
+			v := w.putEntry(p.Value)
+			w.putString(p.Key)
+			put(v)
+		}
+
+	case ContentDomainFlags8Map:
+		w.w.WriteByte(snapshotMapDomainFlags8)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentDomainFlags16Map:
+		w.w.WriteByte(snapshotMapDomainFlags16)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentDomainFlags32Map:
+		w.w.WriteByte(snapshotMapDomainFlags32)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(uint64(p.Value))
+		}
+
+	case ContentDomainFlags64Map:
+		w.w.WriteByte(snapshotMapDomainFlags64)
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			w.putUvarint(p.Value)
+		}
+
+	case ContentDomainMatchMap:
+		w.w.WriteByte(snapshotMapDomainMatch)
+		w.w.WriteByte(byte(m.match))
+		for p := range m.tree.Enumerate() {
+			w.w.WriteByte(snapshotEntry)
+			w.putString(p.Key)
+			for _, e := range p.Value.(*domainRule).e {
+				switch {
+				case !e.ok:
+					w.w.WriteByte(snapshotRuleEntryNone)
+
+				case e.neg:
+					w.w.WriteByte(snapshotRuleEntryNegative)
+
+				default:
+					v, exp := splitExpiringValue(e.v)
+					if exp.IsZero() {
+						w.w.WriteByte(snapshotRuleEntryValue)
+					} else {
+						w.w.WriteByte(snapshotRuleEntryExpiringValue)
+						w.putVarint(exp.UnixNano())
+					}
+					put(v)
+				}
+			}
+		}
+
+	case ContentIntegerMap:
+		w.w.WriteByte(snapshotMapInteger)
+		err = w.putRanges(item, level, m.m)
+
+	case ContentFloatMap:
+		w.w.WriteByte(snapshotMapFloat)
+		err = w.putRanges(item, level, m.m)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.w.WriteByte(snapshotEnd)
+	return nil
+}
+
+func (w *snapshotWriter) putRanges(item *ContentItem, level int, m numRangeMap) error {
+	for _, r := range m {
+		v := w.putEntry(r.v)
+		w.putUint64(r.lo)
+		w.putUint64(r.hi)
+		if err := w.putNode(item, level+1, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// putEntry writes entry marker along with expiration time of given value if
+// it expires and returns the value without expiration.
+func (w *snapshotWriter) putEntry(v interface{}) interface{} {
+	v, exp := splitExpiringValue(v)
+	if exp.IsZero() {
+		w.w.WriteByte(snapshotEntry)
+		return v
+	}
+
+	w.w.WriteByte(snapshotExpiringEntry)
+	w.putVarint(exp.UnixNano())
+	return v
+}
+
+func (w *snapshotWriter) putValue(t Type, v interface{}) error {
+	switch t := t.(type) {
+	case *FlagsType:
+		switch n := v.(type) {
+		case uint8:
+			w.putUvarint(uint64(n))
+
+		case uint16:
+			w.putUvarint(uint64(n))
+
+		case uint32:
+			w.putUvarint(uint64(n))
+
+		case uint64:
+			w.putUvarint(n)
+
+		default:
+			return newInvalidContentSnapshotValueError(v)
+		}
+
+		return nil
+
+	case *EnumType:
+		s, ok := v.(string)
+		if !ok {
+			return newInvalidContentSnapshotValueError(v)
+		}
+
+		w.putString(s)
+		return nil
+
+	case *StructType:
+		av, ok := v.(AttributeValue)
+		if !ok {
+			return newInvalidContentSnapshotValueError(v)
+		}
+
+		fields, ok := av.v.([]AttributeValue)
+		if !ok || len(fields) != len(t.b) {
+			return newInvalidContentSnapshotValueError(av.v)
+		}
+
+		for i, f := range t.b {
+			if err := w.putValue(f.Type, fields[i].v); err != nil {
+				return bindError(err, f.Name)
+			}
+		}
+
+		return nil
+	}
+
+	ok := false
+	switch t {
+	case TypeBoolean:
+		var b bool
+		if b, ok = v.(bool); ok {
+			w.putBool(b)
+		}
+
+	case TypeString:
+		var s string
+		if s, ok = v.(string); ok {
+			w.putString(s)
+		}
+
+	case TypeInteger:
+		var n int64
+		if n, ok = v.(int64); ok {
+			w.putVarint(n)
+		}
+
+	case TypeFloat:
+		var f float64
+		if f, ok = v.(float64); ok {
+			w.putUint64(math.Float64bits(f))
+		}
+
+	case TypeAddress:
+		var a net.IP
+		if a, ok = v.(net.IP); ok {
+			w.putBytes(a)
+		}
+
+	case TypeNetwork:
+		var n *net.IPNet
+		if n, ok = v.(*net.IPNet); ok {
+			w.putNetwork(n)
+		}
+
+	case TypeDomain:
+		var d domain.Name
+		if d, ok = v.(domain.Name); ok {
+			w.putString(d.String())
+		}
+
+	case TypeSetOfStrings:
+		var s *strtree.Tree
+		if s, ok = v.(*strtree.Tree); ok {
+			w.putStrings(SortSetOfStrings(s))
+		}
+
+	case TypeSetOfNetworks:
+		var s *iptree.Tree
+		if s, ok = v.(*iptree.Tree); ok {
+			nets := SortSetOfNetworks(s)
+			w.putUvarint(uint64(len(nets)))
+			for _, n := range nets {
+				w.putNetwork(n)
+			}
+		}
+
+	case TypeSetOfDomains:
+		var s *domaintree.Node
+		if s, ok = v.(*domaintree.Node); ok {
+			w.putStrings(SortSetOfDomains(s))
+		}
+
+	case TypeListOfStrings:
+		var s []string
+		if s, ok = v.([]string); ok {
+			w.putStrings(s)
+		}
+
+	case TypeTime:
+		var tm time.Time
+		if tm, ok = v.(time.Time); ok {
+			b, err := tm.MarshalBinary()
+			if err != nil {
+				return err
+			}
+
+			w.putBytes(b)
+		}
+
+	case TypeDuration:
+		var d time.Duration
+		if d, ok = v.(time.Duration); ok {
+			w.putVarint(int64(d))
+		}
+	}
+
+	if !ok {
+		return newInvalidContentSnapshotValueError(v)
+	}
+
+	return nil
+}
+
+func (w *snapshotWriter) putUvarint(n uint64) {
+	w.w.Write(w.b[:binary.PutUvarint(w.b[:], n)])
+}
+
+func (w *snapshotWriter) putVarint(n int64) {
+	w.w.Write(w.b[:binary.PutVarint(w.b[:], n)])
+}
+
+func (w *snapshotWriter) putUint64(n uint64) {
+	binary.BigEndian.PutUint64(w.b[:], n)
+	w.w.Write(w.b[:8])
+}
+
+func (w *snapshotWriter) putBool(b bool) {
+	if b {
+		w.w.WriteByte(1)
+	} else {
+		w.w.WriteByte(0)
+	}
+}
+
+func (w *snapshotWriter) putString(s string) {
+	w.putUvarint(uint64(len(s)))
+	w.w.WriteString(s)
+}
+
+func (w *snapshotWriter) putBytes(b []byte) {
+	w.putUvarint(uint64(len(b)))
+	w.w.Write(b)
+}
+
+func (w *snapshotWriter) putStrings(s []string) {
+	w.putUvarint(uint64(len(s)))
+	for _, s := range s {
+		w.putString(s)
+	}
+}
+
+func (w *snapshotWriter) putNetwork(n *net.IPNet) {
+	ip := n.IP
+	if ip4 := ip.To4(); ip4 != nil && len(n.Mask) == net.IPv4len {
+		ip = ip4
+	}
+
+	ones, _ := n.Mask.Size()
+	w.putBytes(ip)
+	w.w.WriteByte(byte(ones))
+}
+
+// sortedItems returns content items in order of their ids.
+func (c *LocalContent) sortedItems() []*ContentItem {
+	var items []*ContentItem
+	for p := range c.items.Enumerate() {
+		if item, ok := p.Value.(*ContentItem); ok {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// ReadContentSnapshot reads content from binary snapshot and sets given tag
+// to it. Observer if not nil gets notified on reading progress.
+func ReadContentSnapshot(r io.Reader, tag *uuid.UUID, o ContentSnapshotObserver) (*LocalContent, error) {
+	sr := &snapshotReader{
+		r: bufio.NewReader(r),
+		o: o,
+		s: MakeSymbols(),
+	}
+
+	id, items, err := sr.getContent()
+	if err != nil {
+		if len(id) > 0 {
+			return nil, bindError(err, id)
+		}
+
+		return nil, err
+	}
+
+	return NewLocalContent(id, tag, sr.s, items), nil
+}
+
+type snapshotReader struct {
+	r     *bufio.Reader
+	o     ContentSnapshotObserver
+	s     Symbols
+	types []Type
+	buf   []byte
+}
+
+func (r *snapshotReader) getContent() (string, []*ContentItem, error) {
+	b := make([]byte, len(ContentSnapshotMagic)+1)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return "", nil, newInvalidContentSnapshotHeaderError()
+	}
+
+	if !IsContentSnapshot(b) {
+		return "", nil, newInvalidContentSnapshotHeaderError()
+	}
+
+	if v := int(b[len(b)-1]); v != ContentSnapshotVersion {
+		return "", nil, newUnsupportedContentSnapshotVersionError(v, ContentSnapshotVersion)
+	}
+
+	id, err := r.getString()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := r.getTypes(); err != nil {
+		return id, nil, err
+	}
+
+	var items []*ContentItem
+	for {
+		ok, exp, err := r.getEntry()
+		if err != nil {
+			return id, nil, err
+		}
+
+		if !ok {
+			return id, items, nil
+		}
+
+		item, err := r.getItem()
+		if err != nil {
+			return id, nil, err
+		}
+
+		if !exp.IsZero() {
+			item = MakeExpiringContentItem(item, exp)
+		}
+
+		items = append(items, item)
+		if r.o != nil {
+			if err := r.o.OnItem(); err != nil {
+				return id, nil, bindError(err, item.id)
+			}
+		}
+	}
+}
+
+func (r *snapshotReader) getTypes() error {
+	n, err := r.getUvarint()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < n; i++ {
+		t, err := r.getTypeDeclaration()
+		if err != nil {
+			return err
+		}
+
+		r.types = append(r.types, t)
+	}
+
+	return nil
+}
+
+func (r *snapshotReader) getTypeDeclaration() (Type, error) {
+	meta, err := r.getByte()
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := r.getString()
+	if err != nil {
+		return nil, err
+	}
+
+	named, err := r.getBool()
+	if err != nil {
+		return nil, err
+	}
+
+	var t Type
+	switch meta {
+	default:
+		return nil, newInvalidContentSnapshotTagError("type", meta)
+
+	case snapshotTypeFlags:
+		flags, err := r.getStrings()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err = NewFlagsType(name, flags...)
+		if err != nil {
+			return nil, err
+		}
+
+	case snapshotTypeEnum:
+		values, err := r.getStrings()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err = NewEnumType(name, values...)
+		if err != nil {
+			return nil, err
+		}
+
+	case snapshotTypeStruct:
+		n, err := r.getUvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		var fields []StructField
+		for i := uint64(0); i < n; i++ {
+			name, err := r.getString()
+			if err != nil {
+				return nil, err
+			}
+
+			ft, err := r.getType()
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, StructField{Name: name, Type: ft})
+		}
+
+		t, err = NewStructType(name, fields...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if named {
+		if err := r.s.PutType(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (r *snapshotReader) getType() (Type, error) {
+	i, err := r.getUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if i > 0 {
+		if i > uint64(len(r.types)) {
+			return nil, newInvalidContentSnapshotTypeError(fmt.Sprintf("#%d", i-1))
+		}
+
+		return r.types[i-1], nil
+	}
+
+	k, err := r.getString()
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := BuiltinTypes[k]
+	if !ok || t == TypeUndefined {
+		return nil, newInvalidContentSnapshotTypeError(k)
+	}
+
+	return t, nil
+}
+
+func (r *snapshotReader) getItem() (*ContentItem, error) {
+	id, err := r.getString()
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := r.getItemData(id)
+	if err != nil {
+		return nil, bindError(err, id)
+	}
+
+	return item, nil
+}
+
+func (r *snapshotReader) getItemData(id string) (*ContentItem, error) {
+	t, err := r.getType()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.getUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	var k []Type
+	for i := uint64(0); i < n; i++ {
+		kt, err := r.getType()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ContentKeyTypes.Contains(kt) {
+			return nil, newInvalidContentSnapshotTypeError(kt.GetKey())
+		}
+
+		k = append(k, kt)
+	}
+
+	v, err := r.getNode(t, k, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(k) <= 0 {
+		return MakeContentValueItem(id, t, v), nil
+	}
+
+	return MakeContentMappingItem(id, t, k, v.(ContentSubItem)), nil
+}
+
+func (r *snapshotReader) getNode(t Type, k []Type, level int) (interface{}, error) {
+	if level >= len(k) {
+		return r.getValue(t)
+	}
+
+	tag, err := r.getByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.checkMapTag(tag, t, k[level]) {
+		return nil, newInvalidContentSnapshotTagError(fmt.Sprintf("level %d map", level+1), tag)
+	}
+
+	switch tag {
+	case snapshotMapString:
+		m := strtree.NewTree()
+		err = r.getEntries(func(exp time.Time) error {
+			s, err := r.getString()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getNode(t, k, level+1)
+			if err != nil {
+				return bindError(err, s)
+			}
+
+			m.InplaceInsert(s, MakeExpiringContentValue(v, exp))
+			return nil
+		})
+
+		return MakeContentStringMap(m), err
+
+	case snapshotMapStringFlags8:
+		m := strtree8.NewTree()
+		err = r.getFlagsEntries(func() error {
+			s, err := r.getString()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(s, uint8(n))
+			return err
+		})
+
+		return MakeContentStringFlags8Map(m), err
+
+	case snapshotMapStringFlags16:
+		m := strtree16.NewTree()
+		err = r.getFlagsEntries(func() error {
+			s, err := r.getString()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(s, uint16(n))
+			return err
+		})
+
+		return MakeContentStringFlags16Map(m), err
+
+	case snapshotMapStringFlags32:
+		m := strtree32.NewTree()
+		err = r.getFlagsEntries(func() error {
+			s, err := r.getString()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(s, uint32(n))
+			return err
+		})
+
+		return MakeContentStringFlags32Map(m), err
+
+	case snapshotMapStringFlags64:
+		m := strtree64.NewTree()
+		err = r.getFlagsEntries(func() error {
+			s, err := r.getString()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(s, n)
+			return err
+		})
+
+		return MakeContentStringFlags64Map(m), err
+
+	case snapshotMapNetwork:
+		m := iptree.NewTree()
+		err = r.getEntries(func(exp time.Time) error {
+			n, err := r.getNetwork()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getNode(t, k, level+1)
+			if err != nil {
+				return bindError(err, n.String())
+			}
+
+			m.InplaceInsertNet(n, MakeExpiringContentValue(v, exp))
+			return nil
+		})
+
+		return MakeContentNetworkMap(m), err
+
+	case snapshotMapNetworkFlags8:
+		m := iptree8.NewTree()
+		err = r.getFlagsEntries(func() error {
+			n, err := r.getNetwork()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getUvarint()
+			m.InplaceInsertNet(n, uint8(v))
+			return err
+		})
+
+		return MakeContentNetworkFlags8Map(m), err
+
+	case snapshotMapNetworkFlags16:
+		m := iptree16.NewTree()
+		err = r.getFlagsEntries(func() error {
+			n, err := r.getNetwork()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getUvarint()
+			m.InplaceInsertNet(n, uint16(v))
+			return err
+		})
+
+		return MakeContentNetworkFlags16Map(m), err
+
+	case snapshotMapNetworkFlags32:
+		m := iptree32.NewTree()
+		err = r.getFlagsEntries(func() error {
+			n, err := r.getNetwork()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getUvarint()
+			m.InplaceInsertNet(n, uint32(v))
+			return err
+		})
+
+		return MakeContentNetworkFlags32Map(m), err
+
+	case snapshotMapNetworkFlags64:
+		m := iptree64.NewTree()
+		err = r.getFlagsEntries(func() error {
+			n, err := r.getNetwork()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getUvarint()
+			m.InplaceInsertNet(n, v)
+			return err
+		})
+
+		return MakeContentNetworkFlags64Map(m), err
+
+	case snapshotMapDomain:
+		m := &domaintree.Node{}
+		err = r.getEntries(func(exp time.Time) error {
+			d, err := r.getDomain()
+			if err != nil {
+				return err
+			}
+
+			v, err := r.getNode(t, k, level+1)
+			if err != nil {
+				return bindError(err, d.String())
+			}
+
+			m.InplaceInsert(d, MakeExpiringContentValue(v, exp))
+			return nil
+		})
+
+		return MakeContentDomainMap(m), err
+
+	case snapshotMapDomainFlags8:
+		m := &domaintree8.Node{}
+		err = r.getFlagsEntries(func() error {
+			d, err := r.getDomain()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(d, uint8(n))
+			return err
+		})
+
+		return MakeContentDomainFlags8Map(m), err
+
+	case snapshotMapDomainFlags16:
+		m := &domaintree16.Node{}
+		err = r.getFlagsEntries(func() error {
+			d, err := r.getDomain()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(d, uint16(n))
+			return err
+		})
+
+		return MakeContentDomainFlags16Map(m), err
+
+	case snapshotMapDomainFlags32:
+		m := &domaintree32.Node{}
+		err = r.getFlagsEntries(func() error {
+			d, err := r.getDomain()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(d, uint32(n))
+			return err
+		})
+
+		return MakeContentDomainFlags32Map(m), err
+
+	case snapshotMapDomainFlags64:
+		m := &domaintree64.Node{}
+		err = r.getFlagsEntries(func() error {
+			d, err := r.getDomain()
+			if err != nil {
+				return err
+			}
+
+			n, err := r.getUvarint()
+			m.InplaceInsert(d, n)
+			return err
+		})
+
+		return MakeContentDomainFlags64Map(m), err
+
+	case snapshotMapDomainMatch:
+		return r.getDomainMatchMap(t, k, level)
+
+	case snapshotMapInteger:
+		m, err := r.getRanges(t, k, level)
+		return ContentIntegerMap{m: m}, err
+
+	case snapshotMapFloat:
+		m, err := r.getRanges(t, k, level)
+		return ContentFloatMap{m: m}, err
+	}
+
+	return nil, newInvalidContentSnapshotTagError("map", tag)
+}
+
+// checkMapTag checks if map of given tag can have keys of given type and
+// values of type t.
+func (r *snapshotReader) checkMapTag(tag byte, t, k Type) bool {
+	capacity := 0
+	if ft, ok := t.(*FlagsType); ok {
+		capacity = ft.c
+	}
+
+	switch tag {
+	case snapshotMapString:
+		return k == TypeString
+
+	case snapshotMapNetwork:
+		return k == TypeAddress || k == TypeNetwork
+
+	case snapshotMapDomain, snapshotMapDomainMatch:
+		return k == TypeDomain
+
+	case snapshotMapInteger:
+		return k == TypeInteger
+
+	case snapshotMapFloat:
+		return k == TypeFloat
+
+	case snapshotMapStringFlags8, snapshotMapNetworkFlags8, snapshotMapDomainFlags8:
+		return capacity == 8 && r.checkMapTag(tag-snapshotMapStringFlags8+snapshotMapString, nil, k)
+
+	case snapshotMapStringFlags16, snapshotMapNetworkFlags16, snapshotMapDomainFlags16:
+		return capacity == 16 && r.checkMapTag(tag-snapshotMapStringFlags16+snapshotMapString, nil, k)
+
+	case snapshotMapStringFlags32, snapshotMapNetworkFlags32, snapshotMapDomainFlags32:
+		return capacity == 32 && r.checkMapTag(tag-snapshotMapStringFlags32+snapshotMapString, nil, k)
+
+	case snapshotMapStringFlags64, snapshotMapNetworkFlags64, snapshotMapDomainFlags64:
+		return capacity == 64 && r.checkMapTag(tag-snapshotMapStringFlags64+snapshotMapString, nil, k)
+	}
+
+	return false
+}
+
+func (r *snapshotReader) getDomainMatchMap(t Type, k []Type, level int) (interface{}, error) {
+	b, err := r.getByte()
+	if err != nil {
+		return nil, err
+	}
+
+	match := DomainMatch(b)
+	if match >= domainMatchTotal {
+		return nil, newInvalidContentSnapshotTagError("domain match", b)
+	}
+
+	m := MakeContentDomainMatchMap(match)
+	err = r.getEntries(func(exp time.Time) error {
+		if !exp.IsZero() {
+			return newInvalidContentSnapshotDataError("expiring domain rule")
+		}
+
+		d, err := r.getDomain()
+		if err != nil {
+			return err
+		}
+
+		for i := DomainMatch(0); i < domainMatchTotal; i++ {
+			e, err := r.getByte()
+			if err != nil {
+				return err
+			}
+
+			switch e {
+			default:
+				return newInvalidContentSnapshotTagError("domain rule", e)
+
+			case snapshotRuleEntryNone:
+
+			case snapshotRuleEntryNegative:
+				m.InplaceInsert(DomainKey{Name: d, Match: i, Negative: true}, nil)
+
+			case snapshotRuleEntryValue:
+				v, err := r.getNode(t, k, level+1)
+				if err != nil {
+					return bindError(err, d.String())
+				}
+
+				m.InplaceInsert(DomainKey{Name: d, Match: i}, v)
+
+			case snapshotRuleEntryExpiringValue:
+				exp, err := r.getExpiration()
+				if err != nil {
+					return err
+				}
+
+				v, err := r.getNode(t, k, level+1)
+				if err != nil {
+					return bindError(err, d.String())
+				}
+
+				m.InplaceInsert(DomainKey{Name: d, Match: i}, MakeExpiringContentValue(v, exp))
+			}
+		}
+
+		return nil
+	})
+
+	return m, err
+}
+
+func (r *snapshotReader) getRanges(t Type, k []Type, level int) (numRangeMap, error) {
+	var b numRangeMapBuilder
+	err := r.getEntries(func(exp time.Time) error {
+		lo, err := r.getUint64()
+		if err != nil {
+			return err
+		}
+
+		hi, err := r.getUint64()
+		if err != nil {
+			return err
+		}
+
+		if lo > hi {
+			return newInvalidRangeBoundsError(fmt.Sprintf("[%d, %d]", lo, hi))
+		}
+
+		v, err := r.getNode(t, k, level+1)
+		if err != nil {
+			return err
+		}
+
+		b.add(lo, hi, MakeExpiringContentValue(v, exp))
+		return nil
+	})
+
+	return b.build(), err
+}
+
+// getEntries calls f with expiration time (zero if none) for each entry
+// of the list and notifies observer.
+func (r *snapshotReader) getEntries(f func(exp time.Time) error) error {
+	for {
+		ok, exp, err := r.getEntry()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+
+		if err := f(exp); err != nil {
+			return err
+		}
+
+		if r.o != nil {
+			if err := r.o.OnKey(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// getFlagsEntries calls f for each entry of map of flags. Such entries can't
+// expire.
+func (r *snapshotReader) getFlagsEntries(f func() error) error {
+	return r.getEntries(func(exp time.Time) error {
+		if !exp.IsZero() {
+			return newInvalidContentSnapshotDataError("expiring flags")
+		}
+
+		return f()
+	})
+}
+
+func (r *snapshotReader) getEntry() (bool, time.Time, error) {
+	b, err := r.getByte()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	switch b {
+	case snapshotEntry:
+		return true, time.Time{}, nil
+
+	case snapshotExpiringEntry:
+		exp, err := r.getExpiration()
+		return err == nil, exp, err
+
+	case snapshotEnd:
+		return false, time.Time{}, nil
+	}
+
+	return false, time.Time{}, newInvalidContentSnapshotTagError("entry", b)
+}
+
+func (r *snapshotReader) getExpiration() (time.Time, error) {
+	n, err := r.getVarint()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, n), nil
+}
+
+func (r *snapshotReader) getValue(t Type) (interface{}, error) {
+	switch t := t.(type) {
+	case *FlagsType:
+		n, err := r.getUvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t.c {
+		case 8:
+			return uint8(n), nil
+
+		case 16:
+			return uint16(n), nil
+
+		case 32:
+			return uint32(n), nil
+		}
+
+		return n, nil
+
+	case *EnumType:
+		s, err := r.getString()
+		if err != nil {
+			return nil, err
+		}
+
+		if t.GetIndex(s) < 0 {
+			return nil, newInvalidEnumValueError(s, t)
+		}
+
+		return s, nil
+
+	case *StructType:
+		fields := make([]AttributeValue, len(t.b))
+		for i, f := range t.b {
+			v, err := r.getValue(f.Type)
+			if err != nil {
+				return nil, bindError(err, f.Name)
+			}
+
+			fields[i] = AttributeValue{t: f.Type, v: v}
+		}
+
+		return MakeStructValue(t, fields...)
+	}
+
+	switch t {
+	case TypeBoolean:
+		return r.getBool()
+
+	case TypeString:
+		return r.getString()
+
+	case TypeInteger:
+		return r.getVarint()
+
+	case TypeFloat:
+		n, err := r.getUint64()
+		return math.Float64frombits(n), err
+
+	case TypeAddress:
+		b, err := r.getBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) != net.IPv4len && len(b) != net.IPv6len {
+			return nil, newInvalidContentSnapshotDataError("address")
+		}
+
+		return net.IP(b), nil
+
+	case TypeNetwork:
+		return r.getNetwork()
+
+	case TypeDomain:
+		return r.getDomain()
+
+	case TypeSetOfStrings:
+		s, err := r.getStrings()
+		if err != nil {
+			return nil, err
+		}
+
+		m := strtree.NewTree()
+		for i, s := range s {
+			m.InplaceInsert(s, i)
+		}
+
+		return m, nil
+
+	case TypeSetOfNetworks:
+		n, err := r.getUvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		m := iptree.NewTree()
+		for i := uint64(0); i < n; i++ {
+			n, err := r.getNetwork()
+			if err != nil {
+				return nil, err
+			}
+
+			m.InplaceInsertNet(n, int(i))
+		}
+
+		return m, nil
+
+	case TypeSetOfDomains:
+		s, err := r.getStrings()
+		if err != nil {
+			return nil, err
+		}
+
+		m := &domaintree.Node{}
+		for i, s := range s {
+			d, err := domain.MakeNameFromString(s)
+			if err != nil {
+				return nil, newInvalidDomainNameStringCastError(s, err)
+			}
+
+			m.InplaceInsert(d, i)
+		}
+
+		return m, nil
+
+	case TypeListOfStrings:
+		return r.getStrings()
+
+	case TypeTime:
+		b, err := r.getBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		var tm time.Time
+		if err := tm.UnmarshalBinary(b); err != nil {
+			return nil, err
+		}
+
+		return tm, nil
+
+	case TypeDuration:
+		n, err := r.getVarint()
+		return time.Duration(n), err
+	}
+
+	return nil, newInvalidContentSnapshotTypeError(t.GetKey())
+}
+
+func (r *snapshotReader) readError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return newContentSnapshotReadError(err)
+}
+
+func (r *snapshotReader) getByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return 0, r.readError(err)
+	}
+
+	return b, nil
+}
+
+func (r *snapshotReader) getBool() (bool, error) {
+	b, err := r.getByte()
+	return b != 0, err
+}
+
+func (r *snapshotReader) getUvarint() (uint64, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, r.readError(err)
+	}
+
+	return n, nil
+}
+
+func (r *snapshotReader) getVarint() (int64, error) {
+	n, err := binary.ReadVarint(r.r)
+	if err != nil {
+		return 0, r.readError(err)
+	}
+
+	return n, nil
+}
+
+func (r *snapshotReader) getUint64() (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r.r, b[:]); err != nil {
+		return 0, r.readError(err)
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// snapshotChunkSize limits buffer allocated at once for string of snapshot
+// so broken length can't make reader allocate more than data it has.
+const snapshotChunkSize = 64 * 1024
+
+func (r *snapshotReader) getBytes() ([]byte, error) {
+	n, err := r.getUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= snapshotChunkSize {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r.r, b); err != nil {
+			return nil, r.readError(err)
+		}
+
+		return b, nil
+	}
+
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, r.r, int64(n)); err != nil {
+		return nil, r.readError(err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// getString reads short string via scratch buffer of the reader to make only
+// one allocation for the string.
+func (r *snapshotReader) getString() (string, error) {
+	n, err := r.getUvarint()
+	if err != nil {
+		return "", err
+	}
+
+	if n > snapshotChunkSize {
+		var b strings.Builder
+		if _, err := io.CopyN(&b, r.r, int64(n)); err != nil {
+			return "", r.readError(err)
+		}
+
+		return b.String(), nil
+	}
+
+	if uint64(cap(r.buf)) < n {
+		r.buf = make([]byte, n)
+	}
+
+	b := r.buf[:n]
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return "", r.readError(err)
+	}
+
+	return string(b), nil
+}
+
+func (r *snapshotReader) getStrings() ([]string, error) {
+	n, err := r.getUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []string{}
+	for i := uint64(0); i < n; i++ {
+		s, err := r.getString()
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, s)
+	}
+
+	return out, nil
+}
+
+func (r *snapshotReader) getNetwork() (*net.IPNet, error) {
+	ip, err := r.getBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	ones, err := r.getByte()
+	if err != nil {
+		return nil, err
+	}
+
+	bits := 8 * len(ip)
+	if (bits != 8*net.IPv4len && bits != 8*net.IPv6len) || int(ones) > bits {
+		return nil, newInvalidContentSnapshotDataError("network")
+	}
+
+	return &net.IPNet{IP: net.IP(ip), Mask: net.CIDRMask(int(ones), bits)}, nil
+}
+
+func (r *snapshotReader) getDomain() (domain.Name, error) {
+	s, err := r.getString()
+	if err != nil {
+		return domain.Name{}, err
+	}
+
+	d, err := domain.MakeNameFromString(s)
+	if err != nil {
+		return domain.Name{}, newInvalidDomainNameStringCastError(s, err)
+	}
+
+	return d, nil
+}
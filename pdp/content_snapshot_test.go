@@ -0,0 +1,104 @@
+package pdp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+func TestContentSnapshot(t *testing.T) {
+	level, err := NewEnumType("level", "low", "high")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	st, err := NewStructType("device", StructField{Name: "name", Type: TypeString}, StructField{Name: "level", Type: level})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := MakeSymbols()
+	if err := s.PutType(level); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := s.PutType(st); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	dev, err := MakeStructValue(st, MakeStringValue("core"), AttributeValue{t: level, v: "high"})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	devices := strtree.NewTree()
+	devices.InplaceInsert("router", dev)
+	devices.InplaceInsert("switch", MakeContentValue(dev))
+
+	var b numRangeMapBuilder
+	b.add(integerKey(0), integerKey(1023), "system")
+	b.add(integerKey(53), integerKey(53), "dns")
+
+	c := NewLocalContent("test", nil, s, []*ContentItem{
+		MakeContentMappingItem("devices", st, MakeSignature(TypeString), MakeContentStringMap(devices)),
+		MakeContentMappingItem("ports", TypeString, MakeSignature(TypeInteger), ContentIntegerMap{m: b.build()}),
+		MakeContentValueItem("level", level, "low"),
+	})
+
+	w := new(bytes.Buffer)
+	if err := WriteContentSnapshot(w, c); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	snapshot := w.Bytes()
+	if !IsContentSnapshot(snapshot) {
+		t.Fatalf("Expected snapshot header but got %x", snapshot)
+	}
+
+	tag := uuid.New()
+	rc, err := ReadContentSnapshot(bytes.NewReader(snapshot), &tag, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if rt := rc.symbols.GetType("device"); rt == nil || rt.String() != "device" {
+		t.Errorf("Expected struct type \"device\" but got %v", rt)
+	}
+
+	item, err := rc.Get("ports")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	v, err := item.Get([]Expression{MakeIntegerValue(53)}, nil)
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s, err := v.Serialize(); err != nil || s != "dns" {
+		t.Errorf("Expected \"dns\" but got %q (%v)", s, err)
+	}
+
+	w = new(bytes.Buffer)
+	if err := WriteContentSnapshot(w, rc); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if !bytes.Equal(w.Bytes(), snapshot) {
+		t.Errorf("Expected the same snapshot after reading and writing back")
+	}
+
+	for _, tc := range []struct {
+		b   []byte
+		err string
+	}{
+		{b: []byte("{}"), err: "doesn't start with content snapshot header"},
+		{b: append(append([]byte{}, ContentSnapshotMagic...), 2), err: "Unsupported content snapshot version 2"},
+		{b: snapshot[:len(snapshot)-3], err: "unexpected EOF"},
+	} {
+		if _, err := ReadContentSnapshot(bytes.NewReader(tc.b), nil, nil); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Expected error containing %q but got %v", tc.err, err)
+		}
+	}
+}
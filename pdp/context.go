@@ -3,9 +3,11 @@
 package pdp
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -60,8 +62,14 @@ var (
 // Context represents request context. The context contains all information
 // needed to evaluate request.
 type Context struct {
-	a map[string]interface{}
-	c *LocalContentStorage
+	a     map[string]interface{}
+	c     *LocalContentStorage
+	path  []string
+	vars  map[*Variable]variableValue
+	trace *trace
+	rc    context.Context
+
+	decisionPath bool
 }
 
 // EffectNameFromEnum returns human readable name for Effect enum
@@ -106,6 +114,15 @@ func NewContext(c *LocalContentStorage, count int, f func(i int) (string, Attrib
 // The request umarshaled to sequence of attributes as descirbed by
 // (Un)MarshalRequest* functions help.
 func NewContextFromBytes(c *LocalContentStorage, b []byte) (*Context, error) {
+	return NewContextFromBytesWithSymbols(c, b, Symbols{})
+}
+
+// NewContextFromBytesWithSymbols creates new instance of context like
+// NewContextFromBytes does. Additionally it binds request attributes to
+// custom types declared for the attributes in given symbol table. For example
+// a string attribute declared with an enum type becomes a value of the enum
+// type. If the string doesn't belong to the enum the function returns error.
+func NewContextFromBytesWithSymbols(c *LocalContentStorage, b []byte, s Symbols) (*Context, error) {
 	off, err := checkRequestVersion(b)
 	if err != nil {
 		return nil, err
@@ -125,6 +142,11 @@ func NewContextFromBytes(c *LocalContentStorage, b []byte) (*Context, error) {
 		}
 		off += n
 
+		v, err = s.bindAttributeValue(ID, v)
+		if err != nil {
+			return nil, bindErrorf(bindError(err, ID), "%d", i+1)
+		}
+
 		err = ctx.putAttribute(ID, v)
 		if err != nil {
 			return nil, bindErrorf(err, "%d", i+1)
@@ -230,6 +252,23 @@ func (c *Context) GetContentItem(cID, iID string) (*ContentItem, error) {
 	return c.c.Get(cID, iID)
 }
 
+// SetRequestContext binds context of incoming request (for example gRPC
+// call context carrying tracing span) to the evaluation context. Selectors
+// use it as a parent context for their requests.
+func (c *Context) SetRequestContext(ctx context.Context) {
+	c.rc = ctx
+}
+
+// RequestContext returns context of incoming request or background context
+// if it hasn't been set (or the evaluation context itself is nil).
+func (c *Context) RequestContext() context.Context {
+	if c == nil || c.rc == nil {
+		return context.Background()
+	}
+
+	return c.rc
+}
+
 func (c *Context) calculateBooleanExpression(e Expression) (bool, error) {
 	v, err := e.Calculate(c)
 	if err != nil {
@@ -346,6 +385,24 @@ func (c *Context) calculateListOfStringsExpression(e Expression) ([]string, erro
 	return v.listOfStrings()
 }
 
+func (c *Context) calculateTimeExpression(e Expression) (time.Time, error) {
+	v, err := e.Calculate(c)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return v.time()
+}
+
+func (c *Context) calculateDurationExpression(e Expression) (time.Duration, error) {
+	v, err := e.Calculate(c)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.duration()
+}
+
 func (c *Context) calculateFlags8Expression(e Expression) (uint8, error) {
 	v, err := e.Calculate(c)
 	if err != nil {
@@ -382,6 +439,15 @@ func (c *Context) calculateFlags64Expression(e Expression) (uint64, error) {
 	return v.flags64()
 }
 
+func (c *Context) calculateEnumExpression(e Expression) (string, error) {
+	v, err := e.Calculate(c)
+	if err != nil {
+		return "", err
+	}
+
+	return v.enum()
+}
+
 // Response represent result of policies evaluation.
 type Response struct {
 	// Effect is resulting effect.
@@ -390,12 +456,20 @@ type Response struct {
 	Status error
 	// Obligations constain set of obligations collected during evaluation.
 	Obligations []AttributeAssignment
+
+	// path contains ids of deciding rule and its enclosing policies starting
+	// from the rule. See DecisionPath for details.
+	path []string
 }
 
 // calcValues returns calculated attributes and errors
 func (r Response) calcValues(ctx *Context) ([]AttributeAssignment, []error) {
 	var errs []error
 
+	if ctx != nil {
+		ctx.SetDecision(r)
+	}
+
 	if r.Status != nil {
 		errs = append(errs, newPolicyCalculationError(r.Status))
 	}
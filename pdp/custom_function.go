@@ -0,0 +1,179 @@
+package pdp
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CustomFunction is a Go implementation of user-defined function. It gets
+// values of all arguments in order of declaration and returns result which
+// must be of declared result type.
+type CustomFunction func(args []AttributeValue) (AttributeValue, error)
+
+// FunctionDescription describes function available for policy expressions.
+// Built-in functions have only name while user-defined functions have also
+// types of arguments and result.
+type FunctionDescription struct {
+	Name    string
+	BuiltIn bool
+	Args    Signature
+	Result  Type
+}
+
+var customFunctions = map[string][]FunctionDescription{}
+
+// RegisterFunction makes user-defined function available for policy
+// expressions. Argument types of the function are checked when policies are
+// parsed so YAST and JAST parsers accept the function only for arguments of
+// exactly given types. The same name can be registered several times with
+// different arguments (as well as name of a built-in function) but
+// registration fails if the name already accepts the arguments.
+//
+// The function isn't safe for concurrent use with policy parsers. It's
+// expected to be called on program start before any policy is loaded.
+func RegisterFunction(name string, args Signature, result Type, f CustomFunction) error {
+	if name == "" {
+		return newEmptyFunctionNameError()
+	}
+
+	if f == nil {
+		return newNilFunctionImplementationError(name)
+	}
+
+	if result == nil || result == TypeUndefined {
+		return newInvalidFunctionResultTypeError(name)
+	}
+
+	probes := make([]Expression, len(args))
+	for i, t := range args {
+		if t == nil || t == TypeUndefined {
+			return newInvalidFunctionArgumentTypeError(name, i)
+		}
+
+		probes[i] = signatureProbe{t: t}
+	}
+
+	for _, validator := range FunctionArgumentValidators[name] {
+		if validator(probes) != nil {
+			return newDuplicateFunctionError(name, args)
+		}
+	}
+
+	d := FunctionDescription{
+		Name:   name,
+		Args:   args,
+		Result: result,
+	}
+
+	FunctionArgumentValidators[name] = append(FunctionArgumentValidators[name], makeCustomFunctionValidator(d, f))
+	customFunctions[name] = append(customFunctions[name], d)
+
+	return nil
+}
+
+// ListFunctions returns descriptions of all functions available for policy
+// expressions ordered by name. Built-in function is listed once with its name
+// while user-defined function gets an item for each registered signature.
+func ListFunctions() []FunctionDescription {
+	names := make([]string, 0, len(FunctionArgumentValidators))
+	for name := range FunctionArgumentValidators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []FunctionDescription{}
+	for _, name := range names {
+		if len(FunctionArgumentValidators[name]) > len(customFunctions[name]) {
+			out = append(out, FunctionDescription{
+				Name:    name,
+				BuiltIn: true,
+			})
+		}
+
+		out = append(out, customFunctions[name]...)
+	}
+
+	return out
+}
+
+type functionCustom struct {
+	d    FunctionDescription
+	f    CustomFunction
+	args []Expression
+}
+
+func makeCustomFunctionValidator(d FunctionDescription, f CustomFunction) functionArgumentValidator {
+	return func(args []Expression) functionMaker {
+		if len(args) != len(d.Args) {
+			return nil
+		}
+
+		for i, arg := range args {
+			if arg.GetResultType() != d.Args[i] {
+				return nil
+			}
+		}
+
+		return func(args []Expression) Expression {
+			if len(args) != len(d.Args) {
+				panic(fmt.Errorf("function %q needs exactly %d arguments but got %d", d.Name, len(d.Args), len(args)))
+			}
+
+			return functionCustom{
+				d:    d,
+				f:    f,
+				args: args,
+			}
+		}
+	}
+}
+
+func (f functionCustom) GetResultType() Type {
+	return f.d.Result
+}
+
+func (f functionCustom) describe() string {
+	return f.d.Name
+}
+
+func (f functionCustom) arguments() []Expression {
+	return f.args
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionCustom) Calculate(ctx *Context) (AttributeValue, error) {
+	args := make([]AttributeValue, len(f.args))
+	for i, arg := range f.args {
+		v, err := arg.Calculate(ctx)
+		if err != nil {
+			return UndefinedValue, bindError(bindErrorf(err, "argument %d", i), f.describe())
+		}
+
+		args[i] = v
+	}
+
+	v, err := f.f(args)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	if t := v.GetResultType(); t != f.d.Result {
+		return UndefinedValue, bindError(newInvalidCustomFunctionResultTypeError(f.d.Result, t), f.describe())
+	}
+
+	return v, nil
+}
+
+// signatureProbe is an expression of given type used to check if a function
+// already accepts arguments of some types.
+type signatureProbe struct {
+	t Type
+}
+
+func (p signatureProbe) GetResultType() Type {
+	return p.t
+}
+
+func (p signatureProbe) Calculate(ctx *Context) (AttributeValue, error) {
+	return UndefinedValue, newMissingValueError()
+}
@@ -0,0 +1,144 @@
+package pdp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterFunction(t *testing.T) {
+	err := RegisterFunction("test-risk-score", MakeSignature(TypeString, TypeInteger), TypeInteger,
+		func(args []AttributeValue) (AttributeValue, error) {
+			s, err := args[0].GetString()
+			if err != nil {
+				return UndefinedValue, err
+			}
+
+			n, err := args[1].GetInteger()
+			if err != nil {
+				return UndefinedValue, err
+			}
+
+			if s == "error" {
+				return UndefinedValue, errors.New("test error")
+			}
+
+			return MakeIntegerValue(int64(len(s)) * n), nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	err = RegisterFunction("test-risk-score", MakeSignature(TypeString, TypeInteger), TypeBoolean,
+		func(args []AttributeValue) (AttributeValue, error) {
+			return MakeBooleanValue(true), nil
+		},
+	)
+	if err == nil {
+		t.Errorf("Expected *duplicateFunctionError but got nothing")
+	} else if _, ok := err.(*duplicateFunctionError); !ok {
+		t.Errorf("Expected *duplicateFunctionError but got %T (%s)", err, err)
+	}
+
+	err = RegisterFunction("equal", MakeSignature(TypeString, TypeString), TypeBoolean,
+		func(args []AttributeValue) (AttributeValue, error) {
+			return MakeBooleanValue(true), nil
+		},
+	)
+	if err == nil {
+		t.Errorf("Expected *duplicateFunctionError but got nothing")
+	} else if _, ok := err.(*duplicateFunctionError); !ok {
+		t.Errorf("Expected *duplicateFunctionError but got %T (%s)", err, err)
+	}
+
+	err = RegisterFunction("test-bad-result", nil, TypeUndefined,
+		func(args []AttributeValue) (AttributeValue, error) {
+			return UndefinedValue, nil
+		},
+	)
+	if err == nil {
+		t.Errorf("Expected *invalidFunctionResultTypeError but got nothing")
+	} else if _, ok := err.(*invalidFunctionResultTypeError); !ok {
+		t.Errorf("Expected *invalidFunctionResultTypeError but got %T (%s)", err, err)
+	}
+
+	err = RegisterFunction("test-bad-result", nil, TypeString, nil)
+	if err == nil {
+		t.Errorf("Expected *nilFunctionImplementationError but got nothing")
+	} else if _, ok := err.(*nilFunctionImplementationError); !ok {
+		t.Errorf("Expected *nilFunctionImplementationError but got %T (%s)", err, err)
+	}
+
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "s", MakeStringValue("test"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	e := makeTestFunction(t, "test-risk-score", []Expression{MakeStringDesignator("s"), MakeIntegerValue(3)})
+	if e != nil {
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("Expected no error but got %T (%s)", err, err)
+		} else if n, err := v.GetInteger(); err != nil || n != 12 {
+			t.Errorf("Expected 12 but got %s (%v)", v.describe(), err)
+		}
+	}
+
+	e = makeTestFunction(t, "test-risk-score", []Expression{MakeStringValue("error"), MakeIntegerValue(3)})
+	if e != nil {
+		if _, err := e.Calculate(ctx); err == nil {
+			t.Errorf("Expected error but got nothing")
+		}
+	}
+
+	for _, validator := range FunctionArgumentValidators["test-risk-score"] {
+		if validator([]Expression{MakeIntegerValue(3), MakeStringValue("test")}) != nil {
+			t.Errorf("Expected no function for %q/%q arguments", TypeInteger, TypeString)
+		}
+	}
+
+	builtIn := false
+	custom := false
+	for _, d := range ListFunctions() {
+		if d.Name == "equal" && d.BuiltIn {
+			builtIn = true
+		}
+
+		if d.Name == "test-risk-score" && !d.BuiltIn && d.Result == TypeInteger && d.Args.String() == `"String"/"Integer"` {
+			custom = true
+		}
+	}
+
+	if !builtIn {
+		t.Errorf("Expected built-in function %q in list of functions", "equal")
+	}
+
+	if !custom {
+		t.Errorf("Expected user-defined function %q in list of functions", "test-risk-score")
+	}
+}
+
+func TestCustomFunctionInvalidResult(t *testing.T) {
+	err := RegisterFunction("test-invalid-result", MakeSignature(), TypeString,
+		func(args []AttributeValue) (AttributeValue, error) {
+			return MakeIntegerValue(1), nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %T (%s)", err, err)
+	}
+
+	e := makeTestFunction(t, "test-invalid-result", nil)
+	if e == nil {
+		return
+	}
+
+	_, err = e.Calculate(&Context{})
+	if err == nil {
+		t.Errorf("Expected *invalidCustomFunctionResultTypeError but got nothing")
+	} else if _, ok := err.(*invalidCustomFunctionResultTypeError); !ok {
+		t.Errorf("Expected *invalidCustomFunctionResultTypeError but got %T (%s)", err, err)
+	}
+}
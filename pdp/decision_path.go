@@ -0,0 +1,150 @@
+package pdp
+
+// EnableDecisionPath makes policies collect decision path for responses
+// calculated with the context. Without it DecisionPath and DecisionRule
+// methods of the response return nothing and "decision-path" and
+// "decision-rule" expressions fail. Policies need the path if
+// PolicyStorage.UsesDecisionPath returns true.
+func (c *Context) EnableDecisionPath() {
+	c.decisionPath = true
+}
+
+// makeRuleDecisionPath starts decision path with id of given rule if context
+// collects decision path. Hidden rule leaves empty id in the path. The path
+// gets some extra capacity to fit ids of enclosing policies without
+// reallocation.
+func makeRuleDecisionPath(ctx *Context, r Rule) []string {
+	if ctx == nil || !ctx.decisionPath {
+		return nil
+	}
+
+	path := make([]string, 1, 4)
+	if ID, ok := r.GetID(); ok {
+		path[0] = ID
+	}
+
+	return path
+}
+
+// appendDecisionPath adds id of enclosing policy or policy set to decision
+// path of the response if context collects decision path. If the response
+// hasn't been made by a rule (for example by default branch of "unless"
+// algorithms) the function puts empty rule id first. Hidden policies and
+// policy sets don't go to the path.
+func appendDecisionPath(ctx *Context, r Response, e Evaluable) Response {
+	if ctx == nil || !ctx.decisionPath {
+		return r
+	}
+
+	if len(r.path) <= 0 {
+		r.path = make([]string, 1, 4)
+	}
+
+	if ID, ok := e.GetID(); ok {
+		r.path = append(r.path, ID)
+	}
+
+	return r
+}
+
+// usesDecisionPath checks if obligations of given policies have
+// "decision-path" or "decision-rule" expressions.
+func usesDecisionPath(e Evaluable) bool {
+	switch e := e.(type) {
+	case *PolicySet:
+		if obligationsUseDecisionPath(e.obligations) {
+			return true
+		}
+
+		for _, p := range e.policies {
+			if usesDecisionPath(p) {
+				return true
+			}
+		}
+
+	case *Policy:
+		if obligationsUseDecisionPath(e.obligations) {
+			return true
+		}
+
+		for _, r := range e.rules {
+			if obligationsUseDecisionPath(r.obligations) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func obligationsUseDecisionPath(o []AttributeAssignment) bool {
+	for _, a := range o {
+		if expressionUsesDecisionPath(a.e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func expressionUsesDecisionPath(e Expression) bool {
+	var args []Expression
+	switch e := e.(type) {
+	case functionDecisionPath, functionDecisionRule:
+		return true
+
+	case argumentsExpression:
+		args = e.arguments()
+
+	case ArgumentsExpression:
+		args = e.GetArguments()
+	}
+
+	for _, arg := range args {
+		if expressionUsesDecisionPath(arg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DecisionPath returns ids of policy sets and policies which enclose
+// the deciding rule starting from the root. The last item is id of the rule
+// itself. Hidden rules, policies and policy sets are omitted. The path is
+// empty if the response isn't permit or deny.
+func (r Response) DecisionPath() []string {
+	if len(r.path) <= 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(r.path))
+	for i := len(r.path) - 1; i > 0; i-- {
+		out = append(out, r.path[i])
+	}
+
+	if len(r.path[0]) > 0 {
+		out = append(out, r.path[0])
+	}
+
+	return out
+}
+
+// DecisionRule returns id of rule which made the decision. It returns false
+// if the response isn't permit or deny, the rule is hidden or the decision
+// has been made by combining algorithm without any rule.
+func (r Response) DecisionRule() (string, bool) {
+	if len(r.path) <= 0 || len(r.path[0]) <= 0 {
+		return "", false
+	}
+
+	return r.path[0], true
+}
+
+// SetDecision makes decision path of given response available for
+// "decision-path" and "decision-rule" expressions. All marshaling methods
+// of the response call it automatically so explicit call is required only
+// to calculate obligations in other way.
+func (c *Context) SetDecision(r Response) {
+	c.path = r.path
+}
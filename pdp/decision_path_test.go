@@ -0,0 +1,131 @@
+package pdp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecisionPath(t *testing.T) {
+	testCases := []struct {
+		desc string
+		e    Evaluable
+		path []string
+		rule string
+	}{
+		{
+			desc: "nested policies",
+			e: makeSimplePolicySet("root",
+				makeSimpleHiddenPolicySet(
+					NewPolicy("policy", false, Target{},
+						[]*Rule{
+							makeSimpleRule("first", EffectPermit),
+							makeSimpleRule("second", EffectPermit),
+						},
+						makeDenyOverridesRCA, nil, nil,
+					),
+				),
+			),
+			path: []string{"root", "policy", "first"},
+			rule: "first",
+		},
+		{
+			desc: "hidden rule",
+			e: makeSimplePolicySet("root",
+				makeSimplePolicy("policy", makeSimpleHiddenRule(EffectDeny)),
+			),
+			path: []string{"root", "policy"},
+		},
+		{
+			desc: "no deciding rule",
+			e:    NewPolicy("policy", false, Target{}, []*Rule{}, makeDenyUnlessPermitRCA, nil, nil),
+			path: []string{"policy"},
+		},
+		{
+			desc: "not applicable",
+			e: NewPolicy("policy", false, makeSimpleStringTarget("x", "test"),
+				[]*Rule{makeSimpleRule("permit", EffectPermit)},
+				makeFirstApplicableEffectRCA, nil, nil,
+			),
+		},
+	}
+
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "x", MakeStringValue("example"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	for _, tc := range testCases {
+		if r := tc.e.Calculate(ctx); r.path != nil {
+			t.Errorf("Expected no decision path for %q when it's disabled but got %q", tc.desc, r.path)
+		}
+
+		if NewPolicyStorage(tc.e, MakeSymbols(), nil).UsesDecisionPath() {
+			t.Errorf("Expected %q not to use decision path", tc.desc)
+		}
+	}
+
+	ctx.EnableDecisionPath()
+	for _, tc := range testCases {
+		r := tc.e.Calculate(ctx)
+
+		path := fmt.Sprintf("%q", r.DecisionPath())
+		if e := fmt.Sprintf("%q", tc.path); path != e {
+			t.Errorf("Expected %s path for %q but got %s", e, tc.desc, path)
+		}
+
+		rule, ok := r.DecisionRule()
+		if len(tc.rule) > 0 {
+			if !ok || rule != tc.rule {
+				t.Errorf("Expected %q rule for %q but got %q (%v)", tc.rule, tc.desc, rule, ok)
+			}
+		} else if ok {
+			t.Errorf("Expected no rule for %q but got %q", tc.desc, rule)
+		}
+	}
+}
+
+func TestDecisionPathFunctions(t *testing.T) {
+	path := makeTestFunction(t, "decision-path", nil)
+	if path == nil {
+		return
+	}
+
+	rule := makeTestFunction(t, "decision-rule", nil)
+	if rule == nil {
+		return
+	}
+
+	p := makeSimplePolicySet("root",
+		makeSimplePolicy("policy",
+			makeSimplePermitRuleWithObligations("permit", []AttributeAssignment{
+				MakeExpressionAssignment("path", path),
+				MakeExpressionAssignment("rule", rule),
+			}),
+		),
+	)
+
+	if !NewPolicyStorage(p, MakeSymbols(), nil).UsesDecisionPath() {
+		t.Errorf("Expected policies with decision path functions to use decision path")
+	}
+
+	ctx := &Context{}
+	ctx.EnableDecisionPath()
+	a, errs := p.Calculate(ctx).calcValues(ctx)
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors but got %s", newMultiError(errs))
+	}
+
+	AssertAttributeAssignments(t, "TestDecisionPathFunctions", a,
+		MakeListOfStringsAssignment("path", []string{"root", "policy", "permit"}),
+		MakeStringAssignment("rule", "permit"),
+	)
+
+	_, err := path.Calculate(&Context{})
+	if err == nil {
+		t.Errorf("Expected *missingDecisionPathError but got nothing")
+	} else if _, ok := err.(*missingDecisionPathError); !ok {
+		t.Errorf("Expected *missingDecisionPathError but got %T (%s)", err, err)
+	}
+}
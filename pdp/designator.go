@@ -84,6 +84,18 @@ func MakeListOfStringsDesignator(id string) AttributeDesignator {
 	return MakeAttributeDesignator(MakeAttribute(id, TypeListOfStrings))
 }
 
+// MakeTimeDesignator creates time designator expression instance for given
+// attribute id.
+func MakeTimeDesignator(id string) AttributeDesignator {
+	return MakeAttributeDesignator(MakeAttribute(id, TypeTime))
+}
+
+// MakeDurationDesignator creates duration designator expression instance for
+// given attribute id.
+func MakeDurationDesignator(id string) AttributeDesignator {
+	return MakeAttributeDesignator(MakeAttribute(id, TypeDuration))
+}
+
 // GetID returns ID of wrapped attribute.
 func (d AttributeDesignator) GetID() string {
 	return d.a.id
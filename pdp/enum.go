@@ -0,0 +1,75 @@
+package pdp
+
+import "strings"
+
+// EnumType instance represents custom enumerated type. A value of the type
+// is a string which belongs to closed set of strings defined for the type.
+type EnumType struct {
+	n string
+	k string
+	v map[string]int
+	b []string
+}
+
+// NewEnumType function creates new custom type with given name. A value of
+// the type can take any of listed values. Values are case sensitive and
+// should be unique for the type.
+func NewEnumType(name string, values ...string) (Type, error) {
+	key := strings.ToLower(name)
+	if _, ok := BuiltinTypes[key]; ok {
+		return nil, newDuplicatesBuiltinTypeError(name)
+	}
+
+	if len(values) <= 0 {
+		return nil, newNoEnumValuesDefinedError(name)
+	}
+
+	v := make(map[string]int, len(values))
+	for i, s := range values {
+		if j, ok := v[s]; ok {
+			return nil, newDuplicateEnumValueError(name, s, i, j)
+		}
+		v[s] = i
+	}
+
+	return &EnumType{
+		n: name,
+		k: key,
+		v: v,
+		b: values,
+	}, nil
+}
+
+// String method returns human readable type name.
+func (t *EnumType) String() string {
+	return t.n
+}
+
+// GetKey method returns case insensitive (always lowercase) type key.
+func (t *EnumType) GetKey() string {
+	return t.k
+}
+
+// Match checks equivalence of different enum types. Enum type matches only
+// to itself.
+func (t *EnumType) Match(ot Type) bool {
+	return t == ot
+}
+
+// GetIndex method returns position of given value in the type declaration.
+// If the value doesn't belong to the type it returns -1.
+func (t *EnumType) GetIndex(s string) int {
+	if i, ok := t.v[s]; ok {
+		return i
+	}
+
+	return -1
+}
+
+// Values method returns all values of the type in order of declaration.
+func (t *EnumType) Values() []string {
+	out := make([]string, len(t.b))
+	copy(out, t.b)
+
+	return out
+}
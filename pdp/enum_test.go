@@ -0,0 +1,209 @@
+package pdp
+
+import "testing"
+
+func TestEnumType(t *testing.T) {
+	et, err := NewEnumType("Color", "red", "green", "blue")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s := et.String(); s != "Color" {
+		t.Errorf("Expected %q as type name but got %q", "Color", s)
+	}
+
+	if k := et.GetKey(); k != "color" {
+		t.Errorf("Expected %q as type key but got %q", "color", k)
+	}
+
+	if et, ok := et.(*EnumType); ok {
+		if i := et.GetIndex("blue"); i != 2 {
+			t.Errorf("Expected 2 as index of %q but got %d", "blue", i)
+		}
+
+		if i := et.GetIndex("Blue"); i != -1 {
+			t.Errorf("Expected no value %q (-1) but got %d", "Blue", i)
+		}
+
+		assertStrings(et.Values(), []string{"red", "green", "blue"}, "enum values", t)
+	} else {
+		t.Errorf("Expected *EnumType but got %T", et)
+	}
+
+	oet, err := NewEnumType("OtherColor", "red", "green", "blue")
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if oet.Match(et) {
+		t.Errorf("Expected that %q doesn't match %q", oet, et)
+	}
+
+	_, err = NewEnumType("Boolean", "true", "false")
+	if err == nil {
+		t.Errorf("Expected *duplicatesBuiltinTypeError but got nothing")
+	} else if _, ok := err.(*duplicatesBuiltinTypeError); !ok {
+		t.Errorf("Expected *duplicatesBuiltinTypeError but got %T (%s)", err, err)
+	}
+
+	_, err = NewEnumType("Empty")
+	if err == nil {
+		t.Errorf("Expected *noEnumValuesDefinedError but got nothing")
+	} else if _, ok := err.(*noEnumValuesDefinedError); !ok {
+		t.Errorf("Expected *noEnumValuesDefinedError but got %T (%s)", err, err)
+	}
+
+	_, err = NewEnumType("Duplicate", "red", "green", "red")
+	if err == nil {
+		t.Errorf("Expected *duplicateEnumValueError but got nothing")
+	} else if _, ok := err.(*duplicateEnumValueError); !ok {
+		t.Errorf("Expected *duplicateEnumValueError but got %T (%s)", err, err)
+	}
+}
+
+func TestEnumValue(t *testing.T) {
+	et, err := NewEnumType("Color", "red", "green", "blue")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	v, err := MakeEnumValue("green", et)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s := v.describe(); s != "enum<\"Color\">(\"green\")" {
+		t.Errorf("Expected %q description but got %q", "enum<\"Color\">(\"green\")", s)
+	}
+
+	if s, err := v.Serialize(); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s != "green" {
+		t.Errorf("Expected %q but got %q", "green", s)
+	}
+
+	_, err = MakeEnumValue("black", et)
+	if err == nil {
+		t.Errorf("Expected *invalidEnumValueError but got nothing")
+	} else if _, ok := err.(*invalidEnumValueError); !ok {
+		t.Errorf("Expected *invalidEnumValueError but got %T (%s)", err, err)
+	}
+
+	if _, err := MakeEnumValue("green", TypeString); err == nil {
+		t.Errorf("Expected error for %q type but got nothing", TypeString)
+	}
+
+	if v, err := MakeValueFromString(et, "blue"); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s, err := v.GetEnum(); err != nil || s != "blue" {
+		t.Errorf("Expected %q but got %q (%v)", "blue", s, err)
+	}
+
+	r, err := MakeStringValue("red").Rebind(et)
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if r.GetResultType() != et {
+		t.Errorf("Expected value of %q type but got %q", et, r.GetResultType())
+	}
+
+	_, err = MakeStringValue("black").Rebind(et)
+	if err == nil {
+		t.Errorf("Expected *invalidEnumValueError but got nothing")
+	} else if _, ok := err.(*invalidEnumValueError); !ok {
+		t.Errorf("Expected *invalidEnumValueError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeIntegerValue(1).Rebind(et)
+	if err == nil {
+		t.Errorf("Expected *notMatchingTypeRebindError but got nothing")
+	} else if _, ok := err.(*notMatchingTypeRebindError); !ok {
+		t.Errorf("Expected *notMatchingTypeRebindError but got %T (%s)", err, err)
+	}
+}
+
+func TestEnumFunctions(t *testing.T) {
+	et, err := NewEnumType("Color", "red", "green", "blue")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	red, _ := MakeEnumValue("red", et)
+	blue, _ := MakeEnumValue("blue", et)
+
+	ctx := &Context{}
+
+	e := makeTestFunction(t, "equal", []Expression{red, red})
+	if e != nil {
+		if v, err := e.Calculate(ctx); err != nil {
+			t.Errorf("Expected no error but got %s", err)
+		} else if b, err := v.GetBoolean(); err != nil || !b {
+			t.Errorf("Expected true but got %s (%v)", v.describe(), err)
+		}
+	}
+
+	e = makeTestFunction(t, "equal", []Expression{red, blue})
+	if e != nil {
+		if v, err := e.Calculate(ctx); err != nil {
+			t.Errorf("Expected no error but got %s", err)
+		} else if b, err := v.GetBoolean(); err != nil || b {
+			t.Errorf("Expected false but got %s (%v)", v.describe(), err)
+		}
+	}
+
+	e = makeTestFunction(t, "to-string", []Expression{blue})
+	if e != nil {
+		if v, err := e.Calculate(ctx); err != nil {
+			t.Errorf("Expected no error but got %s", err)
+		} else if s, err := v.GetString(); err != nil || s != "blue" {
+			t.Errorf("Expected %q but got %s (%v)", "blue", v.describe(), err)
+		}
+	}
+}
+
+func TestNewContextFromBytesWithSymbols(t *testing.T) {
+	et, err := NewEnumType("Color", "red", "green", "blue")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := MakeSymbols()
+	if err := s.PutType(et); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	a := MakeAttribute("color", et)
+	if err := s.PutAttribute(a); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	b, err := MarshalRequestAssignments([]AttributeAssignment{
+		MakeStringAssignment("color", "green"),
+		MakeStringAssignment("name", "test"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	ctx, err := NewContextFromBytesWithSymbols(nil, b, s)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if v, err := ctx.getAttribute(a); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s, err := v.GetEnum(); err != nil || s != "green" {
+		t.Errorf("Expected %q but got %s (%v)", "green", v.describe(), err)
+	}
+
+	b, err = MarshalRequestAssignments([]AttributeAssignment{
+		MakeStringAssignment("color", "black"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	_, err = NewContextFromBytesWithSymbols(nil, b, s)
+	if err == nil {
+		t.Errorf("Expected *invalidEnumValueError but got nothing")
+	} else if _, ok := err.(*invalidEnumValueError); !ok {
+		t.Errorf("Expected *invalidEnumValueError but got %T (%s)", err, err)
+	}
+}
@@ -195,6 +195,65 @@ const (
 	policyCalculationErrorID                              = 178
 	obligationCalculationErrorID                          = 179
 	noInformationalErrorID                                = 180
+	invalidRegexpPatternErrorID                           = 181
+	invalidGlobPatternErrorID                             = 182
+	invalidTimeStringCastErrorID                          = 183
+	invalidDurationStringCastErrorID                      = 184
+	requestAttributeUnmarshallingTimeTypeErrorID          = 185
+	requestAttributeUnmarshallingDurationTypeErrorID      = 186
+	requestUnmarshalTimeConstErrorID                      = 187
+	requestUnmarshalTimeTypeErrorID                       = 188
+	requestUnmarshalDurationConstErrorID                  = 189
+	requestUnmarshalDurationTypeErrorID                   = 190
+	invalidTimeZoneErrorID                                = 191
+	invalidTimeOfDayErrorID                               = 192
+	tooManyApplicableErrorID                              = 193
+	emptyFunctionNameErrorID                              = 194
+	nilFunctionImplementationErrorID                      = 195
+	invalidFunctionResultTypeErrorID                      = 196
+	invalidFunctionArgumentTypeErrorID                    = 197
+	duplicateFunctionErrorID                              = 198
+	invalidCustomFunctionResultTypeErrorID                = 199
+	floatToIntegerCastErrorID                             = 200
+	integerOverflowErrorID                                = 201
+	missingDecisionPathErrorID                            = 202
+	noEnumValuesDefinedErrorID                            = 203
+	duplicateEnumValueErrorID                             = 204
+	invalidEnumValueErrorID                               = 205
+	noStructFieldsDefinedErrorID                          = 206
+	tooManyStructFieldsDefinedErrorID                     = 207
+	duplicateStructFieldErrorID                           = 208
+	invalidStructFieldTypeErrorID                         = 209
+	attributeValueStructTypeErrorID                       = 210
+	unknownStructFieldErrorID                             = 211
+	structValueFieldsNumberErrorID                        = 212
+	structValueFieldTypeErrorID                           = 213
+	requestAttributeUnmarshallingStructSizeErrorID        = 214
+	attributeValueEnumTypeErrorID                         = 215
+	invalidCollectionTypeErrorID                          = 216
+	invalidElementVariableErrorID                         = 217
+	invalidQuantifiedConditionTypeErrorID                 = 218
+	unboundElementVariableErrorID                         = 219
+	invalidContentIntegerMapErrorID                       = 220
+	invalidContentFloatMapErrorID                         = 221
+	invalidRangeStringErrorID                             = 222
+	invalidRangeBoundsErrorID                             = 223
+	invalidAddressRangeErrorID                            = 224
+	invalidNaNKeyErrorID                                  = 225
+	keySetNotLastErrorID                                  = 226
+	invalidContentSnapshotHeaderErrorID                   = 227
+	unsupportedContentSnapshotVersionErrorID              = 228
+	contentSnapshotReadErrorID                            = 229
+	invalidContentSnapshotTagErrorID                      = 230
+	invalidContentSnapshotTypeErrorID                     = 231
+	invalidContentSnapshotValueErrorID                    = 232
+	invalidContentSnapshotDataErrorID                     = 233
+	undeclaredContentJSONTypeErrorID                      = 234
+	invalidContentJSONValueErrorID                        = 235
+	invalidContentJSONDomainMatchErrorID                  = 236
+	expiringContentFlagsValueErrorID                      = 237
+	nestedContentJSONExpiryErrorID                        = 238
+	subdomainDifferenceErrorID                            = 239
 )
 
 type externalError struct {
@@ -3023,3 +3082,936 @@ func newNoInformationalError() *noInformationalError {
 func (e *noInformationalError) Error() string {
 	return e.errorf("No information error providied to marshaller")
 }
+
+type invalidRegexpPatternError struct {
+	errorLink
+	p   string
+	err error
+}
+
+func newInvalidRegexpPatternError(p string, err error) *invalidRegexpPatternError {
+	return &invalidRegexpPatternError{
+		errorLink: errorLink{id: invalidRegexpPatternErrorID},
+		p:         p,
+		err:       err}
+}
+
+func (e *invalidRegexpPatternError) Error() string {
+	return e.errorf("Can't compile regular expression %q (%s)", e.p, e.err)
+}
+
+type invalidGlobPatternError struct {
+	errorLink
+	p string
+}
+
+func newInvalidGlobPatternError(p string) *invalidGlobPatternError {
+	return &invalidGlobPatternError{
+		errorLink: errorLink{id: invalidGlobPatternErrorID},
+		p:         p}
+}
+
+func (e *invalidGlobPatternError) Error() string {
+	return e.errorf("Can't treat %q as glob pattern", e.p)
+}
+
+type invalidTimeStringCastError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidTimeStringCastError(s string, err error) *invalidTimeStringCastError {
+	return &invalidTimeStringCastError{
+		errorLink: errorLink{id: invalidTimeStringCastErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidTimeStringCastError) Error() string {
+	return e.errorf("Can't treat %q as time (%s)", e.s, e.err)
+}
+
+type invalidDurationStringCastError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newInvalidDurationStringCastError(s string, err error) *invalidDurationStringCastError {
+	return &invalidDurationStringCastError{
+		errorLink: errorLink{id: invalidDurationStringCastErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *invalidDurationStringCastError) Error() string {
+	return e.errorf("Can't treat %q as duration (%s)", e.s, e.err)
+}
+
+type requestAttributeUnmarshallingTimeTypeError struct {
+	errorLink
+	t int
+}
+
+func newRequestAttributeUnmarshallingTimeTypeError(t int) *requestAttributeUnmarshallingTimeTypeError {
+	return &requestAttributeUnmarshallingTimeTypeError{
+		errorLink: errorLink{id: requestAttributeUnmarshallingTimeTypeErrorID},
+		t:         t}
+}
+
+func (e *requestAttributeUnmarshallingTimeTypeError) Error() string {
+	return e.errorf("Expected %q value but got %q", getRequestWireTypeName(requestWireTypeTime), getRequestWireTypeName(e.t))
+}
+
+type requestAttributeUnmarshallingDurationTypeError struct {
+	errorLink
+	t int
+}
+
+func newRequestAttributeUnmarshallingDurationTypeError(t int) *requestAttributeUnmarshallingDurationTypeError {
+	return &requestAttributeUnmarshallingDurationTypeError{
+		errorLink: errorLink{id: requestAttributeUnmarshallingDurationTypeErrorID},
+		t:         t}
+}
+
+func (e *requestAttributeUnmarshallingDurationTypeError) Error() string {
+	return e.errorf("Expected %q value but got %q", getRequestWireTypeName(requestWireTypeDuration), getRequestWireTypeName(e.t))
+}
+
+type requestUnmarshalTimeConstError struct {
+	errorLink
+	v reflect.Value
+}
+
+func newRequestUnmarshalTimeConstError(v reflect.Value) *requestUnmarshalTimeConstError {
+	return &requestUnmarshalTimeConstError{
+		errorLink: errorLink{id: requestUnmarshalTimeConstErrorID},
+		v:         v}
+}
+
+func (e *requestUnmarshalTimeConstError) Error() string {
+	return e.errorf("Can't unmarshal time to unchengeable %s", e.v.Type())
+}
+
+type requestUnmarshalTimeTypeError struct {
+	errorLink
+	v reflect.Value
+}
+
+func newRequestUnmarshalTimeTypeError(v reflect.Value) *requestUnmarshalTimeTypeError {
+	return &requestUnmarshalTimeTypeError{
+		errorLink: errorLink{id: requestUnmarshalTimeTypeErrorID},
+		v:         v}
+}
+
+func (e *requestUnmarshalTimeTypeError) Error() string {
+	return e.errorf("Can't unmarshal time to %s", e.v.Type())
+}
+
+type requestUnmarshalDurationConstError struct {
+	errorLink
+	v reflect.Value
+}
+
+func newRequestUnmarshalDurationConstError(v reflect.Value) *requestUnmarshalDurationConstError {
+	return &requestUnmarshalDurationConstError{
+		errorLink: errorLink{id: requestUnmarshalDurationConstErrorID},
+		v:         v}
+}
+
+func (e *requestUnmarshalDurationConstError) Error() string {
+	return e.errorf("Can't unmarshal duration to unchengeable %s", e.v.Type())
+}
+
+type requestUnmarshalDurationTypeError struct {
+	errorLink
+	v reflect.Value
+}
+
+func newRequestUnmarshalDurationTypeError(v reflect.Value) *requestUnmarshalDurationTypeError {
+	return &requestUnmarshalDurationTypeError{
+		errorLink: errorLink{id: requestUnmarshalDurationTypeErrorID},
+		v:         v}
+}
+
+func (e *requestUnmarshalDurationTypeError) Error() string {
+	return e.errorf("Can't unmarshal duration to %s", e.v.Type())
+}
+
+type invalidTimeZoneError struct {
+	errorLink
+	tz  string
+	err error
+}
+
+func newInvalidTimeZoneError(tz string, err error) *invalidTimeZoneError {
+	return &invalidTimeZoneError{
+		errorLink: errorLink{id: invalidTimeZoneErrorID},
+		tz:        tz,
+		err:       err}
+}
+
+func (e *invalidTimeZoneError) Error() string {
+	return e.errorf("Can't load time zone %q (%s)", e.tz, e.err)
+}
+
+type invalidTimeOfDayError struct {
+	errorLink
+	s string
+}
+
+func newInvalidTimeOfDayError(s string) *invalidTimeOfDayError {
+	return &invalidTimeOfDayError{
+		errorLink: errorLink{id: invalidTimeOfDayErrorID},
+		s:         s}
+}
+
+func (e *invalidTimeOfDayError) Error() string {
+	return e.errorf("Can't treat %q as time of day (expected hh:mm or hh:mm:ss)", e.s)
+}
+
+type tooManyApplicableError struct {
+	errorLink
+	first  string
+	second string
+}
+
+func newTooManyApplicableError(first, second string) *tooManyApplicableError {
+	return &tooManyApplicableError{
+		errorLink: errorLink{id: tooManyApplicableErrorID},
+		first:     first,
+		second:    second}
+}
+
+func (e *tooManyApplicableError) Error() string {
+	return e.errorf("Expected only one applicable item but got %s and %s", e.first, e.second)
+}
+
+type emptyFunctionNameError struct {
+	errorLink
+}
+
+func newEmptyFunctionNameError() *emptyFunctionNameError {
+	return &emptyFunctionNameError{
+		errorLink: errorLink{id: emptyFunctionNameErrorID}}
+}
+
+func (e *emptyFunctionNameError) Error() string {
+	return e.errorf("Can't register function with empty name")
+}
+
+type nilFunctionImplementationError struct {
+	errorLink
+	name string
+}
+
+func newNilFunctionImplementationError(name string) *nilFunctionImplementationError {
+	return &nilFunctionImplementationError{
+		errorLink: errorLink{id: nilFunctionImplementationErrorID},
+		name:      name}
+}
+
+func (e *nilFunctionImplementationError) Error() string {
+	return e.errorf("Can't register function %q without implementation", e.name)
+}
+
+type invalidFunctionResultTypeError struct {
+	errorLink
+	name string
+}
+
+func newInvalidFunctionResultTypeError(name string) *invalidFunctionResultTypeError {
+	return &invalidFunctionResultTypeError{
+		errorLink: errorLink{id: invalidFunctionResultTypeErrorID},
+		name:      name}
+}
+
+func (e *invalidFunctionResultTypeError) Error() string {
+	return e.errorf("Can't register function %q with undefined result type", e.name)
+}
+
+type invalidFunctionArgumentTypeError struct {
+	errorLink
+	name string
+	i    int
+}
+
+func newInvalidFunctionArgumentTypeError(name string, i int) *invalidFunctionArgumentTypeError {
+	return &invalidFunctionArgumentTypeError{
+		errorLink: errorLink{id: invalidFunctionArgumentTypeErrorID},
+		name:      name,
+		i:         i}
+}
+
+func (e *invalidFunctionArgumentTypeError) Error() string {
+	return e.errorf("Can't register function %q with undefined type of argument %d", e.name, e.i)
+}
+
+type duplicateFunctionError struct {
+	errorLink
+	name string
+	args Signature
+}
+
+func newDuplicateFunctionError(name string, args Signature) *duplicateFunctionError {
+	return &duplicateFunctionError{
+		errorLink: errorLink{id: duplicateFunctionErrorID},
+		name:      name,
+		args:      args}
+}
+
+func (e *duplicateFunctionError) Error() string {
+	return e.errorf("Function %q already accepts %s arguments", e.name, e.args)
+}
+
+type invalidCustomFunctionResultTypeError struct {
+	errorLink
+	expected Type
+	actual   Type
+}
+
+func newInvalidCustomFunctionResultTypeError(expected, actual Type) *invalidCustomFunctionResultTypeError {
+	return &invalidCustomFunctionResultTypeError{
+		errorLink: errorLink{id: invalidCustomFunctionResultTypeErrorID},
+		expected:  expected,
+		actual:    actual}
+}
+
+func (e *invalidCustomFunctionResultTypeError) Error() string {
+	return e.errorf("Expected %q result but got %q", e.expected, e.actual)
+}
+
+type floatToIntegerCastError struct {
+	errorLink
+	f float64
+}
+
+func newFloatToIntegerCastError(f float64) *floatToIntegerCastError {
+	return &floatToIntegerCastError{
+		errorLink: errorLink{id: floatToIntegerCastErrorID},
+		f:         f}
+}
+
+func (e *floatToIntegerCastError) Error() string {
+	return e.errorf("Can't convert %g to integer", e.f)
+}
+
+type integerOverflowError struct {
+	errorLink
+}
+
+func newIntegerOverflowError() *integerOverflowError {
+	return &integerOverflowError{
+		errorLink: errorLink{id: integerOverflowErrorID}}
+}
+
+func (e *integerOverflowError) Error() string {
+	return e.errorf("Integer result is out of range")
+}
+
+type missingDecisionPathError struct {
+	errorLink
+}
+
+func newMissingDecisionPathError() *missingDecisionPathError {
+	return &missingDecisionPathError{
+		errorLink: errorLink{id: missingDecisionPathErrorID}}
+}
+
+func (e *missingDecisionPathError) Error() string {
+	return e.errorf("Decision path is available only for obligations of permit or deny response")
+}
+
+type noEnumValuesDefinedError struct {
+	errorLink
+	name string
+}
+
+func newNoEnumValuesDefinedError(name string) *noEnumValuesDefinedError {
+	return &noEnumValuesDefinedError{
+		errorLink: errorLink{id: noEnumValuesDefinedErrorID},
+		name:      name}
+}
+
+func (e *noEnumValuesDefinedError) Error() string {
+	return e.errorf("Required at least one value to define enum type %q", e.name)
+}
+
+type duplicateEnumValueError struct {
+	errorLink
+	name  string
+	value string
+	i     int
+	j     int
+}
+
+func newDuplicateEnumValueError(name, value string, i, j int) *duplicateEnumValueError {
+	return &duplicateEnumValueError{
+		errorLink: errorLink{id: duplicateEnumValueErrorID},
+		name:      name,
+		value:     value,
+		i:         i,
+		j:         j}
+}
+
+func (e *duplicateEnumValueError) Error() string {
+	return e.errorf("Can't create enum type %q. Value %q at %d position duplicates value at %d", e.name, e.value, e.i, e.j)
+}
+
+type invalidEnumValueError struct {
+	errorLink
+	v string
+	t Type
+}
+
+func newInvalidEnumValueError(v string, t Type) *invalidEnumValueError {
+	return &invalidEnumValueError{
+		errorLink: errorLink{id: invalidEnumValueErrorID},
+		v:         v,
+		t:         t}
+}
+
+func (e *invalidEnumValueError) Error() string {
+	return e.errorf("Value %q doesn't belong to enum type %q", e.v, e.t)
+}
+
+type noStructFieldsDefinedError struct {
+	errorLink
+	name string
+}
+
+func newNoStructFieldsDefinedError(name string) *noStructFieldsDefinedError {
+	return &noStructFieldsDefinedError{
+		errorLink: errorLink{id: noStructFieldsDefinedErrorID},
+		name:      name}
+}
+
+func (e *noStructFieldsDefinedError) Error() string {
+	return e.errorf("Required at least one field to define struct type %q", e.name)
+}
+
+type tooManyStructFieldsDefinedError struct {
+	errorLink
+	name string
+	n    int
+}
+
+func newTooManyStructFieldsDefinedError(name string, n int) *tooManyStructFieldsDefinedError {
+	return &tooManyStructFieldsDefinedError{
+		errorLink: errorLink{id: tooManyStructFieldsDefinedErrorID},
+		name:      name,
+		n:         n}
+}
+
+func (e *tooManyStructFieldsDefinedError) Error() string {
+	return e.errorf("Required no more than 255 fields to define struct type %q got %d", e.name, e.n)
+}
+
+type duplicateStructFieldError struct {
+	errorLink
+	name  string
+	field string
+	i     int
+	j     int
+}
+
+func newDuplicateStructFieldError(name, field string, i, j int) *duplicateStructFieldError {
+	return &duplicateStructFieldError{
+		errorLink: errorLink{id: duplicateStructFieldErrorID},
+		name:      name,
+		field:     field,
+		i:         i,
+		j:         j}
+}
+
+func (e *duplicateStructFieldError) Error() string {
+	return e.errorf("Can't create struct type %q. Field %q at %d position duplicates field at %d", e.name, e.field, e.i, e.j)
+}
+
+type invalidStructFieldTypeError struct {
+	errorLink
+	name  string
+	field string
+	t     Type
+}
+
+func newInvalidStructFieldTypeError(name, field string, t Type) *invalidStructFieldTypeError {
+	return &invalidStructFieldTypeError{
+		errorLink: errorLink{id: invalidStructFieldTypeErrorID},
+		name:      name,
+		field:     field,
+		t:         t}
+}
+
+func (e *invalidStructFieldTypeError) Error() string {
+	return e.errorf("Can't create struct type %q. Field %q can't have type %q", e.name, e.field, e.t)
+}
+
+type attributeValueStructTypeError struct {
+	errorLink
+	t Type
+}
+
+func newAttributeValueStructTypeError(t Type) *attributeValueStructTypeError {
+	return &attributeValueStructTypeError{
+		errorLink: errorLink{id: attributeValueStructTypeErrorID},
+		t:         t}
+}
+
+func (e *attributeValueStructTypeError) Error() string {
+	return e.errorf("Expected value of struct type but got %q", e.t)
+}
+
+type unknownStructFieldError struct {
+	errorLink
+	t     Type
+	field string
+}
+
+func newUnknownStructFieldError(t Type, field string) *unknownStructFieldError {
+	return &unknownStructFieldError{
+		errorLink: errorLink{id: unknownStructFieldErrorID},
+		t:         t,
+		field:     field}
+}
+
+func (e *unknownStructFieldError) Error() string {
+	return e.errorf("Struct type %q has no field %q", e.t, e.field)
+}
+
+type structValueFieldsNumberError struct {
+	errorLink
+	t        Type
+	expected int
+	actual   int
+}
+
+func newStructValueFieldsNumberError(t Type, expected, actual int) *structValueFieldsNumberError {
+	return &structValueFieldsNumberError{
+		errorLink: errorLink{id: structValueFieldsNumberErrorID},
+		t:         t,
+		expected:  expected,
+		actual:    actual}
+}
+
+func (e *structValueFieldsNumberError) Error() string {
+	return e.errorf("Struct type %q requires %d fields but got %d", e.t, e.expected, e.actual)
+}
+
+type structValueFieldTypeError struct {
+	errorLink
+	t        Type
+	field    string
+	expected Type
+	actual   Type
+}
+
+func newStructValueFieldTypeError(t Type, field string, expected, actual Type) *structValueFieldTypeError {
+	return &structValueFieldTypeError{
+		errorLink: errorLink{id: structValueFieldTypeErrorID},
+		t:         t,
+		field:     field,
+		expected:  expected,
+		actual:    actual}
+}
+
+func (e *structValueFieldTypeError) Error() string {
+	return e.errorf("Field %q of struct type %q requires %q value but got %q", e.field, e.t, e.expected, e.actual)
+}
+
+type requestAttributeUnmarshallingStructSizeError struct {
+	errorLink
+	n int
+}
+
+func newRequestAttributeUnmarshallingStructSizeError(n int) *requestAttributeUnmarshallingStructSizeError {
+	return &requestAttributeUnmarshallingStructSizeError{
+		errorLink: errorLink{id: requestAttributeUnmarshallingStructSizeErrorID},
+		n:         n}
+}
+
+func (e *requestAttributeUnmarshallingStructSizeError) Error() string {
+	return e.errorf("Expected at least one struct field but got %d", e.n)
+}
+
+type attributeValueEnumTypeError struct {
+	errorLink
+	t Type
+}
+
+func newAttributeValueEnumTypeError(t Type) *attributeValueEnumTypeError {
+	return &attributeValueEnumTypeError{
+		errorLink: errorLink{id: attributeValueEnumTypeErrorID},
+		t:         t}
+}
+
+func (e *attributeValueEnumTypeError) Error() string {
+	return e.errorf("Expected value of enum type but got %q", e.t)
+}
+
+type invalidCollectionTypeError struct {
+	errorLink
+	t Type
+}
+
+func newInvalidCollectionTypeError(t Type) *invalidCollectionTypeError {
+	return &invalidCollectionTypeError{
+		errorLink: errorLink{id: invalidCollectionTypeErrorID},
+		t:         t}
+}
+
+func (e *invalidCollectionTypeError) Error() string {
+	return e.errorf("Expected list of strings, set of strings, set of networks or set of domains as collection but got %q", e.t)
+}
+
+type invalidElementVariableError struct {
+	errorLink
+	ID string
+	t  Type
+}
+
+func newInvalidElementVariableError(ID string, t Type) *invalidElementVariableError {
+	return &invalidElementVariableError{
+		errorLink: errorLink{id: invalidElementVariableErrorID},
+		ID:        ID,
+		t:         t}
+}
+
+func (e *invalidElementVariableError) Error() string {
+	return e.errorf("Expected element variable of %q type but got %q", e.t, e.ID)
+}
+
+type invalidQuantifiedConditionTypeError struct {
+	errorLink
+	name string
+	t    Type
+}
+
+func newInvalidQuantifiedConditionTypeError(name string, t Type) *invalidQuantifiedConditionTypeError {
+	return &invalidQuantifiedConditionTypeError{
+		errorLink: errorLink{id: invalidQuantifiedConditionTypeErrorID},
+		name:      name,
+		t:         t}
+}
+
+func (e *invalidQuantifiedConditionTypeError) Error() string {
+	return e.errorf("Expected boolean condition for %q but got %q", e.name, e.t)
+}
+
+type unboundElementVariableError struct {
+	errorLink
+	ID string
+}
+
+func newUnboundElementVariableError(ID string) *unboundElementVariableError {
+	return &unboundElementVariableError{
+		errorLink: errorLink{id: unboundElementVariableErrorID},
+		ID:        ID}
+}
+
+func (e *unboundElementVariableError) Error() string {
+	return e.errorf("Element variable %q isn't bound to any value", e.ID)
+}
+
+type invalidContentIntegerMapError struct {
+	errorLink
+	value interface{}
+}
+
+func newInvalidContentIntegerMapError(value interface{}) *invalidContentIntegerMapError {
+	return &invalidContentIntegerMapError{
+		errorLink: errorLink{id: invalidContentIntegerMapErrorID},
+		value:     value}
+}
+
+func (e *invalidContentIntegerMapError) Error() string {
+	return e.errorf("Expected integer map but got %T", e.value)
+}
+
+type invalidContentFloatMapError struct {
+	errorLink
+	value interface{}
+}
+
+func newInvalidContentFloatMapError(value interface{}) *invalidContentFloatMapError {
+	return &invalidContentFloatMapError{
+		errorLink: errorLink{id: invalidContentFloatMapErrorID},
+		value:     value}
+}
+
+func (e *invalidContentFloatMapError) Error() string {
+	return e.errorf("Expected float map but got %T", e.value)
+}
+
+type invalidRangeStringError struct {
+	errorLink
+	s string
+}
+
+func newInvalidRangeStringError(s string) *invalidRangeStringError {
+	return &invalidRangeStringError{
+		errorLink: errorLink{id: invalidRangeStringErrorID},
+		s:         s}
+}
+
+func (e *invalidRangeStringError) Error() string {
+	return e.errorf("Expected range in form of \"[low, high]\" but got %q", e.s)
+}
+
+type invalidRangeBoundsError struct {
+	errorLink
+	s string
+}
+
+func newInvalidRangeBoundsError(s string) *invalidRangeBoundsError {
+	return &invalidRangeBoundsError{
+		errorLink: errorLink{id: invalidRangeBoundsErrorID},
+		s:         s}
+}
+
+func (e *invalidRangeBoundsError) Error() string {
+	return e.errorf("Low bound of range %q is greater than its high bound", e.s)
+}
+
+type invalidAddressRangeError struct {
+	errorLink
+	s string
+}
+
+func newInvalidAddressRangeError(s string) *invalidAddressRangeError {
+	return &invalidAddressRangeError{
+		errorLink: errorLink{id: invalidAddressRangeErrorID},
+		s:         s}
+}
+
+func (e *invalidAddressRangeError) Error() string {
+	return e.errorf("Expected addresses of the same family as bounds of range %q", e.s)
+}
+
+type invalidNaNKeyError struct {
+	errorLink
+}
+
+func newInvalidNaNKeyError() *invalidNaNKeyError {
+	return &invalidNaNKeyError{
+		errorLink: errorLink{id: invalidNaNKeyErrorID}}
+}
+
+func (e *invalidNaNKeyError) Error() string {
+	return e.errorf("NaN can't be a key of float map")
+}
+
+type keySetNotLastError struct {
+	errorLink
+	s string
+}
+
+func newKeySetNotLastError(s string) *keySetNotLastError {
+	return &keySetNotLastError{
+		errorLink: errorLink{id: keySetNotLastErrorID},
+		s:         s}
+}
+
+func (e *keySetNotLastError) Error() string {
+	return e.errorf("Key %q can be used only as the last key of path", e.s)
+}
+
+type invalidContentSnapshotHeaderError struct {
+	errorLink
+}
+
+func newInvalidContentSnapshotHeaderError() *invalidContentSnapshotHeaderError {
+	return &invalidContentSnapshotHeaderError{
+		errorLink: errorLink{id: invalidContentSnapshotHeaderErrorID}}
+}
+
+func (e *invalidContentSnapshotHeaderError) Error() string {
+	return e.errorf("Data doesn't start with content snapshot header")
+}
+
+type unsupportedContentSnapshotVersionError struct {
+	errorLink
+	v int
+	e int
+}
+
+func newUnsupportedContentSnapshotVersionError(v, e int) *unsupportedContentSnapshotVersionError {
+	return &unsupportedContentSnapshotVersionError{
+		errorLink: errorLink{id: unsupportedContentSnapshotVersionErrorID},
+		v:         v,
+		e:         e}
+}
+
+func (e *unsupportedContentSnapshotVersionError) Error() string {
+	return e.errorf("Unsupported content snapshot version %d (expected %d)", e.v, e.e)
+}
+
+type contentSnapshotReadError struct {
+	errorLink
+	err error
+}
+
+func newContentSnapshotReadError(err error) *contentSnapshotReadError {
+	return &contentSnapshotReadError{
+		errorLink: errorLink{id: contentSnapshotReadErrorID},
+		err:       err}
+}
+
+func (e *contentSnapshotReadError) Error() string {
+	return e.errorf("Can't read content snapshot: %s", e.err)
+}
+
+type invalidContentSnapshotTagError struct {
+	errorLink
+	desc string
+	tag  byte
+}
+
+func newInvalidContentSnapshotTagError(desc string, tag byte) *invalidContentSnapshotTagError {
+	return &invalidContentSnapshotTagError{
+		errorLink: errorLink{id: invalidContentSnapshotTagErrorID},
+		desc:      desc,
+		tag:       tag}
+}
+
+func (e *invalidContentSnapshotTagError) Error() string {
+	return e.errorf("Unknown %s tag %d in content snapshot", e.desc, e.tag)
+}
+
+type invalidContentSnapshotTypeError struct {
+	errorLink
+	s string
+}
+
+func newInvalidContentSnapshotTypeError(s string) *invalidContentSnapshotTypeError {
+	return &invalidContentSnapshotTypeError{
+		errorLink: errorLink{id: invalidContentSnapshotTypeErrorID},
+		s:         s}
+}
+
+func (e *invalidContentSnapshotTypeError) Error() string {
+	return e.errorf("Unknown type %q in content snapshot", e.s)
+}
+
+type invalidContentSnapshotValueError struct {
+	errorLink
+	v interface{}
+}
+
+func newInvalidContentSnapshotValueError(v interface{}) *invalidContentSnapshotValueError {
+	return &invalidContentSnapshotValueError{
+		errorLink: errorLink{id: invalidContentSnapshotValueErrorID},
+		v:         v}
+}
+
+func (e *invalidContentSnapshotValueError) Error() string {
+	return e.errorf("Can't put %T to content snapshot", e.v)
+}
+
+type invalidContentSnapshotDataError struct {
+	errorLink
+	desc string
+}
+
+func newInvalidContentSnapshotDataError(desc string) *invalidContentSnapshotDataError {
+	return &invalidContentSnapshotDataError{
+		errorLink: errorLink{id: invalidContentSnapshotDataErrorID},
+		desc:      desc}
+}
+
+func (e *invalidContentSnapshotDataError) Error() string {
+	return e.errorf("Invalid %s in content snapshot", e.desc)
+}
+
+type undeclaredContentJSONTypeError struct {
+	errorLink
+	t Type
+}
+
+func newUndeclaredContentJSONTypeError(t Type) *undeclaredContentJSONTypeError {
+	return &undeclaredContentJSONTypeError{
+		errorLink: errorLink{id: undeclaredContentJSONTypeErrorID},
+		t:         t}
+}
+
+func (e *undeclaredContentJSONTypeError) Error() string {
+	return e.errorf("Can't write type %q to JCON as no content item declares it before", e.t)
+}
+
+type invalidContentJSONValueError struct {
+	errorLink
+	v interface{}
+}
+
+func newInvalidContentJSONValueError(v interface{}) *invalidContentJSONValueError {
+	return &invalidContentJSONValueError{
+		errorLink: errorLink{id: invalidContentJSONValueErrorID},
+		v:         v}
+}
+
+func (e *invalidContentJSONValueError) Error() string {
+	return e.errorf("Can't write %v (%T) to JCON", e.v, e.v)
+}
+
+type invalidContentJSONDomainMatchError struct {
+	errorLink
+	s string
+	m string
+	e string
+}
+
+func newInvalidContentJSONDomainMatchError(s, m, e string) *invalidContentJSONDomainMatchError {
+	return &invalidContentJSONDomainMatchError{
+		errorLink: errorLink{id: invalidContentJSONDomainMatchErrorID},
+		s:         s,
+		m:         m,
+		e:         e}
+}
+
+func (e *invalidContentJSONDomainMatchError) Error() string {
+	return e.errorf("Can't write %q key with %q match to JCON content item with %q match", e.s, e.m, e.e)
+}
+
+type expiringContentFlagsValueError struct {
+	errorLink
+}
+
+func newExpiringContentFlagsValueError() *expiringContentFlagsValueError {
+	return &expiringContentFlagsValueError{
+		errorLink: errorLink{id: expiringContentFlagsValueErrorID}}
+}
+
+func (e *expiringContentFlagsValueError) Error() string {
+	return e.errorf("Can't put expiring value to map of flags")
+}
+
+type nestedContentJSONExpiryError struct {
+	errorLink
+}
+
+func newNestedContentJSONExpiryError() *nestedContentJSONExpiryError {
+	return &nestedContentJSONExpiryError{
+		errorLink: errorLink{id: nestedContentJSONExpiryErrorID}}
+}
+
+func (e *nestedContentJSONExpiryError) Error() string {
+	return e.errorf("Can't write expiration to JCON for value which has expiring keys inside")
+}
+
+type subdomainDifferenceError struct {
+	errorLink
+	sub string
+	d   string
+}
+
+func newSubdomainDifferenceError(sub, d string) *subdomainDifferenceError {
+	return &subdomainDifferenceError{
+		errorLink: errorLink{id: subdomainDifferenceErrorID},
+		sub:       sub,
+		d:         d}
+}
+
+func (e *subdomainDifferenceError) Error() string {
+	return e.errorf("Can't subtract subdomain %q from domain %q as set of domains can't hold the rest", e.sub, e.d)
+}
@@ -0,0 +1,79 @@
+package pdp
+
+import (
+	"net"
+	"testing"
+)
+
+func TestCastFunctions(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	testCases := []struct {
+		name string
+		arg  Expression
+		res  AttributeValue
+		err  bool
+	}{
+		{name: "to-string", arg: MakeIntegerValue(42), res: MakeStringValue("42")},
+		{name: "to-string", arg: MakeFloatValue(2.5), res: MakeStringValue("2.5")},
+		{name: "to-string", arg: MakeBooleanValue(true), res: MakeStringValue("true")},
+		{name: "to-string", arg: MakeAddressValue(net.ParseIP("192.0.2.1")), res: MakeStringValue("192.0.2.1")},
+		{name: "to-string", arg: MakeDomainValue(makeTestDomain("www.example.com")), res: MakeStringValue("www.example.com")},
+		{name: "to-integer", arg: MakeStringValue("-42"), res: MakeIntegerValue(-42)},
+		{name: "to-integer", arg: MakeStringValue("forty two"), err: true},
+		{name: "to-integer", arg: MakeFloatValue(-2.9), res: MakeIntegerValue(-2)},
+		{name: "to-integer", arg: MakeFloatValue(1e20), err: true},
+		{name: "to-float", arg: MakeStringValue("6.022E+23"), res: MakeFloatValue(6.022e23)},
+		{name: "to-float", arg: MakeStringValue("NaN"), err: true},
+		{name: "to-float", arg: MakeIntegerValue(3), res: MakeFloatValue(3)},
+		{name: "to-address", arg: MakeStringValue("2001:db8::1"), res: MakeAddressValue(net.ParseIP("2001:db8::1"))},
+		{name: "to-address", arg: MakeStringValue("2001:db8::/32"), err: true},
+		{name: "to-network", arg: MakeStringValue("192.0.2.0/24"), res: MakeNetworkValue(makeTestNetwork("192.0.2.0/24"))},
+		{name: "to-network", arg: MakeAddressValue(net.ParseIP("192.0.2.1")), res: MakeNetworkValue(makeTestNetwork("192.0.2.1/32"))},
+		{name: "to-network", arg: MakeStringValue("192.0.2.1"), err: true},
+		{name: "to-domain", arg: MakeStringValue("example.com"), res: MakeDomainValue(makeTestDomain("example.com"))},
+		{name: "to-domain", arg: MakeStringValue("example..com"), err: true},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, []Expression{tc.arg})
+		if e == nil {
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%d: Expected error for %q but got %s", i, tc.name, v.describe())
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+		} else if v.describe() != tc.res.describe() {
+			t.Errorf("%d: Expected %s for %q but got %s", i, tc.res.describe(), tc.name, v.describe())
+		}
+	}
+}
+
+func TestCastFunctionsWithTry(t *testing.T) {
+	e := makeTestFunction(t, "try", []Expression{
+		makeTestFunction(t, "to-integer", []Expression{MakeStringValue("not a number")}),
+		MakeIntegerValue(-1),
+	})
+	if e == nil {
+		return
+	}
+
+	v, err := e.Calculate(&Context{})
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if n, err := v.integer(); err != nil || n != -1 {
+		t.Errorf("Expected -1 but got %s (%v)", v.describe(), err)
+	}
+}
@@ -18,6 +18,10 @@ func (f functionConcat) describe() string {
 	return "concat"
 }
 
+func (f functionConcat) arguments() []Expression {
+	return f.args
+}
+
 // Calculate implements Expression interface and returns calculated value.
 func (f functionConcat) Calculate(ctx *Context) (AttributeValue, error) {
 	var err error
@@ -0,0 +1,42 @@
+package pdp
+
+import "fmt"
+
+type functionDecisionPath struct{}
+
+func makeFunctionDecisionPath() Expression {
+	return functionDecisionPath{}
+}
+
+func makeFunctionDecisionPathAlt(args []Expression) Expression {
+	if len(args) != 0 {
+		panic(fmt.Errorf("function \"decision-path\" needs no arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDecisionPath()
+}
+
+func (f functionDecisionPath) GetResultType() Type {
+	return TypeListOfStrings
+}
+
+func (f functionDecisionPath) describe() string {
+	return "decision-path"
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionDecisionPath) Calculate(ctx *Context) (AttributeValue, error) {
+	if len(ctx.path) <= 0 {
+		return UndefinedValue, bindError(newMissingDecisionPathError(), f.describe())
+	}
+
+	return MakeListOfStringsValue(Response{path: ctx.path}.DecisionPath()), nil
+}
+
+func functionDecisionPathValidator(args []Expression) functionMaker {
+	if len(args) != 0 {
+		return nil
+	}
+
+	return makeFunctionDecisionPathAlt
+}
@@ -0,0 +1,42 @@
+package pdp
+
+import "fmt"
+
+type functionDecisionRule struct{}
+
+func makeFunctionDecisionRule() Expression {
+	return functionDecisionRule{}
+}
+
+func makeFunctionDecisionRuleAlt(args []Expression) Expression {
+	if len(args) != 0 {
+		panic(fmt.Errorf("function \"decision-rule\" needs no arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDecisionRule()
+}
+
+func (f functionDecisionRule) GetResultType() Type {
+	return TypeString
+}
+
+func (f functionDecisionRule) describe() string {
+	return "decision-rule"
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionDecisionRule) Calculate(ctx *Context) (AttributeValue, error) {
+	if len(ctx.path) <= 0 {
+		return UndefinedValue, bindError(newMissingDecisionPathError(), f.describe())
+	}
+
+	return MakeStringValue(ctx.path[0]), nil
+}
+
+func functionDecisionRuleValidator(args []Expression) functionMaker {
+	if len(args) != 0 {
+		return nil
+	}
+
+	return makeFunctionDecisionRuleAlt
+}
@@ -0,0 +1,71 @@
+package pdp
+
+import "fmt"
+
+type functionDomainEndsWith struct {
+	d      Expression
+	suffix Expression
+}
+
+func makeFunctionDomainEndsWith(d, suffix Expression) Expression {
+	return functionDomainEndsWith{
+		d:      d,
+		suffix: suffix}
+}
+
+func makeFunctionDomainEndsWithAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"ends-with\" for Domain needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDomainEndsWith(args[0], args[1])
+}
+
+func (f functionDomainEndsWith) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionDomainEndsWith) describe() string {
+	return "ends-with"
+}
+
+func (f functionDomainEndsWith) arguments() []Expression {
+	return []Expression{f.d, f.suffix}
+}
+
+// Calculate implements Expression interface and returns calculated value.
+// The function compares whole labels so "example.com" is a suffix of
+// "www.example.com" and of "example.com" itself but not of "badexample.com".
+func (f functionDomainEndsWith) Calculate(ctx *Context) (AttributeValue, error) {
+	d, err := ctx.calculateDomainExpression(f.d)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "domain argument"), f.describe())
+	}
+
+	suffix, err := ctx.calculateDomainExpression(f.suffix)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "suffix argument"), f.describe())
+	}
+
+	labels := getDomainLabels(d)
+	sLabels := getDomainLabels(suffix)
+	if len(sLabels) > len(labels) {
+		return MakeBooleanValue(false), nil
+	}
+
+	for i, label := range sLabels {
+		if labels[i] != label {
+			return MakeBooleanValue(false), nil
+		}
+	}
+
+	return MakeBooleanValue(true), nil
+}
+
+func functionDomainEndsWithValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeDomain || args[1].GetResultType() != TypeDomain {
+		return nil
+	}
+
+	return makeFunctionDomainEndsWithAlt
+}
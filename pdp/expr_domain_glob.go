@@ -0,0 +1,69 @@
+package pdp
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type functionDomainGlob struct {
+	d       Expression
+	pattern Expression
+	r       *regexp.Regexp
+	err     error
+}
+
+func makeFunctionDomainGlob(d, pattern Expression) Expression {
+	r, err := precompilePattern(pattern, compileGlobPattern)
+	return functionDomainGlob{
+		d:       d,
+		pattern: pattern,
+		r:       r,
+		err:     err}
+}
+
+func makeFunctionDomainGlobAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"glob\" for Domain needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDomainGlob(args[0], args[1])
+}
+
+func (f functionDomainGlob) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionDomainGlob) describe() string {
+	return "glob"
+}
+
+func (f functionDomainGlob) arguments() []Expression {
+	return []Expression{f.d, f.pattern}
+}
+
+func (f functionDomainGlob) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionDomainGlob) Calculate(ctx *Context) (AttributeValue, error) {
+	d, err := ctx.calculateDomainExpression(f.d)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "domain argument"), f.describe())
+	}
+
+	r, err := ctx.calculatePatternExpression(f.pattern, f.r, compileGlobPattern)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "pattern argument"), f.describe())
+	}
+
+	return MakeBooleanValue(r.MatchString(domainPatternString(d))), nil
+}
+
+func functionDomainGlobValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeDomain || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionDomainGlobAlt
+}
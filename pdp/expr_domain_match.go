@@ -0,0 +1,69 @@
+package pdp
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type functionDomainMatch struct {
+	d       Expression
+	pattern Expression
+	r       *regexp.Regexp
+	err     error
+}
+
+func makeFunctionDomainMatch(d, pattern Expression) Expression {
+	r, err := precompilePattern(pattern, compileRegexpPattern)
+	return functionDomainMatch{
+		d:       d,
+		pattern: pattern,
+		r:       r,
+		err:     err}
+}
+
+func makeFunctionDomainMatchAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"match\" for Domain needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDomainMatch(args[0], args[1])
+}
+
+func (f functionDomainMatch) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionDomainMatch) describe() string {
+	return "match"
+}
+
+func (f functionDomainMatch) arguments() []Expression {
+	return []Expression{f.d, f.pattern}
+}
+
+func (f functionDomainMatch) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionDomainMatch) Calculate(ctx *Context) (AttributeValue, error) {
+	d, err := ctx.calculateDomainExpression(f.d)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "domain argument"), f.describe())
+	}
+
+	r, err := ctx.calculatePatternExpression(f.pattern, f.r, compileRegexpPattern)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "pattern argument"), f.describe())
+	}
+
+	return MakeBooleanValue(r.MatchString(domainPatternString(d))), nil
+}
+
+func functionDomainMatchValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeDomain || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionDomainMatchAlt
+}
@@ -0,0 +1,72 @@
+package pdp
+
+import "fmt"
+
+type functionDomainStartsWith struct {
+	d      Expression
+	prefix Expression
+}
+
+func makeFunctionDomainStartsWith(d, prefix Expression) Expression {
+	return functionDomainStartsWith{
+		d:      d,
+		prefix: prefix}
+}
+
+func makeFunctionDomainStartsWithAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"starts-with\" for Domain needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDomainStartsWith(args[0], args[1])
+}
+
+func (f functionDomainStartsWith) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionDomainStartsWith) describe() string {
+	return "starts-with"
+}
+
+func (f functionDomainStartsWith) arguments() []Expression {
+	return []Expression{f.d, f.prefix}
+}
+
+// Calculate implements Expression interface and returns calculated value.
+// The function compares whole labels so "www.example" is a prefix of
+// "www.example.com" while "ww" isn't.
+func (f functionDomainStartsWith) Calculate(ctx *Context) (AttributeValue, error) {
+	d, err := ctx.calculateDomainExpression(f.d)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "domain argument"), f.describe())
+	}
+
+	prefix, err := ctx.calculateDomainExpression(f.prefix)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "prefix argument"), f.describe())
+	}
+
+	labels := getDomainLabels(d)
+	pLabels := getDomainLabels(prefix)
+	if len(pLabels) > len(labels) {
+		return MakeBooleanValue(false), nil
+	}
+
+	labels = labels[len(labels)-len(pLabels):]
+	for i, label := range pLabels {
+		if labels[i] != label {
+			return MakeBooleanValue(false), nil
+		}
+	}
+
+	return MakeBooleanValue(true), nil
+}
+
+func functionDomainStartsWithValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeDomain || args[1].GetResultType() != TypeDomain {
+		return nil
+	}
+
+	return makeFunctionDomainStartsWithAlt
+}
@@ -29,6 +29,10 @@ func (f functionSetOfDomainsContains) describe() string {
 	return "contains"
 }
 
+func (f functionSetOfDomainsContains) arguments() []Expression {
+	return []Expression{f.set, f.value}
+}
+
 func (f functionSetOfDomainsContains) Calculate(ctx *Context) (AttributeValue, error) {
 	set, err := ctx.calculateSetOfDomainsExpression(f.set)
 	if err != nil {
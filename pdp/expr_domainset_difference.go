@@ -0,0 +1,97 @@
+package pdp
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/go-trees/domain"
+	"github.com/infobloxopen/go-trees/domaintree"
+)
+
+type functionSetOfDomainsDifference struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfDomainsDifference(first, second Expression) Expression {
+	return functionSetOfDomainsDifference{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfDomainsDifferenceAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"difference\" for Set of Domains needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsDifference(args[0], args[1])
+}
+
+func (f functionSetOfDomainsDifference) GetResultType() Type {
+	return TypeSetOfDomains
+}
+
+func (f functionSetOfDomainsDifference) describe() string {
+	return "difference"
+}
+
+func (f functionSetOfDomainsDifference) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsDifference) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfDomainsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfDomainsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	firstDomains, err := enumerateSetOfDomains(first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	secondDomains, err := enumerateSetOfDomains(second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfDomainsBuilder()
+	for _, d := range firstDomains {
+		if _, ok := second.Get(d); ok {
+			continue
+		}
+
+		if sub, ok := findSubdomain(d, secondDomains); ok {
+			return UndefinedValue, bindError(newSubdomainDifferenceError(sub.String(), d.String()), f.describe())
+		}
+
+		b.add(d)
+	}
+
+	return MakeSetOfDomainsValue(b.t), nil
+}
+
+// findSubdomain returns the first of given domains which is a subdomain of d.
+// Set of domains holds zones with all their subdomains so it can't represent
+// a domain without some of its subdomains and the difference can't subtract
+// such domain.
+func findSubdomain(d domain.Name, domains []domain.Name) (domain.Name, bool) {
+	t := new(domaintree.Node).Insert(d, nil)
+	for _, s := range domains {
+		if _, ok := t.Get(s); ok {
+			return s, true
+		}
+	}
+
+	return domain.Name{}, false
+}
+
+func functionSetOfDomainsDifferenceValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfDomains || args[1].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsDifferenceAlt
+}
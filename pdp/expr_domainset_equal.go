@@ -0,0 +1,66 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfDomainsEqual struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfDomainsEqual(first, second Expression) Expression {
+	return functionSetOfDomainsEqual{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfDomainsEqualAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"equal\" for Set of Domains needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsEqual(args[0], args[1])
+}
+
+func (f functionSetOfDomainsEqual) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionSetOfDomainsEqual) describe() string {
+	return "equal"
+}
+
+func (f functionSetOfDomainsEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsEqual) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfDomainsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfDomainsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	res, err := isSetOfDomainsSubset(first, second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	if res {
+		res, err = isSetOfDomainsSubset(second, first)
+		if err != nil {
+			return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+		}
+	}
+
+	return MakeBooleanValue(res), nil
+}
+
+func functionSetOfDomainsEqualValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfDomains || args[1].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsEqualAlt
+}
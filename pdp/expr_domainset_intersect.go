@@ -0,0 +1,76 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfDomainsIntersect struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfDomainsIntersect(first, second Expression) Expression {
+	return functionSetOfDomainsIntersect{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfDomainsIntersectAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"intersect\" for Set of Domains needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsIntersect(args[0], args[1])
+}
+
+func (f functionSetOfDomainsIntersect) GetResultType() Type {
+	return TypeSetOfDomains
+}
+
+func (f functionSetOfDomainsIntersect) describe() string {
+	return "intersect"
+}
+
+func (f functionSetOfDomainsIntersect) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsIntersect) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfDomainsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfDomainsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	firstDomains, err := enumerateSetOfDomains(first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	secondDomains, err := enumerateSetOfDomains(second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfDomainsBuilder()
+	for _, d := range firstDomains {
+		if _, ok := second.Get(d); ok {
+			b.add(d)
+		}
+	}
+
+	for _, d := range secondDomains {
+		if _, ok := first.Get(d); ok {
+			b.add(d)
+		}
+	}
+
+	return MakeSetOfDomainsValue(b.t), nil
+}
+
+func functionSetOfDomainsIntersectValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfDomains || args[1].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsIntersectAlt
+}
@@ -0,0 +1,52 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfDomainsLen struct {
+	e Expression
+}
+
+func makeFunctionSetOfDomainsLen(e Expression) Expression {
+	return functionSetOfDomainsLen{e: e}
+}
+
+func makeFunctionSetOfDomainsLenAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"len\" for Set of Domains needs exactly one argument but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsLen(args[0])
+}
+
+func (f functionSetOfDomainsLen) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionSetOfDomainsLen) describe() string {
+	return "len"
+}
+
+func (f functionSetOfDomainsLen) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsLen) Calculate(ctx *Context) (AttributeValue, error) {
+	set, err := ctx.calculateSetOfDomainsExpression(f.e)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "argument"), f.describe())
+	}
+
+	l := 0
+	for range set.Enumerate() {
+		l++
+	}
+
+	return MakeIntegerValue(int64(l)), nil
+}
+
+func functionSetOfDomainsLenValidator(args []Expression) functionMaker {
+	if len(args) != 1 || args[0].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsLenAlt
+}
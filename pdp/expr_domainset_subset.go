@@ -0,0 +1,80 @@
+package pdp
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/go-trees/domaintree"
+)
+
+type functionSetOfDomainsSubset struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfDomainsSubset(first, second Expression) Expression {
+	return functionSetOfDomainsSubset{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfDomainsSubsetAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"subset\" for Set of Domains needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsSubset(args[0], args[1])
+}
+
+func (f functionSetOfDomainsSubset) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionSetOfDomainsSubset) describe() string {
+	return "subset"
+}
+
+func (f functionSetOfDomainsSubset) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsSubset) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfDomainsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfDomainsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	res, err := isSetOfDomainsSubset(first, second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	return MakeBooleanValue(res), nil
+}
+
+// isSetOfDomainsSubset checks if every domain of the first set is equal to or
+// is a subdomain of some domain of the second set.
+func isSetOfDomainsSubset(first, second *domaintree.Node) (bool, error) {
+	firstDomains, err := enumerateSetOfDomains(first)
+	if err != nil {
+		return false, err
+	}
+
+	for _, d := range firstDomains {
+		if _, ok := second.Get(d); !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func functionSetOfDomainsSubsetValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfDomains || args[1].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsSubsetAlt
+}
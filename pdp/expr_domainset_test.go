@@ -0,0 +1,116 @@
+package pdp
+
+import "testing"
+
+func TestSetOfDomainsAlgebra(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	ads := MakeSetOfDomainsValue(newDomainTree(
+		makeTestDomain("ads.example.com"),
+		makeTestDomain("tracker.example.net"),
+		makeTestDomain("example.org"),
+	))
+	malware := MakeSetOfDomainsValue(newDomainTree(
+		makeTestDomain("Example.Net"),
+		makeTestDomain("bad.example.org"),
+		makeTestDomain("malware.test"),
+	))
+	empty := MakeSetOfDomainsValue(newDomainTree())
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  string
+	}{
+		{
+			name: "union",
+			args: []Expression{ads, malware},
+			res:  "\"ads.example.com\",\"tracker.example.net\",\"example.org\",\"example.net\",\"bad.example.org\",\"malware.test\"",
+		},
+		{
+			name: "intersect",
+			args: []Expression{ads, malware},
+			res:  "\"tracker.example.net\",\"bad.example.org\"",
+		},
+		{
+			name: "difference",
+			args: []Expression{ads, MakeSetOfDomainsValue(newDomainTree(
+				makeTestDomain("Example.Net"),
+				makeTestDomain("malware.test"),
+			))},
+			res: "\"ads.example.com\",\"example.org\"",
+		},
+		{
+			name: "difference",
+			args: []Expression{malware, empty},
+			res:  "\"example.net\",\"bad.example.org\",\"malware.test\"",
+		},
+		{
+			name: "len",
+			args: []Expression{malware},
+			res:  "3",
+		},
+		{
+			name: "equal",
+			args: []Expression{ads, ads},
+			res:  "true",
+		},
+		{
+			name: "equal",
+			args: []Expression{ads, malware},
+			res:  "false",
+		},
+		{
+			name: "equal",
+			args: []Expression{
+				MakeSetOfDomainsValue(newDomainTree(makeTestDomain("example.org"), makeTestDomain("www.example.org"))),
+				MakeSetOfDomainsValue(newDomainTree(makeTestDomain("example.org"))),
+			},
+			res: "true",
+		},
+		{
+			name: "subset",
+			args: []Expression{MakeSetOfDomainsValue(newDomainTree(makeTestDomain("www.ads.example.com"))), ads},
+			res:  "true",
+		},
+		{
+			name: "subset",
+			args: []Expression{ads, malware},
+			res:  "false",
+		},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		s, err := v.Serialize()
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+		} else if s != tc.res {
+			t.Errorf("%d: Expected %q for %q but got %q", i, tc.res, tc.name, s)
+		}
+	}
+
+	e := makeTestFunction(t, "difference", []Expression{ads, malware})
+	if e == nil {
+		return
+	}
+
+	if v, err := e.Calculate(ctx); err == nil {
+		t.Errorf("Expected *subdomainDifferenceError but got %s", v.describe())
+	} else if _, ok := err.(*subdomainDifferenceError); !ok {
+		t.Errorf("Expected *subdomainDifferenceError but got %T (%s)", err, err)
+	}
+}
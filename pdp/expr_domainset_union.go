@@ -0,0 +1,68 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfDomainsUnion struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfDomainsUnion(first, second Expression) Expression {
+	return functionSetOfDomainsUnion{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfDomainsUnionAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"union\" for Set of Domains needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfDomainsUnion(args[0], args[1])
+}
+
+func (f functionSetOfDomainsUnion) GetResultType() Type {
+	return TypeSetOfDomains
+}
+
+func (f functionSetOfDomainsUnion) describe() string {
+	return "union"
+}
+
+func (f functionSetOfDomainsUnion) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfDomainsUnion) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfDomainsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfDomainsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	firstDomains, err := enumerateSetOfDomains(first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	secondDomains, err := enumerateSetOfDomains(second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfDomainsBuilder()
+	for _, d := range append(firstDomains, secondDomains...) {
+		b.add(d)
+	}
+
+	return MakeSetOfDomainsValue(b.t), nil
+}
+
+func functionSetOfDomainsUnionValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfDomains || args[1].GetResultType() != TypeSetOfDomains {
+		return nil
+	}
+	return makeFunctionSetOfDomainsUnionAlt
+}
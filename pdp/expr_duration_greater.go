@@ -0,0 +1,58 @@
+package pdp
+
+import "fmt"
+
+type functionDurationGreater struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionDurationGreater(first, second Expression) Expression {
+	return functionDurationGreater{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionDurationGreaterAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"greater\" for Duration needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionDurationGreater(args[0], args[1])
+}
+
+func (f functionDurationGreater) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionDurationGreater) describe() string {
+	return "greater"
+}
+
+func (f functionDurationGreater) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionDurationGreater) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateDurationExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateDurationExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(first > second), nil
+}
+
+func functionDurationGreaterValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeDuration || args[1].GetResultType() != TypeDuration {
+		return nil
+	}
+
+	return makeFunctionDurationGreaterAlt
+}
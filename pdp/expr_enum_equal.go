@@ -0,0 +1,62 @@
+package pdp
+
+import "fmt"
+
+type functionEnumEqual struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionEnumEqual(first, second Expression) Expression {
+	return functionEnumEqual{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionEnumEqualAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"equal\" for Enum needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionEnumEqual(args[0], args[1])
+}
+
+func (f functionEnumEqual) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionEnumEqual) describe() string {
+	return "equal"
+}
+
+func (f functionEnumEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionEnumEqual) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateEnumExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateEnumExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(first == second), nil
+}
+
+func functionEnumEqualValidator(args []Expression) functionMaker {
+	if len(args) != 2 {
+		return nil
+	}
+
+	t, ok := args[0].GetResultType().(*EnumType)
+	if !ok || args[1].GetResultType() != t {
+		return nil
+	}
+
+	return makeFunctionEnumEqualAlt
+}
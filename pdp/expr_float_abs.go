@@ -0,0 +1,53 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionFloatAbs struct {
+	e Expression
+}
+
+func makeFunctionFloatAbs(e Expression) Expression {
+	return functionFloatAbs{
+		e: e,
+	}
+}
+
+func makeFunctionFloatAbsAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"abs\" for Float needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionFloatAbs(args[0])
+}
+
+func (f functionFloatAbs) GetResultType() Type {
+	return TypeFloat
+}
+
+func (f functionFloatAbs) describe() string {
+	return "abs"
+}
+
+func (f functionFloatAbs) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+func (f functionFloatAbs) Calculate(ctx *Context) (AttributeValue, error) {
+	n, err := ctx.calculateFloatExpression(f.e)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "argument"), f.describe())
+	}
+
+	return MakeFloatValue(math.Abs(n)), nil
+}
+
+func functionFloatAbsValidator(args []Expression) functionMaker {
+	if len(args) != 1 || args[0].GetResultType() != TypeFloat {
+		return nil
+	}
+
+	return makeFunctionFloatAbsAlt
+}
@@ -43,6 +43,10 @@ func (f functionFloatAdd) describe() string {
 	return "add"
 }
 
+func (f functionFloatAdd) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatAdd) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
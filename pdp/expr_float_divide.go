@@ -30,6 +30,10 @@ func (f functionFloatDivide) describe() string {
 	return "divide"
 }
 
+func (f functionFloatDivide) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatDivide) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
@@ -30,6 +30,10 @@ func (f functionFloatEqual) describe() string {
 	return "equal"
 }
 
+func (f functionFloatEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatEqual) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
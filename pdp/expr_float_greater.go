@@ -30,6 +30,10 @@ func (f functionFloatGreater) describe() string {
 	return "greater"
 }
 
+func (f functionFloatGreater) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatGreater) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
@@ -0,0 +1,62 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionFloatMax struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionFloatMax(first, second Expression) Expression {
+	return functionFloatMax{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionFloatMaxAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"max\" for Float needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionFloatMax(args[0], args[1])
+}
+
+func (f functionFloatMax) GetResultType() Type {
+	return TypeFloat
+}
+
+func (f functionFloatMax) describe() string {
+	return "max"
+}
+
+func (f functionFloatMax) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionFloatMax) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateFloatOrIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeFloatValue(math.Max(first, second)), nil
+}
+
+func functionFloatMaxValidator(args []Expression) functionMaker {
+	if len(args) != 2 ||
+		(args[0].GetResultType() != TypeFloat && args[0].GetResultType() != TypeInteger) ||
+		(args[1].GetResultType() != TypeFloat && args[1].GetResultType() != TypeInteger) {
+		return nil
+	}
+
+	return makeFunctionFloatMaxAlt
+}
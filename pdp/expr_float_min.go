@@ -0,0 +1,62 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionFloatMin struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionFloatMin(first, second Expression) Expression {
+	return functionFloatMin{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionFloatMinAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"min\" for Float needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionFloatMin(args[0], args[1])
+}
+
+func (f functionFloatMin) GetResultType() Type {
+	return TypeFloat
+}
+
+func (f functionFloatMin) describe() string {
+	return "min"
+}
+
+func (f functionFloatMin) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionFloatMin) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateFloatOrIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeFloatValue(math.Min(first, second)), nil
+}
+
+func functionFloatMinValidator(args []Expression) functionMaker {
+	if len(args) != 2 ||
+		(args[0].GetResultType() != TypeFloat && args[0].GetResultType() != TypeInteger) ||
+		(args[1].GetResultType() != TypeFloat && args[1].GetResultType() != TypeInteger) {
+		return nil
+	}
+
+	return makeFunctionFloatMinAlt
+}
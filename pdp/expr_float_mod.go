@@ -0,0 +1,71 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionFloatMod struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionFloatMod(first, second Expression) Expression {
+	return functionFloatMod{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionFloatModAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"mod\" for Float needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionFloatMod(args[0], args[1])
+}
+
+func (f functionFloatMod) GetResultType() Type {
+	return TypeFloat
+}
+
+func (f functionFloatMod) describe() string {
+	return "mod"
+}
+
+func (f functionFloatMod) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionFloatMod) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateFloatOrIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	if second == 0. {
+		return UndefinedValue, bindError(bindError(newFloatDivideByZeroError(), "second argument"), f.describe())
+	}
+
+	res := math.Mod(first, second)
+	if err = floatErrorCheck(res); err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	return MakeFloatValue(res), nil
+}
+
+func functionFloatModValidator(args []Expression) functionMaker {
+	if len(args) != 2 ||
+		(args[0].GetResultType() != TypeFloat && args[0].GetResultType() != TypeInteger) ||
+		(args[1].GetResultType() != TypeFloat && args[1].GetResultType() != TypeInteger) {
+		return nil
+	}
+
+	return makeFunctionFloatModAlt
+}
@@ -30,6 +30,10 @@ func (f functionFloatMultiply) describe() string {
 	return "multiply"
 }
 
+func (f functionFloatMultiply) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatMultiply) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
@@ -32,6 +32,10 @@ func (f functionFloatRange) describe() string {
 	return "range"
 }
 
+func (f functionFloatRange) arguments() []Expression {
+	return []Expression{f.min, f.max, f.val}
+}
+
 func (f functionFloatRange) Calculate(ctx *Context) (AttributeValue, error) {
 	min, err := ctx.calculateFloatOrIntegerExpression(f.min)
 	if err != nil {
@@ -30,6 +30,10 @@ func (f functionFloatSubtract) describe() string {
 	return "subtract"
 }
 
+func (f functionFloatSubtract) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionFloatSubtract) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateFloatOrIntegerExpression(f.first)
 	if err != nil {
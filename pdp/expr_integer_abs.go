@@ -0,0 +1,61 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionIntegerAbs struct {
+	e Expression
+}
+
+func makeFunctionIntegerAbs(e Expression) Expression {
+	return functionIntegerAbs{
+		e: e,
+	}
+}
+
+func makeFunctionIntegerAbsAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"abs\" for Integer needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionIntegerAbs(args[0])
+}
+
+func (f functionIntegerAbs) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionIntegerAbs) describe() string {
+	return "abs"
+}
+
+func (f functionIntegerAbs) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+func (f functionIntegerAbs) Calculate(ctx *Context) (AttributeValue, error) {
+	n, err := ctx.calculateIntegerExpression(f.e)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "argument"), f.describe())
+	}
+
+	if n == math.MinInt64 {
+		return UndefinedValue, bindError(newIntegerOverflowError(), f.describe())
+	}
+
+	if n < 0 {
+		n = -n
+	}
+
+	return MakeIntegerValue(n), nil
+}
+
+func functionIntegerAbsValidator(args []Expression) functionMaker {
+	if len(args) != 1 || args[0].GetResultType() != TypeInteger {
+		return nil
+	}
+
+	return makeFunctionIntegerAbsAlt
+}
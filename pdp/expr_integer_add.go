@@ -30,6 +30,10 @@ func (f functionIntegerAdd) describe() string {
 	return "add"
 }
 
+func (f functionIntegerAdd) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerAdd) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
@@ -30,6 +30,10 @@ func (f functionIntegerDivide) describe() string {
 	return "divide"
 }
 
+func (f functionIntegerDivide) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerDivide) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
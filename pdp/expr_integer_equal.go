@@ -30,6 +30,10 @@ func (f functionIntegerEqual) describe() string {
 	return "equal"
 }
 
+func (f functionIntegerEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerEqual) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
@@ -30,6 +30,10 @@ func (f functionIntegerGreater) describe() string {
 	return "greater"
 }
 
+func (f functionIntegerGreater) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerGreater) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
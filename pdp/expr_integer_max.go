@@ -0,0 +1,61 @@
+package pdp
+
+import "fmt"
+
+type functionIntegerMax struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionIntegerMax(first, second Expression) Expression {
+	return functionIntegerMax{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionIntegerMaxAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"max\" for Integer needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionIntegerMax(args[0], args[1])
+}
+
+func (f functionIntegerMax) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionIntegerMax) describe() string {
+	return "max"
+}
+
+func (f functionIntegerMax) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionIntegerMax) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	if second > first {
+		return MakeIntegerValue(second), nil
+	}
+
+	return MakeIntegerValue(first), nil
+}
+
+func functionIntegerMaxValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeInteger || args[1].GetResultType() != TypeInteger {
+		return nil
+	}
+
+	return makeFunctionIntegerMaxAlt
+}
@@ -0,0 +1,61 @@
+package pdp
+
+import "fmt"
+
+type functionIntegerMin struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionIntegerMin(first, second Expression) Expression {
+	return functionIntegerMin{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionIntegerMinAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"min\" for Integer needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionIntegerMin(args[0], args[1])
+}
+
+func (f functionIntegerMin) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionIntegerMin) describe() string {
+	return "min"
+}
+
+func (f functionIntegerMin) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionIntegerMin) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	if second < first {
+		return MakeIntegerValue(second), nil
+	}
+
+	return MakeIntegerValue(first), nil
+}
+
+func functionIntegerMinValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeInteger || args[1].GetResultType() != TypeInteger {
+		return nil
+	}
+
+	return makeFunctionIntegerMinAlt
+}
@@ -0,0 +1,61 @@
+package pdp
+
+import "fmt"
+
+type functionIntegerMod struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionIntegerMod(first, second Expression) Expression {
+	return functionIntegerMod{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionIntegerModAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"mod\" for Integer needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionIntegerMod(args[0], args[1])
+}
+
+func (f functionIntegerMod) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionIntegerMod) describe() string {
+	return "mod"
+}
+
+func (f functionIntegerMod) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+func (f functionIntegerMod) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateIntegerExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateIntegerExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	if second == 0 {
+		return UndefinedValue, bindError(bindError(newIntegerDivideByZeroError(), "second argument"), f.describe())
+	}
+
+	return MakeIntegerValue(first % second), nil
+}
+
+func functionIntegerModValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeInteger || args[1].GetResultType() != TypeInteger {
+		return nil
+	}
+
+	return makeFunctionIntegerModAlt
+}
@@ -30,6 +30,10 @@ func (f functionIntegerMultiply) describe() string {
 	return "multiply"
 }
 
+func (f functionIntegerMultiply) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerMultiply) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
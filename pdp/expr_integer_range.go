@@ -33,6 +33,10 @@ func (f functionIntegerRange) describe() string {
 	return "range"
 }
 
+func (f functionIntegerRange) arguments() []Expression {
+	return []Expression{f.min, f.max, f.val}
+}
+
 func (f functionIntegerRange) Calculate(ctx *Context) (AttributeValue, error) {
 	min, err := ctx.calculateIntegerExpression(f.min)
 	if err != nil {
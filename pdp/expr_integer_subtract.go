@@ -30,6 +30,10 @@ func (f functionIntegerSubtract) describe() string {
 	return "subtract"
 }
 
+func (f functionIntegerSubtract) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 func (f functionIntegerSubtract) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateIntegerExpression(f.first)
 	if err != nil {
@@ -28,6 +28,10 @@ func (f functionListOfStrings) describe() string {
 	return "list of strings"
 }
 
+func (f functionListOfStrings) arguments() []Expression {
+	return []Expression{f.e}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionListOfStrings) Calculate(ctx *Context) (AttributeValue, error) {
 	t := f.e.GetResultType()
@@ -31,6 +31,10 @@ func (f functionListOfStringsContains) describe() string {
 	return "contains"
 }
 
+func (f functionListOfStringsContains) arguments() []Expression {
+	return []Expression{f.list, f.value}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionListOfStringsContains) Calculate(ctx *Context) (AttributeValue, error) {
 	list, err := ctx.calculateListOfStringsExpression(f.list)
@@ -30,6 +30,10 @@ func (f functionListOfStringsEqual) describe() string {
 	return "equal"
 }
 
+func (f functionListOfStringsEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionListOfStringsEqual) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateListOfStringsExpression(f.first)
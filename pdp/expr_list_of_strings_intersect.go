@@ -31,6 +31,10 @@ func (f functionListOfStringsIntersect) describe() string {
 	return "intersect"
 }
 
+func (f functionListOfStringsIntersect) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionListOfStringsIntersect) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateListOfStringsExpression(f.first)
@@ -27,6 +27,10 @@ func (f functionListOfStringsLen) describe() string {
 	return "len"
 }
 
+func (f functionListOfStringsLen) arguments() []Expression {
+	return []Expression{f.e}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionListOfStringsLen) Calculate(ctx *Context) (AttributeValue, error) {
 	s, err := ctx.calculateListOfStringsExpression(f.e)
@@ -31,6 +31,10 @@ func (f functionNetworkContainsAddress) describe() string {
 	return "contains"
 }
 
+func (f functionNetworkContainsAddress) arguments() []Expression {
+	return []Expression{f.network, f.address}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionNetworkContainsAddress) Calculate(ctx *Context) (AttributeValue, error) {
 	n, err := ctx.calculateNetworkExpression(f.network)
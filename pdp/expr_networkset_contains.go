@@ -30,6 +30,10 @@ func (f functionSetOfNetworksContainsAddress) describe() string {
 	return "contains"
 }
 
+func (f functionSetOfNetworksContainsAddress) arguments() []Expression {
+	return []Expression{f.set, f.value}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionSetOfNetworksContainsAddress) Calculate(ctx *Context) (AttributeValue, error) {
 	set, err := ctx.calculateSetOfNetworksExpression(f.set)
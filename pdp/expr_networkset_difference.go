@@ -0,0 +1,117 @@
+package pdp
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/infobloxopen/go-trees/iptree"
+)
+
+type functionSetOfNetworksDifference struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfNetworksDifference(first, second Expression) Expression {
+	return functionSetOfNetworksDifference{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfNetworksDifferenceAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"difference\" for Set of Networks needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksDifference(args[0], args[1])
+}
+
+func (f functionSetOfNetworksDifference) GetResultType() Type {
+	return TypeSetOfNetworks
+}
+
+func (f functionSetOfNetworksDifference) describe() string {
+	return "difference"
+}
+
+func (f functionSetOfNetworksDifference) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksDifference) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfNetworksExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfNetworksExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfNetworksBuilder()
+	for _, n := range SortSetOfNetworks(first) {
+		if _, ok := second.GetByNet(n); ok {
+			continue
+		}
+
+		for _, r := range subtractNetworks(n, subnetworksOf(n, second)) {
+			b.add(r)
+		}
+	}
+
+	return MakeSetOfNetworksValue(b.t), nil
+}
+
+// subnetworksOf returns networks of given set which are inside network n.
+func subnetworksOf(n *net.IPNet, set *iptree.Tree) []*net.IPNet {
+	ones, bits := n.Mask.Size()
+
+	var out []*net.IPNet
+	for p := range set.Enumerate() {
+		sOnes, sBits := p.Key.Mask.Size()
+		if sBits == bits && sOnes > ones && n.Contains(p.Key.IP) {
+			out = append(out, p.Key)
+		}
+	}
+
+	return out
+}
+
+// subtractNetworks returns networks which cover addresses of network n
+// outside of given subnetworks. It splits n in halves until each half is
+// either free of the subnetworks or covered by one of them.
+func subtractNetworks(n *net.IPNet, subs []*net.IPNet) []*net.IPNet {
+	if len(subs) <= 0 {
+		return []*net.IPNet{n}
+	}
+
+	ones, bits := n.Mask.Size()
+	for _, s := range subs {
+		if sOnes, _ := s.Mask.Size(); sOnes <= ones {
+			return nil
+		}
+	}
+
+	lo := &net.IPNet{IP: n.IP.Mask(n.Mask), Mask: net.CIDRMask(ones+1, bits)}
+	hi := &net.IPNet{IP: make(net.IP, len(lo.IP)), Mask: lo.Mask}
+	copy(hi.IP, lo.IP)
+	hi.IP[ones/8] |= 0x80 >> uint(ones%8)
+
+	var loSubs, hiSubs []*net.IPNet
+	for _, s := range subs {
+		if lo.Contains(s.IP) {
+			loSubs = append(loSubs, s)
+		} else {
+			hiSubs = append(hiSubs, s)
+		}
+	}
+
+	return append(subtractNetworks(lo, loSubs), subtractNetworks(hi, hiSubs)...)
+}
+
+func functionSetOfNetworksDifferenceValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfNetworks || args[1].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksDifferenceAlt
+}
@@ -0,0 +1,54 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfNetworksEqual struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfNetworksEqual(first, second Expression) Expression {
+	return functionSetOfNetworksEqual{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfNetworksEqualAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"equal\" for Set of Networks needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksEqual(args[0], args[1])
+}
+
+func (f functionSetOfNetworksEqual) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionSetOfNetworksEqual) describe() string {
+	return "equal"
+}
+
+func (f functionSetOfNetworksEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksEqual) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfNetworksExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfNetworksExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(isSetOfNetworksSubset(first, second) && isSetOfNetworksSubset(second, first)), nil
+}
+
+func functionSetOfNetworksEqualValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfNetworks || args[1].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksEqualAlt
+}
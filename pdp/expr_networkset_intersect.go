@@ -0,0 +1,67 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfNetworksIntersect struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfNetworksIntersect(first, second Expression) Expression {
+	return functionSetOfNetworksIntersect{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfNetworksIntersectAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"intersect\" for Set of Networks needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksIntersect(args[0], args[1])
+}
+
+func (f functionSetOfNetworksIntersect) GetResultType() Type {
+	return TypeSetOfNetworks
+}
+
+func (f functionSetOfNetworksIntersect) describe() string {
+	return "intersect"
+}
+
+func (f functionSetOfNetworksIntersect) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksIntersect) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfNetworksExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfNetworksExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfNetworksBuilder()
+	for _, n := range SortSetOfNetworks(first) {
+		if _, ok := second.GetByNet(n); ok {
+			b.add(n)
+		}
+	}
+
+	for _, n := range SortSetOfNetworks(second) {
+		if _, ok := first.GetByNet(n); ok {
+			b.add(n)
+		}
+	}
+
+	return MakeSetOfNetworksValue(b.t), nil
+}
+
+func functionSetOfNetworksIntersectValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfNetworks || args[1].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksIntersectAlt
+}
@@ -0,0 +1,52 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfNetworksLen struct {
+	e Expression
+}
+
+func makeFunctionSetOfNetworksLen(e Expression) Expression {
+	return functionSetOfNetworksLen{e: e}
+}
+
+func makeFunctionSetOfNetworksLenAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"len\" for Set of Networks needs exactly one argument but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksLen(args[0])
+}
+
+func (f functionSetOfNetworksLen) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionSetOfNetworksLen) describe() string {
+	return "len"
+}
+
+func (f functionSetOfNetworksLen) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksLen) Calculate(ctx *Context) (AttributeValue, error) {
+	set, err := ctx.calculateSetOfNetworksExpression(f.e)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "argument"), f.describe())
+	}
+
+	l := 0
+	for range set.Enumerate() {
+		l++
+	}
+
+	return MakeIntegerValue(int64(l)), nil
+}
+
+func functionSetOfNetworksLenValidator(args []Expression) functionMaker {
+	if len(args) != 1 || args[0].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksLenAlt
+}
@@ -0,0 +1,70 @@
+package pdp
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/go-trees/iptree"
+)
+
+type functionSetOfNetworksSubset struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfNetworksSubset(first, second Expression) Expression {
+	return functionSetOfNetworksSubset{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfNetworksSubsetAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"subset\" for Set of Networks needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksSubset(args[0], args[1])
+}
+
+func (f functionSetOfNetworksSubset) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionSetOfNetworksSubset) describe() string {
+	return "subset"
+}
+
+func (f functionSetOfNetworksSubset) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksSubset) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfNetworksExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfNetworksExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(isSetOfNetworksSubset(first, second)), nil
+}
+
+// isSetOfNetworksSubset checks if every network of the first set is equal to
+// or is a subnetwork of some network of the second set.
+func isSetOfNetworksSubset(first, second *iptree.Tree) bool {
+	for p := range first.Enumerate() {
+		if _, ok := second.GetByNet(p.Key); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func functionSetOfNetworksSubsetValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfNetworks || args[1].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksSubsetAlt
+}
@@ -0,0 +1,130 @@
+package pdp
+
+import "testing"
+
+func TestSetOfNetworksAlgebra(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	allowed := MakeSetOfNetworksValue(newIPTree(
+		makeTestNetwork("10.0.0.0/8"),
+		makeTestNetwork("192.0.2.0/24"),
+		makeTestNetwork("2001:db8::/32"),
+	))
+	blocked := MakeSetOfNetworksValue(newIPTree(
+		makeTestNetwork("10.1.0.0/16"),
+		makeTestNetwork("198.51.100.0/24"),
+		makeTestNetwork("192.0.2.0/24"),
+	))
+	empty := MakeSetOfNetworksValue(newIPTree())
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  string
+	}{
+		{
+			name: "union",
+			args: []Expression{allowed, blocked},
+			res:  "\"10.0.0.0/8\",\"192.0.2.0/24\",\"2001:db8::/32\",\"10.1.0.0/16\",\"198.51.100.0/24\"",
+		},
+		{
+			name: "intersect",
+			args: []Expression{allowed, blocked},
+			res:  "\"192.0.2.0/24\",\"10.1.0.0/16\"",
+		},
+		{
+			name: "intersect",
+			args: []Expression{allowed, empty},
+			res:  "",
+		},
+		{
+			name: "difference",
+			args: []Expression{allowed, blocked},
+			res: "\"10.0.0.0/16\",\"10.2.0.0/15\",\"10.4.0.0/14\",\"10.8.0.0/13\",\"10.16.0.0/12\"," +
+				"\"10.32.0.0/11\",\"10.64.0.0/10\",\"10.128.0.0/9\",\"2001:db8::/32\"",
+		},
+		{
+			name: "difference",
+			args: []Expression{
+				MakeSetOfNetworksValue(newIPTree(makeTestNetwork("192.0.2.0/30"), makeTestNetwork("2001:db8::/126"))),
+				MakeSetOfNetworksValue(newIPTree(
+					makeTestNetwork("192.0.2.1/32"),
+					makeTestNetwork("192.0.2.2/32"),
+					makeTestNetwork("2001:db8::/127"),
+				)),
+			},
+			res: "\"192.0.2.0/32\",\"192.0.2.3/32\",\"2001:db8::2/127\"",
+		},
+		{
+			name: "difference",
+			args: []Expression{blocked, allowed},
+			res:  "\"198.51.100.0/24\"",
+		},
+		{
+			name: "len",
+			args: []Expression{allowed},
+			res:  "3",
+		},
+		{
+			name: "len",
+			args: []Expression{empty},
+			res:  "0",
+		},
+		{
+			name: "equal",
+			args: []Expression{allowed, allowed},
+			res:  "true",
+		},
+		{
+			name: "equal",
+			args: []Expression{allowed, blocked},
+			res:  "false",
+		},
+		{
+			name: "equal",
+			args: []Expression{empty, allowed},
+			res:  "false",
+		},
+		{
+			name: "equal",
+			args: []Expression{
+				MakeSetOfNetworksValue(newIPTree(makeTestNetwork("10.0.0.0/8"), makeTestNetwork("10.1.0.0/16"))),
+				MakeSetOfNetworksValue(newIPTree(makeTestNetwork("10.0.0.0/8"))),
+			},
+			res: "true",
+		},
+		{
+			name: "subset",
+			args: []Expression{MakeSetOfNetworksValue(newIPTree(makeTestNetwork("10.1.2.0/24"))), allowed},
+			res:  "true",
+		},
+		{
+			name: "subset",
+			args: []Expression{blocked, allowed},
+			res:  "false",
+		},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		s, err := v.Serialize()
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+		} else if s != tc.res {
+			t.Errorf("%d: Expected %q for %q but got %q", i, tc.res, tc.name, s)
+		}
+	}
+}
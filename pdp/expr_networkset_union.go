@@ -0,0 +1,59 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfNetworksUnion struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfNetworksUnion(first, second Expression) Expression {
+	return functionSetOfNetworksUnion{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfNetworksUnionAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"union\" for Set of Networks needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfNetworksUnion(args[0], args[1])
+}
+
+func (f functionSetOfNetworksUnion) GetResultType() Type {
+	return TypeSetOfNetworks
+}
+
+func (f functionSetOfNetworksUnion) describe() string {
+	return "union"
+}
+
+func (f functionSetOfNetworksUnion) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfNetworksUnion) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfNetworksExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfNetworksExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	b := newSetOfNetworksBuilder()
+	for _, n := range append(SortSetOfNetworks(first), SortSetOfNetworks(second)...) {
+		b.add(n)
+	}
+
+	return MakeSetOfNetworksValue(b.t), nil
+}
+
+func functionSetOfNetworksUnionValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfNetworks || args[1].GetResultType() != TypeSetOfNetworks {
+		return nil
+	}
+	return makeFunctionSetOfNetworksUnionAlt
+}
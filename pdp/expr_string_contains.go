@@ -32,6 +32,10 @@ func (f functionStringContains) describe() string {
 	return "contains"
 }
 
+func (f functionStringContains) arguments() []Expression {
+	return []Expression{f.str, f.substr}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionStringContains) Calculate(ctx *Context) (AttributeValue, error) {
 	str, err := ctx.calculateStringExpression(f.str)
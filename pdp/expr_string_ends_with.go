@@ -0,0 +1,60 @@
+package pdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+type functionStringEndsWith struct {
+	str    Expression
+	suffix Expression
+}
+
+func makeFunctionStringEndsWith(str, suffix Expression) Expression {
+	return functionStringEndsWith{
+		str:    str,
+		suffix: suffix}
+}
+
+func makeFunctionStringEndsWithAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"ends-with\" for String needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionStringEndsWith(args[0], args[1])
+}
+
+func (f functionStringEndsWith) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionStringEndsWith) describe() string {
+	return "ends-with"
+}
+
+func (f functionStringEndsWith) arguments() []Expression {
+	return []Expression{f.str, f.suffix}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionStringEndsWith) Calculate(ctx *Context) (AttributeValue, error) {
+	str, err := ctx.calculateStringExpression(f.str)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "string argument"), f.describe())
+	}
+
+	suffix, err := ctx.calculateStringExpression(f.suffix)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "suffix argument"), f.describe())
+	}
+
+	return MakeBooleanValue(strings.HasSuffix(str, suffix)), nil
+}
+
+func functionStringEndsWithValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeString || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionStringEndsWithAlt
+}
@@ -29,6 +29,10 @@ func (f functionStringEqual) describe() string {
 	return "equal"
 }
 
+func (f functionStringEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionStringEqual) Calculate(ctx *Context) (AttributeValue, error) {
 	first, err := ctx.calculateStringExpression(f.first)
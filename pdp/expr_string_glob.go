@@ -0,0 +1,69 @@
+package pdp
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type functionStringGlob struct {
+	str     Expression
+	pattern Expression
+	r       *regexp.Regexp
+	err     error
+}
+
+func makeFunctionStringGlob(str, pattern Expression) Expression {
+	r, err := precompilePattern(pattern, compileGlobPattern)
+	return functionStringGlob{
+		str:     str,
+		pattern: pattern,
+		r:       r,
+		err:     err}
+}
+
+func makeFunctionStringGlobAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"glob\" for String needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionStringGlob(args[0], args[1])
+}
+
+func (f functionStringGlob) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionStringGlob) describe() string {
+	return "glob"
+}
+
+func (f functionStringGlob) arguments() []Expression {
+	return []Expression{f.str, f.pattern}
+}
+
+func (f functionStringGlob) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionStringGlob) Calculate(ctx *Context) (AttributeValue, error) {
+	str, err := ctx.calculateStringExpression(f.str)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "string argument"), f.describe())
+	}
+
+	r, err := ctx.calculatePatternExpression(f.pattern, f.r, compileGlobPattern)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "pattern argument"), f.describe())
+	}
+
+	return MakeBooleanValue(r.MatchString(str)), nil
+}
+
+func functionStringGlobValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeString || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionStringGlobAlt
+}
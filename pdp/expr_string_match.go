@@ -0,0 +1,69 @@
+package pdp
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type functionStringMatch struct {
+	str     Expression
+	pattern Expression
+	r       *regexp.Regexp
+	err     error
+}
+
+func makeFunctionStringMatch(str, pattern Expression) Expression {
+	r, err := precompilePattern(pattern, compileRegexpPattern)
+	return functionStringMatch{
+		str:     str,
+		pattern: pattern,
+		r:       r,
+		err:     err}
+}
+
+func makeFunctionStringMatchAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"match\" for String needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionStringMatch(args[0], args[1])
+}
+
+func (f functionStringMatch) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionStringMatch) describe() string {
+	return "match"
+}
+
+func (f functionStringMatch) arguments() []Expression {
+	return []Expression{f.str, f.pattern}
+}
+
+func (f functionStringMatch) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionStringMatch) Calculate(ctx *Context) (AttributeValue, error) {
+	str, err := ctx.calculateStringExpression(f.str)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "string argument"), f.describe())
+	}
+
+	r, err := ctx.calculatePatternExpression(f.pattern, f.r, compileRegexpPattern)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "pattern argument"), f.describe())
+	}
+
+	return MakeBooleanValue(r.MatchString(str)), nil
+}
+
+func functionStringMatchValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeString || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionStringMatchAlt
+}
@@ -0,0 +1,60 @@
+package pdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+type functionStringStartsWith struct {
+	str    Expression
+	prefix Expression
+}
+
+func makeFunctionStringStartsWith(str, prefix Expression) Expression {
+	return functionStringStartsWith{
+		str:    str,
+		prefix: prefix}
+}
+
+func makeFunctionStringStartsWithAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"starts-with\" for String needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionStringStartsWith(args[0], args[1])
+}
+
+func (f functionStringStartsWith) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionStringStartsWith) describe() string {
+	return "starts-with"
+}
+
+func (f functionStringStartsWith) arguments() []Expression {
+	return []Expression{f.str, f.prefix}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionStringStartsWith) Calculate(ctx *Context) (AttributeValue, error) {
+	str, err := ctx.calculateStringExpression(f.str)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "string argument"), f.describe())
+	}
+
+	prefix, err := ctx.calculateStringExpression(f.prefix)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "prefix argument"), f.describe())
+	}
+
+	return MakeBooleanValue(strings.HasPrefix(str, prefix)), nil
+}
+
+func functionStringStartsWithValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeString || args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionStringStartsWithAlt
+}
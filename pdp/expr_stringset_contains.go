@@ -31,6 +31,10 @@ func (f functionSetOfStringsContains) describe() string {
 	return "contains"
 }
 
+func (f functionSetOfStringsContains) arguments() []Expression {
+	return []Expression{f.set, f.value}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionSetOfStringsContains) Calculate(ctx *Context) (AttributeValue, error) {
 	set, err := ctx.calculateSetOfStringsExpression(f.set)
@@ -0,0 +1,67 @@
+package pdp
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+type functionSetOfStringsDifference struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfStringsDifference(first, second Expression) Expression {
+	return functionSetOfStringsDifference{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfStringsDifferenceAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"difference\" for Set of Strings needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfStringsDifference(args[0], args[1])
+}
+
+func (f functionSetOfStringsDifference) GetResultType() Type {
+	return TypeSetOfStrings
+}
+
+func (f functionSetOfStringsDifference) describe() string {
+	return "difference"
+}
+
+func (f functionSetOfStringsDifference) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfStringsDifference) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfStringsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfStringsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	res := strtree.NewTree()
+	i := 0
+	for _, s := range SortSetOfStrings(first) {
+		if _, ok := second.Get(s); !ok {
+			res.InplaceInsert(s, i)
+			i++
+		}
+	}
+
+	return MakeSetOfStringsValue(res), nil
+}
+
+func functionSetOfStringsDifferenceValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfStrings || args[1].GetResultType() != TypeSetOfStrings {
+		return nil
+	}
+	return makeFunctionSetOfStringsDifferenceAlt
+}
@@ -32,6 +32,10 @@ func (f functionSetOfStringsEqual) describe() string {
 	return "equal"
 }
 
+func (f functionSetOfStringsEqual) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionSetOfStringsEqual) Calculate(ctx *Context) (AttributeValue, error) {
 	firstSet, err := ctx.calculateSetOfStringsExpression(f.first)
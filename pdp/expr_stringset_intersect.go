@@ -32,6 +32,10 @@ func (f functionSetOfStringsIntersect) describe() string {
 	return "intersect"
 }
 
+func (f functionSetOfStringsIntersect) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionSetOfStringsIntersect) Calculate(ctx *Context) (AttributeValue, error) {
 	firstSet, err := ctx.calculateSetOfStringsExpression(f.first)
@@ -27,6 +27,10 @@ func (f functionSetOfStringsLen) describe() string {
 	return "len"
 }
 
+func (f functionSetOfStringsLen) arguments() []Expression {
+	return []Expression{f.e}
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (f functionSetOfStringsLen) Calculate(ctx *Context) (AttributeValue, error) {
 	set, err := ctx.calculateSetOfStringsExpression(f.e)
@@ -0,0 +1,61 @@
+package pdp
+
+import "fmt"
+
+type functionSetOfStringsSubset struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfStringsSubset(first, second Expression) Expression {
+	return functionSetOfStringsSubset{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfStringsSubsetAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"subset\" for Set of Strings needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfStringsSubset(args[0], args[1])
+}
+
+func (f functionSetOfStringsSubset) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionSetOfStringsSubset) describe() string {
+	return "subset"
+}
+
+func (f functionSetOfStringsSubset) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfStringsSubset) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfStringsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfStringsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	res := true
+	for p := range first.Enumerate() {
+		if res {
+			_, res = second.Get(p.Key)
+		}
+	}
+
+	return MakeBooleanValue(res), nil
+}
+
+func functionSetOfStringsSubsetValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfStrings || args[1].GetResultType() != TypeSetOfStrings {
+		return nil
+	}
+	return makeFunctionSetOfStringsSubsetAlt
+}
@@ -302,3 +302,48 @@ func compareEnumeratedSets(a, b *strtree.Tree, t *testing.T) bool {
 	}
 	return fok == sok
 }
+
+func TestSetOfStringsAlgebra(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	a := MakeSetOfStringsValue(newStrTree("foo", "bar", "boo"))
+	b := MakeSetOfStringsValue(newStrTree("mar", "bar"))
+	c := MakeSetOfStringsValue(newStrTree("boo", "foo"))
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  string
+	}{
+		{name: "union", args: []Expression{a, b}, res: "\"foo\",\"bar\",\"boo\",\"mar\""},
+		{name: "union", args: []Expression{b, b}, res: "\"mar\",\"bar\""},
+		{name: "difference", args: []Expression{a, b}, res: "\"foo\",\"boo\""},
+		{name: "difference", args: []Expression{c, a}, res: ""},
+		{name: "subset", args: []Expression{c, a}, res: "true"},
+		{name: "subset", args: []Expression{a, c}, res: "false"},
+		{name: "subset", args: []Expression{MakeSetOfStringsValue(newStrTree()), c}, res: "true"},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		s, err := v.Serialize()
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+		} else if s != tc.res {
+			t.Errorf("%d: Expected %q for %q but got %q", i, tc.res, tc.name, s)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package pdp
+
+import (
+	"fmt"
+
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+type functionSetOfStringsUnion struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionSetOfStringsUnion(first, second Expression) Expression {
+	return functionSetOfStringsUnion{
+		first:  first,
+		second: second}
+}
+
+func makeFunctionSetOfStringsUnionAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"union\" for Set of Strings needs exactly two arguments but got %d", len(args)))
+	}
+	return makeFunctionSetOfStringsUnion(args[0], args[1])
+}
+
+func (f functionSetOfStringsUnion) GetResultType() Type {
+	return TypeSetOfStrings
+}
+
+func (f functionSetOfStringsUnion) describe() string {
+	return "union"
+}
+
+func (f functionSetOfStringsUnion) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionSetOfStringsUnion) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateSetOfStringsExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+	second, err := ctx.calculateSetOfStringsExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	res := strtree.NewTree()
+	i := 0
+	for _, s := range append(SortSetOfStrings(first), SortSetOfStrings(second)...) {
+		if _, ok := res.Get(s); !ok {
+			res.InplaceInsert(s, i)
+			i++
+		}
+	}
+
+	return MakeSetOfStringsValue(res), nil
+}
+
+func functionSetOfStringsUnionValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeSetOfStrings || args[1].GetResultType() != TypeSetOfStrings {
+		return nil
+	}
+	return makeFunctionSetOfStringsUnionAlt
+}
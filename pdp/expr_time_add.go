@@ -0,0 +1,58 @@
+package pdp
+
+import "fmt"
+
+type functionTimeAdd struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionTimeAdd(first, second Expression) Expression {
+	return functionTimeAdd{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionTimeAddAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"add\" for Time and Duration needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionTimeAdd(args[0], args[1])
+}
+
+func (f functionTimeAdd) GetResultType() Type {
+	return TypeTime
+}
+
+func (f functionTimeAdd) describe() string {
+	return "add"
+}
+
+func (f functionTimeAdd) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeAdd) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateTimeExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateDurationExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeTimeValue(first.Add(second)), nil
+}
+
+func functionTimeAddValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeTime || args[1].GetResultType() != TypeDuration {
+		return nil
+	}
+
+	return makeFunctionTimeAddAlt
+}
@@ -0,0 +1,58 @@
+package pdp
+
+import "fmt"
+
+type functionTimeAfter struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionTimeAfter(first, second Expression) Expression {
+	return functionTimeAfter{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionTimeAfterAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"after\" for Time needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionTimeAfter(args[0], args[1])
+}
+
+func (f functionTimeAfter) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionTimeAfter) describe() string {
+	return "after"
+}
+
+func (f functionTimeAfter) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeAfter) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateTimeExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateTimeExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(first.After(second)), nil
+}
+
+func functionTimeAfterValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeTime || args[1].GetResultType() != TypeTime {
+		return nil
+	}
+
+	return makeFunctionTimeAfterAlt
+}
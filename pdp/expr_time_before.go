@@ -0,0 +1,58 @@
+package pdp
+
+import "fmt"
+
+type functionTimeBefore struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionTimeBefore(first, second Expression) Expression {
+	return functionTimeBefore{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionTimeBeforeAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"before\" for Time needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionTimeBefore(args[0], args[1])
+}
+
+func (f functionTimeBefore) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionTimeBefore) describe() string {
+	return "before"
+}
+
+func (f functionTimeBefore) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeBefore) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateTimeExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateTimeExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeBooleanValue(first.Before(second)), nil
+}
+
+func functionTimeBeforeValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeTime || args[1].GetResultType() != TypeTime {
+		return nil
+	}
+
+	return makeFunctionTimeBeforeAlt
+}
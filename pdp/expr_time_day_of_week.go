@@ -0,0 +1,83 @@
+package pdp
+
+import (
+	"fmt"
+	"time"
+)
+
+type functionTimeDayOfWeek struct {
+	t   Expression
+	tz  Expression
+	loc *time.Location
+	err error
+}
+
+func makeFunctionTimeDayOfWeek(t, tz Expression) Expression {
+	if tz == nil {
+		return functionTimeDayOfWeek{
+			t:   t,
+			loc: time.UTC}
+	}
+
+	loc, err := precompileLocation(tz)
+	return functionTimeDayOfWeek{
+		t:   t,
+		tz:  tz,
+		loc: loc,
+		err: err}
+}
+
+func makeFunctionTimeDayOfWeekAlt(args []Expression) Expression {
+	switch len(args) {
+	case 1:
+		return makeFunctionTimeDayOfWeek(args[0], nil)
+
+	case 2:
+		return makeFunctionTimeDayOfWeek(args[0], args[1])
+	}
+
+	panic(fmt.Errorf("function \"day-of-week\" needs one or two arguments but got %d", len(args)))
+}
+
+func (f functionTimeDayOfWeek) GetResultType() Type {
+	return TypeString
+}
+
+func (f functionTimeDayOfWeek) describe() string {
+	return "day-of-week"
+}
+
+func (f functionTimeDayOfWeek) arguments() []Expression {
+	return []Expression{f.t, f.tz}
+}
+
+func (f functionTimeDayOfWeek) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeDayOfWeek) Calculate(ctx *Context) (AttributeValue, error) {
+	t, err := ctx.calculateTimeExpression(f.t)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "time argument"), f.describe())
+	}
+
+	loc, err := ctx.calculateLocationExpression(f.tz, f.loc)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "time zone argument"), f.describe())
+	}
+
+	return MakeStringValue(t.In(loc).Weekday().String()), nil
+}
+
+func functionTimeDayOfWeekValidator(args []Expression) functionMaker {
+	if len(args) < 1 || len(args) > 2 || args[0].GetResultType() != TypeTime {
+		return nil
+	}
+
+	if len(args) > 1 && args[1].GetResultType() != TypeString {
+		return nil
+	}
+
+	return makeFunctionTimeDayOfWeekAlt
+}
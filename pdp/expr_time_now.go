@@ -0,0 +1,41 @@
+package pdp
+
+import (
+	"fmt"
+	"time"
+)
+
+type functionTimeNow struct{}
+
+func makeFunctionTimeNow() Expression {
+	return functionTimeNow{}
+}
+
+func makeFunctionTimeNowAlt(args []Expression) Expression {
+	if len(args) != 0 {
+		panic(fmt.Errorf("function \"now\" needs no arguments but got %d", len(args)))
+	}
+
+	return makeFunctionTimeNow()
+}
+
+func (f functionTimeNow) GetResultType() Type {
+	return TypeTime
+}
+
+func (f functionTimeNow) describe() string {
+	return "now"
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeNow) Calculate(ctx *Context) (AttributeValue, error) {
+	return MakeTimeValue(time.Now()), nil
+}
+
+func functionTimeNowValidator(args []Expression) functionMaker {
+	if len(args) != 0 {
+		return nil
+	}
+
+	return makeFunctionTimeNowAlt
+}
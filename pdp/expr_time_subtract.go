@@ -0,0 +1,58 @@
+package pdp
+
+import "fmt"
+
+type functionTimeSubtract struct {
+	first  Expression
+	second Expression
+}
+
+func makeFunctionTimeSubtract(first, second Expression) Expression {
+	return functionTimeSubtract{
+		first:  first,
+		second: second,
+	}
+}
+
+func makeFunctionTimeSubtractAlt(args []Expression) Expression {
+	if len(args) != 2 {
+		panic(fmt.Errorf("function \"subtract\" for Time needs exactly two arguments but got %d", len(args)))
+	}
+
+	return makeFunctionTimeSubtract(args[0], args[1])
+}
+
+func (f functionTimeSubtract) GetResultType() Type {
+	return TypeDuration
+}
+
+func (f functionTimeSubtract) describe() string {
+	return "subtract"
+}
+
+func (f functionTimeSubtract) arguments() []Expression {
+	return []Expression{f.first, f.second}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeSubtract) Calculate(ctx *Context) (AttributeValue, error) {
+	first, err := ctx.calculateTimeExpression(f.first)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "first argument"), f.describe())
+	}
+
+	second, err := ctx.calculateTimeExpression(f.second)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "second argument"), f.describe())
+	}
+
+	return MakeDurationValue(first.Sub(second)), nil
+}
+
+func functionTimeSubtractValidator(args []Expression) functionMaker {
+	if len(args) != 2 || args[0].GetResultType() != TypeTime || args[1].GetResultType() != TypeTime {
+		return nil
+	}
+
+	return makeFunctionTimeSubtractAlt
+}
@@ -0,0 +1,203 @@
+package pdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFunctions(t *testing.T) {
+	// Friday, 13:30 in New York.
+	tm := time.Date(2018, 6, 15, 17, 30, 0, 0, time.UTC)
+
+	ctx, err := NewContext(nil, 3, func(i int) (string, AttributeValue, error) {
+		switch i {
+		case 0:
+			return "t", MakeTimeValue(tm), nil
+
+		case 1:
+			return "tz", MakeStringValue("America/New_York"), nil
+		}
+
+		return "start", MakeStringValue("09:00"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	ta := MakeTimeDesignator("t")
+	tz := MakeAttributeDesignator(MakeAttribute("tz", TypeString))
+	start := MakeAttributeDesignator(MakeAttribute("start", TypeString))
+	hour := MakeDurationValue(time.Hour)
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  AttributeValue
+	}{
+		{
+			name: "before",
+			args: []Expression{ta, MakeTimeValue(tm.Add(time.Second))},
+			res:  MakeBooleanValue(true),
+		},
+		{
+			name: "before",
+			args: []Expression{ta, ta},
+			res:  MakeBooleanValue(false),
+		},
+		{
+			name: "after",
+			args: []Expression{ta, MakeTimeValue(tm.Add(-time.Second))},
+			res:  MakeBooleanValue(true),
+		},
+		{
+			name: "add",
+			args: []Expression{ta, hour},
+			res:  MakeTimeValue(tm.Add(time.Hour)),
+		},
+		{
+			name: "subtract",
+			args: []Expression{ta, MakeTimeValue(tm.Add(-25 * time.Hour))},
+			res:  MakeDurationValue(25 * time.Hour),
+		},
+		{
+			name: "greater",
+			args: []Expression{MakeDurationValue(25 * time.Hour), MakeDurationValue(24 * time.Hour)},
+			res:  MakeBooleanValue(true),
+		},
+		{
+			name: "within-window",
+			args: []Expression{ta, start, MakeStringValue("17:00"), tz},
+			res:  MakeBooleanValue(true),
+		},
+		{
+			name: "within-window",
+			args: []Expression{ta, MakeStringValue("09:00"), MakeStringValue("17:00"), MakeStringValue("UTC")},
+			res:  MakeBooleanValue(false),
+		},
+		{
+			name: "within-window",
+			args: []Expression{ta, MakeStringValue("22:00"), MakeStringValue("06:00:00"), MakeStringValue("Asia/Tokyo")},
+			res:  MakeBooleanValue(true),
+		},
+		{
+			name: "within-window",
+			args: []Expression{MakeStringValue("00:00"), MakeStringValue("00:00"), MakeStringValue("UTC")},
+			res:  MakeBooleanValue(false),
+		},
+		{
+			name: "day-of-week",
+			args: []Expression{ta},
+			res:  MakeStringValue("Friday"),
+		},
+		{
+			name: "day-of-week",
+			args: []Expression{ta, MakeStringValue("Asia/Tokyo")},
+			res:  MakeStringValue("Saturday"),
+		},
+		{
+			name: "day-of-week",
+			args: []Expression{ta, tz},
+			res:  MakeStringValue("Friday"),
+		},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		if err := CheckExpression(e); err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		if v.describe() != tc.res.describe() {
+			t.Errorf("%d: Expected %s for %q but got %s", i, tc.res.describe(), tc.name, v.describe())
+		}
+	}
+}
+
+func TestTimeNowFunction(t *testing.T) {
+	e := makeTestFunction(t, "now", nil)
+	if e == nil {
+		return
+	}
+
+	before := time.Now()
+	v, err := e.Calculate(&Context{})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	now, err := v.time()
+	if err != nil {
+		t.Fatalf("Expected time value but got %s", err)
+	}
+
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("Expected current time but got %s", now)
+	}
+}
+
+func TestTimeFunctionsWithInvalidArguments(t *testing.T) {
+	e := makeTestFunction(t, "within-window", []Expression{
+		MakeStringValue("09:00"),
+		MakeStringValue("17:00"),
+		MakeStringValue("Nowhere/Unknown"),
+	})
+	if e == nil {
+		return
+	}
+
+	err := CheckExpression(e)
+	if err == nil {
+		t.Errorf("Expected *invalidTimeZoneError but got nothing")
+	} else if _, ok := err.(*invalidTimeZoneError); !ok {
+		t.Errorf("Expected *invalidTimeZoneError but got %T (%s)", err, err)
+	}
+
+	e = makeTestFunction(t, "within-window", []Expression{
+		MakeStringValue("9 am"),
+		MakeStringValue("17:00"),
+		MakeStringValue("UTC"),
+	})
+	if e == nil {
+		return
+	}
+
+	err = CheckExpression(e)
+	if err == nil {
+		t.Errorf("Expected *invalidTimeOfDayError but got nothing")
+	} else if _, ok := err.(*invalidTimeOfDayError); !ok {
+		t.Errorf("Expected *invalidTimeOfDayError but got %T (%s)", err, err)
+	}
+
+	e = makeTestFunction(t, "day-of-week", []Expression{
+		MakeTimeValue(time.Now()),
+		MakeAttributeDesignator(MakeAttribute("tz", TypeString)),
+	})
+	if e == nil {
+		return
+	}
+
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "tz", MakeStringValue("Nowhere/Unknown"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	_, err = e.Calculate(ctx)
+	if err == nil {
+		t.Errorf("Expected *invalidTimeZoneError but got nothing")
+	} else if _, ok := err.(*invalidTimeZoneError); !ok {
+		t.Errorf("Expected *invalidTimeZoneError but got %T (%s)", err, err)
+	}
+}
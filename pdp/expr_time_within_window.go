@@ -0,0 +1,128 @@
+package pdp
+
+import (
+	"fmt"
+	"time"
+)
+
+type functionTimeWithinWindow struct {
+	t     Expression
+	start Expression
+	end   Expression
+	tz    Expression
+
+	startD  time.Duration
+	startOk bool
+	endD    time.Duration
+	endOk   bool
+	loc     *time.Location
+	err     error
+}
+
+func makeFunctionTimeWithinWindow(t, start, end, tz Expression) Expression {
+	f := functionTimeWithinWindow{
+		t:     t,
+		start: start,
+		end:   end,
+		tz:    tz,
+	}
+
+	var startErr, endErr, tzErr error
+	f.startD, f.startOk, startErr = precompileTimeOfDay(start)
+	f.endD, f.endOk, endErr = precompileTimeOfDay(end)
+	f.loc, tzErr = precompileLocation(tz)
+
+	switch {
+	case startErr != nil:
+		f.err = startErr
+
+	case endErr != nil:
+		f.err = endErr
+
+	case tzErr != nil:
+		f.err = tzErr
+	}
+
+	return f
+}
+
+func makeFunctionTimeWithinWindowAlt(args []Expression) Expression {
+	switch len(args) {
+	case 3:
+		return makeFunctionTimeWithinWindow(nil, args[0], args[1], args[2])
+
+	case 4:
+		return makeFunctionTimeWithinWindow(args[0], args[1], args[2], args[3])
+	}
+
+	panic(fmt.Errorf("function \"within-window\" needs three or four arguments but got %d", len(args)))
+}
+
+func (f functionTimeWithinWindow) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (f functionTimeWithinWindow) describe() string {
+	return "within-window"
+}
+
+func (f functionTimeWithinWindow) arguments() []Expression {
+	return []Expression{f.t, f.start, f.end, f.tz}
+}
+
+func (f functionTimeWithinWindow) check() error {
+	return f.err
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionTimeWithinWindow) Calculate(ctx *Context) (AttributeValue, error) {
+	t := time.Now()
+	if f.t != nil {
+		var err error
+		t, err = ctx.calculateTimeExpression(f.t)
+		if err != nil {
+			return UndefinedValue, bindError(bindError(err, "time argument"), f.describe())
+		}
+	}
+
+	start, err := ctx.calculateTimeOfDayExpression(f.start, f.startD, f.startOk)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "start argument"), f.describe())
+	}
+
+	end, err := ctx.calculateTimeOfDayExpression(f.end, f.endD, f.endOk)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "end argument"), f.describe())
+	}
+
+	loc, err := ctx.calculateLocationExpression(f.tz, f.loc)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "time zone argument"), f.describe())
+	}
+
+	return MakeBooleanValue(inTimeWindow(sinceMidnight(t.In(loc)), start, end)), nil
+}
+
+func functionTimeWithinWindowValidator(args []Expression) functionMaker {
+	switch len(args) {
+	case 3:
+		if args[0].GetResultType() != TypeString ||
+			args[1].GetResultType() != TypeString ||
+			args[2].GetResultType() != TypeString {
+			return nil
+		}
+
+	case 4:
+		if args[0].GetResultType() != TypeTime ||
+			args[1].GetResultType() != TypeString ||
+			args[2].GetResultType() != TypeString ||
+			args[3].GetResultType() != TypeString {
+			return nil
+		}
+
+	default:
+		return nil
+	}
+
+	return makeFunctionTimeWithinWindowAlt
+}
@@ -0,0 +1,69 @@
+package pdp
+
+import "fmt"
+
+type functionToAddress struct {
+	e Expression
+}
+
+func makeFunctionToAddress(e Expression) Expression {
+	return functionToAddress{
+		e: e,
+	}
+}
+
+func makeFunctionToAddressAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-address\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToAddress(args[0])
+}
+
+func (f functionToAddress) GetResultType() Type {
+	return TypeAddress
+}
+
+func (f functionToAddress) describe() string {
+	return "to-address"
+}
+
+func (f functionToAddress) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToAddress) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	if v.GetResultType() != TypeString {
+		return v, nil
+	}
+
+	s, err := v.str()
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	v, err = MakeValueFromString(TypeAddress, s)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	return v, nil
+}
+
+func functionToAddressValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if t := args[0].GetResultType(); t == TypeString || t == TypeAddress {
+		return makeFunctionToAddressAlt
+	}
+
+	return nil
+}
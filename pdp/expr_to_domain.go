@@ -0,0 +1,69 @@
+package pdp
+
+import "fmt"
+
+type functionToDomain struct {
+	e Expression
+}
+
+func makeFunctionToDomain(e Expression) Expression {
+	return functionToDomain{
+		e: e,
+	}
+}
+
+func makeFunctionToDomainAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-domain\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToDomain(args[0])
+}
+
+func (f functionToDomain) GetResultType() Type {
+	return TypeDomain
+}
+
+func (f functionToDomain) describe() string {
+	return "to-domain"
+}
+
+func (f functionToDomain) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToDomain) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	if v.GetResultType() != TypeString {
+		return v, nil
+	}
+
+	s, err := v.str()
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	v, err = MakeValueFromString(TypeDomain, s)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	return v, nil
+}
+
+func functionToDomainValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if t := args[0].GetResultType(); t == TypeString || t == TypeDomain {
+		return makeFunctionToDomainAlt
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package pdp
+
+import "fmt"
+
+type functionToFloat struct {
+	e Expression
+}
+
+func makeFunctionToFloat(e Expression) Expression {
+	return functionToFloat{
+		e: e,
+	}
+}
+
+func makeFunctionToFloatAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-float\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToFloat(args[0])
+}
+
+func (f functionToFloat) GetResultType() Type {
+	return TypeFloat
+}
+
+func (f functionToFloat) describe() string {
+	return "to-float"
+}
+
+func (f functionToFloat) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToFloat) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	switch v.GetResultType() {
+	case TypeString:
+		s, err := v.str()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		v, err = MakeValueFromString(TypeFloat, s)
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		n, err := v.float()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		if err := floatErrorCheck(n); err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+	case TypeInteger:
+		n, err := v.integer()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		v = MakeFloatValue(float64(n))
+	}
+
+	return v, nil
+}
+
+func functionToFloatValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if t := args[0].GetResultType(); t == TypeString || t == TypeInteger || t == TypeFloat {
+		return makeFunctionToFloatAlt
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package pdp
+
+import (
+	"fmt"
+	"math"
+)
+
+type functionToInteger struct {
+	e Expression
+}
+
+func makeFunctionToInteger(e Expression) Expression {
+	return functionToInteger{
+		e: e,
+	}
+}
+
+func makeFunctionToIntegerAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-integer\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToInteger(args[0])
+}
+
+func (f functionToInteger) GetResultType() Type {
+	return TypeInteger
+}
+
+func (f functionToInteger) describe() string {
+	return "to-integer"
+}
+
+func (f functionToInteger) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToInteger) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	switch v.GetResultType() {
+	case TypeString:
+		s, err := v.str()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		v, err = MakeValueFromString(TypeInteger, s)
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+	case TypeFloat:
+		n, err := v.float()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		if math.IsNaN(n) || n >= math.MaxInt64 || n < math.MinInt64 {
+			return UndefinedValue, bindError(newFloatToIntegerCastError(n), f.describe())
+		}
+
+		v = MakeIntegerValue(int64(n))
+	}
+
+	return v, nil
+}
+
+func functionToIntegerValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if t := args[0].GetResultType(); t == TypeString || t == TypeInteger || t == TypeFloat {
+		return makeFunctionToIntegerAlt
+	}
+
+	return nil
+}
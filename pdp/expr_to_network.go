@@ -0,0 +1,85 @@
+package pdp
+
+import (
+	"fmt"
+	"net"
+)
+
+type functionToNetwork struct {
+	e Expression
+}
+
+func makeFunctionToNetwork(e Expression) Expression {
+	return functionToNetwork{
+		e: e,
+	}
+}
+
+func makeFunctionToNetworkAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-network\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToNetwork(args[0])
+}
+
+func (f functionToNetwork) GetResultType() Type {
+	return TypeNetwork
+}
+
+func (f functionToNetwork) describe() string {
+	return "to-network"
+}
+
+func (f functionToNetwork) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToNetwork) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	switch v.GetResultType() {
+	case TypeString:
+		s, err := v.str()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		v, err = MakeValueFromString(TypeNetwork, s)
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+	case TypeAddress:
+		a, err := v.address()
+		if err != nil {
+			return UndefinedValue, bindError(err, f.describe())
+		}
+
+		bits := 8 * net.IPv6len
+		if ip := a.To4(); ip != nil {
+			a = ip
+			bits = 8 * net.IPv4len
+		}
+
+		v = MakeNetworkValue(&net.IPNet{IP: a, Mask: net.CIDRMask(bits, bits)})
+	}
+
+	return v, nil
+}
+
+func functionToNetworkValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if t := args[0].GetResultType(); t == TypeString || t == TypeAddress || t == TypeNetwork {
+		return makeFunctionToNetworkAlt
+	}
+
+	return nil
+}
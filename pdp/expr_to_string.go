@@ -0,0 +1,65 @@
+package pdp
+
+import "fmt"
+
+type functionToString struct {
+	e Expression
+}
+
+func makeFunctionToString(e Expression) Expression {
+	return functionToString{
+		e: e,
+	}
+}
+
+func makeFunctionToStringAlt(args []Expression) Expression {
+	if len(args) != 1 {
+		panic(fmt.Errorf("function \"to-string\" needs exactly one argument but got %d", len(args)))
+	}
+
+	return makeFunctionToString(args[0])
+}
+
+func (f functionToString) GetResultType() Type {
+	return TypeString
+}
+
+func (f functionToString) describe() string {
+	return "to-string"
+}
+
+func (f functionToString) arguments() []Expression {
+	return []Expression{f.e}
+}
+
+// Calculate implements Expression interface and returns calculated value
+func (f functionToString) Calculate(ctx *Context) (AttributeValue, error) {
+	v, err := f.e.Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	s, err := v.Serialize()
+	if err != nil {
+		return UndefinedValue, bindError(err, f.describe())
+	}
+
+	return MakeStringValue(s), nil
+}
+
+func functionToStringValidator(args []Expression) functionMaker {
+	if len(args) != 1 {
+		return nil
+	}
+
+	if _, ok := args[0].GetResultType().(*EnumType); ok {
+		return makeFunctionToStringAlt
+	}
+
+	switch args[0].GetResultType() {
+	case TypeBoolean, TypeString, TypeInteger, TypeFloat, TypeAddress, TypeNetwork, TypeDomain, TypeTime, TypeDuration:
+		return makeFunctionToStringAlt
+	}
+
+	return nil
+}
@@ -22,6 +22,10 @@ func (f functionTry) describe() string {
 	return "try"
 }
 
+func (f functionTry) arguments() []Expression {
+	return f.args
+}
+
 func (f functionTry) Calculate(ctx *Context) (AttributeValue, error) {
 	var (
 		v   AttributeValue
@@ -11,6 +11,24 @@ type Expression interface {
 type functionMaker func(args []Expression) Expression
 type functionArgumentValidator func(args []Expression) functionMaker
 
+// checkableExpression is implemented by expressions which can find errors
+// in their immediate arguments when they are built (for example malformed
+// regular expression pattern).
+type checkableExpression interface {
+	check() error
+}
+
+// CheckExpression returns an error if given expression has been built with
+// invalid immediate arguments. Parsers call it for each function expression
+// they create to report such errors at parse time.
+func CheckExpression(e Expression) error {
+	if c, ok := e.(checkableExpression); ok {
+		return c.check()
+	}
+
+	return nil
+}
+
 // FunctionArgumentValidators maps function name to list of validators.
 // For given set of arguments validator returns nil if the function
 // doesn't accept the arguments or function which creates expression based
@@ -22,18 +40,24 @@ var FunctionArgumentValidators = map[string][]functionArgumentValidator{
 		functionFloatEqualValidator,
 		functionListOfStringsEqualValidator,
 		functionSetOfStringsEqualValidator,
+		functionSetOfNetworksEqualValidator,
+		functionSetOfDomainsEqualValidator,
+		functionEnumEqualValidator,
 	},
 	"greater": {
 		functionIntegerGreaterValidator,
 		functionFloatGreaterValidator,
+		functionDurationGreaterValidator,
 	},
 	"add": {
 		functionIntegerAddValidator,
 		functionFloatAddValidator,
+		functionTimeAddValidator,
 	},
 	"subtract": {
 		functionIntegerSubtractValidator,
 		functionFloatSubtractValidator,
+		functionTimeSubtractValidator,
 	},
 	"multiply": {
 		functionIntegerMultiplyValidator,
@@ -43,6 +67,22 @@ var FunctionArgumentValidators = map[string][]functionArgumentValidator{
 		functionIntegerDivideValidator,
 		functionFloatDivideValidator,
 	},
+	"mod": {
+		functionIntegerModValidator,
+		functionFloatModValidator,
+	},
+	"min": {
+		functionIntegerMinValidator,
+		functionFloatMinValidator,
+	},
+	"max": {
+		functionIntegerMaxValidator,
+		functionFloatMaxValidator,
+	},
+	"abs": {
+		functionIntegerAbsValidator,
+		functionFloatAbsValidator,
+	},
 	"contains": {
 		functionStringContainsValidator,
 		functionListOfStringsContainsValidator,
@@ -51,6 +91,22 @@ var FunctionArgumentValidators = map[string][]functionArgumentValidator{
 		functionSetOfNetworksContainsAddressValidator,
 		functionSetOfDomainsContainsValidator,
 	},
+	"match": {
+		functionStringMatchValidator,
+		functionDomainMatchValidator,
+	},
+	"glob": {
+		functionStringGlobValidator,
+		functionDomainGlobValidator,
+	},
+	"starts-with": {
+		functionStringStartsWithValidator,
+		functionDomainStartsWithValidator,
+	},
+	"ends-with": {
+		functionStringEndsWithValidator,
+		functionDomainEndsWithValidator,
+	},
 	"not": {functionBooleanNotValidator},
 	"or":  {functionBooleanOrValidator},
 	"and": {functionBooleanAndValidator},
@@ -64,10 +120,29 @@ var FunctionArgumentValidators = map[string][]functionArgumentValidator{
 	"intersect": {
 		functionListOfStringsIntersectValidator,
 		functionSetOfStringsIntersectValidator,
+		functionSetOfNetworksIntersectValidator,
+		functionSetOfDomainsIntersectValidator,
+	},
+	"union": {
+		functionSetOfStringsUnionValidator,
+		functionSetOfNetworksUnionValidator,
+		functionSetOfDomainsUnionValidator,
+	},
+	"difference": {
+		functionSetOfStringsDifferenceValidator,
+		functionSetOfNetworksDifferenceValidator,
+		functionSetOfDomainsDifferenceValidator,
+	},
+	"subset": {
+		functionSetOfStringsSubsetValidator,
+		functionSetOfNetworksSubsetValidator,
+		functionSetOfDomainsSubsetValidator,
 	},
 	"len": {
 		functionListOfStringsLenValidator,
 		functionSetOfStringsLenValidator,
+		functionSetOfNetworksLenValidator,
+		functionSetOfDomainsLenValidator,
 	},
 	"concat": {
 		functionConcatValidator,
@@ -75,4 +150,19 @@ var FunctionArgumentValidators = map[string][]functionArgumentValidator{
 	"try": {
 		functionTryValidator,
 	},
+	"now":           {functionTimeNowValidator},
+	"before":        {functionTimeBeforeValidator},
+	"after":         {functionTimeAfterValidator},
+	"within-window": {functionTimeWithinWindowValidator},
+	"day-of-week":   {functionTimeDayOfWeekValidator},
+
+	"to-string":  {functionToStringValidator},
+	"to-integer": {functionToIntegerValidator},
+	"to-float":   {functionToFloatValidator},
+	"to-address": {functionToAddressValidator},
+	"to-network": {functionToNetworkValidator},
+	"to-domain":  {functionToDomainValidator},
+
+	"decision-path": {functionDecisionPathValidator},
+	"decision-rule": {functionDecisionRuleValidator},
 }
@@ -11,6 +11,8 @@ type flagsMapperPCA struct {
 	policies  []Evaluable
 	def       Evaluable
 	err       Evaluable
+	defID     string
+	errID     string
 	order     int
 	algorithm PolicyCombiningAlg
 }
@@ -96,6 +98,8 @@ func (a flagsMapperPCA) add(ID string, child, old Evaluable) PolicyCombiningAlg
 			policies:  a.policies,
 			def:       def,
 			err:       err,
+			defID:     a.defID,
+			errID:     a.errID,
 			order:     a.order,
 			algorithm: a.algorithm,
 		}
@@ -110,6 +114,8 @@ func (a flagsMapperPCA) add(ID string, child, old Evaluable) PolicyCombiningAlg
 		policies:  m,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm,
 	}
@@ -133,6 +139,8 @@ func (a flagsMapperPCA) del(ID string, old Evaluable) PolicyCombiningAlg {
 			policies:  a.policies,
 			def:       def,
 			err:       err,
+			defID:     a.defID,
+			errID:     a.errID,
 			order:     a.order,
 			algorithm: a.algorithm,
 		}
@@ -147,6 +155,8 @@ func (a flagsMapperPCA) del(ID string, old Evaluable) PolicyCombiningAlg {
 		policies:  m,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm,
 	}
@@ -161,7 +171,7 @@ func (a flagsMapperPCA) calculateErrorPolicy(ctx *Context, err error) Response {
 		return a.err.Calculate(ctx)
 	}
 
-	return Response{EffectIndeterminate, bindError(err, a.describe()), nil}
+	return Response{EffectIndeterminate, bindError(err, a.describe()), nil, nil}
 }
 
 func (a flagsMapperPCA) getPoliciesMap(policies []Evaluable, t *FlagsType) []Evaluable {
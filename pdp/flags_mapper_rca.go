@@ -11,6 +11,8 @@ type flagsMapperRCA struct {
 	rules     []*Rule
 	def       *Rule
 	err       *Rule
+	defID     string
+	errID     string
 	order     int
 	algorithm RuleCombiningAlg
 }
@@ -96,6 +98,8 @@ func (a flagsMapperRCA) add(ID string, child, old *Rule) RuleCombiningAlg {
 			rules:     a.rules,
 			def:       def,
 			err:       err,
+			defID:     a.defID,
+			errID:     a.errID,
 			order:     a.order,
 			algorithm: a.algorithm,
 		}
@@ -110,6 +114,8 @@ func (a flagsMapperRCA) add(ID string, child, old *Rule) RuleCombiningAlg {
 		rules:     m,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm,
 	}
@@ -133,6 +139,8 @@ func (a flagsMapperRCA) del(ID string, old *Rule) RuleCombiningAlg {
 			rules:     a.rules,
 			def:       def,
 			err:       err,
+			defID:     a.defID,
+			errID:     a.errID,
 			order:     a.order,
 			algorithm: a.algorithm,
 		}
@@ -147,6 +155,8 @@ func (a flagsMapperRCA) del(ID string, old *Rule) RuleCombiningAlg {
 		rules:     m,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm,
 	}
@@ -161,7 +171,7 @@ func (a flagsMapperRCA) calculateErrorRule(ctx *Context, err error) Response {
 		return a.err.calculate(ctx)
 	}
 
-	return Response{EffectIndeterminate, bindError(err, a.describe()), nil}
+	return Response{EffectIndeterminate, bindError(err, a.describe()), nil, nil}
 }
 
 func (a flagsMapperRCA) getRulesMap(rules []*Rule, t *FlagsType) []*Rule {
@@ -0,0 +1,228 @@
+package pdp
+
+import "sort"
+
+// PolicyNode* constants identify kind of PolicyNode.
+const (
+	// PolicyNodePolicySet stands for policy set.
+	PolicyNodePolicySet = iota
+	// PolicyNodePolicy stands for policy.
+	PolicyNodePolicy
+	// PolicyNodeRule stands for rule.
+	PolicyNodeRule
+)
+
+// PolicyNode is a read-only view of policy set, policy or rule. It exposes
+// structure of policies to static analysis tools.
+type PolicyNode struct {
+	Kind   int
+	ID     string
+	Hidden bool
+
+	// Target contains match expressions of the target grouped to AllOf
+	// and AnyOf lists. Empty target matches any request.
+	Target      [][][]Expression
+	Obligations []AttributeAssignment
+
+	// Condition and Effect are set only for rule. Condition is nil
+	// if the rule has no condition.
+	Condition Expression
+	Effect    int
+
+	// Algorithm is name of combining algorithm of policy set or policy
+	// (for example "FirstApplicableEffect" or "Mapper"). Mapper contains
+	// parameters of mapper algorithm.
+	Algorithm string
+	Mapper    *MapperNode
+
+	Children []*PolicyNode
+}
+
+// MapperNode is a read-only view of mapper combining algorithm parameters.
+type MapperNode struct {
+	Argument Expression
+
+	// Def and Err are ids of default and error children as they have been
+	// given to the algorithm maker even if there is no child with such id.
+	// Empty string means the id hasn't been given.
+	Def string
+	Err string
+
+	// Algorithm is name of additional combining algorithm used when
+	// argument can return several ids. It's empty if there is no such
+	// algorithm.
+	Algorithm string
+}
+
+// InspectPolicies returns PolicyNode tree for given policy set or policy.
+// It returns nil for nil or unknown evaluable.
+func InspectPolicies(e Evaluable) *PolicyNode {
+	switch e := e.(type) {
+	case *PolicySet:
+		n := &PolicyNode{
+			Kind:        PolicyNodePolicySet,
+			ID:          e.id,
+			Hidden:      e.hidden,
+			Target:      inspectTarget(e.target),
+			Obligations: e.obligations,
+			Children:    make([]*PolicyNode, 0, len(e.policies)),
+		}
+		n.Algorithm, n.Mapper = inspectAlgorithm(e.algorithm)
+
+		for _, p := range e.policies {
+			if c := InspectPolicies(p); c != nil {
+				n.Children = append(n.Children, c)
+			}
+		}
+
+		return n
+
+	case *Policy:
+		n := &PolicyNode{
+			Kind:        PolicyNodePolicy,
+			ID:          e.id,
+			Hidden:      e.hidden,
+			Target:      inspectTarget(e.target),
+			Obligations: e.obligations,
+			Children:    make([]*PolicyNode, len(e.rules)),
+		}
+		n.Algorithm, n.Mapper = inspectAlgorithm(e.algorithm)
+
+		for i, r := range e.rules {
+			n.Children[i] = &PolicyNode{
+				Kind:        PolicyNodeRule,
+				ID:          r.id,
+				Hidden:      r.hidden,
+				Target:      inspectTarget(r.target),
+				Obligations: r.obligations,
+				Condition:   r.condition,
+				Effect:      r.effect,
+			}
+		}
+
+		return n
+	}
+
+	return nil
+}
+
+func inspectTarget(t Target) [][][]Expression {
+	out := make([][][]Expression, len(t.a))
+	for i, anyOf := range t.a {
+		out[i] = make([][]Expression, len(anyOf.a))
+		for j, allOf := range anyOf.a {
+			out[i][j] = make([]Expression, len(allOf.m))
+			for k, m := range allOf.m {
+				out[i][j][k] = m.m
+			}
+		}
+	}
+
+	return out
+}
+
+func inspectAlgorithm(a interface{}) (string, *MapperNode) {
+	switch a := a.(type) {
+	case mapperPCA:
+		return "Mapper", &MapperNode{a.argument, a.defID, a.errID, inspectAlgorithmName(a.algorithm)}
+
+	case flagsMapperPCA:
+		return "Mapper", &MapperNode{a.argument, a.defID, a.errID, inspectAlgorithmName(a.algorithm)}
+
+	case mapperRCA:
+		return "Mapper", &MapperNode{a.argument, a.defID, a.errID, inspectAlgorithmName(a.algorithm)}
+
+	case flagsMapperRCA:
+		return "Mapper", &MapperNode{a.argument, a.defID, a.errID, inspectAlgorithmName(a.algorithm)}
+	}
+
+	return inspectAlgorithmName(a), nil
+}
+
+func inspectAlgorithmName(a interface{}) string {
+	switch a.(type) {
+	case firstApplicableEffectPCA, firstApplicableEffectRCA:
+		return "FirstApplicableEffect"
+
+	case denyOverridesPCA, denyOverridesRCA:
+		return "DenyOverrides"
+
+	case orderedDenyOverridesPCA, orderedDenyOverridesRCA:
+		return "OrderedDenyOverrides"
+
+	case permitOverridesPCA, permitOverridesRCA:
+		return "PermitOverrides"
+
+	case orderedPermitOverridesPCA, orderedPermitOverridesRCA:
+		return "OrderedPermitOverrides"
+
+	case denyUnlessPermitPCA, denyUnlessPermitRCA:
+		return "DenyUnlessPermit"
+
+	case permitUnlessDenyPCA, permitUnlessDenyRCA:
+		return "PermitUnlessDeny"
+
+	case onlyOneApplicablePCA, onlyOneApplicableRCA:
+		return "OnlyOneApplicable"
+
+	case mapperPCA, flagsMapperPCA, mapperRCA, flagsMapperRCA:
+		return "Mapper"
+	}
+
+	return ""
+}
+
+// ArgumentsExpression is implemented by expressions defined out of pdp
+// package (for example selectors) which calculate their values from other
+// expressions. ExpressionAttributeIDs looks into the arguments to find
+// attributes such expression refers to.
+type ArgumentsExpression interface {
+	Expression
+	// GetArguments returns expressions the expression depends on. It may
+	// contain nil for optional arguments.
+	GetArguments() []Expression
+}
+
+// argumentsExpression is implemented by expressions of pdp package which have
+// arguments.
+type argumentsExpression interface {
+	arguments() []Expression
+}
+
+// ExpressionAttributeIDs returns sorted ids of attributes which given
+// expression refers to with attribute designators. It looks into arguments
+// of functions and selectors and into expressions of referenced variables.
+func ExpressionAttributeIDs(e Expression) []string {
+	ids := make(map[string]struct{})
+	collectAttributeIDs(e, ids)
+
+	out := make([]string, 0, len(ids))
+	for id := range ids {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+func collectAttributeIDs(e Expression, ids map[string]struct{}) {
+	var args []Expression
+	switch e := e.(type) {
+	case nil:
+		return
+
+	case AttributeDesignator:
+		ids[e.GetID()] = struct{}{}
+		return
+
+	case argumentsExpression:
+		args = e.arguments()
+
+	case ArgumentsExpression:
+		args = e.GetArguments()
+	}
+
+	for _, arg := range args {
+		collectAttributeIDs(arg, ids)
+	}
+}
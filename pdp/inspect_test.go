@@ -0,0 +1,110 @@
+package pdp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspectPolicies(t *testing.T) {
+	if n := InspectPolicies(nil); n != nil {
+		t.Errorf("Expected nil for nil evaluable but got %#v", n)
+	}
+
+	target := MakeTarget()
+	anyOf := MakeAnyOf()
+	allOf := MakeAllOf()
+	allOf.Append(MakeMatch(makeFunctionStringEqual(MakeStringDesignator("s"), MakeStringValue("test"))))
+	anyOf.Append(allOf)
+	target.Append(anyOf)
+
+	p := NewPolicySet("root", false, Target{},
+		[]Evaluable{
+			NewPolicy("first", false, target,
+				[]*Rule{
+					makeSimpleRule("permit", EffectPermit),
+					NewRule("", true, Target{}, MakeStringDesignator("c"), EffectDeny, nil),
+				},
+				makeFirstApplicableEffectRCA, nil,
+				[]AttributeAssignment{MakeStringAssignment("o", "value")}),
+		},
+		makeMapperPCA, MapperPCAParams{
+			Argument: MakeStringDesignator("k"),
+			DefOk:    true,
+			Def:      "missing"},
+		nil)
+
+	n := InspectPolicies(p)
+	if n == nil {
+		t.Fatal("Expected policy set node but got nothing")
+	}
+
+	if n.Kind != PolicyNodePolicySet || n.ID != "root" || n.Algorithm != "Mapper" {
+		t.Errorf("Expected \"root\" policy set with Mapper but got %d %q %q", n.Kind, n.ID, n.Algorithm)
+	}
+
+	if n.Mapper == nil {
+		t.Error("Expected mapper parameters but got nothing")
+	} else if n.Mapper.Def != "missing" || n.Mapper.Err != "" {
+		t.Errorf("Expected \"missing\" default and no error ids but got %q and %q", n.Mapper.Def, n.Mapper.Err)
+	}
+
+	if len(n.Children) != 1 {
+		t.Fatalf("Expected single child but got %d", len(n.Children))
+	}
+
+	c := n.Children[0]
+	if c.Kind != PolicyNodePolicy || c.ID != "first" || c.Algorithm != "FirstApplicableEffect" || c.Mapper != nil {
+		t.Errorf("Expected \"first\" policy with FirstApplicableEffect but got %d %q %q %#v",
+			c.Kind, c.ID, c.Algorithm, c.Mapper)
+	}
+
+	if len(c.Target) != 1 || len(c.Target[0]) != 1 || len(c.Target[0][0]) != 1 {
+		t.Errorf("Expected single match in target but got %#v", c.Target)
+	}
+
+	if len(c.Obligations) != 1 || c.Obligations[0].GetID() != "o" {
+		t.Errorf("Expected \"o\" obligation but got %#v", c.Obligations)
+	}
+
+	if len(c.Children) != 2 {
+		t.Fatalf("Expected two rules but got %d", len(c.Children))
+	}
+
+	r := c.Children[1]
+	if r.Kind != PolicyNodeRule || !r.Hidden || r.Effect != EffectDeny || r.Condition == nil {
+		t.Errorf("Expected hidden deny rule with condition but got %#v", r)
+	}
+}
+
+func TestExpressionAttributeIDs(t *testing.T) {
+	if ids := ExpressionAttributeIDs(nil); len(ids) > 0 {
+		t.Errorf("Expected no ids for nil expression but got %#v", ids)
+	}
+
+	v := NewVariable("v", MakeStringDesignator("b"))
+	e := makeFunctionStringEqual(
+		MakeVariableReference(v),
+		makeFunctionStringEqualAlt([]Expression{MakeStringDesignator("a"), MakeStringValue("c")}),
+	)
+
+	ids := ExpressionAttributeIDs(e)
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("Expected %#v but got %#v", []string{"a", "b"}, ids)
+	}
+
+	c := MakeListOfStringsDesignator("l")
+	ev, err := NewElementVariable("x", c)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	q, err := MakeAnyOfExpression(ev, c, makeFunctionStringEqual(MakeVariableReference(ev), MakeStringDesignator("s")))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	ids = ExpressionAttributeIDs(makeFunctionBooleanNot([]Expression{q}))
+	if !reflect.DeepEqual(ids, []string{"l", "s"}) {
+		t.Errorf("Expected %#v but got %#v", []string{"l", "s"}, ids)
+	}
+}
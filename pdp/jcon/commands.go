@@ -59,7 +59,7 @@ func unmarshalCommand(d *json.Decoder, s pdp.Symbols, u *pdp.ContentUpdate) erro
 			}
 
 			var err error
-			entity, err = unmarshalContentItem("", s, d)
+			entity, err = unmarshalContentItem("", s, nil, d)
 			if err != nil {
 				return err
 			}
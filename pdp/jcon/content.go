@@ -12,6 +12,7 @@ type content struct {
 	id      string
 	symbols pdp.Symbols
 	items   []*pdp.ContentItem
+	l       *loader
 }
 
 func (c *content) bindError(err error) error {
@@ -73,12 +74,15 @@ func (c *content) unmarshalItemsField(d *json.Decoder) error {
 
 	items := []*pdp.ContentItem{}
 	err = jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
-		v, err := unmarshalContentItem(k, c.symbols, d)
+		v, err := unmarshalContentItem(k, c.symbols, c.l, d)
 		if err != nil {
 			return bindError(err, k)
 		}
 
 		items = append(items, v)
+		if err := c.l.item(); err != nil {
+			return bindError(err, k)
+		}
 
 		return nil
 	}, "content items")
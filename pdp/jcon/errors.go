@@ -4,7 +4,6 @@ package jcon
 
 import (
 	"encoding/json"
-
 	"github.com/infobloxopen/themis/pdp"
 )
 
@@ -42,6 +41,17 @@ const (
 	missingCommandEntityErrorID           = 30
 	unknownContentUpdateOperationErrorID  = 31
 	arrayEndDelimiterErrorID              = 32
+	timeCastErrorID                       = 33
+	durationCastErrorID                   = 34
+	missingEnumValueListErrorID           = 35
+	missingStructFieldListErrorID         = 36
+	unknownStructFieldErrorID             = 37
+	missingStructFieldErrorID             = 38
+	unknownDomainMatchErrorID             = 39
+	domainMatchAfterDataErrorID           = 40
+	memoryLimitExceededErrorID            = 41
+	keyExpiresAfterDataErrorID            = 42
+	invalidExpiresFormatErrorID           = 43
 )
 
 type externalError struct {
@@ -544,3 +554,166 @@ func newArrayEndDelimiterError(actual json.Delim, expected, desc string) *arrayE
 func (e *arrayEndDelimiterError) Error() string {
 	return e.errorf("Expected %s JSON array end %q but got delimiter %q", e.desc, e.expected, e.actual)
 }
+
+type timeCastError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newTimeCastError(s string, err error) *timeCastError {
+	return &timeCastError{
+		errorLink: errorLink{id: timeCastErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *timeCastError) Error() string {
+	return e.errorf("Can't treat %q as time (%s)", e.s, e.err)
+}
+
+type durationCastError struct {
+	errorLink
+	s   string
+	err error
+}
+
+func newDurationCastError(s string, err error) *durationCastError {
+	return &durationCastError{
+		errorLink: errorLink{id: durationCastErrorID},
+		s:         s,
+		err:       err}
+}
+
+func (e *durationCastError) Error() string {
+	return e.errorf("Can't treat %q as duration (%s)", e.s, e.err)
+}
+
+type missingEnumValueListError struct {
+	errorLink
+}
+
+func newMissingEnumValueListError() *missingEnumValueListError {
+	return &missingEnumValueListError{
+		errorLink: errorLink{id: missingEnumValueListErrorID}}
+}
+
+func (e *missingEnumValueListError) Error() string {
+	return e.errorf("Missing list of enum values")
+}
+
+type missingStructFieldListError struct {
+	errorLink
+}
+
+func newMissingStructFieldListError() *missingStructFieldListError {
+	return &missingStructFieldListError{
+		errorLink: errorLink{id: missingStructFieldListErrorID}}
+}
+
+func (e *missingStructFieldListError) Error() string {
+	return e.errorf("Missing list of struct fields")
+}
+
+type unknownStructFieldError struct {
+	errorLink
+	name string
+}
+
+func newUnknownStructFieldError(name string) *unknownStructFieldError {
+	return &unknownStructFieldError{
+		errorLink: errorLink{id: unknownStructFieldErrorID},
+		name:      name}
+}
+
+func (e *unknownStructFieldError) Error() string {
+	return e.errorf("Unknown struct field %q", e.name)
+}
+
+type missingStructFieldError struct {
+	errorLink
+	name string
+}
+
+func newMissingStructFieldError(name string) *missingStructFieldError {
+	return &missingStructFieldError{
+		errorLink: errorLink{id: missingStructFieldErrorID},
+		name:      name}
+}
+
+func (e *missingStructFieldError) Error() string {
+	return e.errorf("Missing value for struct field %q", e.name)
+}
+
+type unknownDomainMatchError struct {
+	errorLink
+	s string
+}
+
+func newUnknownDomainMatchError(s string) *unknownDomainMatchError {
+	return &unknownDomainMatchError{
+		errorLink: errorLink{id: unknownDomainMatchErrorID},
+		s:         s}
+}
+
+func (e *unknownDomainMatchError) Error() string {
+	return e.errorf("Unknown domain match %q", e.s)
+}
+
+type domainMatchAfterDataError struct {
+	errorLink
+}
+
+func newDomainMatchAfterDataError() *domainMatchAfterDataError {
+	return &domainMatchAfterDataError{
+		errorLink: errorLink{id: domainMatchAfterDataErrorID}}
+}
+
+func (e *domainMatchAfterDataError) Error() string {
+	return e.errorf("Field \"match\" should precede \"data\"")
+}
+
+type memoryLimitExceededError struct {
+	errorLink
+	size  int64
+	limit int64
+}
+
+func newMemoryLimitExceededError(size, limit int64) *memoryLimitExceededError {
+	return &memoryLimitExceededError{
+		errorLink: errorLink{id: memoryLimitExceededErrorID},
+		size:      size,
+		limit:     limit}
+}
+
+func (e *memoryLimitExceededError) Error() string {
+	return e.errorf("Content is estimated to take %d bytes which exceeds memory limit of %d bytes", e.size, e.limit)
+}
+
+type keyExpiresAfterDataError struct {
+	errorLink
+}
+
+func newKeyExpiresAfterDataError() *keyExpiresAfterDataError {
+	return &keyExpiresAfterDataError{
+		errorLink: errorLink{id: keyExpiresAfterDataErrorID}}
+}
+
+func (e *keyExpiresAfterDataError) Error() string {
+	return e.errorf("Field \"expires\" with expiration of keys should precede \"data\"")
+}
+
+type invalidExpiresFormatError struct {
+	errorLink
+	t interface{}
+}
+
+func newInvalidExpiresFormatError(t interface{}) *invalidExpiresFormatError {
+	return &invalidExpiresFormatError{
+		errorLink: errorLink{id: invalidExpiresFormatErrorID},
+		t:         t}
+}
+
+func (e *invalidExpiresFormatError) Error() string {
+	return e.errorf("Expected time or object with expiration of keys but got %T", e.t)
+}
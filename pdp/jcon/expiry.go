@@ -0,0 +1,126 @@
+package jcon
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/infobloxopen/themis/jparser"
+	"github.com/infobloxopen/themis/pdp"
+)
+
+// expiry is a node of "expires" field. It keeps expiration time of content
+// item or a key and expiration of nested keys by their raw JSON strings.
+type expiry struct {
+	t   time.Time
+	sub map[string]*expiry
+}
+
+func unmarshalExpiry(d *json.Decoder) (*expiry, error) {
+	token, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := token.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, newTimeCastError(v, err)
+		}
+
+		return &expiry{t: t}, nil
+
+	case json.Delim:
+		if v.String() != jparser.DelimObjectStart {
+			break
+		}
+
+		e := &expiry{sub: make(map[string]*expiry)}
+		if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+			sub, err := unmarshalExpiry(d)
+			if err != nil {
+				return bindError(err, k)
+			}
+
+			e.sub[k] = sub
+			return nil
+		}, "expiration of keys"); err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	}
+
+	return nil, newInvalidExpiresFormatError(token)
+}
+
+// hasKeys checks if the node defines expiration of nested keys.
+func (e *expiry) hasKeys() bool {
+	return e != nil && len(e.sub) > 0
+}
+
+func (e *expiry) child(k string) *expiry {
+	if e == nil {
+		return nil
+	}
+
+	return e.sub[k]
+}
+
+func (c *contentItem) unmarshalExpiresField(d *json.Decoder) error {
+	if c.expOk {
+		return newDuplicateContentItemFieldError("expires")
+	}
+
+	e, err := unmarshalExpiry(d)
+	if err != nil {
+		return err
+	}
+
+	if e.hasKeys() && c.vOk && c.vReady {
+		return newKeyExpiresAfterDataError()
+	}
+
+	c.exp = e
+	c.expOk = true
+
+	return nil
+}
+
+// startExpiry prepares path of expiration nodes for parsing data of the item.
+func (c *contentItem) startExpiry() {
+	if c.exp.hasKeys() {
+		c.expPath = make([]*expiry, len(c.k)+1)
+		c.expPath[0] = c.exp
+	}
+}
+
+// enterKey selects expiration node for given key of map at given level.
+func (c *contentItem) enterKey(keyIdx int, k string) {
+	if c.expPath != nil {
+		c.expPath[keyIdx+1] = c.expPath[keyIdx].child(k)
+	}
+}
+
+// expiring makes value of the last entered key at given level expire
+// if the key has expiration time.
+func (c *contentItem) expiring(v interface{}, keyIdx int) interface{} {
+	if c.expPath == nil || keyIdx <= 0 {
+		return v
+	}
+
+	if e := c.expPath[keyIdx]; e != nil {
+		return pdp.MakeExpiringContentValue(v, e.t)
+	}
+
+	return v
+}
+
+// expiringItem makes item expire as a whole if "expires" field is a time.
+func (c *contentItem) expiringItem(item *pdp.ContentItem) *pdp.ContentItem {
+	if c.exp == nil || c.exp.t.IsZero() {
+		return item
+	}
+
+	return pdp.MakeExpiringContentItem(item, c.exp.t)
+}
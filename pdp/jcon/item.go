@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -17,6 +18,7 @@ import (
 type contentItem struct {
 	id string
 	s  pdp.Symbols
+	l  *loader
 
 	k      pdp.Signature
 	keysOk bool
@@ -24,9 +26,20 @@ type contentItem struct {
 	t   pdp.Type
 	tOk bool
 
+	dm   pdp.DomainMatch
+	dmOk bool
+
+	exp     *expiry
+	expOk   bool
+	expPath []*expiry
+
 	v      interface{}
 	vOk    bool
 	vReady bool
+
+	// raw keeps data of the item which comes before its type or keys.
+	// The data goes to content maps when the item ends.
+	raw *rawData
 }
 
 func (c *contentItem) unmarshalTypeField(d *json.Decoder) error {
@@ -76,7 +89,9 @@ func (c *contentItem) unmarshalTypeDeclaration(d *json.Decoder) error {
 		nameOk bool
 		name   string
 
-		flags []string
+		flags  []string
+		values []string
+		fields []pdp.StructField
 	)
 
 	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
@@ -110,6 +125,22 @@ func (c *contentItem) unmarshalTypeDeclaration(d *json.Decoder) error {
 			}, "list of flag names"); err != nil {
 				return err
 			}
+
+		case "values":
+			values = []string{}
+			if err := jparser.GetStringSequence(d, func(i int, s string) error {
+				values = append(values, s)
+				return nil
+			}, "list of enum values"); err != nil {
+				return err
+			}
+
+		case "fields":
+			var err error
+			fields, err = c.unmarshalStructFields(d)
+			if err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -135,23 +166,89 @@ func (c *contentItem) unmarshalTypeDeclaration(d *json.Decoder) error {
 			return err
 		}
 
-		if nameOk {
-			if err := c.s.PutType(t); err != nil {
-				if _, ok := err.(*pdp.ReadOnlySymbolsChangeError); ok {
-					return newNewTypeOnUpdateError()
-				}
+		return c.putDeclaredType(t, nameOk)
 
-				return err
-			}
+	case "enum":
+		if values == nil {
+			return newMissingEnumValueListError()
 		}
 
-		c.t = t
-		c.tOk = true
+		t, err := pdp.NewEnumType(name, values...)
+		if err != nil {
+			return err
+		}
+
+		return c.putDeclaredType(t, nameOk)
+
+	case "struct":
+		if fields == nil {
+			return newMissingStructFieldListError()
+		}
+
+		t, err := pdp.NewStructType(name, fields...)
+		if err != nil {
+			return err
+		}
+
+		return c.putDeclaredType(t, nameOk)
+	}
+}
+
+func (c *contentItem) putDeclaredType(t pdp.Type, named bool) error {
+	if named {
+		if err := c.s.PutType(t); err != nil {
+			if _, ok := err.(*pdp.ReadOnlySymbolsChangeError); ok {
+				return newNewTypeOnUpdateError()
+			}
+
+			return err
+		}
 	}
 
+	c.t = t
+	c.tOk = true
+
 	return nil
 }
 
+// unmarshalStructFields parses list of struct fields. Each field is an object
+// with field name as a key and type name as a value.
+func (c *contentItem) unmarshalStructFields(d *json.Decoder) ([]pdp.StructField, error) {
+	if err := jparser.CheckArrayStart(d, "list of struct fields"); err != nil {
+		return nil, err
+	}
+
+	fields := []pdp.StructField{}
+	if err := jparser.UnmarshalObjectArray(d, func(idx int, d *json.Decoder) error {
+		if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+			s, err := jparser.GetString(d, "field type")
+			if err != nil {
+				return bindError(err, k)
+			}
+
+			t := c.s.GetType(s)
+			if t == nil {
+				return bindError(newUnknownTypeError(s), k)
+			}
+
+			fields = append(fields, pdp.StructField{
+				Name: k,
+				Type: t,
+			})
+
+			return nil
+		}, "struct field"); err != nil {
+			return bindErrorf(err, "%d", idx)
+		}
+
+		return nil
+	}, "list of struct fields"); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
 func (c *contentItem) unmarshalKeysField(d *json.Decoder) error {
 	if c.keysOk {
 		return newDuplicateContentItemFieldError("keys")
@@ -201,6 +298,31 @@ func (c *contentItem) unmarshalKeysField(d *json.Decoder) error {
 	}
 }
 
+func (c *contentItem) unmarshalMatchField(d *json.Decoder) error {
+	if c.dmOk {
+		return newDuplicateContentItemFieldError("match")
+	}
+
+	if c.vOk && c.vReady {
+		return newDomainMatchAfterDataError()
+	}
+
+	s, err := jparser.GetString(d, "domain match")
+	if err != nil {
+		return err
+	}
+
+	dm, ok := pdp.DomainMatchIDs[strings.ToLower(s)]
+	if !ok {
+		return newUnknownDomainMatchError(s)
+	}
+
+	c.dm = dm
+	c.dmOk = true
+
+	return nil
+}
+
 func (c *contentItem) unmarshalMap(d *json.Decoder, keyIdx int) (interface{}, error) {
 	src := fmt.Sprintf("level %d map", keyIdx+1)
 	err := jparser.CheckObjectStart(d, src)
@@ -213,7 +335,19 @@ func (c *contentItem) unmarshalMap(d *json.Decoder, keyIdx int) (interface{}, er
 		return nil, err
 	}
 
-	err = jparser.UnmarshalObject(d, m.unmarshal, src)
+	kt := c.k[keyIdx]
+	err = jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+		c.enterKey(keyIdx, k)
+		if err := m.unmarshal(k, d); err != nil {
+			return err
+		}
+
+		if err := c.l.key(keySize(kt, k)); err != nil {
+			return bindError(err, k)
+		}
+
+		return nil
+	}, src)
 	if err != nil {
 		return nil, err
 	}
@@ -252,6 +386,23 @@ func (c *contentItem) unmarshalValue(d *json.Decoder) (interface{}, error) {
 		return n, nil
 	}
 
+	switch t := c.t.(type) {
+	case *pdp.EnumType:
+		s, err := jparser.GetString(d, "enum value")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := pdp.MakeEnumValue(s, t); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+
+	case *pdp.StructType:
+		return c.unmarshalStructValue(d, t)
+	}
+
 	switch c.t {
 	case pdp.TypeBoolean:
 		return jparser.GetBoolean(d, "value")
@@ -325,6 +476,8 @@ func (c *contentItem) unmarshalValue(d *json.Decoder) (interface{}, error) {
 			if _, ok := m.Get(s); !ok {
 				m.InplaceInsert(s, i)
 				i++
+
+				return c.l.alloc(keySize(pdp.TypeString, s))
 			}
 
 			return nil
@@ -353,7 +506,7 @@ func (c *contentItem) unmarshalValue(d *json.Decoder) (interface{}, error) {
 				i++
 			}
 
-			return nil
+			return c.l.alloc(keySize(pdp.TypeNetwork, s))
 		}, "set of networks value")
 		if err != nil {
 			return nil, err
@@ -373,7 +526,7 @@ func (c *contentItem) unmarshalValue(d *json.Decoder) (interface{}, error) {
 			m.InplaceInsert(dn, i)
 			i++
 
-			return nil
+			return c.l.alloc(keySize(pdp.TypeDomain, s))
 		}, "set of domains value")
 		if err != nil {
 			return nil, err
@@ -392,11 +545,128 @@ func (c *contentItem) unmarshalValue(d *json.Decoder) (interface{}, error) {
 		}
 
 		return lst, nil
+
+	case pdp.TypeTime:
+		s, err := jparser.GetString(d, "time value")
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, newTimeCastError(s, err)
+		}
+
+		return t, nil
+
+	case pdp.TypeDuration:
+		s, err := jparser.GetString(d, "duration value")
+		if err != nil {
+			return nil, err
+		}
+
+		dur, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, newDurationCastError(s, err)
+		}
+
+		return dur, nil
 	}
 
 	return nil, newInvalidContentItemTypeError(c.t)
 }
 
+func (c *contentItem) unmarshalStructValue(d *json.Decoder, t *pdp.StructType) (pdp.AttributeValue, error) {
+	if err := jparser.CheckObjectStart(d, "struct value"); err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	sf := t.Fields()
+	fields := make([]interface{}, len(sf))
+	if err := jparser.UnmarshalObject(d, func(k string, d *json.Decoder) error {
+		i := t.GetFieldIndex(k)
+		if i < 0 {
+			return newUnknownStructFieldError(k)
+		}
+
+		fc := &contentItem{s: c.s, t: sf[i].Type}
+		v, err := fc.unmarshalValue(d)
+		if err != nil {
+			return bindError(err, k)
+		}
+
+		if err := c.l.alloc(valueSize(v)); err != nil {
+			return bindError(err, k)
+		}
+
+		fields[i] = v
+		return nil
+	}, "struct value"); err != nil {
+		return pdp.UndefinedValue, err
+	}
+
+	return makeStructValue(t, sf, fields)
+}
+
+// makeStructValue creates struct value from raw values of its fields. Each
+// raw value should have golang type which corresponds to the field type.
+func makeStructValue(t pdp.Type, sf []pdp.StructField, fields []interface{}) (pdp.AttributeValue, error) {
+	values := make([]pdp.AttributeValue, len(sf))
+	for i, f := range sf {
+		v := fields[i]
+		if v == nil {
+			return pdp.UndefinedValue, newMissingStructFieldError(f.Name)
+		}
+
+		if _, ok := f.Type.(*pdp.EnumType); ok {
+			av, err := pdp.MakeEnumValue(v.(string), f.Type)
+			if err != nil {
+				return pdp.UndefinedValue, bindError(err, f.Name)
+			}
+
+			values[i] = av
+			continue
+		}
+
+		switch f.Type {
+		case pdp.TypeBoolean:
+			values[i] = pdp.MakeBooleanValue(v.(bool))
+
+		case pdp.TypeString:
+			values[i] = pdp.MakeStringValue(v.(string))
+
+		case pdp.TypeInteger:
+			values[i] = pdp.MakeIntegerValue(v.(int64))
+
+		case pdp.TypeFloat:
+			values[i] = pdp.MakeFloatValue(v.(float64))
+
+		case pdp.TypeAddress:
+			values[i] = pdp.MakeAddressValue(v.(net.IP))
+
+		case pdp.TypeNetwork:
+			values[i] = pdp.MakeNetworkValue(v.(*net.IPNet))
+
+		case pdp.TypeDomain:
+			values[i] = pdp.MakeDomainValue(v.(domain.Name))
+
+		case pdp.TypeListOfStrings:
+			values[i] = pdp.MakeListOfStringsValue(v.([]string))
+
+		case pdp.TypeTime:
+			values[i] = pdp.MakeTimeValue(v.(time.Time))
+
+		case pdp.TypeDuration:
+			values[i] = pdp.MakeDurationValue(v.(time.Duration))
+
+		default:
+			return pdp.UndefinedValue, bindError(newInvalidContentItemTypeError(f.Type), f.Name)
+		}
+	}
+
+	return pdp.MakeStructValue(t, values...)
+}
+
 func (c *contentItem) unmarshalFlags8Value(d *json.Decoder) (uint8, error) {
 	t, ok := c.t.(*pdp.FlagsType)
 	if !ok {
@@ -494,13 +764,29 @@ func (c *contentItem) unmarshalFlags64Value(d *json.Decoder) (uint64, error) {
 }
 
 func (c *contentItem) unmarshalTypedData(d *json.Decoder, keyIdx int) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
 	if len(c.k) > keyIdx {
-		return c.unmarshalMap(d, keyIdx)
+		v, err = c.unmarshalMap(d, keyIdx)
+	} else {
+		v, err = c.unmarshalValue(d)
+		if err == nil {
+			err = c.l.alloc(valueSize(v))
+		}
 	}
 
-	return c.unmarshalValue(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.expiring(v, keyIdx), nil
 }
 
+// unmarshalDataField parses data directly to content maps if type and keys
+// of the item are already known. Otherwise it copies data in compact form
+// to parse it when the item ends.
 func (c *contentItem) unmarshalDataField(d *json.Decoder) error {
 	if c.vOk {
 		return newDuplicateContentItemFieldError("type")
@@ -508,6 +794,7 @@ func (c *contentItem) unmarshalDataField(d *json.Decoder) error {
 
 	c.vReady = c.keysOk && c.tOk
 	if c.vReady {
+		c.startExpiry()
 		v, err := c.unmarshalTypedData(d, 0)
 		if err != nil {
 			return err
@@ -519,12 +806,14 @@ func (c *contentItem) unmarshalDataField(d *json.Decoder) error {
 			c.v = v
 		}
 	} else {
-		v, err := jparser.GetUndefined(d, "content")
-		if err != nil {
+		c.raw = &rawData{l: c.l}
+		if err := jparser.CopyValue(d, c.raw, "content"); err != nil {
+			if c.raw.err != nil {
+				return c.raw.err
+			}
+
 			return err
 		}
-
-		c.v = v
 	}
 
 	c.vOk = true
@@ -539,8 +828,14 @@ func (c *contentItem) unmarshal(k string, d *json.Decoder) error {
 	case "keys":
 		return c.unmarshalKeysField(d)
 
+	case "match":
+		return c.unmarshalMatchField(d)
+
 	case "data":
 		return c.unmarshalDataField(d)
+
+	case "expires":
+		return c.unmarshalExpiresField(d)
 	}
 
 	return newUnknownContentItemFieldError(k)
@@ -565,22 +860,25 @@ func (c *contentItem) get() (*pdp.ContentItem, error) {
 	}
 
 	if c.vReady {
-		return pdp.MakeContentMappingItem(c.id, c.t, c.k, c.adjustValue(c.v)), nil
+		return c.expiringItem(pdp.MakeContentMappingItem(c.id, c.t, c.k, c.adjustValue(c.v))), nil
 	}
 
-	v, err := c.postProcess(c.v, 0)
+	c.startExpiry()
+	v, err := c.unmarshalTypedData(json.NewDecoder(c.raw), 0)
 	if err != nil {
 		return nil, err
 	}
 
+	c.raw = nil
+
 	if len(c.k) <= 0 {
-		return pdp.MakeContentValueItem(c.id, c.t, v), nil
+		return c.expiringItem(pdp.MakeContentValueItem(c.id, c.t, v)), nil
 	}
 
-	return pdp.MakeContentMappingItem(c.id, c.t, c.k, c.adjustValue(v)), nil
+	return c.expiringItem(pdp.MakeContentMappingItem(c.id, c.t, c.k, c.adjustValue(v))), nil
 }
 
-func unmarshalContentItem(id string, s pdp.Symbols, d *json.Decoder) (*pdp.ContentItem, error) {
+func unmarshalContentItem(id string, s pdp.Symbols, l *loader, d *json.Decoder) (*pdp.ContentItem, error) {
 	err := jparser.CheckObjectStart(d, "content item")
 	if err != nil {
 		return nil, err
@@ -589,6 +887,7 @@ func unmarshalContentItem(id string, s pdp.Symbols, d *json.Decoder) (*pdp.Conte
 	item := &contentItem{
 		id: id,
 		s:  s,
+		l:  l,
 	}
 	err = jparser.UnmarshalObject(d, item.unmarshal, "content item")
 	if err != nil {
@@ -0,0 +1,290 @@
+package jcon
+
+import (
+	"io"
+	"net"
+
+	"github.com/infobloxopen/go-trees/domain"
+
+	"github.com/infobloxopen/themis/pdp"
+)
+
+const (
+	// keyMemoryOverhead is an approximate size of tree node which holds a key
+	// of content map or an element of set.
+	keyMemoryOverhead = 64
+	// stringHeaderSize is a size of string header in a slice of strings.
+	stringHeaderSize = 16
+	// rawChunkSize is a size of chunk which holds data of content item
+	// coming before type or keys of the item.
+	rawChunkSize = 32 * 1024
+)
+
+// Progress shows how much of content has been loaded.
+type Progress struct {
+	// Items is a number of content items loaded completely.
+	Items int
+	// Keys is a number of keys put to content maps at all levels.
+	Keys int64
+	// Size is an estimation of memory taken by the content in bytes.
+	Size int64
+}
+
+// LoaderOption configures streaming content loader.
+type LoaderOption func(*loader)
+
+// WithMemoryLimit returns a LoaderOption which makes loader fail as soon as
+// estimated size of content exceeds given number of bytes. Zero or negative
+// limit means no limit.
+func WithMemoryLimit(n int64) LoaderOption {
+	return func(l *loader) {
+		l.limit = n
+	}
+}
+
+// WithProgress returns a LoaderOption which makes loader call given function
+// after each content item and after each given number of keys (if positive).
+func WithProgress(f func(p Progress), keys int64) LoaderOption {
+	return func(l *loader) {
+		l.progress = f
+		l.keys = keys
+	}
+}
+
+// loader tracks progress of content parsing and estimates memory the content
+// takes. For JSON content parser reports size of each key and value it puts
+// to content maps and the loader adds size of tree node per key. Data of items
+// which get type or keys after data is counted by chunks of its compact copy
+// until the chunks are parsed. Binary snapshot keeps keys and values in the form close
+// to their in-memory representation so for snapshot the loader counts bytes
+// read instead of keys and values sizes.
+type loader struct {
+	limit    int64
+	progress func(p Progress)
+	keys     int64
+
+	in       func() int64
+	p        Progress
+	data     int64
+	deferred int64
+}
+
+// newLoader creates loader. For binary snapshot in function should return
+// number of bytes read so far. For JSON it should be nil.
+func newLoader(in func() int64, opts ...LoaderOption) *loader {
+	l := &loader{in: in}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+func (l *loader) check() error {
+	l.p.Size = l.data + l.deferred + l.p.Keys*keyMemoryOverhead
+	if l.in != nil {
+		l.p.Size += l.in()
+	}
+
+	if l.limit > 0 && l.p.Size > l.limit {
+		return newMemoryLimitExceededError(l.p.Size, l.limit)
+	}
+
+	return nil
+}
+
+func (l *loader) report() {
+	if l.progress != nil {
+		l.progress(l.p)
+	}
+}
+
+// key counts key put to content map. Argument n is a size of the key
+// in addition to tree node.
+func (l *loader) key(n int64) error {
+	if l == nil {
+		return nil
+	}
+
+	l.p.Keys++
+	l.data += n
+	if err := l.check(); err != nil {
+		return err
+	}
+
+	if l.keys > 0 && l.p.Keys%l.keys == 0 {
+		l.report()
+	}
+
+	return nil
+}
+
+func (l *loader) item() error {
+	if l == nil {
+		return nil
+	}
+
+	l.p.Items++
+	if err := l.check(); err != nil {
+		return err
+	}
+
+	l.report()
+	return nil
+}
+
+// alloc counts memory taken by content value or an element of set.
+func (l *loader) alloc(n int64) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	l.data += n
+	return l.check()
+}
+
+func (l *loader) deferData(n int64) error {
+	if l == nil {
+		return nil
+	}
+
+	l.deferred += n
+	return l.check()
+}
+
+func (l *loader) releaseData(n int64) {
+	if l == nil {
+		return
+	}
+
+	l.deferred -= n
+}
+
+// OnKey implements pdp.ContentSnapshotObserver.
+func (l *loader) OnKey() error {
+	return l.key(0)
+}
+
+// OnItem implements pdp.ContentSnapshotObserver.
+func (l *loader) OnItem() error {
+	return l.item()
+}
+
+// keySize returns memory taken by key of given type in addition to fixed size
+// tree node.
+func keySize(t pdp.Type, k string) int64 {
+	switch t {
+	case pdp.TypeString:
+		return int64(len(k))
+
+	case pdp.TypeDomain:
+		return 2 * int64(len(k))
+	}
+
+	return 0
+}
+
+// valueSize returns memory taken by value beyond size of value holder.
+// Trees of set values are counted while they're filled.
+func valueSize(v interface{}) int64 {
+	switch v := v.(type) {
+	case string:
+		return int64(len(v))
+
+	case net.IP:
+		return int64(len(v))
+
+	case *net.IPNet:
+		return int64(len(v.IP) + len(v.Mask))
+
+	case domain.Name:
+		return 2 * int64(len(v.String()))
+
+	case []string:
+		n := int64(0)
+		for _, s := range v {
+			n += stringHeaderSize + int64(len(s))
+		}
+
+		return n
+	}
+
+	return 0
+}
+
+// countingReader counts bytes read from underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) count() int64 {
+	return r.n
+}
+
+// rawData keeps compact copy of content item data in chunks. Reading drops
+// chunks which have been read so memory of parsed data can be reused for
+// content maps built from it. Each chunk is counted by loader.
+type rawData struct {
+	l      *loader
+	chunks [][]byte
+	err    error
+}
+
+func (r *rawData) Write(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n := len(p)
+	for len(p) > 0 {
+		i := len(r.chunks) - 1
+		if i < 0 || len(r.chunks[i]) >= rawChunkSize {
+			if err := r.l.deferData(rawChunkSize); err != nil {
+				r.err = err
+				return n - len(p), err
+			}
+
+			r.chunks = append(r.chunks, make([]byte, 0, rawChunkSize))
+			i++
+		}
+
+		c := r.chunks[i]
+		m := copy(c[len(c):rawChunkSize], p)
+		r.chunks[i] = c[:len(c)+m]
+		p = p[m:]
+	}
+
+	return n, nil
+}
+
+func (r *rawData) WriteByte(c byte) error {
+	_, err := r.Write([]byte{c})
+	return err
+}
+
+func (r *rawData) WriteString(s string) (int, error) {
+	return r.Write([]byte(s))
+}
+
+func (r *rawData) Read(p []byte) (int, error) {
+	if len(r.chunks) <= 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.chunks[0])
+	r.chunks[0] = r.chunks[0][n:]
+	if len(r.chunks[0]) <= 0 {
+		r.chunks[0] = nil
+		r.chunks = r.chunks[1:]
+		r.l.releaseData(rawChunkSize)
+	}
+
+	return n, nil
+}
@@ -27,15 +27,17 @@ import (
 type mapUnmarshaller interface {
 	get() interface{}
 	unmarshal(k string, d *json.Decoder) error
-	postProcess(p jparser.Pair) error
 }
 
+// newTypedMap creates map for keys at given level. Values of flags type go
+// to dedicated maps unless the item has expiring keys as such maps can't keep
+// expiration.
 func newTypedMap(c *contentItem, keyIdx int) (mapUnmarshaller, error) {
 	t := c.k[keyIdx]
 
 	switch t {
 	case pdp.TypeString:
-		if t, ok := c.t.(*pdp.FlagsType); ok {
+		if t, ok := c.t.(*pdp.FlagsType); ok && !c.exp.hasKeys() {
 			switch t.Capacity() {
 			case 8:
 				return &string8Map{
@@ -63,7 +65,7 @@ func newTypedMap(c *contentItem, keyIdx int) (mapUnmarshaller, error) {
 			m:               strtree.NewTree()}, nil
 
 	case pdp.TypeAddress, pdp.TypeNetwork:
-		if t, ok := c.t.(*pdp.FlagsType); ok {
+		if t, ok := c.t.(*pdp.FlagsType); ok && !c.exp.hasKeys() {
 			switch t.Capacity() {
 			case 8:
 				return &network8Map{
@@ -91,35 +93,53 @@ func newTypedMap(c *contentItem, keyIdx int) (mapUnmarshaller, error) {
 			m:               iptree.NewTree()}, nil
 
 	case pdp.TypeDomain:
-		if t, ok := c.t.(*pdp.FlagsType); ok {
-			switch t.Capacity() {
-			case 8:
-				return &domain8Map{
-					contentItemLink: contentItemLink{c: c, i: keyIdx},
-					m:               &domaintree8.Node{}}, nil
+		m := &domainMatchMap{contentItemLink: contentItemLink{c: c, i: keyIdx}}
+		if c.dm != pdp.DomainMatchSubdomainsAndApex {
+			m.m = pdp.MakeContentDomainMatchMap(c.dm)
+		} else {
+			m.plain = newDomainMap(c, keyIdx)
+		}
 
-			case 16:
-				return &domain16Map{
-					contentItemLink: contentItemLink{c: c, i: keyIdx},
-					m:               &domaintree16.Node{}}, nil
+		return m, nil
 
-			case 32:
-				return &domain32Map{
-					contentItemLink: contentItemLink{c: c, i: keyIdx},
-					m:               &domaintree32.Node{}}, nil
-			}
+	case pdp.TypeInteger:
+		return &integerMap{contentItemLink: contentItemLink{c: c, i: keyIdx}}, nil
+
+	case pdp.TypeFloat:
+		return &floatMap{contentItemLink: contentItemLink{c: c, i: keyIdx}}, nil
+	}
+
+	return nil, newInvalidContentKeyTypeError(t, pdp.ContentKeyTypes)
+}
+
+// newDomainMap creates map for domain keys with default match mode.
+func newDomainMap(c *contentItem, keyIdx int) mapUnmarshaller {
+	if t, ok := c.t.(*pdp.FlagsType); ok && !c.exp.hasKeys() {
+		switch t.Capacity() {
+		case 8:
+			return &domain8Map{
+				contentItemLink: contentItemLink{c: c, i: keyIdx},
+				m:               &domaintree8.Node{}}
+
+		case 16:
+			return &domain16Map{
+				contentItemLink: contentItemLink{c: c, i: keyIdx},
+				m:               &domaintree16.Node{}}
 
-			return &domain64Map{
+		case 32:
+			return &domain32Map{
 				contentItemLink: contentItemLink{c: c, i: keyIdx},
-				m:               &domaintree64.Node{}}, nil
+				m:               &domaintree32.Node{}}
 		}
 
-		return &domainMap{
+		return &domain64Map{
 			contentItemLink: contentItemLink{c: c, i: keyIdx},
-			m:               &domaintree.Node{}}, nil
+			m:               &domaintree64.Node{}}
 	}
 
-	return nil, newInvalidContentKeyTypeError(t, pdp.ContentKeyTypes)
+	return &domainMap{
+		contentItemLink: contentItemLink{c: c, i: keyIdx},
+		m:               &domaintree.Node{}}
 }
 
 type contentItemLink struct {
@@ -147,17 +167,6 @@ func (m *stringMap) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *stringMap) postProcess(p jparser.Pair) error {
-	v, err := m.c.postProcess(p.V, m.i+1)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(p.K, v)
-
-	return nil
-}
-
 type string8Map struct {
 	contentItemLink
 	m *strtree8.Tree
@@ -178,17 +187,6 @@ func (m *string8Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *string8Map) postProcess(p jparser.Pair) error {
-	v, err := m.c.postProcessFlags8Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(p.K, v)
-
-	return nil
-}
-
 type string16Map struct {
 	contentItemLink
 	m *strtree16.Tree
@@ -209,17 +207,6 @@ func (m *string16Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *string16Map) postProcess(p jparser.Pair) error {
-	v, err := m.c.postProcessFlags16Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(p.K, v)
-
-	return nil
-}
-
 type string32Map struct {
 	contentItemLink
 	m *strtree32.Tree
@@ -240,17 +227,6 @@ func (m *string32Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *string32Map) postProcess(p jparser.Pair) error {
-	v, err := m.c.postProcessFlags32Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(p.K, v)
-
-	return nil
-}
-
 type string64Map struct {
 	contentItemLink
 	m *strtree64.Tree
@@ -271,15 +247,29 @@ func (m *string64Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *string64Map) postProcess(p jparser.Pair) error {
-	v, err := m.c.postProcessFlags64Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
+// parseNetworkMapKey parses key of network map. The key can be an address,
+// a network or a range of addresses. The range is represented as a list
+// of networks which cover it.
+func parseNetworkMapKey(k string) (net.IP, []*net.IPNet, error) {
+	if pdp.IsRangeKey(k) {
+		nets, err := pdp.ParseAddressRange(k)
+		if err != nil {
+			return nil, nil, newAddressNetworkCastError(k, err)
+		}
+
+		return nil, nets, nil
 	}
 
-	m.m.InplaceInsert(p.K, v)
+	if a := net.ParseIP(k); a != nil {
+		return a, nil, nil
+	}
 
-	return nil
+	_, n, err := net.ParseCIDR(k)
+	if err != nil {
+		return nil, nil, newAddressNetworkCastError(k, err)
+	}
+
+	return nil, []*net.IPNet{n}, nil
 }
 
 type networkMap struct {
@@ -288,17 +278,9 @@ type networkMap struct {
 }
 
 func (m *networkMap) unmarshal(k string, d *json.Decoder) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(k)
-	if a == nil {
-		_, n, err = net.ParseCIDR(k)
-		if err != nil {
-			return newAddressNetworkCastError(k, err)
-		}
+	a, nets, err := parseNetworkMapKey(k)
+	if err != nil {
+		return err
 	}
 
 	v, err := m.c.unmarshalTypedData(d, m.i+1)
@@ -309,37 +291,11 @@ func (m *networkMap) unmarshal(k string, d *json.Decoder) error {
 	if a != nil {
 		m.m.InplaceInsertIP(a, v)
 	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
-	return nil
-}
-
-func (m *networkMap) postProcess(p jparser.Pair) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(p.K)
-	if a == nil {
-		_, n, err = net.ParseCIDR(p.K)
-		if err != nil {
-			return newAddressNetworkCastError(p.K, err)
+		for _, n := range nets {
+			m.m.InplaceInsertNet(n, v)
 		}
 	}
 
-	v, err := m.c.postProcess(p.V, m.i+1)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	if a != nil {
-		m.m.InplaceInsertIP(a, v)
-	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
 	return nil
 }
 
@@ -353,17 +309,9 @@ type network8Map struct {
 }
 
 func (m *network8Map) unmarshal(k string, d *json.Decoder) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(k)
-	if a == nil {
-		_, n, err = net.ParseCIDR(k)
-		if err != nil {
-			return newAddressNetworkCastError(k, err)
-		}
+	a, nets, err := parseNetworkMapKey(k)
+	if err != nil {
+		return err
 	}
 
 	v, err := m.c.unmarshalFlags8Value(d)
@@ -374,37 +322,11 @@ func (m *network8Map) unmarshal(k string, d *json.Decoder) error {
 	if a != nil {
 		m.m.InplaceInsertIP(a, v)
 	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
-	return nil
-}
-
-func (m *network8Map) postProcess(p jparser.Pair) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(p.K)
-	if a == nil {
-		_, n, err = net.ParseCIDR(p.K)
-		if err != nil {
-			return newAddressNetworkCastError(p.K, err)
+		for _, n := range nets {
+			m.m.InplaceInsertNet(n, v)
 		}
 	}
 
-	v, err := m.c.postProcessFlags8Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	if a != nil {
-		m.m.InplaceInsertIP(a, v)
-	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
 	return nil
 }
 
@@ -418,17 +340,9 @@ type network16Map struct {
 }
 
 func (m *network16Map) unmarshal(k string, d *json.Decoder) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(k)
-	if a == nil {
-		_, n, err = net.ParseCIDR(k)
-		if err != nil {
-			return newAddressNetworkCastError(k, err)
-		}
+	a, nets, err := parseNetworkMapKey(k)
+	if err != nil {
+		return err
 	}
 
 	v, err := m.c.unmarshalFlags16Value(d)
@@ -439,37 +353,11 @@ func (m *network16Map) unmarshal(k string, d *json.Decoder) error {
 	if a != nil {
 		m.m.InplaceInsertIP(a, v)
 	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
-	return nil
-}
-
-func (m *network16Map) postProcess(p jparser.Pair) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(p.K)
-	if a == nil {
-		_, n, err = net.ParseCIDR(p.K)
-		if err != nil {
-			return newAddressNetworkCastError(p.K, err)
+		for _, n := range nets {
+			m.m.InplaceInsertNet(n, v)
 		}
 	}
 
-	v, err := m.c.postProcessFlags16Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	if a != nil {
-		m.m.InplaceInsertIP(a, v)
-	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
 	return nil
 }
 
@@ -483,17 +371,9 @@ type network32Map struct {
 }
 
 func (m *network32Map) unmarshal(k string, d *json.Decoder) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(k)
-	if a == nil {
-		_, n, err = net.ParseCIDR(k)
-		if err != nil {
-			return newAddressNetworkCastError(k, err)
-		}
+	a, nets, err := parseNetworkMapKey(k)
+	if err != nil {
+		return err
 	}
 
 	v, err := m.c.unmarshalFlags32Value(d)
@@ -504,37 +384,11 @@ func (m *network32Map) unmarshal(k string, d *json.Decoder) error {
 	if a != nil {
 		m.m.InplaceInsertIP(a, v)
 	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
-	return nil
-}
-
-func (m *network32Map) postProcess(p jparser.Pair) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(p.K)
-	if a == nil {
-		_, n, err = net.ParseCIDR(p.K)
-		if err != nil {
-			return newAddressNetworkCastError(p.K, err)
+		for _, n := range nets {
+			m.m.InplaceInsertNet(n, v)
 		}
 	}
 
-	v, err := m.c.postProcessFlags32Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	if a != nil {
-		m.m.InplaceInsertIP(a, v)
-	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
 	return nil
 }
 
@@ -548,17 +402,9 @@ type network64Map struct {
 }
 
 func (m *network64Map) unmarshal(k string, d *json.Decoder) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(k)
-	if a == nil {
-		_, n, err = net.ParseCIDR(k)
-		if err != nil {
-			return newAddressNetworkCastError(k, err)
-		}
+	a, nets, err := parseNetworkMapKey(k)
+	if err != nil {
+		return err
 	}
 
 	v, err := m.c.unmarshalFlags64Value(d)
@@ -569,37 +415,11 @@ func (m *network64Map) unmarshal(k string, d *json.Decoder) error {
 	if a != nil {
 		m.m.InplaceInsertIP(a, v)
 	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
-	return nil
-}
-
-func (m *network64Map) postProcess(p jparser.Pair) error {
-	var (
-		n   *net.IPNet
-		err error
-	)
-
-	a := net.ParseIP(p.K)
-	if a == nil {
-		_, n, err = net.ParseCIDR(p.K)
-		if err != nil {
-			return newAddressNetworkCastError(p.K, err)
+		for _, n := range nets {
+			m.m.InplaceInsertNet(n, v)
 		}
 	}
 
-	v, err := m.c.postProcessFlags64Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	if a != nil {
-		m.m.InplaceInsertIP(a, v)
-	} else {
-		m.m.InplaceInsertNet(n, v)
-	}
-
 	return nil
 }
 
@@ -628,22 +448,6 @@ func (m *domainMap) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *domainMap) postProcess(p jparser.Pair) error {
-	dn, err := domain.MakeNameFromString(p.K)
-	if err != nil {
-		return newDomainCastError(p.K, err)
-	}
-
-	v, err := m.c.postProcess(p.V, m.i+1)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(dn, v)
-
-	return nil
-}
-
 func (m *domainMap) get() interface{} {
 	return pdp.MakeContentDomainMap(m.m)
 }
@@ -669,22 +473,6 @@ func (m *domain8Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *domain8Map) postProcess(p jparser.Pair) error {
-	dn, err := domain.MakeNameFromString(p.K)
-	if err != nil {
-		return newDomainCastError(p.K, err)
-	}
-
-	v, err := m.c.postProcessFlags8Value(p.V)
-	if err != nil {
-		return bindError(err, p.K)
-	}
-
-	m.m.InplaceInsert(dn, v)
-
-	return nil
-}
-
 func (m *domain8Map) get() interface{} {
 	return pdp.MakeContentDomainFlags8Map(m.m)
 }
@@ -710,15 +498,24 @@ func (m *domain16Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *domain16Map) postProcess(p jparser.Pair) error {
-	dn, err := domain.MakeNameFromString(p.K)
+func (m *domain16Map) get() interface{} {
+	return pdp.MakeContentDomainFlags16Map(m.m)
+}
+
+type domain32Map struct {
+	contentItemLink
+	m *domaintree32.Node
+}
+
+func (m *domain32Map) unmarshal(k string, d *json.Decoder) error {
+	dn, err := domain.MakeNameFromString(k)
 	if err != nil {
-		return newDomainCastError(p.K, err)
+		return newDomainCastError(k, err)
 	}
 
-	v, err := m.c.postProcessFlags16Value(p.V)
+	v, err := m.c.unmarshalFlags32Value(d)
 	if err != nil {
-		return bindError(err, p.K)
+		return bindError(err, k)
 	}
 
 	m.m.InplaceInsert(dn, v)
@@ -726,22 +523,22 @@ func (m *domain16Map) postProcess(p jparser.Pair) error {
 	return nil
 }
 
-func (m *domain16Map) get() interface{} {
-	return pdp.MakeContentDomainFlags16Map(m.m)
+func (m *domain32Map) get() interface{} {
+	return pdp.MakeContentDomainFlags32Map(m.m)
 }
 
-type domain32Map struct {
+type domain64Map struct {
 	contentItemLink
-	m *domaintree32.Node
+	m *domaintree64.Node
 }
 
-func (m *domain32Map) unmarshal(k string, d *json.Decoder) error {
+func (m *domain64Map) unmarshal(k string, d *json.Decoder) error {
 	dn, err := domain.MakeNameFromString(k)
 	if err != nil {
 		return newDomainCastError(k, err)
 	}
 
-	v, err := m.c.unmarshalFlags32Value(d)
+	v, err := m.c.unmarshalFlags64Value(d)
 	if err != nil {
 		return bindError(err, k)
 	}
@@ -751,63 +548,118 @@ func (m *domain32Map) unmarshal(k string, d *json.Decoder) error {
 	return nil
 }
 
-func (m *domain32Map) postProcess(p jparser.Pair) error {
-	dn, err := domain.MakeNameFromString(p.K)
+func (m *domain64Map) get() interface{} {
+	return pdp.MakeContentDomainFlags64Map(m.m)
+}
+
+type integerMap struct {
+	contentItemLink
+	b pdp.ContentIntegerMapBuilder
+}
+
+func (m *integerMap) unmarshal(k string, d *json.Decoder) error {
+	r, err := pdp.ParseIntegerRange(k)
 	if err != nil {
-		return newDomainCastError(p.K, err)
+		return err
 	}
 
-	v, err := m.c.postProcessFlags32Value(p.V)
+	v, err := m.c.unmarshalTypedData(d, m.i+1)
 	if err != nil {
-		return bindError(err, p.K)
+		return bindError(err, k)
 	}
 
-	m.m.InplaceInsert(dn, v)
+	m.b.Add(r, v)
 
 	return nil
 }
 
-func (m *domain32Map) get() interface{} {
-	return pdp.MakeContentDomainFlags32Map(m.m)
+func (m *integerMap) get() interface{} {
+	return m.b.Map()
 }
 
-type domain64Map struct {
+type floatMap struct {
 	contentItemLink
-	m *domaintree64.Node
+	b pdp.ContentFloatMapBuilder
 }
 
-func (m *domain64Map) unmarshal(k string, d *json.Decoder) error {
-	dn, err := domain.MakeNameFromString(k)
+func (m *floatMap) unmarshal(k string, d *json.Decoder) error {
+	r, err := pdp.ParseFloatRange(k)
 	if err != nil {
-		return newDomainCastError(k, err)
+		return err
 	}
 
-	v, err := m.c.unmarshalFlags64Value(d)
+	v, err := m.c.unmarshalTypedData(d, m.i+1)
 	if err != nil {
 		return bindError(err, k)
 	}
 
-	m.m.InplaceInsert(dn, v)
+	m.b.Add(r, v)
 
 	return nil
 }
 
-func (m *domain64Map) postProcess(p jparser.Pair) error {
-	dn, err := domain.MakeNameFromString(p.K)
+func (m *floatMap) get() interface{} {
+	return m.b.Map()
+}
+
+// domainMatchMap builds domain map with match modes. For item with default
+// match mode it starts as a plain domain map and switches to the map with
+// match modes on the first key which is negative or matches only subdomains.
+type domainMatchMap struct {
+	contentItemLink
+	plain mapUnmarshaller
+	m     pdp.ContentDomainMatchMap
+}
+
+func (m *domainMatchMap) switchToMatchModes() error {
+	m.m = pdp.MakeContentDomainMatchMap(m.c.dm)
+	if err := m.m.InplaceInsertDomainMap(m.plain.get().(pdp.ContentSubItem)); err != nil {
+		return err
+	}
+
+	m.plain = nil
+	return nil
+}
+
+func (m *domainMatchMap) unmarshal(k string, d *json.Decoder) error {
+	if m.plain != nil {
+		if !pdp.IsDomainKeyPattern(k) {
+			return m.plain.unmarshal(k, d)
+		}
+
+		if err := m.switchToMatchModes(); err != nil {
+			return err
+		}
+	}
+
+	dk, err := pdp.ParseDomainKey(k, m.c.dm)
 	if err != nil {
-		return newDomainCastError(p.K, err)
+		return err
+	}
+
+	if dk.Negative {
+		if err := jparser.SkipValue(d, "negative key value"); err != nil {
+			return bindError(err, k)
+		}
+
+		m.m.InplaceInsert(dk, nil)
+		return nil
 	}
 
-	v, err := m.c.postProcessFlags64Value(p.V)
+	v, err := m.c.unmarshalTypedData(d, m.i+1)
 	if err != nil {
-		return bindError(err, p.K)
+		return bindError(err, k)
 	}
 
-	m.m.InplaceInsert(dn, v)
+	m.m.InplaceInsert(dk, v)
 
 	return nil
 }
 
-func (m *domain64Map) get() interface{} {
-	return pdp.MakeContentDomainFlags64Map(m.m)
+func (m *domainMatchMap) get() interface{} {
+	if m.plain != nil {
+		return m.plain.get()
+	}
+
+	return m.m
 }
@@ -2,6 +2,7 @@
 package jcon
 
 import (
+	"bufio"
 	"encoding/json"
 	"io"
 
@@ -14,15 +15,38 @@ import (
 // and returns pointer to LocalContent. It sets given tag to the content.
 // Content with no tag can't be updated.
 func Unmarshal(r io.Reader, tag *uuid.UUID) (*pdp.LocalContent, error) {
+	c, _, err := UnmarshalStream(r, tag)
+	return c, err
+}
+
+// UnmarshalStream works as Unmarshal but additionally reports progress
+// of loading and can limit memory the content takes. Data of content item goes
+// directly to final maps as it's read if type and keys of the item precede
+// data. Otherwise compact copy of the data is kept until the item ends and then
+// goes to final maps the same way.
+// The function returns progress at the moment it stopped even on error.
+// It also accepts binary content snapshot (see pdp.WriteContentSnapshot)
+// detected by its header.
+func UnmarshalStream(r io.Reader, tag *uuid.UUID, opts ...LoaderOption) (*pdp.LocalContent, Progress, error) {
+	br := bufio.NewReader(r)
+	if b, _ := br.Peek(len(pdp.ContentSnapshotMagic)); pdp.IsContentSnapshot(b) {
+		cr := &countingReader{r: br}
+		l := newLoader(cr.count, opts...)
+		c, err := pdp.ReadContentSnapshot(cr, tag, l)
+		return c, l.p, err
+	}
+
+	d := json.NewDecoder(br)
 	c := &content{
 		symbols: pdp.MakeSymbols(),
+		l:       newLoader(nil, opts...),
 	}
-	err := c.unmarshal(json.NewDecoder(r))
+	err := c.unmarshal(d)
 	if err != nil {
-		return nil, err
+		return nil, c.l.p, err
 	}
 
-	return pdp.NewLocalContent(c.id, tag, c.symbols, c.items), nil
+	return pdp.NewLocalContent(c.id, tag, c.symbols, c.items), c.l.p, nil
 }
 
 // UnmarshalUpdate parses JSON content update representation to PDP's internal
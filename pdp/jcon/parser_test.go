@@ -1,8 +1,13 @@
 package jcon
 
 import (
+	"bytes"
+	"fmt"
+	"net"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -867,6 +872,20 @@ const (
 				]
 			}
 		},
+		"time": {
+			"type": "time",
+			"keys": ["string"],
+			"data": {
+				"key": "2018-06-15T09:30:00Z"
+			}
+		},
+		"duration": {
+			"type": "duration",
+			"keys": ["string"],
+			"data": {
+				"key": "1h30m"
+			}
+		},
 		"flags8": {
 			"type": {
 				"meta": "flags",
@@ -1025,6 +1044,20 @@ const (
 			"type": "list of strings",
 			"keys": ["string"]
 		},
+		"time": {
+			"data": {
+				"key": "2018-06-15T09:30:00Z"
+			},
+			"type": "time",
+			"keys": ["string"]
+		},
+		"duration": {
+			"data": {
+				"key": "1h30m"
+			},
+			"type": "duration",
+			"keys": ["string"]
+		},
 		"flags8": {
 			"data": {
 				"key": ["f00", "f02", "f04", "f06"]
@@ -1082,6 +1115,171 @@ const (
 		}
 	}
 }`
+
+	jsonCustomTypesStream = `{
+	"ID": "Test",
+	"Items": {
+		"level": {
+			"type": {
+				"meta": "enum",
+				"name": "level",
+				"values": ["low", "high"]
+			},
+			"keys": ["string"],
+			"data": {
+				"example.com": "high",
+				"example.net": "low"
+			}
+		},
+		"devices": {
+			"data": {
+				"router": {"name": "core", "level": "high", "weight": 10},
+				"switch": {"weight": 3, "level": "low", "name": "edge"}
+			},
+			"type": {
+				"meta": "struct",
+				"name": "device",
+				"fields": [{"name": "string"}, {"level": "level"}, {"weight": "integer"}]
+			},
+			"keys": ["string"]
+		},
+		"default": {
+			"type": "device",
+			"data": {"name": "default", "level": "low", "weight": 0}
+		}
+	}
+}`
+
+	jsonInvalidEnumStream = `{
+	"ID": "Test",
+	"Items": {
+		"level": {
+			"type": {
+				"meta": "enum",
+				"values": ["low", "high"]
+			},
+			"data": "medium"
+		}
+	}
+}`
+
+	jsonMissingStructFieldStream = `{
+	"ID": "Test",
+	"Items": {
+		"device": {
+			"type": {
+				"meta": "struct",
+				"fields": [{"name": "string"}, {"weight": "integer"}]
+			},
+			"data": {"name": "core"}
+		}
+	}
+}`
+
+	jsonRangeKeysStream = `{
+	"ID": "Ranges",
+	"Items": {
+		"ports": {
+			"type": "string",
+			"keys": ["integer"],
+			"data": {
+				"[0, 1023]": "system",
+				"53": "dns",
+				"[1024, 49151]": "registered"
+			}
+		},
+		"scores": {
+			"type": "string",
+			"keys": ["float"],
+			"data": {
+				"[0, 0.5]": "low",
+				"[0.5, 1]": "high"
+			}
+		},
+		"pools": {
+			"type": "string",
+			"keys": ["address"],
+			"data": {
+				"[192.0.2.10, 192.0.2.20]": "pool",
+				"192.0.2.0/24": "net"
+			}
+		}
+	}
+}`
+
+	jsonRangeKeysUpdateStream = `[
+  {
+    "op": "Add",
+    "path": ["ports", "[8000, 8999]"],
+    "entity": {
+      "type": "string",
+      "data": "web"
+    }
+  },
+  {
+    "op": "Delete",
+    "path": ["pools", "[192.0.2.10, 192.0.2.20]"]
+  }
+]`
+
+	jsonExpiresStream = `{
+	"ID": "Threats",
+	"Items": {
+		"blocked": {
+			"type": "string",
+			"keys": ["domain"],
+			"expires": {
+				"bad.example.com": "2000-01-01T00:00:00Z",
+				"*.ads.example.com": "2100-01-01T00:00:00Z"
+			},
+			"data": {
+				"bad.example.com": "malware",
+				"*.ads.example.com": "ads",
+				"good.example.com": "clean"
+			}
+		},
+		"campaign": {
+			"type": "string",
+			"expires": "2000-01-01T00:00:00Z",
+			"data": "autumn"
+		},
+		"tags": {
+			"type": {
+				"meta": "flags",
+				"name": "tags",
+				"flags": ["red", "green", "blue"]
+			},
+			"keys": ["string", "integer"],
+			"expires": {
+				"a": {"[1, 5]": "2000-01-01T00:00:00Z"}
+			},
+			"data": {
+				"a": {"[1, 5]": ["red"], "7": ["green", "blue"]}
+			}
+		}
+	}
+}`
+
+	jsonExpiresUpdateStream = `[
+  {
+    "op": "Add",
+    "path": ["blocked", "new.example.com"],
+    "entity": {
+      "type": "string",
+      "expires": "2100-01-01T00:00:00Z",
+      "data": "phishing"
+    }
+  },
+  {
+    "op": "Add",
+    "path": ["blocked", "old.example.com"],
+    "entity": {
+      "type": "string",
+      "expires": "2000-01-01T00:00:00Z",
+      "data": "phishing"
+    }
+  }
+]`
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -1867,6 +2065,42 @@ func TestUnmarshal(t *testing.T) {
 				}
 			}
 		}
+
+		lc, err = c.Get("time")
+		if err != nil {
+			t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		} else {
+			r, err := lc.Get(path, nil)
+			if err != nil {
+				t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+			} else {
+				e := "2018-06-15T09:30:00Z"
+				s, err := r.Serialize()
+				if err != nil {
+					t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+				} else if s != e {
+					t.Errorf("Expected %q but got %q", e, s)
+				}
+			}
+		}
+
+		lc, err = c.Get("duration")
+		if err != nil {
+			t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		} else {
+			r, err := lc.Get(path, nil)
+			if err != nil {
+				t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+			} else {
+				e := "1h30m0s"
+				s, err := r.Serialize()
+				if err != nil {
+					t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+				} else if s != e {
+					t.Errorf("Expected %q but got %q", e, s)
+				}
+			}
+		}
 	}
 
 	c, err = Unmarshal(strings.NewReader(jsonPostprocessAllValuesStream), nil)
@@ -2054,6 +2288,87 @@ func TestUnmarshal(t *testing.T) {
 				}
 			}
 		}
+
+		lc, err = c.Get("time")
+		if err != nil {
+			t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		} else {
+			r, err := lc.Get(path, nil)
+			if err != nil {
+				t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+			} else {
+				e := "2018-06-15T09:30:00Z"
+				s, err := r.Serialize()
+				if err != nil {
+					t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+				} else if s != e {
+					t.Errorf("Expected %q but got %q", e, s)
+				}
+			}
+		}
+
+		lc, err = c.Get("duration")
+		if err != nil {
+			t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		} else {
+			r, err := lc.Get(path, nil)
+			if err != nil {
+				t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+			} else {
+				e := "1h30m0s"
+				s, err := r.Serialize()
+				if err != nil {
+					t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+				} else if s != e {
+					t.Errorf("Expected %q but got %q", e, s)
+				}
+			}
+		}
+	}
+}
+
+func TestUnmarshalCustomTypes(t *testing.T) {
+	c, err := Unmarshal(strings.NewReader(jsonCustomTypesStream), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertCustomTypeValue(t, c, "level", []pdp.Expression{pdp.MakeStringValue("example.com")}, "high")
+	assertCustomTypeValue(t, c, "devices", []pdp.Expression{pdp.MakeStringValue("router")}, "\"core\",\"high\",\"10\"")
+	assertCustomTypeValue(t, c, "devices", []pdp.Expression{pdp.MakeStringValue("switch")}, "\"edge\",\"low\",\"3\"")
+	assertCustomTypeValue(t, c, "default", nil, "\"default\",\"low\",\"0\"")
+
+	_, err = Unmarshal(strings.NewReader(jsonInvalidEnumStream), nil)
+	if err == nil {
+		t.Errorf("Expected error for invalid enum value but got nothing")
+	}
+
+	_, err = Unmarshal(strings.NewReader(jsonMissingStructFieldStream), nil)
+	if err == nil {
+		t.Errorf("Expected *missingStructFieldError but got nothing")
+	} else if !strings.Contains(err.Error(), "Missing value for struct field \"weight\"") {
+		t.Errorf("Expected *missingStructFieldError but got %T (%s)", err, err)
+	}
+}
+
+func assertCustomTypeValue(t *testing.T, c *pdp.LocalContent, id string, path []pdp.Expression, e string) {
+	lc, err := c.Get(id)
+	if err != nil {
+		t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		return
+	}
+
+	r, err := lc.Get(path, nil)
+	if err != nil {
+		t.Errorf("Expected no error for %q but got (%T):\n\t%s", id, err, err)
+		return
+	}
+
+	s, err := r.Serialize()
+	if err != nil {
+		t.Errorf("Expected no error for %q but got (%T):\n\t%s", id, err, err)
+	} else if s != e {
+		t.Errorf("Expected [%s] for %q but got [%s]", e, id, s)
 	}
 }
 
@@ -2149,3 +2464,405 @@ func makeTestDN(t *testing.T, s string) domain.Name {
 
 	return d
 }
+
+func TestUnmarshalRangeKeys(t *testing.T) {
+	tag := uuid.New()
+	c, err := Unmarshal(strings.NewReader(jsonRangeKeysStream), &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertCustomTypeValue(t, c, "ports", []pdp.Expression{pdp.MakeIntegerValue(53)}, "dns")
+	assertCustomTypeValue(t, c, "ports", []pdp.Expression{pdp.MakeIntegerValue(8080)}, "registered")
+	assertCustomTypeValue(t, c, "scores", []pdp.Expression{pdp.MakeFloatValue(0.5)}, "high")
+	assertCustomTypeValue(t, c, "pools", []pdp.Expression{pdp.MakeAddressValue(net.ParseIP("192.0.2.15"))}, "pool")
+	assertCustomTypeValue(t, c, "pools", []pdp.Expression{pdp.MakeAddressValue(net.ParseIP("192.0.2.21"))}, "net")
+
+	s := pdp.NewLocalContentStorage([]*pdp.LocalContent{c})
+	tr, err := s.NewTransaction("Ranges", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	newTag := uuid.New()
+	u, err := UnmarshalUpdate(strings.NewReader(jsonRangeKeysUpdateStream), "Ranges", tag, newTag, tr.Symbols())
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	s, err = tr.Commit(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	c, err = s.GetLocalContent("Ranges", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertCustomTypeValue(t, c, "ports", []pdp.Expression{pdp.MakeIntegerValue(8080)}, "web")
+	assertCustomTypeValue(t, c, "pools", []pdp.Expression{pdp.MakeAddressValue(net.ParseIP("192.0.2.15"))}, "net")
+
+	_, err = Unmarshal(strings.NewReader(`{"ID": "Ranges", "Items": {"ports": {
+		"type": "string", "keys": ["integer"], "data": {"[10, 1]": "invalid"}
+	}}}`), nil)
+	if err == nil {
+		t.Errorf("Expected error for invalid range but got nothing")
+	}
+}
+
+func TestUnmarshalExpires(t *testing.T) {
+	tag := uuid.New()
+	c, err := Unmarshal(strings.NewReader(jsonExpiresStream), &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertDomainMatchValue(t, c, "blocked", "bad.example.com", "")
+	assertDomainMatchValue(t, c, "blocked", "x.ads.example.com", "ads")
+	assertDomainMatchValue(t, c, "blocked", "good.example.com", "clean")
+	assertCustomTypeValue(t, c, "tags", []pdp.Expression{pdp.MakeStringValue("a"), pdp.MakeIntegerValue(7)},
+		"\"green\",\"blue\"")
+	assertMissingValue(t, c, "tags", []pdp.Expression{pdp.MakeStringValue("a"), pdp.MakeIntegerValue(3)})
+	assertMissingValue(t, c, "campaign", nil)
+
+	s := pdp.NewLocalContentStorage([]*pdp.LocalContent{c})
+	tr, err := s.NewTransaction("Threats", &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	newTag := uuid.New()
+	u, err := UnmarshalUpdate(strings.NewReader(jsonExpiresUpdateStream), "Threats", tag, newTag, tr.Symbols())
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	if err := tr.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	s, err = tr.Commit(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	c, err = s.GetLocalContent("Threats", &newTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertDomainMatchValue(t, c, "blocked", "new.example.com", "phishing")
+	assertDomainMatchValue(t, c, "blocked", "old.example.com", "")
+
+	sc, n := c.Sweep(time.Now())
+	if n != 4 {
+		t.Errorf("Expected 4 swept entries but got %d", n)
+	}
+
+	assertDomainMatchValue(t, sc, "blocked", "new.example.com", "phishing")
+	assertDomainMatchValue(t, sc, "blocked", "good.example.com", "clean")
+	if _, err := sc.Get("campaign"); err == nil {
+		t.Errorf("Expected no \"campaign\" item after sweep")
+	}
+
+	_, err = Unmarshal(strings.NewReader(`{"ID": "Threats", "Items": {"blocked": {
+		"type": "string", "keys": ["string"], "data": {"a": "x"}, "expires": {"a": "2100-01-01T00:00:00Z"}
+	}}}`), nil)
+	if err == nil || !strings.Contains(err.Error(), "should precede") {
+		t.Errorf("Expected error for expiration of keys after data but got %v", err)
+	}
+
+	_, err = Unmarshal(strings.NewReader(`{"ID": "Threats", "Items": {"blocked": {
+		"type": "string", "expires": 1, "data": "x"
+	}}}`), nil)
+	if err == nil {
+		t.Errorf("Expected error for invalid expiration but got nothing")
+	}
+}
+
+func assertMissingValue(t *testing.T, c *pdp.LocalContent, id string, path []pdp.Expression) {
+	lc, err := c.Get(id)
+	if err != nil {
+		t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		return
+	}
+
+	if r, err := lc.Get(path, nil); err == nil {
+		t.Errorf("Expected no value for %q but got %#v", id, r)
+	}
+}
+
+func TestUnmarshalDomainMatch(t *testing.T) {
+	c, err := Unmarshal(strings.NewReader(`{
+	"ID": "Domains",
+	"Items": {
+		"blocked": {
+			"type": "string",
+			"keys": ["domain"],
+			"data": {
+				"example.com": "all",
+				"*.ads.example.com": "ads",
+				"!mail.example.com": null
+			}
+		},
+		"exact": {
+			"type": "string",
+			"keys": ["domain"],
+			"match": "exact",
+			"data": {
+				"example.com": "apex",
+				"*.example.com": "sub"
+			}
+		},
+		"deferred": {
+			"data": {
+				"!test.example.com": null,
+				"example.com": ["red"]
+			},
+			"match": "subdomains",
+			"keys": ["domain"],
+			"type": {
+				"meta": "flags",
+				"name": "colors",
+				"flags": ["red", "green"]
+			}
+		}
+	}
+}`), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	assertDomainMatchValue(t, c, "blocked", "www.example.com", "all")
+	assertDomainMatchValue(t, c, "blocked", "ads.example.com", "all")
+	assertDomainMatchValue(t, c, "blocked", "x.ads.example.com", "ads")
+	assertDomainMatchValue(t, c, "blocked", "mail.example.com", "")
+	assertDomainMatchValue(t, c, "exact", "example.com", "apex")
+	assertDomainMatchValue(t, c, "exact", "www.example.com", "sub")
+	assertDomainMatchValue(t, c, "deferred", "example.com", "")
+	assertDomainMatchValue(t, c, "deferred", "www.example.com", "\"red\"")
+	assertDomainMatchValue(t, c, "deferred", "test.example.com", "\"red\"")
+	assertDomainMatchValue(t, c, "deferred", "x.test.example.com", "")
+
+	_, err = Unmarshal(strings.NewReader(`{"ID": "Domains", "Items": {"late": {
+		"type": "string", "keys": ["domain"], "data": {"example.com": "x"}, "match": "exact"
+	}}}`), nil)
+	if err == nil || !strings.Contains(err.Error(), "Field \"match\" should precede \"data\"") {
+		t.Errorf("Expected *domainMatchAfterDataError but got %v", err)
+	}
+}
+
+func assertDomainMatchValue(t *testing.T, c *pdp.LocalContent, id, s, e string) {
+	dn, err := domain.MakeNameFromString(s)
+	if err != nil {
+		t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+	}
+
+	if len(e) > 0 {
+		assertCustomTypeValue(t, c, id, []pdp.Expression{pdp.MakeDomainValue(dn)}, e)
+		return
+	}
+
+	lc, err := c.Get(id)
+	if err != nil {
+		t.Errorf("Expected no error but got (%T):\n\t%s", err, err)
+		return
+	}
+
+	if r, err := lc.Get([]pdp.Expression{pdp.MakeDomainValue(dn)}, nil); err == nil {
+		t.Errorf("Expected no value for %q in %q but got %#v", s, id, r)
+	}
+}
+
+func TestUnmarshalStream(t *testing.T) {
+	const s = `{
+	"ID": "Test",
+	"Items": {
+		"streamed": {
+			"type": "string",
+			"keys": ["string", "string"],
+			"data": {
+				"a": {"x": "1", "y": "2"},
+				"b": {"z": "3"}
+			}
+		},
+		"deferred": {
+			"data": {
+				"c": "4",
+				"d": "5"
+			},
+			"keys": ["string"],
+			"type": "string"
+		}
+	}
+}`
+
+	var reports []Progress
+	c, p, err := UnmarshalStream(strings.NewReader(s), nil, WithProgress(func(p Progress) {
+		reports = append(reports, p)
+	}, 2))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("Expected content but got nothing")
+	}
+
+	size := int64(12 + 7*keyMemoryOverhead)
+	if p.Items != 2 || p.Keys != 7 || p.Size != size {
+		t.Errorf("Expected 2 items, 7 keys and size %d but got %#v", size, p)
+	}
+
+	if len(reports) != 5 {
+		t.Errorf("Expected 5 progress reports but got %#v", reports)
+	} else if reports[4] != p {
+		t.Errorf("Expected last report %#v but got %#v", p, reports[4])
+	}
+
+	_, p, err = UnmarshalStream(strings.NewReader(s), nil, WithMemoryLimit(200))
+	if err == nil {
+		t.Errorf("Expected memory limit error but got nothing")
+	} else if _, ok := err.(*memoryLimitExceededError); !ok {
+		t.Errorf("Expected *memoryLimitExceededError but got %T (%s)", err, err)
+	} else if p.Items != 0 {
+		t.Errorf("Expected to stop at first item but got %#v", p)
+	}
+}
+
+func TestUnmarshalStreamDeferredMemory(t *testing.T) {
+	pairs := make([]string, 20000)
+	for i := range pairs {
+		pairs[i] = fmt.Sprintf("\"key-%08d\": \"value-%08d\"", i, i)
+	}
+	data := "{" + strings.Join(pairs, ", ") + "}"
+
+	streamed := `{"ID": "Test", "Items": {"x": {"type": "string", "keys": ["string"], "data": ` + data + `}}}`
+	sPeak := measureUnmarshalStreamPeak(t, streamed)
+
+	deferred := `{"ID": "Test", "Items": {"x": {"data": ` + data + `, "type": "string", "keys": ["string"]}}}`
+	dPeak := measureUnmarshalStreamPeak(t, deferred)
+
+	if dPeak > sPeak+uint64(len(deferred))/4 {
+		t.Errorf("Expected peak memory for data before type and keys close to %d but got %d (input size %d)",
+			sPeak, dPeak, len(deferred))
+	}
+
+	_, p, err := UnmarshalStream(strings.NewReader(deferred), nil, WithMemoryLimit(rawChunkSize/2))
+	if err == nil {
+		t.Errorf("Expected memory limit error but got nothing")
+	} else if _, ok := err.(*memoryLimitExceededError); !ok {
+		t.Errorf("Expected *memoryLimitExceededError but got %T (%s)", err, err)
+	} else if p.Keys != 0 {
+		t.Errorf("Expected to stop before any key but got %#v", p)
+	}
+}
+
+func measureUnmarshalStreamPeak(t *testing.T, s string) uint64 {
+	var ms runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&ms)
+	base := ms.HeapAlloc
+
+	peak := uint64(0)
+	measure := func(p Progress) {
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		if ms.HeapAlloc > base+peak {
+			peak = ms.HeapAlloc - base
+		}
+	}
+
+	c, _, err := UnmarshalStream(strings.NewReader(s), nil, WithProgress(measure, 1000))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	runtime.KeepAlive(c)
+	return peak
+}
+
+func TestContentSnapshot(t *testing.T) {
+	for _, s := range []string{
+		jsonStream,
+		jsonAllMapsStream,
+		jsonAllValuesStream,
+		jsonCustomTypesStream,
+		jsonRangeKeysStream,
+		jsonExpiresStream,
+		`{"ID": "Domains", "Items": {
+			"blocked": {"type": "string", "keys": ["domain"], "data": {
+				"example.com": "all", "*.ads.example.com": "ads", "!mail.example.com": null
+			}},
+			"exact": {"type": "string", "keys": ["string", "domain"], "match": "exact", "data": {
+				"x": {"example.com": "apex", "*.example.com": "sub"}
+			}}
+		}}`,
+	} {
+		c, err := Unmarshal(strings.NewReader(s), nil)
+		if err != nil {
+			t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+		}
+
+		b := new(bytes.Buffer)
+		if err := pdp.WriteContentSnapshot(b, c); err != nil {
+			t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+		}
+
+		snapshot := b.Bytes()
+		tag := uuid.New()
+		c, p, err := UnmarshalStream(bytes.NewReader(snapshot), &tag)
+		if err != nil {
+			t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+		}
+
+		if p.Items <= 0 || p.Size <= 0 {
+			t.Errorf("Expected progress of snapshot loading but got %#v", p)
+		}
+
+		b = new(bytes.Buffer)
+		if err := pdp.WriteContentJSON(b, c); err != nil {
+			t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+		}
+
+		c, err = Unmarshal(b, nil)
+		if err != nil {
+			t.Fatalf("Expected no error for JCON written from snapshot but got (%T):\n\t%s", err, err)
+		}
+
+		b = new(bytes.Buffer)
+		if err := pdp.WriteContentSnapshot(b, c); err != nil {
+			t.Fatalf("Expected no error but got (%T):\n\t%s", err, err)
+		}
+
+		if !bytes.Equal(b.Bytes(), snapshot) {
+			t.Errorf("Expected the same snapshot after conversion to JCON and back for:\n%s", s)
+		}
+
+		switch c.GetID() {
+		case "Ranges":
+			assertCustomTypeValue(t, c, "ports", []pdp.Expression{pdp.MakeIntegerValue(53)}, "dns")
+			assertCustomTypeValue(t, c, "scores", []pdp.Expression{pdp.MakeFloatValue(0.5)}, "high")
+			assertCustomTypeValue(t, c, "pools", []pdp.Expression{pdp.MakeAddressValue(net.ParseIP("192.0.2.15"))}, "pool")
+
+		case "Domains":
+			assertDomainMatchValue(t, c, "blocked", "x.ads.example.com", "ads")
+			assertDomainMatchValue(t, c, "blocked", "mail.example.com", "")
+
+		case "Threats":
+			assertDomainMatchValue(t, c, "blocked", "bad.example.com", "")
+			assertDomainMatchValue(t, c, "blocked", "x.ads.example.com", "ads")
+			assertMissingValue(t, c, "campaign", nil)
+		}
+	}
+
+	_, _, err := UnmarshalStream(bytes.NewReader(pdp.ContentSnapshotMagic), nil)
+	if err == nil {
+		t.Errorf("Expected error for truncated snapshot but got nothing")
+	}
+}
@@ -30,6 +30,10 @@ func (f functionBooleanNot) describe() string {
 	return "not"
 }
 
+func (f functionBooleanNot) arguments() []Expression {
+	return []Expression{f.arg}
+}
+
 func (f functionBooleanNot) Calculate(ctx *Context) (AttributeValue, error) {
 	a, err := ctx.calculateBooleanExpression(f.arg)
 	if err != nil {
@@ -63,6 +67,10 @@ func (f functionBooleanOr) describe() string {
 	return "or"
 }
 
+func (f functionBooleanOr) arguments() []Expression {
+	return f.args
+}
+
 func (f functionBooleanOr) Calculate(ctx *Context) (AttributeValue, error) {
 	for i, arg := range f.args {
 		a, err := ctx.calculateBooleanExpression(arg)
@@ -108,6 +116,10 @@ func (f functionBooleanAnd) describe() string {
 	return "and"
 }
 
+func (f functionBooleanAnd) arguments() []Expression {
+	return f.args
+}
+
 func (f functionBooleanAnd) Calculate(ctx *Context) (AttributeValue, error) {
 	for i, arg := range f.args {
 		a, err := ctx.calculateBooleanExpression(arg)
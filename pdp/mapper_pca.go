@@ -15,6 +15,8 @@ type mapperPCA struct {
 	policies  *strtree.Tree
 	def       Evaluable
 	err       Evaluable
+	defID     string
+	errID     string
 	order     int
 	algorithm PolicyCombiningAlg
 }
@@ -101,6 +103,16 @@ func collectSubPolicies(IDs []string, m *strtree.Tree) []Evaluable {
 	return policies
 }
 
+// mapperChildID returns id of default or error child given to mapper maker
+// or empty string if the id isn't set.
+func mapperChildID(ID string, ok bool) string {
+	if ok {
+		return ID
+	}
+
+	return ""
+}
+
 func makeMapperPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
 	mapperParams, ok := params.(MapperPCAParams)
 	if !ok {
@@ -137,6 +149,8 @@ func makeMapperPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg
 			policies:  m,
 			def:       def,
 			err:       err,
+			defID:     mapperChildID(mapperParams.Def, mapperParams.DefOk),
+			errID:     mapperChildID(mapperParams.Err, mapperParams.ErrOk),
 			order:     mapperParams.Order,
 			algorithm: mapperParams.Algorithm,
 		}
@@ -175,6 +189,8 @@ func makeMapperPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg
 		policies:  m,
 		def:       def,
 		err:       err,
+		defID:     mapperChildID(mapperParams.Def, mapperParams.DefOk),
+		errID:     mapperChildID(mapperParams.Err, mapperParams.ErrOk),
 		order:     mapperParams.Order,
 		algorithm: mapperParams.Algorithm}
 }
@@ -204,7 +220,7 @@ func (a mapperPCA) calculateErrorPolicy(ctx *Context, err error) Response {
 		return a.err.Calculate(ctx)
 	}
 
-	return Response{EffectIndeterminate, bindError(err, a.describe()), nil}
+	return Response{EffectIndeterminate, bindError(err, a.describe()), nil, nil}
 }
 
 func (a mapperPCA) getPoliciesMap(policies []Evaluable) *strtree.Tree {
@@ -244,6 +260,8 @@ func (a mapperPCA) add(ID string, child, old Evaluable) PolicyCombiningAlg {
 		policies:  a.policies.Insert(ID, child),
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm}
 }
@@ -272,6 +290,8 @@ func (a mapperPCA) del(ID string, old Evaluable) PolicyCombiningAlg {
 		policies:  policies,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm}
 }
@@ -329,5 +349,5 @@ func (a mapperPCA) execute(policies []Evaluable, ctx *Context) Response {
 		return a.def.Calculate(ctx)
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
 }
@@ -15,6 +15,8 @@ type mapperRCA struct {
 	rules     *strtree.Tree
 	def       *Rule
 	err       *Rule
+	defID     string
+	errID     string
 	order     int
 	algorithm RuleCombiningAlg
 }
@@ -167,6 +169,8 @@ func makeMapperRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
 			rules:     m,
 			def:       def,
 			err:       err,
+			defID:     mapperChildID(mapperParams.Def, mapperParams.DefOk),
+			errID:     mapperChildID(mapperParams.Err, mapperParams.ErrOk),
 			order:     mapperParams.Order,
 			algorithm: mapperParams.Algorithm,
 		}
@@ -205,6 +209,8 @@ func makeMapperRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
 		rules:     m,
 		def:       def,
 		err:       err,
+		defID:     mapperChildID(mapperParams.Def, mapperParams.DefOk),
+		errID:     mapperChildID(mapperParams.Err, mapperParams.ErrOk),
 		order:     mapperParams.Order,
 		algorithm: mapperParams.Algorithm,
 	}
@@ -235,7 +241,7 @@ func (a mapperRCA) calculateErrorRule(ctx *Context, err error) Response {
 		return a.err.calculate(ctx)
 	}
 
-	return Response{EffectIndeterminate, bindError(err, a.describe()), nil}
+	return Response{EffectIndeterminate, bindError(err, a.describe()), nil, nil}
 }
 
 func (a mapperRCA) getRulesMap(rules []*Rule) *strtree.Tree {
@@ -275,6 +281,8 @@ func (a mapperRCA) add(ID string, child, old *Rule) RuleCombiningAlg {
 		rules:     a.rules.Insert(ID, child),
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm}
 }
@@ -303,6 +311,8 @@ func (a mapperRCA) del(ID string, old *Rule) RuleCombiningAlg {
 		rules:     rules,
 		def:       def,
 		err:       err,
+		defID:     a.defID,
+		errID:     a.errID,
 		order:     a.order,
 		algorithm: a.algorithm}
 }
@@ -360,5 +370,5 @@ func (a mapperRCA) execute(rules []*Rule, ctx *Context) Response {
 		return a.def.calculate(ctx)
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
 }
@@ -1329,3 +1329,52 @@ func TestFunctionArgumentValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestModMinMaxAbs(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  AttributeValue
+		err  bool
+	}{
+		{name: "mod", args: []Expression{MakeIntegerValue(7), MakeIntegerValue(3)}, res: MakeIntegerValue(1)},
+		{name: "mod", args: []Expression{MakeIntegerValue(-7), MakeIntegerValue(3)}, res: MakeIntegerValue(-1)},
+		{name: "mod", args: []Expression{MakeIntegerValue(7), MakeIntegerValue(0)}, err: true},
+		{name: "mod", args: []Expression{MakeFloatValue(7.5), MakeIntegerValue(2)}, res: MakeFloatValue(1.5)},
+		{name: "mod", args: []Expression{MakeFloatValue(7.5), MakeFloatValue(0)}, err: true},
+		{name: "min", args: []Expression{MakeIntegerValue(7), MakeIntegerValue(3)}, res: MakeIntegerValue(3)},
+		{name: "min", args: []Expression{MakeIntegerValue(7), MakeFloatValue(7.5)}, res: MakeFloatValue(7)},
+		{name: "max", args: []Expression{MakeIntegerValue(7), MakeIntegerValue(3)}, res: MakeIntegerValue(7)},
+		{name: "max", args: []Expression{MakeFloatValue(-1.5), MakeFloatValue(-2.5)}, res: MakeFloatValue(-1.5)},
+		{name: "abs", args: []Expression{MakeIntegerValue(-7)}, res: MakeIntegerValue(7)},
+		{name: "abs", args: []Expression{MakeIntegerValue(-9223372036854775808)}, err: true},
+		{name: "abs", args: []Expression{MakeFloatValue(-7.5)}, res: MakeFloatValue(7.5)},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%d: Expected error for %q but got %s", i, tc.name, v.describe())
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+		} else if v.describe() != tc.res.describe() {
+			t.Errorf("%d: Expected %s for %q but got %s", i, tc.res.describe(), tc.name, v.describe())
+		}
+	}
+}
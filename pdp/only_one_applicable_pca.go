@@ -0,0 +1,56 @@
+package pdp
+
+import "encoding/json"
+
+type onlyOneApplicablePCA struct {
+}
+
+func makeOnlyOneApplicablePCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return onlyOneApplicablePCAInstance
+}
+
+func (onlyOneApplicablePCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "onlyOneApplicablePCA",
+	})
+}
+
+func (a onlyOneApplicablePCA) describe() string {
+	return "only one applicable"
+}
+
+func (a onlyOneApplicablePCA) execute(policies []Evaluable, ctx *Context) Response {
+	var (
+		res     Response
+		applied Evaluable
+	)
+
+	for _, p := range policies {
+		r := p.Calculate(ctx)
+		if r.Effect == EffectNotApplicable {
+			continue
+		}
+
+		if r.Effect != EffectDeny && r.Effect != EffectPermit {
+			return Response{EffectIndeterminateDP, bindError(r.Status, a.describe()), nil, nil}
+		}
+
+		if applied != nil {
+			return Response{
+				EffectIndeterminateDP,
+				bindError(newTooManyApplicableError(applied.describe(), p.describe()), a.describe()),
+				nil,
+				nil,
+			}
+		}
+
+		res = r
+		applied = p
+	}
+
+	if applied == nil {
+		return Response{EffectNotApplicable, nil, nil, nil}
+	}
+
+	return res
+}
@@ -0,0 +1,30 @@
+package pdp
+
+import "testing"
+
+func TestOnlyOneApplicablePCA(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	policies := []Evaluable{
+		makeSimplePolicy("first"),
+		makeSimplePolicy("second", makeSimpleRule("deny", EffectDeny)),
+	}
+	r := makeOnlyOneApplicablePCA(policies, nil).execute(policies, ctx)
+	if r.Effect != EffectDeny {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectDeny], effectNames[r.Effect], r.Status)
+	}
+
+	policies = []Evaluable{
+		makeSimplePolicy("first", makeSimpleRule("permit", EffectPermit)),
+		makeSimplePolicy("second", makeSimpleRule("deny", EffectDeny)),
+	}
+	r = makeOnlyOneApplicablePCA(policies, nil).execute(policies, ctx)
+	if r.Effect != EffectIndeterminateDP {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectIndeterminateDP], effectNames[r.Effect], r.Status)
+	} else if _, ok := r.Status.(*tooManyApplicableError); !ok {
+		t.Errorf("Expected *tooManyApplicableError but got %T (%s)", r.Status, r.Status)
+	}
+}
@@ -0,0 +1,56 @@
+package pdp
+
+import "encoding/json"
+
+type onlyOneApplicableRCA struct {
+}
+
+func makeOnlyOneApplicableRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return onlyOneApplicableRCAInstance
+}
+
+func (onlyOneApplicableRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "onlyOneApplicableRCA",
+	})
+}
+
+func (a onlyOneApplicableRCA) describe() string {
+	return "only one applicable"
+}
+
+func (a onlyOneApplicableRCA) execute(rules []*Rule, ctx *Context) Response {
+	var (
+		res     Response
+		applied *Rule
+	)
+
+	for _, rule := range rules {
+		r := rule.calculate(ctx)
+		if r.Effect == EffectNotApplicable {
+			continue
+		}
+
+		if r.Effect != EffectDeny && r.Effect != EffectPermit {
+			return Response{EffectIndeterminateDP, bindError(r.Status, a.describe()), nil, nil}
+		}
+
+		if applied != nil {
+			return Response{
+				EffectIndeterminateDP,
+				bindError(newTooManyApplicableError(applied.describe(), rule.describe()), a.describe()),
+				nil,
+				nil,
+			}
+		}
+
+		res = r
+		applied = rule
+	}
+
+	if applied == nil {
+		return Response{EffectNotApplicable, nil, nil, nil}
+	}
+
+	return res
+}
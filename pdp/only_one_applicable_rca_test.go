@@ -0,0 +1,45 @@
+package pdp
+
+import "testing"
+
+func TestOnlyOneApplicableRCA(t *testing.T) {
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "x", MakeStringValue("example"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	rules := []*Rule{
+		makeSimpleRule("first", EffectPermit),
+		NewRule("second", false, makeSimpleStringTarget("x", "test"), nil, EffectDeny, nil),
+	}
+	r := makeOnlyOneApplicableRCA(rules, nil).execute(rules, ctx)
+	if r.Effect != EffectPermit {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectPermit], effectNames[r.Effect], r.Status)
+	}
+
+	rules = []*Rule{
+		makeSimpleRule("first", EffectPermit),
+		makeSimpleRule("second", EffectDeny),
+	}
+	r = makeOnlyOneApplicableRCA(rules, nil).execute(rules, ctx)
+	if r.Effect != EffectIndeterminateDP {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectIndeterminateDP], effectNames[r.Effect], r.Status)
+	} else if _, ok := r.Status.(*tooManyApplicableError); !ok {
+		t.Errorf("Expected *tooManyApplicableError but got %T (%s)", r.Status, r.Status)
+	}
+
+	rules = []*Rule{
+		makeErrorRule("first", EffectPermit),
+	}
+	r = makeOnlyOneApplicableRCA(rules, nil).execute(rules, ctx)
+	if r.Effect != EffectIndeterminateDP {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectIndeterminateDP], effectNames[r.Effect], r.Status)
+	}
+
+	r = makeOnlyOneApplicableRCA(nil, nil).execute(nil, ctx)
+	if r.Effect != EffectNotApplicable {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectNotApplicable], effectNames[r.Effect], r.Status)
+	}
+}
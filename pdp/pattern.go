@@ -0,0 +1,152 @@
+package pdp
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/infobloxopen/go-trees/domain"
+)
+
+type patternCompiler func(p string) (*regexp.Regexp, error)
+
+func compileRegexpPattern(p string) (*regexp.Regexp, error) {
+	r, err := regexp.Compile(p)
+	if err != nil {
+		return nil, newInvalidRegexpPatternError(p, err)
+	}
+
+	return r, nil
+}
+
+func compileGlobPattern(p string) (*regexp.Regexp, error) {
+	s, ok := globToRegexp(p)
+	if !ok {
+		return nil, newInvalidGlobPatternError(p)
+	}
+
+	r, err := regexp.Compile(s)
+	if err != nil {
+		return nil, newInvalidGlobPatternError(p)
+	}
+
+	return r, nil
+}
+
+// globToRegexp translates shell-like glob pattern to regular expression.
+// Asterisk matches any sequence of characters (including empty one), question
+// mark matches exactly one character, square brackets define character class
+// (exclamation mark or caret at the beginning negates the class) and
+// backslash escapes next character.
+func globToRegexp(p string) (string, bool) {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+
+		case '*':
+			b.WriteString(".*")
+
+		case '?':
+			b.WriteByte('.')
+
+		case '\\':
+			i++
+			if i >= len(p) {
+				return "", false
+			}
+
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+
+		case '[':
+			j := i + 1
+			neg := false
+			if j < len(p) && (p[j] == '!' || p[j] == '^') {
+				neg = true
+				j++
+			}
+
+			start := j
+			if j < len(p) && p[j] == ']' {
+				j++
+			}
+
+			for j < len(p) && p[j] != ']' {
+				j++
+			}
+
+			if j >= len(p) || j <= start {
+				return "", false
+			}
+
+			b.WriteByte('[')
+			if neg {
+				b.WriteByte('^')
+			}
+
+			for k := start; k < j; k++ {
+				if c := p[k]; c == '\\' || c == '[' || c == ']' || c == '^' {
+					b.WriteByte('\\')
+				}
+
+				b.WriteByte(p[k])
+			}
+
+			b.WriteByte(']')
+			i = j
+		}
+	}
+
+	b.WriteByte('$')
+	return b.String(), true
+}
+
+// precompilePattern compiles pattern if it's given as an immediate value of
+// string type. It returns nil if the pattern can be calculated only for
+// particular request.
+func precompilePattern(e Expression, compile patternCompiler) (*regexp.Regexp, error) {
+	v, ok := e.(AttributeValue)
+	if !ok {
+		return nil, nil
+	}
+
+	s, err := v.str()
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(s)
+}
+
+func (c *Context) calculatePatternExpression(e Expression, r *regexp.Regexp, compile patternCompiler) (*regexp.Regexp, error) {
+	if r != nil {
+		return r, nil
+	}
+
+	s, err := c.calculateStringExpression(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(s)
+}
+
+// domainPatternString returns domain name in form suitable for pattern
+// matching (lower case and without trailing dot).
+func domainPatternString(d domain.Name) string {
+	return strings.ToLower(strings.TrimSuffix(d.String(), "."))
+}
+
+// getDomainLabels returns domain name labels in canonical form starting from
+// top level domain.
+func getDomainLabels(d domain.Name) []string {
+	var labels []string
+	d.GetLabels(func(label string) error {
+		labels = append(labels, label)
+		return nil
+	})
+
+	return labels
+}
@@ -0,0 +1,212 @@
+package pdp
+
+import "testing"
+
+func TestGlobToRegexp(t *testing.T) {
+	testCases := []struct {
+		p  string
+		s  string
+		ok bool
+		m  bool
+	}{
+		{p: "curl/*", s: "curl/7.58.0", ok: true, m: true},
+		{p: "curl/*", s: "wget/1.19", ok: true, m: false},
+		{p: "*.example.com", s: "www.example.com", ok: true, m: true},
+		{p: "*.example.com", s: "example.com", ok: true, m: false},
+		{p: "ad?.example.com", s: "ad1.example.com", ok: true, m: true},
+		{p: "ad?.example.com", s: "ad12.example.com", ok: true, m: false},
+		{p: "ad[0-9].com", s: "ad7.com", ok: true, m: true},
+		{p: "ad[!0-9].com", s: "ad7.com", ok: true, m: false},
+		{p: "ad[^0-9].com", s: "adx.com", ok: true, m: true},
+		{p: "[]]", s: "]", ok: true, m: true},
+		{p: "\\*", s: "*", ok: true, m: true},
+		{p: "\\*", s: "x", ok: true, m: false},
+		{p: "a+b(c)", s: "a+b(c)", ok: true, m: true},
+		{p: "ad[0-9", ok: false},
+		{p: "ad\\", ok: false},
+		{p: "[]", ok: false},
+	}
+
+	for _, tc := range testCases {
+		r, err := compileGlobPattern(tc.p)
+		if !tc.ok {
+			if err == nil {
+				t.Errorf("Expected error for glob %q but got regular expression %q", tc.p, r)
+			} else if _, ok := err.(*invalidGlobPatternError); !ok {
+				t.Errorf("Expected *invalidGlobPatternError for glob %q but got %T (%s)", tc.p, err, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Expected no error for glob %q but got %s", tc.p, err)
+			continue
+		}
+
+		if m := r.MatchString(tc.s); m != tc.m {
+			t.Errorf("Expected %v for glob %q and %q but got %v", tc.m, tc.p, tc.s, m)
+		}
+	}
+}
+
+func TestPatternFunctions(t *testing.T) {
+	ctx, err := NewContext(nil, 2, func(i int) (string, AttributeValue, error) {
+		if i == 0 {
+			return "p", MakeStringValue("^ad[0-9]+\\."), nil
+		}
+
+		return "s", MakeStringValue("curl/7.58.0"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	dynPattern := MakeAttributeDesignator(MakeAttribute("p", TypeString))
+	str := MakeAttributeDesignator(MakeAttribute("s", TypeString))
+
+	testCases := []struct {
+		name string
+		args []Expression
+		res  bool
+	}{
+		{
+			name: "match",
+			args: []Expression{str, MakeStringValue("^curl/[0-9.]+$")},
+			res:  true,
+		},
+		{
+			name: "match",
+			args: []Expression{MakeDomainValue(makeTestDomain("AD12.Example.com")), dynPattern},
+			res:  true,
+		},
+		{
+			name: "match",
+			args: []Expression{MakeDomainValue(makeTestDomain("www.example.com")), MakeStringValue("^ad[0-9]+$")},
+			res:  false,
+		},
+		{
+			name: "glob",
+			args: []Expression{str, MakeStringValue("curl/*")},
+			res:  true,
+		},
+		{
+			name: "glob",
+			args: []Expression{MakeDomainValue(makeTestDomain("www.Example.com.")), MakeStringValue("*.example.com")},
+			res:  true,
+		},
+		{
+			name: "starts-with",
+			args: []Expression{str, MakeStringValue("curl/")},
+			res:  true,
+		},
+		{
+			name: "starts-with",
+			args: []Expression{MakeDomainValue(makeTestDomain("www.example.com")), MakeDomainValue(makeTestDomain("WWW.example"))},
+			res:  true,
+		},
+		{
+			name: "starts-with",
+			args: []Expression{MakeDomainValue(makeTestDomain("www.example.com")), MakeDomainValue(makeTestDomain("ww"))},
+			res:  false,
+		},
+		{
+			name: "ends-with",
+			args: []Expression{str, MakeStringValue(".0")},
+			res:  true,
+		},
+		{
+			name: "ends-with",
+			args: []Expression{MakeDomainValue(makeTestDomain("www.example.com")), MakeDomainValue(makeTestDomain("example.com"))},
+			res:  true,
+		},
+		{
+			name: "ends-with",
+			args: []Expression{MakeDomainValue(makeTestDomain("example.com")), MakeDomainValue(makeTestDomain("example.com"))},
+			res:  true,
+		},
+		{
+			name: "ends-with",
+			args: []Expression{MakeDomainValue(makeTestDomain("badexample.com")), MakeDomainValue(makeTestDomain("example.com"))},
+			res:  false,
+		},
+	}
+
+	for i, tc := range testCases {
+		e := makeTestFunction(t, tc.name, tc.args)
+		if e == nil {
+			continue
+		}
+
+		if err := CheckExpression(e); err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		v, err := e.Calculate(ctx)
+		if err != nil {
+			t.Errorf("%d: Expected no error for %q but got %s", i, tc.name, err)
+			continue
+		}
+
+		b, err := v.boolean()
+		if err != nil {
+			t.Errorf("%d: Expected boolean for %q but got %s", i, tc.name, err)
+		} else if b != tc.res {
+			t.Errorf("%d: Expected %v for %q but got %v", i, tc.res, tc.name, b)
+		}
+	}
+}
+
+func TestPatternFunctionsWithInvalidPattern(t *testing.T) {
+	e := makeTestFunction(t, "match", []Expression{MakeStringValue("test"), MakeStringValue("^ad[0-9+$")})
+	if e == nil {
+		return
+	}
+
+	err := CheckExpression(e)
+	if err == nil {
+		t.Errorf("Expected *invalidRegexpPatternError but got nothing")
+	} else if _, ok := err.(*invalidRegexpPatternError); !ok {
+		t.Errorf("Expected *invalidRegexpPatternError but got %T (%s)", err, err)
+	}
+
+	_, err = e.Calculate(&Context{})
+	if err == nil {
+		t.Errorf("Expected *invalidRegexpPatternError but got nothing")
+	} else if _, ok := err.(*invalidRegexpPatternError); !ok {
+		t.Errorf("Expected *invalidRegexpPatternError but got %T (%s)", err, err)
+	}
+
+	e = makeTestFunction(t, "glob", []Expression{MakeStringValue("test"), MakeStringValue("ad[0-9")})
+	if e == nil {
+		return
+	}
+
+	err = CheckExpression(e)
+	if err == nil {
+		t.Errorf("Expected *invalidGlobPatternError but got nothing")
+	} else if _, ok := err.(*invalidGlobPatternError); !ok {
+		t.Errorf("Expected *invalidGlobPatternError but got %T (%s)", err, err)
+	}
+}
+
+func makeTestFunction(t *testing.T, name string, args []Expression) Expression {
+	for _, validator := range FunctionArgumentValidators[name] {
+		if maker := validator(args); maker != nil {
+			return maker(args)
+		}
+	}
+
+	t.Errorf("Expected function %q for %s but got nothing", name, makeSignatureFromArgs(args))
+	return nil
+}
+
+func makeSignatureFromArgs(args []Expression) Signature {
+	s := make(Signature, len(args))
+	for i, arg := range args {
+		s[i] = arg.GetResultType()
+	}
+
+	return s
+}
@@ -0,0 +1,106 @@
+package pdp
+
+import "encoding/json"
+
+type permitOverridesPCA struct {
+}
+
+func makePermitOverridesPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return permitOverridesPCAInstance
+}
+
+func (permitOverridesPCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "permitOverridesPCA",
+	})
+}
+
+func (a permitOverridesPCA) describe() string {
+	return "permit overrides"
+}
+
+func (a permitOverridesPCA) execute(policies []Evaluable, ctx *Context) Response {
+	errs := []error{}
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	indetD := 0
+	indetP := 0
+	indetDP := 0
+
+	denies := 0
+
+	for _, p := range policies {
+		r := p.Calculate(ctx)
+		if r.Effect == EffectPermit {
+			return r
+		}
+
+		if r.Effect == EffectDeny {
+			denies++
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+			continue
+		}
+
+		if r.Effect == EffectNotApplicable {
+			continue
+		}
+
+		if r.Effect == EffectIndeterminateP {
+			indetP++
+		} else {
+			if r.Effect == EffectIndeterminateD {
+				indetD++
+			} else {
+				indetDP++
+			}
+		}
+
+		errs = append(errs, r.Status)
+	}
+
+	var err boundError
+	if len(errs) > 1 {
+		err = bindError(newMultiError(errs), a.describe())
+	} else if len(errs) > 0 {
+		err = bindError(errs[0], a.describe())
+	}
+
+	if indetDP > 0 || (indetP > 0 && (indetD > 0 || denies > 0)) {
+		return Response{EffectIndeterminateDP, err, nil, nil}
+	}
+
+	if indetP > 0 {
+		return Response{EffectIndeterminateP, err, nil, nil}
+	}
+
+	if denies > 0 {
+		return Response{EffectDeny, nil, obligations, path}
+	}
+
+	if indetD > 0 {
+		return Response{EffectIndeterminateD, err, nil, nil}
+	}
+
+	return Response{EffectNotApplicable, nil, nil, nil}
+}
+
+// orderedPermitOverridesPCA is the same as permitOverridesPCA as the latter
+// already evaluates policies in order of definition. The algorithm exists to
+// keep its id in storage dump.
+type orderedPermitOverridesPCA struct {
+	permitOverridesPCA
+}
+
+func makeOrderedPermitOverridesPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return orderedPermitOverridesPCAInstance
+}
+
+func (orderedPermitOverridesPCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "orderedPermitOverridesPCA",
+	})
+}
@@ -0,0 +1,79 @@
+package pdp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPermitOverridesPCA(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	testCases := []struct {
+		policies []Evaluable
+		effect   int
+	}{
+		{
+			policies: []Evaluable{},
+			effect:   EffectNotApplicable,
+		},
+		{
+			policies: []Evaluable{
+				makeSimplePolicy("first", makeSimpleRule("deny", EffectDeny)),
+				makeSimplePolicy("second", makeSimpleRule("permit", EffectPermit)),
+			},
+			effect: EffectPermit,
+		},
+		{
+			policies: []Evaluable{
+				makeSimplePolicy("first", makeSimpleRule("deny", EffectDeny)),
+			},
+			effect: EffectDeny,
+		},
+		{
+			policies: []Evaluable{
+				makeSimplePolicy("first", makeErrorRule("permit", EffectPermit)),
+				makeSimplePolicy("second", makeSimpleRule("deny", EffectDeny)),
+			},
+			effect: EffectIndeterminateDP,
+		},
+		{
+			policies: []Evaluable{
+				makeSimplePolicy("first", makeErrorRule("deny", EffectDeny)),
+			},
+			effect: EffectIndeterminateD,
+		},
+	}
+
+	for i, tc := range testCases {
+		for _, a := range []PolicyCombiningAlg{
+			makePermitOverridesPCA(tc.policies, nil),
+			makeOrderedPermitOverridesPCA(tc.policies, nil),
+		} {
+			r := a.execute(tc.policies, ctx)
+			if r.Effect != tc.effect {
+				t.Errorf("%d: Expected %q but got %q (%v)",
+					i, effectNames[tc.effect], effectNames[r.Effect], r.Status)
+			}
+		}
+	}
+}
+
+func TestPermitOverridesPCAMarshalWithDepth(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := NewPolicySet("test", false, Target{}, []Evaluable{
+		NewPolicy("first", false, Target{}, nil, makeOrderedPermitOverridesRCA, nil, nil),
+	}, makePermitOverridesPCA, nil, nil)
+
+	expect := `{"ord":0,"id":"test","target":{},"obligations":null,"algorithm":{"type":"permitOverridesPCA"},` +
+		`"policies":[{"ord":0,"id":"first","target":{},"obligations":null,` +
+		`"algorithm":{"type":"orderedPermitOverridesRCA"},"rules":[]}]}`
+	if err := p.MarshalWithDepth(&buf, 1); err != nil {
+		t.Errorf("Expecting no error, got %v", err)
+	} else if s := buf.String(); s != expect {
+		t.Errorf("Expecting marshal output %s, got %s", expect, s)
+	}
+}
@@ -0,0 +1,106 @@
+package pdp
+
+import "encoding/json"
+
+type permitOverridesRCA struct {
+}
+
+func makePermitOverridesRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return permitOverridesRCAInstance
+}
+
+func (permitOverridesRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "permitOverridesRCA",
+	})
+}
+
+func (a permitOverridesRCA) describe() string {
+	return "permit overrides"
+}
+
+func (a permitOverridesRCA) execute(rules []*Rule, ctx *Context) Response {
+	errs := []error{}
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	indetD := 0
+	indetP := 0
+	indetDP := 0
+
+	denies := 0
+
+	for _, rule := range rules {
+		r := rule.calculate(ctx)
+		if r.Effect == EffectPermit {
+			return r
+		}
+
+		if r.Effect == EffectDeny {
+			denies++
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+			continue
+		}
+
+		if r.Effect == EffectNotApplicable {
+			continue
+		}
+
+		if r.Effect == EffectIndeterminateP {
+			indetP++
+		} else {
+			if r.Effect == EffectIndeterminateD {
+				indetD++
+			} else {
+				indetDP++
+			}
+		}
+
+		errs = append(errs, r.Status)
+	}
+
+	var err boundError
+	if len(errs) > 1 {
+		err = bindError(newMultiError(errs), a.describe())
+	} else if len(errs) > 0 {
+		err = bindError(errs[0], a.describe())
+	}
+
+	if indetDP > 0 || (indetP > 0 && (indetD > 0 || denies > 0)) {
+		return Response{EffectIndeterminateDP, err, nil, nil}
+	}
+
+	if indetP > 0 {
+		return Response{EffectIndeterminateP, err, nil, nil}
+	}
+
+	if denies > 0 {
+		return Response{EffectDeny, nil, obligations, path}
+	}
+
+	if indetD > 0 {
+		return Response{EffectIndeterminateD, err, nil, nil}
+	}
+
+	return Response{EffectNotApplicable, nil, nil, nil}
+}
+
+// orderedPermitOverridesRCA is the same as permitOverridesRCA as the latter
+// already evaluates rules in order of definition. The algorithm exists to
+// keep its id in storage dump.
+type orderedPermitOverridesRCA struct {
+	permitOverridesRCA
+}
+
+func makeOrderedPermitOverridesRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return orderedPermitOverridesRCAInstance
+}
+
+func (orderedPermitOverridesRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "orderedPermitOverridesRCA",
+	})
+}
@@ -0,0 +1,85 @@
+package pdp
+
+import "testing"
+
+func TestPermitOverridesRCA(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	testCases := []struct {
+		rules  []*Rule
+		effect int
+	}{
+		{
+			rules:  []*Rule{},
+			effect: EffectNotApplicable,
+		},
+		{
+			rules: []*Rule{
+				makeSimpleRule("first", EffectDeny),
+				makeSimpleRule("second", EffectPermit),
+			},
+			effect: EffectPermit,
+		},
+		{
+			rules: []*Rule{
+				makeSimpleRule("first", EffectDeny),
+				makeSimpleRule("second", EffectDeny),
+			},
+			effect: EffectDeny,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectPermit),
+				makeSimpleRule("second", EffectPermit),
+			},
+			effect: EffectPermit,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectPermit),
+				makeSimpleRule("second", EffectDeny),
+			},
+			effect: EffectIndeterminateDP,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectPermit),
+				makeErrorRule("second", EffectPermit),
+			},
+			effect: EffectIndeterminateP,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectDeny),
+				makeSimpleRule("second", EffectDeny),
+			},
+			effect: EffectDeny,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectDeny),
+			},
+			effect: EffectIndeterminateD,
+		},
+	}
+
+	for i, tc := range testCases {
+		for _, a := range []RuleCombiningAlg{
+			makePermitOverridesRCA(tc.rules, nil),
+			makeOrderedPermitOverridesRCA(tc.rules, nil),
+		} {
+			r := a.execute(tc.rules, ctx)
+			if r.Effect != tc.effect {
+				t.Errorf("%d: Expected %q but got %q (%v)",
+					i, effectNames[tc.effect], effectNames[r.Effect], r.Status)
+			}
+		}
+	}
+}
+
+func makeErrorRule(ID string, effect int) *Rule {
+	return NewRule(ID, false, Target{}, MakeBooleanDesignator("missing"), effect, nil)
+}
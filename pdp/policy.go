@@ -18,15 +18,27 @@ type RuleCombiningAlg interface {
 type RuleCombiningAlgMaker func(rules []*Rule, params interface{}) RuleCombiningAlg
 
 var (
-	firstApplicableEffectRCAInstance = firstApplicableEffectRCA{}
-	denyOverridesRCAInstance         = denyOverridesRCA{}
+	firstApplicableEffectRCAInstance  = firstApplicableEffectRCA{}
+	denyOverridesRCAInstance          = denyOverridesRCA{}
+	orderedDenyOverridesRCAInstance   = orderedDenyOverridesRCA{}
+	permitOverridesRCAInstance        = permitOverridesRCA{}
+	orderedPermitOverridesRCAInstance = orderedPermitOverridesRCA{}
+	denyUnlessPermitRCAInstance       = denyUnlessPermitRCA{}
+	permitUnlessDenyRCAInstance       = permitUnlessDenyRCA{}
+	onlyOneApplicableRCAInstance      = onlyOneApplicableRCA{}
 
 	// RuleCombiningAlgs defines map of algorithm id to particular maker of
 	// the algorithm. Contains only algorithms which don't require any
 	// parameters.
 	RuleCombiningAlgs = map[string]RuleCombiningAlgMaker{
-		"firstapplicableeffect": makeFirstApplicableEffectRCA,
-		"denyoverrides":         makeDenyOverridesRCA}
+		"firstapplicableeffect":  makeFirstApplicableEffectRCA,
+		"denyoverrides":          makeDenyOverridesRCA,
+		"ordereddenyoverrides":   makeOrderedDenyOverridesRCA,
+		"permitoverrides":        makePermitOverridesRCA,
+		"orderedpermitoverrides": makeOrderedPermitOverridesRCA,
+		"denyunlesspermit":       makeDenyUnlessPermitRCA,
+		"permitunlessdeny":       makePermitUnlessDenyRCA,
+		"onlyoneapplicable":      makeOnlyOneApplicableRCA}
 
 	// RuleCombiningParamAlgs defines map of algorithm id to particular maker
 	// of the algorithm. Contains only algorithms which require parameters.
@@ -82,7 +94,16 @@ func (p *Policy) GetID() (string, bool) {
 // Calculate implements Evaluable interface and evaluates policy for given
 // request contest.
 func (p *Policy) Calculate(ctx *Context) Response {
+	t := ctx.enterTrace(TraceNodePolicy, p.id, p.hidden, p.algorithm)
+	r := p.calculate(ctx, t)
+	ctx.leaveTrace(t, r)
+
+	return r
+}
+
+func (p *Policy) calculate(ctx *Context, t *TraceNode) Response {
 	match, err := p.target.calculate(ctx)
+	t.setTarget(match, err)
 	if err != nil {
 		r := combineEffectAndStatus(err, p.algorithm.execute(p.rules, ctx))
 		if r.Status != nil {
@@ -92,12 +113,13 @@ func (p *Policy) Calculate(ctx *Context) Response {
 	}
 
 	if !match {
-		return Response{EffectNotApplicable, nil, nil}
+		return Response{EffectNotApplicable, nil, nil, nil}
 	}
 
 	r := p.algorithm.execute(p.rules, ctx)
 	if r.Effect == EffectDeny || r.Effect == EffectPermit {
 		r.Obligations = append(r.Obligations, p.obligations...)
+		r = appendDecisionPath(ctx, r, p)
 	}
 
 	if r.Status != nil {
@@ -323,7 +345,7 @@ func (a firstApplicableEffectRCA) execute(rules []*Rule, ctx *Context) Response
 		}
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
 }
 
 func (firstApplicableEffectRCA) MarshalJSON() ([]byte, error) {
@@ -352,6 +374,7 @@ func (a denyOverridesRCA) describe() string {
 func (a denyOverridesRCA) execute(rules []*Rule, ctx *Context) Response {
 	errs := []error{}
 	obligations := make([]AttributeAssignment, 0)
+	var path []string
 
 	indetD := 0
 	indetP := 0
@@ -369,6 +392,9 @@ func (a denyOverridesRCA) execute(rules []*Rule, ctx *Context) Response {
 		if r.Effect == EffectPermit {
 			permits++
 			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
 			continue
 		}
 
@@ -398,20 +424,37 @@ func (a denyOverridesRCA) execute(rules []*Rule, ctx *Context) Response {
 	}
 
 	if indetDP > 0 || (indetD > 0 && (indetP > 0 || permits > 0)) {
-		return Response{EffectIndeterminateDP, err, nil}
+		return Response{EffectIndeterminateDP, err, nil, nil}
 	}
 
 	if indetD > 0 {
-		return Response{EffectIndeterminateD, err, nil}
+		return Response{EffectIndeterminateD, err, nil, nil}
 	}
 
 	if permits > 0 {
-		return Response{EffectPermit, nil, obligations}
+		return Response{EffectPermit, nil, obligations, path}
 	}
 
 	if indetP > 0 {
-		return Response{EffectIndeterminateP, err, nil}
+		return Response{EffectIndeterminateP, err, nil, nil}
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
+}
+
+// orderedDenyOverridesRCA is the same as denyOverridesRCA as the latter
+// already evaluates rules in order of definition. The algorithm exists to
+// keep its id in storage dump.
+type orderedDenyOverridesRCA struct {
+	denyOverridesRCA
+}
+
+func makeOrderedDenyOverridesRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return orderedDenyOverridesRCAInstance
+}
+
+func (orderedDenyOverridesRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "orderedDenyOverridesRCA",
+	})
 }
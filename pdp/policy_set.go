@@ -20,15 +20,27 @@ type PolicyCombiningAlg interface {
 type PolicyCombiningAlgMaker func(policies []Evaluable, params interface{}) PolicyCombiningAlg
 
 var (
-	firstApplicableEffectPCAInstance = firstApplicableEffectPCA{}
-	denyOverridesPCAInstance         = denyOverridesPCA{}
+	firstApplicableEffectPCAInstance  = firstApplicableEffectPCA{}
+	denyOverridesPCAInstance          = denyOverridesPCA{}
+	orderedDenyOverridesPCAInstance   = orderedDenyOverridesPCA{}
+	permitOverridesPCAInstance        = permitOverridesPCA{}
+	orderedPermitOverridesPCAInstance = orderedPermitOverridesPCA{}
+	denyUnlessPermitPCAInstance       = denyUnlessPermitPCA{}
+	permitUnlessDenyPCAInstance       = permitUnlessDenyPCA{}
+	onlyOneApplicablePCAInstance      = onlyOneApplicablePCA{}
 
 	// PolicyCombiningAlgs defines map of algorithm id to particular maker
 	// of the algorithm. Contains only algorithms which don't require
 	// any parameters.
 	PolicyCombiningAlgs = map[string]PolicyCombiningAlgMaker{
-		"firstapplicableeffect": makeFirstApplicableEffectPCA,
-		"denyoverrides":         makeDenyOverridesPCA}
+		"firstapplicableeffect":  makeFirstApplicableEffectPCA,
+		"denyoverrides":          makeDenyOverridesPCA,
+		"ordereddenyoverrides":   makeOrderedDenyOverridesPCA,
+		"permitoverrides":        makePermitOverridesPCA,
+		"orderedpermitoverrides": makeOrderedPermitOverridesPCA,
+		"denyunlesspermit":       makeDenyUnlessPermitPCA,
+		"permitunlessdeny":       makePermitUnlessDenyPCA,
+		"onlyoneapplicable":      makeOnlyOneApplicablePCA}
 
 	// PolicyCombiningParamAlgs defines map of algorithm id to particular maker
 	// of the algorithm. Contains only algorithms which require parameters.
@@ -84,7 +96,16 @@ func (p *PolicySet) GetID() (string, bool) {
 // Calculate implements Evaluable interface and evaluates policy set for given
 // request context.
 func (p *PolicySet) Calculate(ctx *Context) Response {
+	t := ctx.enterTrace(TraceNodePolicySet, p.id, p.hidden, p.algorithm)
+	r := p.calculate(ctx, t)
+	ctx.leaveTrace(t, r)
+
+	return r
+}
+
+func (p *PolicySet) calculate(ctx *Context, t *TraceNode) Response {
 	match, err := p.target.calculate(ctx)
+	t.setTarget(match, err)
 	if err != nil {
 		r := combineEffectAndStatus(err, p.algorithm.execute(p.policies, ctx))
 		if r.Status != nil {
@@ -94,12 +115,13 @@ func (p *PolicySet) Calculate(ctx *Context) Response {
 	}
 
 	if !match {
-		return Response{EffectNotApplicable, nil, nil}
+		return Response{EffectNotApplicable, nil, nil, nil}
 	}
 
 	r := p.algorithm.execute(p.policies, ctx)
 	if r.Effect == EffectDeny || r.Effect == EffectPermit {
 		r.Obligations = append(r.Obligations, p.obligations...)
+		r = appendDecisionPath(ctx, r, p)
 	}
 
 	if r.Status != nil {
@@ -367,7 +389,7 @@ func (a firstApplicableEffectPCA) execute(policies []Evaluable, ctx *Context) Re
 		}
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
 }
 
 type denyOverridesPCA struct {
@@ -390,6 +412,7 @@ func (a denyOverridesPCA) describe() string {
 func (a denyOverridesPCA) execute(policies []Evaluable, ctx *Context) Response {
 	errs := []error{}
 	obligations := make([]AttributeAssignment, 0)
+	var path []string
 
 	indetD := 0
 	indetP := 0
@@ -406,6 +429,9 @@ func (a denyOverridesPCA) execute(policies []Evaluable, ctx *Context) Response {
 		if r.Effect == EffectPermit {
 			permits++
 			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
 			continue
 		}
 
@@ -435,22 +461,39 @@ func (a denyOverridesPCA) execute(policies []Evaluable, ctx *Context) Response {
 	}
 
 	if indetDP > 0 || (indetD > 0 && (indetP > 0 || permits > 0)) {
-		return Response{EffectIndeterminateDP, err, nil}
+		return Response{EffectIndeterminateDP, err, nil, nil}
 	}
 
 	if indetD > 0 {
-		return Response{EffectIndeterminateD, err, nil}
+		return Response{EffectIndeterminateD, err, nil, nil}
 	}
 
 	if permits > 0 {
-		return Response{EffectPermit, nil, obligations}
+		return Response{EffectPermit, nil, obligations, path}
 	}
 
 	if indetP > 0 {
-		return Response{EffectIndeterminateP, err, nil}
+		return Response{EffectIndeterminateP, err, nil, nil}
 	}
 
-	return Response{EffectNotApplicable, nil, nil}
+	return Response{EffectNotApplicable, nil, nil, nil}
+}
+
+// orderedDenyOverridesPCA is the same as denyOverridesPCA as the latter
+// already evaluates policies in order of definition. The algorithm exists to
+// keep its id in storage dump.
+type orderedDenyOverridesPCA struct {
+	denyOverridesPCA
+}
+
+func makeOrderedDenyOverridesPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return orderedDenyOverridesPCAInstance
+}
+
+func (orderedDenyOverridesPCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "orderedDenyOverridesPCA",
+	})
 }
 
 type byPolicyOrder []Evaluable
@@ -0,0 +1,192 @@
+package pdp
+
+import (
+	"github.com/infobloxopen/go-trees/domaintree"
+	"github.com/infobloxopen/go-trees/iptree"
+	"github.com/infobloxopen/go-trees/strtree"
+)
+
+// CollectionElementTypes maps collection types which any-of and all-of
+// expressions can iterate over to types of their elements.
+var CollectionElementTypes = map[Type]Type{
+	TypeListOfStrings: TypeString,
+	TypeSetOfStrings:  TypeString,
+	TypeSetOfNetworks: TypeNetwork,
+	TypeSetOfDomains:  TypeDomain,
+}
+
+// NewElementVariable creates variable which gets elements of given
+// collection expression. The variable should be passed to MakeAnyOfExpression
+// or MakeAllOfExpression with the same collection expression and can be
+// referred by VariableReference only from condition of the expression.
+func NewElementVariable(ID string, c Expression) (*Variable, error) {
+	t, ok := CollectionElementTypes[c.GetResultType()]
+	if !ok {
+		return nil, newInvalidCollectionTypeError(c.GetResultType())
+	}
+
+	return &Variable{
+		id: ID,
+		t:  t,
+	}, nil
+}
+
+type quantifiedExpression struct {
+	v   *Variable
+	c   Expression
+	e   Expression
+	any bool
+}
+
+// MakeAnyOfExpression creates expression which returns true if given
+// condition is true for at least one element of collection c. Each element
+// is bound to element variable v. The expression stops at first element
+// the condition is true for. If condition fails for some elements and isn't
+// true for all others the expression returns the first error.
+func MakeAnyOfExpression(v *Variable, c, e Expression) (Expression, error) {
+	return makeQuantifiedExpression(v, c, e, true)
+}
+
+// MakeAllOfExpression creates expression which returns true if given
+// condition is true for all elements of collection c. Each element is bound
+// to element variable v. The expression stops at first element the condition
+// is false for. If condition fails for some elements and isn't false for all
+// others the expression returns the first error.
+func MakeAllOfExpression(v *Variable, c, e Expression) (Expression, error) {
+	return makeQuantifiedExpression(v, c, e, false)
+}
+
+func makeQuantifiedExpression(v *Variable, c, e Expression, any bool) (Expression, error) {
+	t, ok := CollectionElementTypes[c.GetResultType()]
+	if !ok {
+		return nil, newInvalidCollectionTypeError(c.GetResultType())
+	}
+
+	if v.e != nil || v.t != t {
+		return nil, newInvalidElementVariableError(v.id, t)
+	}
+
+	q := quantifiedExpression{
+		v:   v,
+		c:   c,
+		e:   e,
+		any: any,
+	}
+
+	if t := e.GetResultType(); t != TypeBoolean {
+		return nil, newInvalidQuantifiedConditionTypeError(q.describe(), t)
+	}
+
+	return q, nil
+}
+
+func (q quantifiedExpression) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (q quantifiedExpression) describe() string {
+	if q.any {
+		return "any-of"
+	}
+
+	return "all-of"
+}
+
+func (q quantifiedExpression) arguments() []Expression {
+	return []Expression{q.c, q.e}
+}
+
+func (q quantifiedExpression) Calculate(ctx *Context) (AttributeValue, error) {
+	items, err := q.elements(ctx)
+	if err != nil {
+		return UndefinedValue, bindError(bindError(err, "collection"), q.describe())
+	}
+
+	var first error
+	for i, item := range items {
+		b, err := q.calculateElement(ctx, item)
+		if err != nil {
+			if first == nil {
+				first = bindErrorf(err, "element %d", i)
+			}
+
+			continue
+		}
+
+		if b == q.any {
+			return MakeBooleanValue(b), nil
+		}
+	}
+
+	if first != nil {
+		return UndefinedValue, bindError(first, q.describe())
+	}
+
+	return MakeBooleanValue(!q.any), nil
+}
+
+func (q quantifiedExpression) calculateElement(ctx *Context, v AttributeValue) (bool, error) {
+	if ctx.vars == nil {
+		ctx.vars = make(map[*Variable]variableValue)
+	}
+
+	prev, ok := ctx.vars[q.v]
+	ctx.vars[q.v] = variableValue{v: v}
+	defer func() {
+		if ok {
+			ctx.vars[q.v] = prev
+		} else {
+			delete(ctx.vars, q.v)
+		}
+	}()
+
+	return ctx.calculateBooleanExpression(q.e)
+}
+
+func (q quantifiedExpression) elements(ctx *Context) ([]AttributeValue, error) {
+	v, err := q.c.Calculate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v.t {
+	case TypeListOfStrings:
+		return makeStringElements(v.v.([]string)), nil
+
+	case TypeSetOfStrings:
+		return makeStringElements(SortSetOfStrings(v.v.(*strtree.Tree))), nil
+
+	case TypeSetOfNetworks:
+		nets := SortSetOfNetworks(v.v.(*iptree.Tree))
+		out := make([]AttributeValue, len(nets))
+		for i, n := range nets {
+			out[i] = MakeNetworkValue(n)
+		}
+
+		return out, nil
+
+	case TypeSetOfDomains:
+		dns, err := enumerateSetOfDomains(v.v.(*domaintree.Node))
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]AttributeValue, len(dns))
+		for i, d := range dns {
+			out[i] = MakeDomainValue(d)
+		}
+
+		return out, nil
+	}
+
+	return nil, newInvalidCollectionTypeError(v.t)
+}
+
+func makeStringElements(s []string) []AttributeValue {
+	out := make([]AttributeValue, len(s))
+	for i, s := range s {
+		out[i] = MakeStringValue(s)
+	}
+
+	return out
+}
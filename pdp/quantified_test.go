@@ -0,0 +1,160 @@
+package pdp
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAnyOfExpression(t *testing.T) {
+	c := MakeListOfStringsValue([]string{"a", "b", "c"})
+	v, err := NewElementVariable("s", c)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if v.GetResultType() != TypeString {
+		t.Errorf("Expected %q element type but got %q", TypeString, v.GetResultType())
+	}
+
+	n := 0
+	e, err := MakeAnyOfExpression(v, c, countingExpression{
+		e: makeFunctionStringEqual(MakeVariableReference(v), MakeStringValue("b")),
+		n: &n,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	ctx := &Context{}
+	assertQuantifiedExpression(t, ctx, e, true, "any-of")
+	if n != 2 {
+		t.Errorf("Expected any-of to stop at second element but got %d calculations", n)
+	}
+
+	if _, ok := ctx.vars[v]; ok {
+		t.Errorf("Expected element variable to be unbound after any-of")
+	}
+
+	e, err = MakeAnyOfExpression(v, c,
+		makeFunctionStringEqual(MakeVariableReference(v), MakeStringValue("d")),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertQuantifiedExpression(t, ctx, e, false, "any-of with no match")
+
+	e, err = MakeAnyOfExpression(v, c, makeFunctionBooleanOr([]Expression{
+		makeFunctionStringEqual(MakeVariableReference(v), MakeStringValue("b")),
+		makeFunctionStringEqual(MakeStringDesignator("missing"), MakeVariableReference(v)),
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertQuantifiedExpression(t, ctx, e, true, "any-of with error and match")
+
+	e, err = MakeAnyOfExpression(v, c,
+		makeFunctionStringEqual(MakeStringDesignator("missing"), MakeVariableReference(v)),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	_, err = e.Calculate(ctx)
+	if err == nil {
+		t.Errorf("Expected *missingAttributeError but got nothing")
+	} else if _, ok := err.(*missingAttributeError); !ok {
+		t.Errorf("Expected *missingAttributeError but got %T (%s)", err, err)
+	}
+}
+
+func TestAllOfExpression(t *testing.T) {
+	c := MakeSetOfNetworksValue(newIPTree(makeTestNetwork("192.0.2.0/28"), makeTestNetwork("192.0.2.0/24")))
+	v, err := NewElementVariable("n", c)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	e, err := MakeAllOfExpression(v, c, makeFunctionNetworkContainsAddress(
+		MakeVariableReference(v),
+		MakeAddressValue(net.ParseIP("192.0.2.1")),
+	))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	ctx := &Context{}
+	assertQuantifiedExpression(t, ctx, e, true, "all-of")
+
+	e, err = MakeAllOfExpression(v, c, makeFunctionNetworkContainsAddress(
+		MakeVariableReference(v),
+		MakeAddressValue(net.ParseIP("192.0.2.100")),
+	))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertQuantifiedExpression(t, ctx, e, false, "all-of with mismatch")
+
+	e, err = MakeAllOfExpression(v, c, makeFunctionBooleanAnd([]Expression{
+		makeFunctionNetworkContainsAddress(
+			MakeVariableReference(v),
+			MakeAddressValue(net.ParseIP("192.0.2.100")),
+		),
+		makeFunctionNetworkContainsAddress(
+			MakeVariableReference(v),
+			MakeAddressDesignator("missing"),
+		),
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertQuantifiedExpression(t, ctx, e, false, "all-of with error and mismatch")
+
+	e, err = MakeAllOfExpression(v, MakeSetOfNetworksValue(newIPTree()), MakeBooleanValue(false))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertQuantifiedExpression(t, ctx, e, true, "all-of for empty set")
+
+	_, err = NewElementVariable("s", MakeStringValue("test"))
+	if err == nil {
+		t.Errorf("Expected *invalidCollectionTypeError but got nothing")
+	} else if _, ok := err.(*invalidCollectionTypeError); !ok {
+		t.Errorf("Expected *invalidCollectionTypeError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeAllOfExpression(v, MakeListOfStringsValue([]string{"test"}), MakeBooleanValue(true))
+	if err == nil {
+		t.Errorf("Expected *invalidElementVariableError but got nothing")
+	} else if _, ok := err.(*invalidElementVariableError); !ok {
+		t.Errorf("Expected *invalidElementVariableError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeAllOfExpression(v, c, MakeStringValue("test"))
+	if err == nil {
+		t.Errorf("Expected *invalidQuantifiedConditionTypeError but got nothing")
+	} else if _, ok := err.(*invalidQuantifiedConditionTypeError); !ok {
+		t.Errorf("Expected *invalidQuantifiedConditionTypeError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeVariableReference(v).Calculate(ctx)
+	if err == nil {
+		t.Errorf("Expected *unboundElementVariableError but got nothing")
+	} else if _, ok := err.(*unboundElementVariableError); !ok {
+		t.Errorf("Expected *unboundElementVariableError but got %T (%s)", err, err)
+	}
+}
+
+func assertQuantifiedExpression(t *testing.T, ctx *Context, e Expression, expected bool, desc string) {
+	v, err := e.Calculate(ctx)
+	if err != nil {
+		t.Errorf("Expected no error for %s but got %s", desc, err)
+		return
+	}
+
+	b, err := v.GetBoolean()
+	if err != nil {
+		t.Errorf("Expected boolean for %s but got %s", desc, err)
+	} else if b != expected {
+		t.Errorf("Expected %v for %s but got %v", expected, desc, b)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"time"
 	"unsafe"
 
 	"github.com/infobloxopen/go-trees/domain"
@@ -25,6 +26,8 @@ var (
 	reflectTypeIPTree     = reflect.TypeOf((*iptree.Tree)(nil))
 	reflectTypeDomaintree = reflect.TypeOf((*domaintree.Node)(nil))
 	reflectTypeStrings    = reflect.TypeOf([]string(nil))
+	reflectTypeTime       = reflect.TypeOf(time.Time{})
+	reflectTypeDuration   = reflect.TypeOf(time.Duration(0))
 )
 
 func setEffect(v reflect.Value, effect int) error {
@@ -442,3 +445,61 @@ func setListOfStrings(v reflect.Value, ls []string) error {
 	v.Set(reflect.ValueOf(ls))
 	return nil
 }
+
+func getTime(v reflect.Value) time.Time {
+	if v == reflectValueNil {
+		return time.Time{}
+	}
+
+	t := v.Type()
+	if t != reflectTypeTime {
+		panic(fmt.Errorf("can't marshal %s as time value", t))
+	}
+
+	if v.CanInterface() {
+		return v.Interface().(time.Time)
+	}
+
+	if v.CanAddr() {
+		return *(*time.Time)(unsafe.Pointer(v.UnsafeAddr()))
+	}
+
+	panic(fmt.Errorf("can't marshal unexported field of %s type from unaddressable value", t))
+}
+
+func setTime(v reflect.Value, tm time.Time) error {
+	if v == reflectValueNil {
+		return nil
+	}
+
+	if !v.CanSet() {
+		return newRequestUnmarshalTimeConstError(v)
+	}
+
+	if v.Type() != reflectTypeTime {
+		return newRequestUnmarshalTimeTypeError(v)
+	}
+
+	v.Set(reflect.ValueOf(tm))
+	return nil
+}
+
+func setDuration(v reflect.Value, d time.Duration) error {
+	if v == reflectValueNil {
+		return nil
+	}
+
+	if !v.CanSet() {
+		return newRequestUnmarshalDurationConstError(v)
+	}
+
+	switch v.Kind() {
+	default:
+		return newRequestUnmarshalDurationTypeError(v)
+
+	case reflect.Int64:
+		v.SetInt(int64(d))
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -31,6 +32,9 @@ const (
 	requestWireTypeSetOfDomains
 	requestWireTypeListOfStrings
 	requestWireTypeSetOfFlags
+	requestWireTypeTime
+	requestWireTypeDuration
+	requestWireTypeStruct
 
 	requestWireTypesTotal
 )
@@ -52,6 +56,9 @@ var (
 		"set of domains",
 		"list of strings",
 		"set of flags",
+		"time",
+		"duration",
+		"struct",
 	}
 
 	builtinTypeByWire = []Type{
@@ -69,6 +76,10 @@ var (
 		TypeSetOfNetworks,
 		TypeSetOfDomains,
 		TypeListOfStrings,
+		nil,
+		TypeTime,
+		TypeDuration,
+		nil,
 	}
 )
 
@@ -83,6 +94,8 @@ const (
 	reqBooleanValueSize     = 0
 	reqIntegerValueSize     = 8
 	reqFloatValueSize       = 8
+	reqTimeValueSize        = 12
+	reqDurationValueSize    = 8
 	reqIPv4AddressValueSize = 4
 	reqIPv6AddressValueSize = 16
 	reqNetworkCIDRSize      = 1
@@ -156,7 +169,7 @@ func MarshalRequestAssignmentsToBuffer(b []byte, in []AttributeAssignment) (int,
 // TypeSetOfNetworks - *iptree.Node from
 // github.com/infobloxopen/go-trees/iptree, TypeSetOfDomains - *domaintree.Node
 // from github.com/infobloxopen/go-trees/domaintree, TypeListOfStrings -
-// []string.
+// []string, TypeTime - time.Time and TypeDuration - time.Duration.
 func MarshalRequestReflection(c int, f func(i int) (string, Type, reflect.Value, error)) ([]byte, error) {
 	n, err := calcRequestSizeFromReflection(c, f)
 	if err != nil {
@@ -404,6 +417,11 @@ func CheckInfoRequestHeader(b []byte, count uint16) ([]byte, error) {
 func putRequestAttribute(b []byte, name string, value AttributeValue) (int, error) {
 	t := value.GetResultType()
 
+	switch t.(type) {
+	case *EnumType, *StructType:
+		return putRequestAttributeCustom(b, name, value)
+	}
+
 	switch t {
 	case TypeBoolean:
 		v, _ := value.boolean()
@@ -448,6 +466,14 @@ func putRequestAttribute(b []byte, name string, value AttributeValue) (int, erro
 	case TypeListOfStrings:
 		v, _ := value.listOfStrings()
 		return putRequestAttributeListOfStrings(b, name, v)
+
+	case TypeTime:
+		v, _ := value.time()
+		return putRequestAttributeTime(b, name, v)
+
+	case TypeDuration:
+		v, _ := value.duration()
+		return putRequestAttributeDuration(b, name, v)
 	}
 
 	return 0, newRequestAttributeMarshallingNotImplementedError(t)
@@ -474,6 +500,15 @@ func putRequestAttributeValue(b []byte, value AttributeValue) (int, error) {
 		return putRequestSetOfFlags64Value(b, v, t)
 	}
 
+	switch t.(type) {
+	case *EnumType:
+		v, _ := value.enum()
+		return putRequestStringValue(b, v)
+
+	case *StructType:
+		return putRequestStructValue(b, value.v.([]AttributeValue))
+	}
+
 	switch t {
 	case TypeBoolean:
 		v, _ := value.boolean()
@@ -518,6 +553,14 @@ func putRequestAttributeValue(b []byte, value AttributeValue) (int, error) {
 	case TypeListOfStrings:
 		v, _ := value.listOfStrings()
 		return putRequestListOfStringsValue(b, v)
+
+	case TypeTime:
+		v, _ := value.time()
+		return putRequestTimeValue(b, v)
+
+	case TypeDuration:
+		v, _ := value.duration()
+		return putRequestDurationValue(b, v)
 	}
 
 	return 0, newRequestAttributeMarshallingNotImplementedError(t)
@@ -679,6 +722,33 @@ func getRequestAttributeValueWithType(t int, b []byte) (AttributeValue, int, err
 		}
 
 		return MakeFlagsValue64(v, ft), n, nil
+
+	case requestWireTypeTime:
+		tm, n, err := getRequestTimeValue(b)
+		if err != nil {
+			return UndefinedValue, 0, err
+		}
+
+		return MakeTimeValue(tm), n, nil
+
+	case requestWireTypeDuration:
+		d, n, err := getRequestDurationValue(b)
+		if err != nil {
+			return UndefinedValue, 0, err
+		}
+
+		return MakeDurationValue(d), n, nil
+
+	case requestWireTypeStruct:
+		fields, n, err := getRequestStructValue(b)
+		if err != nil {
+			return UndefinedValue, 0, err
+		}
+
+		return AttributeValue{
+			t: newAbstractStructType(fields),
+			v: fields,
+		}, n, nil
 	}
 
 	return UndefinedValue, 0, newRequestAttributeUnmarshallingTypeError(t)
@@ -1740,6 +1810,198 @@ func GetInfoRequestListOfStringsValue(b []byte) ([]string, []byte, error) {
 	return v, b[n:], nil
 }
 
+func putRequestAttributeTime(b []byte, name string, value time.Time) (int, error) {
+	off, err := putRequestAttributeName(b, name)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := putRequestTimeValue(b[off:], value)
+	if err != nil {
+		return 0, err
+	}
+
+	return off + n, err
+}
+
+// putRequestTimeValue puts time as a number of seconds since Unix epoch
+// followed by nanoseconds within the second. Time zone isn't preserved and
+// value is always unmarshalled in UTC.
+func putRequestTimeValue(b []byte, value time.Time) (int, error) {
+	off, err := putRequestAttributeType(b, requestWireTypeTime)
+	if err != nil {
+		return 0, err
+	}
+
+	b = b[off:]
+
+	if len(b) < reqTimeValueSize {
+		return 0, newRequestBufferOverflowError()
+	}
+
+	binary.LittleEndian.PutUint64(b, uint64(value.Unix()))
+	binary.LittleEndian.PutUint32(b[8:], uint32(value.Nanosecond()))
+	return off + reqTimeValueSize, nil
+}
+
+func getRequestTimeValue(b []byte) (time.Time, int, error) {
+	if len(b) < reqTimeValueSize {
+		return time.Time{}, 0, newRequestBufferUnderflowError()
+	}
+
+	sec := int64(binary.LittleEndian.Uint64(b))
+	nsec := int64(binary.LittleEndian.Uint32(b[8:]))
+	return time.Unix(sec, nsec).UTC(), reqTimeValueSize, nil
+}
+
+// GetInfoRequestTimeValue extracts time value from request for additional
+// information.
+func GetInfoRequestTimeValue(b []byte) (time.Time, []byte, error) {
+	if len(b) < reqTypeSize {
+		return time.Time{}, nil, newRequestBufferUnderflowError()
+	}
+
+	if t := int(b[0]); t != requestWireTypeTime {
+		return time.Time{}, nil, newRequestAttributeUnmarshallingTimeTypeError(t)
+	}
+	b = b[reqTypeSize:]
+
+	v, n, err := getRequestTimeValue(b)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	return v, b[n:], nil
+}
+
+func putRequestAttributeDuration(b []byte, name string, value time.Duration) (int, error) {
+	off, err := putRequestAttributeName(b, name)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := putRequestDurationValue(b[off:], value)
+	if err != nil {
+		return 0, err
+	}
+
+	return off + n, err
+}
+
+func putRequestDurationValue(b []byte, value time.Duration) (int, error) {
+	off, err := putRequestAttributeType(b, requestWireTypeDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	b = b[off:]
+
+	if len(b) < reqDurationValueSize {
+		return 0, newRequestBufferOverflowError()
+	}
+
+	binary.LittleEndian.PutUint64(b, uint64(value))
+	return off + reqDurationValueSize, nil
+}
+
+func getRequestDurationValue(b []byte) (time.Duration, int, error) {
+	if len(b) < reqDurationValueSize {
+		return 0, 0, newRequestBufferUnderflowError()
+	}
+
+	return time.Duration(binary.LittleEndian.Uint64(b)), reqDurationValueSize, nil
+}
+
+// GetInfoRequestDurationValue extracts duration value from request for
+// additional information.
+func GetInfoRequestDurationValue(b []byte) (time.Duration, []byte, error) {
+	if len(b) < reqTypeSize {
+		return 0, nil, newRequestBufferUnderflowError()
+	}
+
+	if t := int(b[0]); t != requestWireTypeDuration {
+		return 0, nil, newRequestAttributeUnmarshallingDurationTypeError(t)
+	}
+	b = b[reqTypeSize:]
+
+	v, n, err := getRequestDurationValue(b)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return v, b[n:], nil
+}
+
+func putRequestAttributeCustom(b []byte, name string, value AttributeValue) (int, error) {
+	off, err := putRequestAttributeName(b, name)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := putRequestAttributeValue(b[off:], value)
+	if err != nil {
+		return 0, err
+	}
+
+	return off + n, err
+}
+
+func putRequestStructValue(b []byte, value []AttributeValue) (int, error) {
+	off, err := putRequestAttributeType(b, requestWireTypeStruct)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(value) > math.MaxUint8 {
+		return 0, newRequestTooLongCollectionValueError(newAbstractStructType(value), len(value))
+	}
+
+	if len(b[off:]) < reqSmallCounterSize {
+		return 0, newRequestBufferOverflowError()
+	}
+
+	b[off] = byte(len(value))
+	off += reqSmallCounterSize
+
+	for i, v := range value {
+		n, err := putRequestAttributeValue(b[off:], v)
+		if err != nil {
+			return 0, bindErrorf(err, "%d", i+1)
+		}
+
+		off += n
+	}
+
+	return off, nil
+}
+
+func getRequestStructValue(b []byte) ([]AttributeValue, int, error) {
+	if len(b) < reqSmallCounterSize {
+		return nil, 0, newRequestBufferUnderflowError()
+	}
+
+	count := int(b[0])
+	if count <= 0 {
+		return nil, 0, newRequestAttributeUnmarshallingStructSizeError(count)
+	}
+
+	off := reqSmallCounterSize
+
+	fields := make([]AttributeValue, count)
+	for i := range fields {
+		v, n, err := getRequestAttributeValue(b[off:])
+		if err != nil {
+			return nil, 0, bindErrorf(err, "%d", i+1)
+		}
+
+		off += n
+
+		fields[i] = v
+	}
+
+	return fields, off, nil
+}
+
 func getRequestAbstractSetOfFlagsValue(b []byte) (uint64, int, int, error) {
 	if len(b) < reqSmallCounterSize {
 		return 0, 0, 0, newRequestBufferUnderflowError()
@@ -1803,6 +2065,17 @@ func calcRequestAttributeSize(value AttributeValue) (int, error) {
 	)
 
 	t := value.GetResultType()
+	switch t.(type) {
+	case *EnumType:
+		v, _ := value.enum()
+		s, err = calcRequestAttributeStringSize(v)
+		return reqTypeSize + s, err
+
+	case *StructType:
+		s, err = calcRequestAttributeStructSize(value.v.([]AttributeValue))
+		return reqTypeSize + s, err
+	}
+
 	switch t {
 	default:
 		return 0, newRequestAttributeMarshallingNotImplementedError(t)
@@ -1849,6 +2122,14 @@ func calcRequestAttributeSize(value AttributeValue) (int, error) {
 	case TypeListOfStrings:
 		v, _ := value.listOfStrings()
 		s, err = calcRequestAttributeListOfStringsSize(v)
+
+	case TypeTime:
+		v, _ := value.time()
+		s, err = calcRequestAttributeTimeSize(v)
+
+	case TypeDuration:
+		v, _ := value.duration()
+		s, err = calcRequestAttributeDurationSize(v)
 	}
 
 	return reqTypeSize + s, err
@@ -1878,6 +2159,14 @@ func calcRequestAttributeFloatSize(value float64) (int, error) {
 	return reqFloatValueSize, nil
 }
 
+func calcRequestAttributeTimeSize(value time.Time) (int, error) {
+	return reqTimeValueSize, nil
+}
+
+func calcRequestAttributeDurationSize(value time.Duration) (int, error) {
+	return reqDurationValueSize, nil
+}
+
 func calcRequestAttributeAddressSize(value net.IP) (int, error) {
 	if ip := value.To4(); ip != nil {
 		return len(ip), nil
@@ -1978,6 +2267,24 @@ func calcRequestAttributeListOfStringsSize(value []string) (int, error) {
 	return total, nil
 }
 
+func calcRequestAttributeStructSize(value []AttributeValue) (int, error) {
+	if len(value) > math.MaxUint8 {
+		return 0, newRequestTooLongCollectionValueError(newAbstractStructType(value), len(value))
+	}
+
+	total := reqSmallCounterSize
+	for i, v := range value {
+		s, err := calcRequestAttributeSize(v)
+		if err != nil {
+			return 0, bindErrorf(err, "%d", i+1)
+		}
+
+		total += s
+	}
+
+	return total, nil
+}
+
 func getRequestWireTypeName(t int) string {
 	if t < 0 || t >= len(requestWireTypeNames) {
 		return fmt.Sprintf("unknown (%d)", t)
@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -1019,6 +1020,152 @@ func TestGetRequestListOfStringsValue(t *testing.T) {
 	}
 }
 
+func TestGetRequestTimeValue(t *testing.T) {
+	testWireTimeValue := []byte{
+		0x18, 0x87, 0x23, 0x5b, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	v, n, err := getRequestTimeValue(testWireTimeValue)
+	expected := time.Date(2018, 6, 15, 9, 30, 0, 0, time.UTC)
+	if err != nil {
+		t.Error(err)
+	} else if n != len(testWireTimeValue) {
+		t.Errorf("expected whole buffer consumed (%d) but got (%d)", len(testWireTimeValue), n)
+	} else if !v.Equal(expected) {
+		t.Errorf("expected time %s as attribute value but got %s", expected, v)
+	}
+
+	v, _, err = getRequestTimeValue([]byte{})
+	if err == nil {
+		t.Errorf("expected *requestBufferUnderflowError but got time %s", v)
+	} else if _, ok := err.(*requestBufferUnderflowError); !ok {
+		t.Errorf("expected *requestBufferUnderflowError but got %T (%s)", err, err)
+	}
+}
+
+func TestRequestTimeValueRoundTrip(t *testing.T) {
+	for _, tm := range []time.Time{
+		{},
+		time.Date(1, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 6, 15, 9, 30, 0, 123456789, time.UTC),
+		time.Date(2999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+	} {
+		b := make([]byte, reqTypeSize+reqTimeValueSize)
+		n, err := putRequestTimeValue(b, tm)
+		if err != nil {
+			t.Errorf("expected no error for %s but got %s", tm, err)
+			continue
+		} else if n != len(b) {
+			t.Errorf("expected whole buffer used (%d) for %s but got (%d)", len(b), tm, n)
+			continue
+		}
+
+		v, _, err := getRequestTimeValue(b[reqTypeSize:])
+		if err != nil {
+			t.Errorf("expected no error for %s but got %s", tm, err)
+		} else if !v.Equal(tm) {
+			t.Errorf("expected time %s but got %s", tm, v)
+		}
+	}
+
+	var rt time.Time
+	b, err := MarshalRequestAssignments([]AttributeAssignment{MakeTimeAssignment("time", time.Time{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = UnmarshalRequestReflection(b, func(id string, t Type) (reflect.Value, error) {
+		return reflect.Indirect(reflect.ValueOf(&rt)), nil
+	})
+	if err != nil {
+		t.Error(err)
+	} else if !rt.IsZero() {
+		t.Errorf("expected zero time but got %s", rt)
+	}
+}
+
+func TestGetRequestDurationValue(t *testing.T) {
+	testWireDurationValue := []byte{
+		0x00, 0x00, 0x4f, 0x91, 0x94, 0x4e, 0x00, 0x00,
+	}
+	v, n, err := getRequestDurationValue(testWireDurationValue)
+	if err != nil {
+		t.Error(err)
+	} else if n != len(testWireDurationValue) {
+		t.Errorf("expected whole buffer consumed (%d) but got (%d)", len(testWireDurationValue), n)
+	} else if v != 24*time.Hour {
+		t.Errorf("expected duration %s as attribute value but got %s", 24*time.Hour, v)
+	}
+
+	v, _, err = getRequestDurationValue([]byte{})
+	if err == nil {
+		t.Errorf("expected *requestBufferUnderflowError but got duration %s", v)
+	} else if _, ok := err.(*requestBufferUnderflowError); !ok {
+		t.Errorf("expected *requestBufferUnderflowError but got %T (%s)", err, err)
+	}
+}
+
+func TestMarshalRequestTimeAndDuration(t *testing.T) {
+	tm := time.Date(2018, 6, 15, 9, 30, 0, 0, time.UTC)
+	in := []AttributeAssignment{
+		MakeTimeAssignment("time", tm),
+		MakeDurationAssignment("duration", 24*time.Hour),
+	}
+
+	b, err := MarshalRequestAssignments(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := UnmarshalRequestAssignments(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out) != len(in) {
+		t.Fatalf("expected %d assignments but got %d", len(in), len(out))
+	}
+
+	id, tp, v, err := out[0].Serialize(nil)
+	if err != nil {
+		t.Error(err)
+	} else if id != "time" || tp != TypeTime.GetKey() || v != "2018-06-15T09:30:00Z" {
+		t.Errorf("expected \"time\" of %q type with value %q but got %q of %q with %q",
+			TypeTime.GetKey(), "2018-06-15T09:30:00Z", id, tp, v)
+	}
+
+	id, tp, v, err = out[1].Serialize(nil)
+	if err != nil {
+		t.Error(err)
+	} else if id != "duration" || tp != TypeDuration.GetKey() || v != "24h0m0s" {
+		t.Errorf("expected \"duration\" of %q type with value %q but got %q of %q with %q",
+			TypeDuration.GetKey(), "24h0m0s", id, tp, v)
+	}
+
+	var (
+		rt time.Time
+		rd time.Duration
+	)
+
+	err = UnmarshalRequestReflection(b, func(id string, t Type) (reflect.Value, error) {
+		switch id {
+		case "time":
+			return reflect.Indirect(reflect.ValueOf(&rt)), nil
+
+		case "duration":
+			return reflect.Indirect(reflect.ValueOf(&rd)), nil
+		}
+
+		return reflectValueNil, fmt.Errorf("unexpected attribute %q", id)
+	})
+	if err != nil {
+		t.Error(err)
+	} else if !rt.Equal(tm) || rd != 24*time.Hour {
+		t.Errorf("expected %s and %s but got %s and %s", tm, 24*time.Hour, rt, rd)
+	}
+}
+
 func TestGetRequestAbstractSetOfFlagsValue(t *testing.T) {
 	testWireSetOfFlags8Value := []byte{
 		8, 0x55,
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -506,7 +507,8 @@ func UnmarshalResponseToAssignmentsArray(b []byte, out []AttributeAssignment) (i
 // *iptree.Tree from github.com/infobloxopen/go-trees/iptree package,
 // TypeSetOfDomains - *domaintree.Node from
 // github.com/infobloxopen/go-trees/domaintree package, TypeListOfStrings -
-// []string.
+// []string, TypeTime - time.Time, TypeDuration - time.Duration (or int64 as
+// a number of nanoseconds).
 func UnmarshalResponseToReflection(b []byte, f func(string, Type) (reflect.Value, error)) error {
 	off, err := checkRequestVersion(b)
 	if err != nil {
@@ -586,6 +588,35 @@ func putResponseEffect(b []byte, effect int) (int, error) {
 	return 1, nil
 }
 
+// InspectResponse returns effect of marshaled response and checks if status
+// or obligations of the response have been replaced by error because they
+// haven't fit response buffer. It returns negative effect for malformed
+// response.
+func InspectResponse(b []byte) (int, bool) {
+	if len(b) < reqVersionSize {
+		return -1, false
+	}
+
+	effect, n, err := getResponseEffect(b[reqVersionSize:])
+	if err != nil {
+		return -1, false
+	}
+
+	b = b[reqVersionSize+n:]
+	if len(b) < 2 {
+		return effect, false
+	}
+
+	size := int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < size {
+		return effect, false
+	}
+
+	status := string(b[:size])
+	return effect, status == responseStatusTooLong || status == responseStatusObligationsTooLong
+}
+
 func getResponseEffect(b []byte) (int, int, error) {
 	if len(b) < 1 {
 		return EffectIndeterminate, 0, newRequestBufferUnderflowError()
@@ -774,6 +805,12 @@ func putAttributesFromReflection(b []byte, c int, f func(i int) (string, Type, r
 
 		case TypeListOfStrings:
 			n, err = putRequestAttributeListOfStrings(b[off:], id, getListOfStrings(v))
+
+		case TypeTime:
+			n, err = putRequestAttributeTime(b[off:], id, getTime(v))
+
+		case TypeDuration:
+			n, err = putRequestAttributeDuration(b[off:], id, time.Duration(v.Int()))
 		}
 
 		if err != nil {
@@ -890,7 +927,7 @@ func getAttributesToReflection(b []byte, f func(string, Type) (reflect.Value, er
 		}
 		b = b[n:]
 
-		if t < 0 || t >= len(builtinTypeByWire) {
+		if t < 0 || t >= len(builtinTypeByWire) || builtinTypeByWire[t] == nil {
 			return bindError(newRequestAttributeUnmarshallingTypeError(t), id)
 		}
 
@@ -1025,6 +1062,26 @@ func getAttributesToReflection(b []byte, f func(string, Type) (reflect.Value, er
 			b = b[n:]
 
 			err = setListOfStrings(v, ls)
+
+		case requestWireTypeTime:
+			var tm time.Time
+			tm, n, err = getRequestTimeValue(b)
+			if err != nil {
+				return bindError(err, id)
+			}
+			b = b[n:]
+
+			err = setTime(v, tm)
+
+		case requestWireTypeDuration:
+			var d time.Duration
+			d, n, err = getRequestDurationValue(b)
+			if err != nil {
+				return bindError(err, id)
+			}
+			b = b[n:]
+
+			err = setDuration(v, d)
 		}
 
 		if err != nil {
@@ -1142,6 +1199,12 @@ func calcAttributesSizeFromReflection(c int, f func(i int) (string, Type, reflec
 
 		case TypeListOfStrings:
 			n, err = calcRequestAttributeListOfStringsSize(getListOfStrings(v))
+
+		case TypeTime:
+			n, err = calcRequestAttributeTimeSize(getTime(v))
+
+		case TypeDuration:
+			n, err = calcRequestAttributeDurationSize(time.Duration(v.Int()))
 		}
 
 		if err != nil {
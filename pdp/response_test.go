@@ -230,6 +230,34 @@ func TestMarshalResponseToBuffer(t *testing.T) {
 	}
 }
 
+func TestInspectResponse(t *testing.T) {
+	var b [90]byte
+
+	n, err := marshalResponseToBuffer(b[:], EffectPermit, testRequestAssignments)
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+
+	if effect, overflow := InspectResponse(b[:n]); effect != EffectPermit || overflow {
+		t.Errorf("expected %q without overflow but got %q and %#v",
+			EffectNameFromEnum(EffectPermit), EffectNameFromEnum(effect), overflow)
+	}
+
+	n, err = marshalResponseToBuffer(b[:27], EffectIndeterminate, testRequestAssignments, fmt.Errorf("testError"))
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+
+	if effect, overflow := InspectResponse(b[:n]); effect != EffectIndeterminate || !overflow {
+		t.Errorf("expected %q with overflow but got %q and %#v",
+			EffectNameFromEnum(EffectIndeterminate), EffectNameFromEnum(effect), overflow)
+	}
+
+	if effect, _ := InspectResponse(b[:2]); effect >= 0 {
+		t.Errorf("expected negative effect for malformed response but got %d", effect)
+	}
+}
+
 func TestMakeIndeterminateResponse(t *testing.T) {
 	b, err := MakeIndeterminateResponse(fmt.Errorf("test error"))
 	assertRequestBytesBuffer(t, "MakeIndeterminateResponse", err, b, len(b),
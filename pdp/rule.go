@@ -19,10 +19,10 @@ type Rule struct {
 
 func makeConditionStatus(err boundError, effect int) Response {
 	if effect == EffectDeny {
-		return Response{EffectIndeterminateD, err, nil}
+		return Response{EffectIndeterminateD, err, nil, nil}
 	}
 
-	return Response{EffectIndeterminateP, err, nil}
+	return Response{EffectIndeterminateP, err, nil, nil}
 }
 
 // NewRule creates new instance of rule with given id (or hidden), target,
@@ -51,29 +51,39 @@ func (r Rule) GetID() (string, bool) {
 }
 
 func (r Rule) calculate(ctx *Context) Response {
+	t := ctx.enterTrace(TraceNodeRule, r.id, r.hidden, nil)
+	resp := r.evaluate(ctx, t)
+	ctx.leaveTrace(t, resp)
+
+	return resp
+}
+
+func (r Rule) evaluate(ctx *Context, t *TraceNode) Response {
 	match, boundErr := r.target.calculate(ctx)
+	t.setTarget(match, boundErr)
 	if boundErr != nil {
 		return makeMatchStatus(bindError(boundErr, r.describe()), r.effect)
 	}
 
 	if !match {
-		return Response{EffectNotApplicable, nil, nil}
+		return Response{EffectNotApplicable, nil, nil, nil}
 	}
 
 	if r.condition == nil {
-		return Response{r.effect, nil, r.obligations}
+		return Response{r.effect, nil, r.obligations, makeRuleDecisionPath(ctx, r)}
 	}
 
 	c, err := ctx.calculateBooleanExpression(r.condition)
+	t.setCondition(c, err)
 	if err != nil {
 		return makeConditionStatus(bindError(bindError(err, "condition"), r.describe()), r.effect)
 	}
 
 	if !c {
-		return Response{EffectNotApplicable, nil, nil}
+		return Response{EffectNotApplicable, nil, nil, nil}
 	}
 
-	return Response{r.effect, nil, r.obligations}
+	return Response{r.effect, nil, r.obligations, makeRuleDecisionPath(ctx, r)}
 }
 
 // MarshalWithDepth implements StorageMarshal
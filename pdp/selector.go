@@ -19,6 +19,8 @@ const (
 	SelectorOptionError = "error"
 	// SelectorOptionAggregation specifies how to aggregate data
 	SelectorOptionAggregation = "aggregation"
+	// SelectorOptionField defines a field to get from struct value
+	SelectorOptionField = "field"
 )
 
 // Selector provides a generic way to access external data may required
@@ -37,6 +37,8 @@ type LocalSelector struct {
 	def     pdp.Expression
 	err     pdp.Expression
 	agg     pdp.AggType
+	field   string
+	ft      pdp.Type
 }
 
 // MakeLocalSelector creates instance of local selector. Arguments content and
@@ -45,7 +47,9 @@ type LocalSelector struct {
 // selector implements late binding and checks path and type on any evaluation.
 // If content storage doesn't have a value for given path the value of
 // def expression is returned if it was provided. In case if other error occurs
-// the value of err expression is returned if it was provided.
+// the value of err expression is returned if it was provided. If field option
+// is set type t should be a struct type and the selector returns value of
+// the field.
 func MakeLocalSelector(uri *url.URL, path []pdp.Expression, t pdp.Type, opts ...pdp.SelectorOption) (pdp.Expression, error) {
 	loc := strings.Split(uri.Opaque, "/")
 	if len(loc) != 2 {
@@ -68,28 +72,68 @@ func MakeLocalSelector(uri *url.URL, path []pdp.Expression, t pdp.Type, opts ...
 			ls.err, ok = opt.Data.(pdp.Expression)
 		case pdp.SelectorOptionAggregation:
 			ls.agg, ok = opt.Data.(pdp.AggType)
+		case pdp.SelectorOptionField:
+			ls.field, ok = opt.Data.(string)
 		}
 		if !ok {
 			panic("bad data provided as local selector option " + opt.Name)
 		}
 	}
+
+	ls.ft = t
+	if len(ls.field) > 0 {
+		ft, err := pdp.GetStructFieldType(t, ls.field)
+		if err != nil {
+			return nil, err
+		}
+
+		ls.ft = ft
+	}
+
 	return ls, nil
 }
 
 // GetResultType implements Expression interface and returns type of final value
-// expected by the selector from corresponding content.
+// expected by the selector from corresponding content (or type of the field
+// if the selector gets a field of struct value).
 func (s LocalSelector) GetResultType() pdp.Type {
-	return s.t
+	return s.ft
+}
+
+// GetArguments implements pdp.ArgumentsExpression interface and returns
+// path expressions of the selector along with its default and error
+// expressions.
+func (s LocalSelector) GetArguments() []pdp.Expression {
+	args := make([]pdp.Expression, 0, len(s.path)+2)
+	args = append(args, s.path...)
+	return append(args, s.def, s.err)
 }
 
 // Calculate implements Expression interface and returns calculated value
 func (s LocalSelector) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
+	if !ctx.Tracing() {
+		return s.calculate(ctx, s.path)
+	}
+
+	var keys []pdp.AttributeValue
+	path := make([]pdp.Expression, len(s.path))
+	for i, e := range s.path {
+		path[i] = tracedKey{e: e, i: i, keys: &keys}
+	}
+
+	v, err := s.calculate(ctx, path)
+	ctx.TraceSelector("local:"+s.content+"/"+s.item, keys, v, err)
+
+	return v, err
+}
+
+func (s LocalSelector) calculate(ctx *pdp.Context, path []pdp.Expression) (pdp.AttributeValue, error) {
 	item, err := ctx.GetContentItem(s.content, s.item)
 	if err != nil {
 		return s.handleError(ctx, err)
 	}
 
-	r, err := item.GetAggregated(s.path, ctx, s.agg)
+	r, err := item.GetAggregated(path, ctx, s.agg)
 	if err != nil {
 		return s.handleError(ctx, err)
 	}
@@ -103,6 +147,13 @@ func (s LocalSelector) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
 		))
 	}
 
+	if len(s.field) > 0 {
+		r, err = r.GetField(s.field)
+		if err != nil {
+			return s.handleError(ctx, err)
+		}
+	}
+
 	return r, nil
 }
 
@@ -117,3 +168,30 @@ func (s LocalSelector) handleError(ctx *pdp.Context, err error) (pdp.AttributeVa
 
 	return pdp.UndefinedValue, err
 }
+
+// tracedKey wraps selector path expression to record its value for decision
+// trace as content lookup calculates it. Content item calculates path
+// expressions one by one so the value of i-th expression replaces all keys
+// recorded after i-1-th one.
+type tracedKey struct {
+	e    pdp.Expression
+	i    int
+	keys *[]pdp.AttributeValue
+}
+
+func (k tracedKey) GetResultType() pdp.Type {
+	return k.e.GetResultType()
+}
+
+func (k tracedKey) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
+	v, err := k.e.Calculate(ctx)
+	if len(*k.keys) > k.i {
+		*k.keys = (*k.keys)[:k.i]
+	}
+
+	if err == nil {
+		*k.keys = append(*k.keys, v)
+	}
+
+	return v, err
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/infobloxopen/go-trees/domain"
+	"github.com/infobloxopen/go-trees/strtree"
 	"github.com/infobloxopen/go-trees/uintX/domaintree8"
 	"github.com/infobloxopen/themis/pdp"
 )
@@ -42,6 +43,11 @@ func TestMakeLocalSelector(t *testing.T) {
 			if st != pdp.TypeString {
 				t.Errorf("Expected %q as selector result type but got %q", pdp.TypeString, st)
 			}
+
+			ids := pdp.ExpressionAttributeIDs(e)
+			if len(ids) != 1 || ids[0] != "domain" {
+				t.Errorf("Expected [\"domain\"] as selector attributes but got %q", ids)
+			}
 		}
 	}
 
@@ -330,3 +336,148 @@ func makeTestDN(t *testing.T, s string) domain.Name {
 
 	return d
 }
+
+func TestSelectorCalculateStructField(t *testing.T) {
+	uri, err := url.Parse("local:test-content/test-item")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	et, err := pdp.NewEnumType("level", "low", "high")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	st, err := pdp.NewStructType("device",
+		pdp.StructField{Name: "name", Type: pdp.TypeString},
+		pdp.StructField{Name: "level", Type: et},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	high, err := pdp.MakeEnumValue("high", et)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	v, err := pdp.MakeStructValue(st, pdp.MakeStringValue("router"), high)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	cs := pdp.NewLocalContentStorage([]*pdp.LocalContent{
+		pdp.NewLocalContent("test-content", nil, pdp.MakeSymbols(),
+			[]*pdp.ContentItem{
+				pdp.MakeContentValueItem("test-item", st, v),
+			},
+		),
+	})
+	ctx, err := pdp.NewContext(cs, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	e, err := pdp.MakeSelector(uri, []pdp.Expression{}, st,
+		pdp.SelectorOption{Name: pdp.SelectorOptionField, Data: "level"},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if rt := e.GetResultType(); rt != et {
+		t.Errorf("Expected %q as selector result type but got %q", et, rt)
+	}
+
+	r, err := e.Calculate(ctx)
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	} else if s, err := r.GetEnum(); err != nil || s != "high" {
+		t.Errorf("Expected %q value from selector but got %q (%v)", "high", s, err)
+	}
+
+	_, err = pdp.MakeSelector(uri, []pdp.Expression{}, st,
+		pdp.SelectorOption{Name: pdp.SelectorOptionField, Data: "missing"},
+	)
+	if err == nil {
+		t.Errorf("Expected error for missing field but got nothing")
+	}
+}
+
+type countingKey struct {
+	v pdp.AttributeValue
+	n *int
+}
+
+func (k countingKey) GetResultType() pdp.Type {
+	return k.v.GetResultType()
+}
+
+func (k countingKey) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
+	*k.n++
+	return k.v, nil
+}
+
+func TestSelectorCalculateTrace(t *testing.T) {
+	uri, err := url.Parse("local:test-content/test-item")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	sub := strtree.NewTree()
+	sub.InplaceInsert("b", true)
+
+	m := strtree.NewTree()
+	m.InplaceInsert("a", pdp.MakeContentStringMap(sub))
+
+	cs := pdp.NewLocalContentStorage([]*pdp.LocalContent{
+		pdp.NewLocalContent("test-content", nil, pdp.MakeSymbols(),
+			[]*pdp.ContentItem{
+				pdp.MakeContentMappingItem(
+					"test-item",
+					pdp.TypeBoolean,
+					pdp.MakeSignature(pdp.TypeString, pdp.TypeString),
+					pdp.MakeContentStringMap(m),
+				),
+			},
+		),
+	})
+
+	ctx, err := pdp.NewContext(cs, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	ctx.EnableTrace()
+
+	var first, second int
+	e, err := pdp.MakeSelector(uri, []pdp.Expression{
+		countingKey{v: pdp.MakeStringValue("a"), n: &first},
+		countingKey{v: pdp.MakeStringValue("b"), n: &second},
+	}, pdp.TypeBoolean)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	p := pdp.NewPolicy("test", false, pdp.Target{},
+		[]*pdp.Rule{pdp.NewRule("permit", false, pdp.Target{}, e, pdp.EffectPermit, nil)},
+		pdp.RuleCombiningAlgs["firstapplicableeffect"], nil, nil)
+
+	if r := p.Calculate(ctx); r.Effect != pdp.EffectPermit {
+		t.Errorf("Expected permit but got %s (%v)", pdp.EffectNameFromEnum(r.Effect), r.Status)
+	}
+
+	if first != 1 || second != 1 {
+		t.Errorf("Expected each path expression calculated once but got %d and %d", first, second)
+	}
+
+	n := ctx.Trace()
+	if n == nil || len(n.Children) != 1 || len(n.Children[0].Selectors) != 1 {
+		t.Fatalf("Expected single selector call in trace but got %#v", n)
+	}
+
+	s := n.Children[0].Selectors[0]
+	if len(s.Keys) != 2 || s.Keys[0] != "\"a\"" || s.Keys[1] != "\"b\"" || s.Result != "true" {
+		t.Errorf("Expected selector call with \"a\" and \"b\" keys and true result but got %#v", s)
+	}
+}
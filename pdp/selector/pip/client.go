@@ -1,9 +1,13 @@
 package pip
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/infobloxopen/themis/pdp"
 	"github.com/infobloxopen/themis/pip/client"
 )
 
@@ -47,6 +51,30 @@ func ClearCache() {
 	})
 }
 
+// CallHandler defines a function prototype to call after each request
+// of PIP selector with duration of the request and its error.
+type CallHandler func(d time.Duration, err error)
+
+type callHandlerKey struct{}
+
+// NewContextWithCallHandler returns copy of given request context which
+// carries handler for PIP selector requests. Selectors call the handler
+// for requests made while evaluating pdp.Context with the request context
+// (see pdp.Context.SetRequestContext).
+func NewContextWithCallHandler(ctx context.Context, h CallHandler) context.Context {
+	return context.WithValue(ctx, callHandlerKey{}, h)
+}
+
+// SetTracer sets OpenTracing tracer for new PIP clients. Nil tracer turns
+// tracing off.
+func SetTracer(t ot.Tracer) {
+	tracer.Store(tracerHolder{t: t})
+}
+
+type tracerHolder struct {
+	t ot.Tracer
+}
+
 type timedClient struct {
 	t *int64
 	u *int64
@@ -63,6 +91,7 @@ var (
 	clientTTL *int64
 	isHotSpot *int64
 	cacheOpts *atomic.Value
+	tracer    *atomic.Value
 )
 
 func init() {
@@ -74,6 +103,9 @@ func init() {
 
 	cacheOpts = new(atomic.Value)
 	ClearCache()
+
+	tracer = new(atomic.Value)
+	SetTracer(nil)
 }
 
 func makeClientOptions(net, addr string, k8s bool) []client.Option {
@@ -83,6 +115,7 @@ func makeClientOptions(net, addr string, k8s bool) []client.Option {
 			client.WithAddress(addr),
 			makeBalancerOption(),
 			makeRadarOption(k8s),
+			client.WithTracer(tracer.Load().(tracerHolder).t),
 		},
 		makeCacheOptions()...,
 	)
@@ -107,17 +140,33 @@ func makeRadarOption(k8s bool) client.Option {
 func makeCacheOptions() []client.Option {
 	if v := cacheOpts.Load(); v != nil {
 		if co := v.(cacheOptions); co.cache {
+			opts := []client.Option{client.WithCacheTTL(co.ttl)}
 			if co.size > 0 {
-				return []client.Option{client.WithCacheTTLAndMaxSize(co.ttl, co.size)}
+				opts[0] = client.WithCacheTTLAndMaxSize(co.ttl, co.size)
 			}
 
-			return []client.Option{client.WithCacheTTL(co.ttl)}
+			return opts
 		}
 	}
 
 	return nil
 }
 
+// getInfo makes request with given client and reports its duration and
+// error to call handler of the request context if any.
+func getInfo(ctx context.Context, c client.Client, id string, args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	h, _ := ctx.Value(callHandlerKey{}).(CallHandler)
+	if h == nil {
+		return c.GetContext(ctx, id, args)
+	}
+
+	start := time.Now()
+	v, err := c.GetContext(ctx, id, args)
+	h(time.Since(start), err)
+
+	return v, err
+}
+
 func makeTimedClient(net, addr string, k8s bool) (timedClient, error) {
 	c := client.NewClient(makeClientOptions(net, addr, k8s)...)
 	if err := c.Connect(); err != nil {
@@ -1,9 +1,13 @@
 package pip
 
 import (
+	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/infobloxopen/themis/pdp"
 )
 
 func TestSetClientTTL(t *testing.T) {
@@ -143,6 +147,58 @@ func TestMakeCacheOptions(t *testing.T) {
 	}
 }
 
+func TestGetInfoCallHandler(t *testing.T) {
+	c := &testClient{err: errors.New("test")}
+
+	first := 0
+	firstErrs := 0
+	firstCtx := NewContextWithCallHandler(context.Background(), func(d time.Duration, err error) {
+		first++
+		if err != nil {
+			firstErrs++
+		}
+	})
+
+	second := 0
+	secondCtx := NewContextWithCallHandler(context.Background(), func(d time.Duration, err error) {
+		second++
+	})
+
+	getInfo(firstCtx, c, "test", nil)
+	getInfo(secondCtx, c, "test", nil)
+	getInfo(firstCtx, c, "test", nil)
+	getInfo(context.Background(), c, "test", nil)
+
+	if first != 2 || firstErrs != 2 {
+		t.Errorf("expected 2 calls and 2 errors for first handler but got %d and %d", first, firstErrs)
+	}
+
+	if second != 1 {
+		t.Errorf("expected 1 call for second handler but got %d", second)
+	}
+
+	if c.n != 4 {
+		t.Errorf("expected 4 requests to client but got %d", c.n)
+	}
+}
+
+type testClient struct {
+	n   int
+	err error
+}
+
+func (c *testClient) Connect() error { return nil }
+func (c *testClient) Close()         {}
+
+func (c *testClient) Get(path string, args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	return c.GetContext(context.Background(), path, args)
+}
+
+func (c *testClient) GetContext(ctx context.Context, path string, args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	c.n++
+	return pdp.UndefinedValue, c.err
+}
+
 func TestMakeTimedClient(t *testing.T) {
 	c, err := makeTimedClient("tcp", "localhost:5600", false)
 	if err != nil {
@@ -81,6 +81,7 @@ func (s *selectorK8s) Initialize() {
 type PipSelector struct {
 	clients *clientsPool
 
+	uri  string
 	net  string
 	k8s  bool
 	addr string
@@ -91,6 +92,9 @@ type PipSelector struct {
 
 	def pdp.Expression
 	err pdp.Expression
+
+	field string
+	ft    pdp.Type
 }
 
 // MakePipSelector creates an expression base on PIP selector. Client pool must
@@ -98,6 +102,7 @@ type PipSelector struct {
 func MakePipSelector(clients *clientsPool, uri *url.URL, path []pdp.Expression, t pdp.Type, opts ...pdp.SelectorOption) (pdp.Expression, error) {
 	ps := PipSelector{
 		clients: clients,
+		uri:     uri.String(),
 		net:     "tcp",
 		addr:    uri.Host,
 		id:      uri.Path,
@@ -119,7 +124,23 @@ func MakePipSelector(clients *clientsPool, uri *url.URL, path []pdp.Expression,
 			} else {
 				panic("bad data provided as pip selector option " + pdp.SelectorOptionError)
 			}
+		case pdp.SelectorOptionField:
+			if field, ok := opt.Data.(string); ok {
+				ps.field = field
+			} else {
+				panic("bad data provided as pip selector option " + pdp.SelectorOptionField)
+			}
+		}
+	}
+
+	ps.ft = t
+	if len(ps.field) > 0 {
+		ft, err := pdp.GetStructFieldType(t, ps.field)
+		if err != nil {
+			return PipSelector{}, err
 		}
+
+		ps.ft = ft
 	}
 
 	switch strings.ToLower(uri.Scheme) {
@@ -141,31 +162,64 @@ func MakePipSelector(clients *clientsPool, uri *url.URL, path []pdp.Expression,
 }
 
 // GetResultType implements pdp.Expression interface and returns type of
-// selector's result.
+// selector's result (or type of the field if the selector gets a field of
+// struct value).
 func (s PipSelector) GetResultType() pdp.Type {
-	return s.t
+	return s.ft
+}
+
+// GetArguments implements pdp.ArgumentsExpression interface and returns
+// path expressions of the selector along with its default and error
+// expressions.
+func (s PipSelector) GetArguments() []pdp.Expression {
+	args := make([]pdp.Expression, 0, len(s.path)+2)
+	args = append(args, s.path...)
+	return append(args, s.def, s.err)
 }
 
 // Calculate implements pdp.Expression interface and obtains result from
 // unified PIP for given context.
 func (s PipSelector) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
+	vals, err := s.calculatePath(ctx)
+
+	v := pdp.UndefinedValue
+	if err != nil {
+		v, err = s.handleError(ctx, err)
+	} else {
+		v, err = s.calculate(ctx, vals)
+	}
+
+	if ctx.Tracing() {
+		ctx.TraceSelector(s.uri, vals, v, err)
+	}
+
+	return v, err
+}
+
+// calculatePath returns values of path expressions. On error it returns
+// values calculated before the failed expression.
+func (s PipSelector) calculatePath(ctx *pdp.Context) ([]pdp.AttributeValue, error) {
 	vals := make([]pdp.AttributeValue, 0, len(s.path))
 	for i, item := range s.path {
 		v, err := item.Calculate(ctx)
 		if err != nil {
-			return s.handleError(ctx, fmt.Errorf("Failed to calculate argument %d: %s", i+1, err))
+			return vals, fmt.Errorf("Failed to calculate argument %d: %s", i+1, err)
 		}
 
 		vals = append(vals, v)
 	}
 
+	return vals, nil
+}
+
+func (s PipSelector) calculate(ctx *pdp.Context, vals []pdp.AttributeValue) (pdp.AttributeValue, error) {
 	c, err := s.clients.Get(s.addr)
 	if err != nil {
 		return s.handleError(ctx, fmt.Errorf("Failed to get PIP client for %s: %s", s.addr, err))
 	}
 	defer s.clients.Free(s.addr)
 
-	r, err := c.Get(s.id, vals)
+	r, err := getInfo(ctx.RequestContext(), c, s.id, vals)
 	if err != nil {
 		return s.handleError(ctx, fmt.Errorf("Failed to get information from PIP: %s", err))
 	}
@@ -175,6 +229,13 @@ func (s PipSelector) Calculate(ctx *pdp.Context) (pdp.AttributeValue, error) {
 		return s.handleError(ctx, fmt.Errorf("Expected content with value type %q but got %q", s.t, r.GetResultType()))
 	}
 
+	if len(s.field) > 0 {
+		r, err = r.GetField(s.field)
+		if err != nil {
+			return s.handleError(ctx, err)
+		}
+	}
+
 	return r, nil
 }
 
@@ -1,6 +1,7 @@
 package pip
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -441,3 +442,7 @@ func (c *testPipClient) Close() {
 func (c *testPipClient) Get(string, []pdp.AttributeValue) (pdp.AttributeValue, error) {
 	panic("not implemented")
 }
+
+func (c *testPipClient) GetContext(context.Context, string, []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	panic("not implemented")
+}
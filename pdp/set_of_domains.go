@@ -3,6 +3,7 @@ package pdp
 import (
 	"sort"
 
+	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
 )
 
@@ -48,3 +49,44 @@ func (p domainPairList) Less(i, j int) bool {
 func (p domainPairList) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 }
+
+// setOfDomainsBuilder collects domains to a new set of domains. Each domain
+// is added only once and gets integer value which keeps order of addition.
+type setOfDomainsBuilder struct {
+	t    *domaintree.Node
+	seen map[string]struct{}
+}
+
+func newSetOfDomainsBuilder() *setOfDomainsBuilder {
+	return &setOfDomainsBuilder{
+		t:    new(domaintree.Node),
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (b *setOfDomainsBuilder) add(d domain.Name) {
+	k := d.String()
+	if _, ok := b.seen[k]; ok {
+		return
+	}
+
+	b.t.InplaceInsert(d, len(b.seen))
+	b.seen[k] = struct{}{}
+}
+
+// enumerateSetOfDomains returns domains of the set ordered by assigned integer
+// values.
+func enumerateSetOfDomains(v *domaintree.Node) ([]domain.Name, error) {
+	s := SortSetOfDomains(v)
+	out := make([]domain.Name, len(s))
+	for i, item := range s {
+		d, err := domain.MakeNameFromString(item)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = d
+	}
+
+	return out, nil
+}
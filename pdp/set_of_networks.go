@@ -48,3 +48,28 @@ func (p networkPairList) Less(i, j int) bool {
 func (p networkPairList) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 }
+
+// setOfNetworksBuilder collects networks to a new set of networks. Each
+// network is added only once and gets integer value which keeps order of
+// addition.
+type setOfNetworksBuilder struct {
+	t    *iptree.Tree
+	seen map[string]struct{}
+}
+
+func newSetOfNetworksBuilder() *setOfNetworksBuilder {
+	return &setOfNetworksBuilder{
+		t:    iptree.NewTree(),
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (b *setOfNetworksBuilder) add(n *net.IPNet) {
+	k := n.String()
+	if _, ok := b.seen[k]; ok {
+		return
+	}
+
+	b.t.InplaceInsertNet(n, len(b.seen))
+	b.seen[k] = struct{}{}
+}
@@ -13,6 +13,8 @@ type PolicyStorage struct {
 	tag      *uuid.UUID
 	symbols  Symbols
 	policies Evaluable
+
+	decisionPath bool
 }
 
 // NewPolicyStorage creates new policy storage with given root policy set
@@ -21,9 +23,10 @@ type PolicyStorage struct {
 // incrementally.
 func NewPolicyStorage(p Evaluable, s Symbols, t *uuid.UUID) *PolicyStorage {
 	return &PolicyStorage{
-		tag:      t,
-		symbols:  s,
-		policies: p,
+		tag:          t,
+		symbols:      s,
+		policies:     p,
+		decisionPath: usesDecisionPath(p),
 	}
 }
 
@@ -32,6 +35,27 @@ func (s *PolicyStorage) Root() Evaluable {
 	return s.policies
 }
 
+// Symbols returns symbol tables of the storage.
+func (s *PolicyStorage) Symbols() Symbols {
+	return s.symbols
+}
+
+// UsesDecisionPath returns true if obligations of the policies have
+// "decision-path" or "decision-rule" expressions. Contexts for such policies
+// should collect decision path (see Context.EnableDecisionPath).
+func (s *PolicyStorage) UsesDecisionPath() bool {
+	return s.decisionPath
+}
+
+// GetTag returns tag of the storage or nil if the storage isn't tagged.
+func (s *PolicyStorage) GetTag() *uuid.UUID {
+	if s == nil {
+		return nil
+	}
+
+	return s.tag
+}
+
 // CheckTag checks if given tag matches to the storage tag. If the storage
 // doesn't have any tag, no tag matches the storage and vice versa nil tag
 // doesn't match any storage.
@@ -266,9 +290,10 @@ func (t *PolicyStorageTransaction) Commit() (*PolicyStorage, error) {
 	}
 
 	return &PolicyStorage{
-		tag:      &t.tag,
-		symbols:  t.symbols,
-		policies: t.policies,
+		tag:          &t.tag,
+		symbols:      t.symbols,
+		policies:     t.policies,
+		decisionPath: usesDecisionPath(t.policies),
 	}, nil
 }
 
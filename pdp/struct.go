@@ -0,0 +1,175 @@
+package pdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StructField represents named field of struct type.
+type StructField struct {
+	Name string
+	Type Type
+}
+
+// StructType instance represents custom record type. A value of the type
+// holds a value for each of named fields in order of declaration.
+type StructType struct {
+	n string
+	k string
+	f map[string]int
+	b []StructField
+}
+
+// StructFieldTypes lists types a struct field can have in addition to enum
+// types.
+var StructFieldTypes = makeTypeSet(
+	TypeBoolean,
+	TypeString,
+	TypeInteger,
+	TypeFloat,
+	TypeAddress,
+	TypeNetwork,
+	TypeDomain,
+	TypeListOfStrings,
+	TypeTime,
+	TypeDuration,
+)
+
+// NewStructType function creates new custom type with given name and fields.
+// It supports up to 255 fields. Field names are case sensitive and should be
+// unique for the type. A field can have any type from StructFieldTypes or
+// an enum type.
+func NewStructType(name string, fields ...StructField) (Type, error) {
+	key := strings.ToLower(name)
+	if _, ok := BuiltinTypes[key]; ok {
+		return nil, newDuplicatesBuiltinTypeError(name)
+	}
+
+	if len(fields) <= 0 {
+		return nil, newNoStructFieldsDefinedError(name)
+	}
+
+	if len(fields) > 255 {
+		return nil, newTooManyStructFieldsDefinedError(name, len(fields))
+	}
+
+	f := make(map[string]int, len(fields))
+	for i, sf := range fields {
+		if !isStructFieldType(sf.Type) {
+			return nil, newInvalidStructFieldTypeError(name, sf.Name, sf.Type)
+		}
+
+		if j, ok := f[sf.Name]; ok {
+			return nil, newDuplicateStructFieldError(name, sf.Name, i, j)
+		}
+		f[sf.Name] = i
+	}
+
+	return &StructType{
+		n: name,
+		k: key,
+		f: f,
+		b: fields,
+	}, nil
+}
+
+func isStructFieldType(t Type) bool {
+	if _, ok := t.(*EnumType); ok {
+		return true
+	}
+
+	return StructFieldTypes.Contains(t)
+}
+
+// String method returns human readable type name.
+func (t *StructType) String() string {
+	return t.n
+}
+
+// GetKey method returns case insensitive (always lowercase) type key.
+func (t *StructType) GetKey() string {
+	return t.k
+}
+
+// Match checks equivalence of different struct types. Struct types match iff
+// they have the same number of fields and types of fields at the same
+// positions match.
+func (t *StructType) Match(ot Type) bool {
+	sot, ok := ot.(*StructType)
+	if !ok {
+		return false
+	}
+
+	if t == sot {
+		return true
+	}
+
+	if len(t.b) != len(sot.b) {
+		return false
+	}
+
+	for i, f := range t.b {
+		if !f.Type.Match(sot.b[i].Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fields method returns all fields of the type in order of declaration.
+func (t *StructType) Fields() []StructField {
+	out := make([]StructField, len(t.b))
+	copy(out, t.b)
+
+	return out
+}
+
+// GetFieldIndex method returns position of field with given name. If there
+// is no field with the name it returns -1.
+func (t *StructType) GetFieldIndex(name string) int {
+	if i, ok := t.f[name]; ok {
+		return i
+	}
+
+	return -1
+}
+
+// GetStructFieldType returns type of field with given name. It returns error
+// if given type isn't a struct type or the struct doesn't have the field.
+func GetStructFieldType(t Type, name string) (Type, error) {
+	st, ok := t.(*StructType)
+	if !ok {
+		return nil, newAttributeValueStructTypeError(t)
+	}
+
+	i := st.GetFieldIndex(name)
+	if i < 0 {
+		return nil, newUnknownStructFieldError(t, name)
+	}
+
+	return st.b[i].Type, nil
+}
+
+// newAbstractStructType creates struct type for given fields values which
+// come without type declaration (for example from request or PIP response).
+// Fields get names like f01, f02 and so on.
+func newAbstractStructType(v []AttributeValue) *StructType {
+	fields := make([]StructField, len(v))
+	f := make(map[string]int, len(v))
+	for i, v := range v {
+		name := fmt.Sprintf("f%02d", i+1)
+		fields[i] = StructField{
+			Name: name,
+			Type: v.t,
+		}
+		f[name] = i
+	}
+
+	return &StructType{
+		n: "abstractStruct",
+		k: "abstractstruct",
+		f: f,
+		b: fields,
+	}
+}
@@ -0,0 +1,197 @@
+package pdp
+
+import "testing"
+
+func TestStructType(t *testing.T) {
+	et, err := NewEnumType("Level", "low", "high")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	st, err := NewStructType("Device",
+		StructField{Name: "name", Type: TypeString},
+		StructField{Name: "level", Type: et},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s := st.String(); s != "Device" {
+		t.Errorf("Expected %q as type name but got %q", "Device", s)
+	}
+
+	if k := st.GetKey(); k != "device" {
+		t.Errorf("Expected %q as type key but got %q", "device", k)
+	}
+
+	if ft, err := GetStructFieldType(st, "level"); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if ft != et {
+		t.Errorf("Expected %q as field type but got %q", et, ft)
+	}
+
+	_, err = GetStructFieldType(st, "missing")
+	if err == nil {
+		t.Errorf("Expected *unknownStructFieldError but got nothing")
+	} else if _, ok := err.(*unknownStructFieldError); !ok {
+		t.Errorf("Expected *unknownStructFieldError but got %T (%s)", err, err)
+	}
+
+	_, err = GetStructFieldType(TypeString, "level")
+	if err == nil {
+		t.Errorf("Expected *attributeValueStructTypeError but got nothing")
+	} else if _, ok := err.(*attributeValueStructTypeError); !ok {
+		t.Errorf("Expected *attributeValueStructTypeError but got %T (%s)", err, err)
+	}
+
+	ost, err := NewStructType("OtherDevice",
+		StructField{Name: "id", Type: TypeString},
+		StructField{Name: "level", Type: et},
+	)
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if !ost.Match(st) {
+		t.Errorf("Expected that %q matches %q", ost, st)
+	}
+
+	_, err = NewStructType("Empty")
+	if err == nil {
+		t.Errorf("Expected *noStructFieldsDefinedError but got nothing")
+	} else if _, ok := err.(*noStructFieldsDefinedError); !ok {
+		t.Errorf("Expected *noStructFieldsDefinedError but got %T (%s)", err, err)
+	}
+
+	_, err = NewStructType("Duplicate",
+		StructField{Name: "name", Type: TypeString},
+		StructField{Name: "name", Type: TypeInteger},
+	)
+	if err == nil {
+		t.Errorf("Expected *duplicateStructFieldError but got nothing")
+	} else if _, ok := err.(*duplicateStructFieldError); !ok {
+		t.Errorf("Expected *duplicateStructFieldError but got %T (%s)", err, err)
+	}
+
+	_, err = NewStructType("Nested",
+		StructField{Name: "device", Type: st},
+	)
+	if err == nil {
+		t.Errorf("Expected *invalidStructFieldTypeError but got nothing")
+	} else if _, ok := err.(*invalidStructFieldTypeError); !ok {
+		t.Errorf("Expected *invalidStructFieldTypeError but got %T (%s)", err, err)
+	}
+}
+
+func TestStructValue(t *testing.T) {
+	et, err := NewEnumType("Level", "low", "high")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	st, err := NewStructType("Device",
+		StructField{Name: "name", Type: TypeString},
+		StructField{Name: "level", Type: et},
+		StructField{Name: "weight", Type: TypeInteger},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	high, err := MakeEnumValue("high", et)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	v, err := MakeStructValue(st, MakeStringValue("router"), high, MakeIntegerValue(5))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	e := "struct<\"Device\">(name: \"router\", level: enum<\"Level\">(\"high\"), ...)"
+	if s := v.describe(); s != e {
+		t.Errorf("Expected %q description but got %q", e, s)
+	}
+
+	if s, err := v.Serialize(); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s != "\"router\",\"high\",\"5\"" {
+		t.Errorf("Expected %q but got %q", "\"router\",\"high\",\"5\"", s)
+	}
+
+	if f, err := v.GetField("level"); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s, err := f.GetEnum(); err != nil || s != "high" {
+		t.Errorf("Expected %q but got %s (%v)", "high", f.describe(), err)
+	}
+
+	if _, err := v.GetField("missing"); err == nil {
+		t.Errorf("Expected *unknownStructFieldError but got nothing")
+	} else if _, ok := err.(*unknownStructFieldError); !ok {
+		t.Errorf("Expected *unknownStructFieldError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeStructValue(st, MakeStringValue("router"), high)
+	if err == nil {
+		t.Errorf("Expected *structValueFieldsNumberError but got nothing")
+	} else if _, ok := err.(*structValueFieldsNumberError); !ok {
+		t.Errorf("Expected *structValueFieldsNumberError but got %T (%s)", err, err)
+	}
+
+	_, err = MakeStructValue(st, MakeStringValue("router"), MakeStringValue("high"), MakeIntegerValue(5))
+	if err == nil {
+		t.Errorf("Expected *structValueFieldTypeError but got nothing")
+	} else if _, ok := err.(*structValueFieldTypeError); !ok {
+		t.Errorf("Expected *structValueFieldTypeError but got %T (%s)", err, err)
+	}
+
+	var b [64]byte
+	n, err := putRequestAttributeValue(b[:], v)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s, err := calcRequestAttributeSize(v); err != nil {
+		t.Errorf("Expected no error but got %s", err)
+	} else if s != n {
+		t.Errorf("Expected %d bytes as struct size but got %d", n, s)
+	}
+
+	a, m, err := getRequestAttributeValue(b[:n])
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if m != n {
+		t.Errorf("Expected %d bytes to be consumed but got %d", n, m)
+	}
+
+	r, err := a.Rebind(st)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s := r.describe(); s != e {
+		t.Errorf("Expected %q description but got %q", e, s)
+	}
+
+	ost, err := NewStructType("Other",
+		StructField{Name: "name", Type: TypeString},
+		StructField{Name: "level", Type: TypeString},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	_, err = a.Rebind(ost)
+	if err == nil {
+		t.Errorf("Expected *notMatchingTypeRebindError but got nothing")
+	} else if _, ok := err.(*notMatchingTypeRebindError); !ok {
+		t.Errorf("Expected *notMatchingTypeRebindError but got %T (%s)", err, err)
+	}
+
+	_, _, err = getRequestStructValue([]byte{0})
+	if err == nil {
+		t.Errorf("Expected *requestAttributeUnmarshallingStructSizeError but got nothing")
+	} else if _, ok := err.(*requestAttributeUnmarshallingStructSizeError); !ok {
+		t.Errorf("Expected *requestAttributeUnmarshallingStructSizeError but got %T (%s)", err, err)
+	}
+}
@@ -1,6 +1,9 @@
 package pdp
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // Symbols wraps type and attribute symbol tables.
 type Symbols struct {
@@ -92,6 +95,33 @@ func (s Symbols) GetAttribute(ID string) (Attribute, bool) {
 	return Attribute{}, false
 }
 
+// AttributeIDs returns sorted ids of all attributes in the symbol table.
+func (s Symbols) AttributeIDs() []string {
+	out := make([]string, 0, len(s.attrs))
+	for id := range s.attrs {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// bindAttributeValue converts value of attribute with given id to custom type
+// declared for the attribute. It returns the value as is if there is no such
+// declaration or the value can't be converted to the type.
+func (s Symbols) bindAttributeValue(ID string, v AttributeValue) (AttributeValue, error) {
+	a, ok := s.attrs[ID]
+	if !ok || a.t == v.t {
+		return v, nil
+	}
+
+	if _, ok := a.t.(*builtinType); ok || !canRebind(v.t, a.t) {
+		return v, nil
+	}
+
+	return v.Rebind(a.t)
+}
+
 func (s Symbols) makeROCopy() Symbols {
 	return Symbols{
 		types: s.types,
@@ -2,7 +2,7 @@ package pdp
 
 // Match represents match expression. Specific kind of boolean expression which
 // can have two arguments. One of arguments should be immediate value and other
-// should be attribute designator.
+// should be attribute designator or variable reference.
 type Match struct {
 	m Expression
 }
@@ -133,10 +133,10 @@ func (t *Target) Append(item AnyOf) {
 
 func makeMatchStatus(err boundError, effect int) Response {
 	if effect == EffectDeny {
-		return Response{EffectIndeterminateD, err, nil}
+		return Response{EffectIndeterminateD, err, nil, nil}
 	}
 
-	return Response{EffectIndeterminateP, err, nil}
+	return Response{EffectIndeterminateP, err, nil, nil}
 }
 
 func combineEffectAndStatus(err boundError, r Response) Response {
@@ -145,18 +145,18 @@ func combineEffectAndStatus(err boundError, r Response) Response {
 	}
 
 	if r.Effect == EffectNotApplicable {
-		return Response{EffectNotApplicable, err, nil}
+		return Response{EffectNotApplicable, err, nil, nil}
 	}
 
 	if r.Effect == EffectDeny || r.Effect == EffectIndeterminateD {
-		return Response{EffectIndeterminateD, err, nil}
+		return Response{EffectIndeterminateD, err, nil, nil}
 	}
 
 	if r.Effect == EffectPermit || r.Effect == EffectIndeterminateP {
-		return Response{EffectIndeterminateP, err, nil}
+		return Response{EffectIndeterminateP, err, nil, nil}
 	}
 
-	return Response{EffectIndeterminateDP, err, nil}
+	return Response{EffectIndeterminateDP, err, nil, nil}
 }
 
 // TargetCompatibleArgument* identify expressions which supported as
@@ -168,6 +168,9 @@ const (
 	// TargetCompatibleArgumentAttributeDesignator is AttributeDesignator
 	// expression.
 	TargetCompatibleArgumentAttributeDesignator
+	// TargetCompatibleArgumentVariableReference is VariableReference
+	// expression.
+	TargetCompatibleArgumentVariableReference
 )
 
 // CheckExpressionAsTargetArgument checks if given expression can be used
@@ -180,6 +183,9 @@ func CheckExpressionAsTargetArgument(e Expression) (int, bool) {
 
 	case AttributeDesignator:
 		return TargetCompatibleArgumentAttributeDesignator, true
+
+	case VariableReference:
+		return TargetCompatibleArgumentVariableReference, true
 	}
 
 	return 0, false
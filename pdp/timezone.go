@@ -0,0 +1,116 @@
+package pdp
+
+import "time"
+
+var timeOfDayLayouts = []string{
+	"15:04:05",
+	"15:04",
+}
+
+func loadLocation(tz string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, newInvalidTimeZoneError(tz, err)
+	}
+
+	return loc, nil
+}
+
+// precompileLocation loads time zone if it's given as an immediate value of
+// string type. It returns nil if the time zone can be calculated only for
+// particular request.
+func precompileLocation(e Expression) (*time.Location, error) {
+	v, ok := e.(AttributeValue)
+	if !ok {
+		return nil, nil
+	}
+
+	s, err := v.str()
+	if err != nil {
+		return nil, err
+	}
+
+	return loadLocation(s)
+}
+
+func (c *Context) calculateLocationExpression(e Expression, loc *time.Location) (*time.Location, error) {
+	if loc != nil {
+		return loc, nil
+	}
+
+	s, err := c.calculateStringExpression(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadLocation(s)
+}
+
+// parseTimeOfDay converts string in form of hh:mm or hh:mm:ss to duration
+// since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	for _, layout := range timeOfDayLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour +
+				time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second, nil
+		}
+	}
+
+	return 0, newInvalidTimeOfDayError(s)
+}
+
+// precompileTimeOfDay parses time of day if it's given as an immediate value
+// of string type. It returns false if the time of day can be calculated only
+// for particular request.
+func precompileTimeOfDay(e Expression) (time.Duration, bool, error) {
+	v, ok := e.(AttributeValue)
+	if !ok {
+		return 0, false, nil
+	}
+
+	s, err := v.str()
+	if err != nil {
+		return 0, false, err
+	}
+
+	d, err := parseTimeOfDay(s)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return d, true, nil
+}
+
+func (c *Context) calculateTimeOfDayExpression(e Expression, d time.Duration, ok bool) (time.Duration, error) {
+	if ok {
+		return d, nil
+	}
+
+	s, err := c.calculateStringExpression(e)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTimeOfDay(s)
+}
+
+// sinceMidnight returns duration passed since midnight of the same day in
+// time zone of given time.
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour +
+		time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second +
+		time.Duration(t.Nanosecond())
+}
+
+// inTimeWindow checks if given time of day falls into window [start, end).
+// If end is less than start the window wraps around midnight.
+func inTimeWindow(d, start, end time.Duration) bool {
+	if start <= end {
+		return d >= start && d < end
+	}
+
+	return d >= start || d < end
+}
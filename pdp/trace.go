@@ -0,0 +1,179 @@
+package pdp
+
+// TraceNode* constants are kinds of decision trace nodes.
+const (
+	// TraceNodePolicySet stands for policy set.
+	TraceNodePolicySet = "policy set"
+	// TraceNodePolicy stands for policy.
+	TraceNodePolicy = "policy"
+	// TraceNodeRule stands for rule.
+	TraceNodeRule = "rule"
+)
+
+// Target and condition results of decision trace node.
+const (
+	TraceMatch   = "match"
+	TraceNoMatch = "no match"
+	TraceTrue    = "true"
+	TraceFalse   = "false"
+)
+
+// TraceNode describes evaluation of policy set, policy or rule for a request.
+// Target holds TraceMatch, TraceNoMatch or text of error occurred on target
+// evaluation. Condition holds TraceTrue, TraceFalse or error text and it's
+// empty if rule has no condition or it hasn't been evaluated. Algorithm is name
+// of combining algorithm of policy set or policy while Children shows how
+// the algorithm has evaluated child nodes. Effect and Status are result of
+// the node evaluation.
+type TraceNode struct {
+	Kind      string          `json:"kind"`
+	ID        string          `json:"id,omitempty"`
+	Hidden    bool            `json:"hidden,omitempty"`
+	Target    string          `json:"target,omitempty"`
+	Condition string          `json:"condition,omitempty"`
+	Algorithm string          `json:"algorithm,omitempty"`
+	Selectors []SelectorTrace `json:"selectors,omitempty"`
+	Children  []*TraceNode    `json:"children,omitempty"`
+	Effect    string          `json:"effect"`
+	Status    string          `json:"status,omitempty"`
+}
+
+// SelectorTrace describes a selector call. Keys contains values of selector
+// path expressions the selector has calculated (it's shorter than the path
+// if the selector has stopped before calculating all of them). Result is
+// value returned by the selector and Error is text of error if the selector
+// has failed.
+type SelectorTrace struct {
+	URI    string   `json:"uri"`
+	Keys   []string `json:"keys,omitempty"`
+	Result string   `json:"result,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+type trace struct {
+	root  *TraceNode
+	stack []*TraceNode
+}
+
+// EnableTrace makes context to collect decision trace. The trace is
+// available with Trace method after policies evaluation.
+func (c *Context) EnableTrace() {
+	c.trace = &trace{}
+}
+
+// Tracing returns true if context collects decision trace.
+func (c *Context) Tracing() bool {
+	return c != nil && c.trace != nil
+}
+
+// Trace returns root node of collected decision trace. It returns nil if
+// tracing hasn't been enabled or nothing has been evaluated.
+func (c *Context) Trace() *TraceNode {
+	if !c.Tracing() {
+		return nil
+	}
+
+	return c.trace.root
+}
+
+// TraceSelector records selector call to the node which is being evaluated.
+// It does nothing if context doesn't collect decision trace. Selectors call
+// it with values of path expressions they have calculated for the call and
+// result. The keys aren't calculated again so the trace shows exactly what
+// the selector has used.
+func (c *Context) TraceSelector(uri string, keys []AttributeValue, v AttributeValue, err error) {
+	if !c.Tracing() || len(c.trace.stack) <= 0 {
+		return
+	}
+
+	s := SelectorTrace{
+		URI:  uri,
+		Keys: make([]string, len(keys)),
+	}
+
+	for i, k := range keys {
+		s.Keys[i] = k.describe()
+	}
+
+	if err != nil {
+		s.Error = err.Error()
+	} else {
+		s.Result = v.describe()
+	}
+
+	n := c.trace.stack[len(c.trace.stack)-1]
+	n.Selectors = append(n.Selectors, s)
+}
+
+// enterTrace starts new trace node as a child of the node which is being
+// evaluated. It returns nil if context doesn't collect decision trace.
+func (c *Context) enterTrace(kind, ID string, hidden bool, alg interface{}) *TraceNode {
+	if !c.Tracing() {
+		return nil
+	}
+
+	n := &TraceNode{
+		Kind:      kind,
+		ID:        ID,
+		Hidden:    hidden,
+		Algorithm: inspectAlgorithmName(alg),
+	}
+
+	if len(c.trace.stack) > 0 {
+		p := c.trace.stack[len(c.trace.stack)-1]
+		p.Children = append(p.Children, n)
+	} else {
+		c.trace.root = n
+	}
+
+	c.trace.stack = append(c.trace.stack, n)
+	return n
+}
+
+// leaveTrace completes given trace node with the response.
+func (c *Context) leaveTrace(n *TraceNode, r Response) {
+	if n == nil {
+		return
+	}
+
+	n.Effect = EffectNameFromEnum(r.Effect)
+	if r.Status != nil {
+		n.Status = r.Status.Error()
+	}
+
+	c.trace.stack = c.trace.stack[:len(c.trace.stack)-1]
+}
+
+func (n *TraceNode) setTarget(match bool, err error) {
+	if n == nil {
+		return
+	}
+
+	switch {
+	case err != nil:
+		n.Target = err.Error()
+
+	case match:
+		n.Target = TraceMatch
+
+	default:
+		n.Target = TraceNoMatch
+	}
+}
+
+func (n *TraceNode) setCondition(c bool, err error) {
+	if n == nil {
+		return
+	}
+
+	switch {
+	case err != nil:
+		n.Condition = err.Error()
+
+	case c:
+		n.Condition = TraceTrue
+
+	default:
+		n.Condition = TraceFalse
+	}
+}
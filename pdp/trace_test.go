@@ -0,0 +1,89 @@
+package pdp
+
+import "testing"
+
+type traceSelectorExpr struct{}
+
+func (e traceSelectorExpr) GetResultType() Type {
+	return TypeBoolean
+}
+
+func (e traceSelectorExpr) Calculate(ctx *Context) (AttributeValue, error) {
+	k, err := MakeStringDesignator("x").Calculate(ctx)
+	if err != nil {
+		return UndefinedValue, err
+	}
+
+	v := MakeBooleanValue(true)
+	ctx.TraceSelector("test:selector", []AttributeValue{k}, v, nil)
+	return v, nil
+}
+
+func TestContextTrace(t *testing.T) {
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "x", MakeStringValue("test"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	target := MakeTarget()
+	anyOf := MakeAnyOf()
+	allOf := MakeAllOf()
+	allOf.Append(MakeMatch(makeFunctionStringEqual(MakeStringDesignator("x"), MakeStringValue("other"))))
+	anyOf.Append(allOf)
+	target.Append(anyOf)
+
+	p := NewPolicy("test", false, Target{},
+		[]*Rule{
+			NewRule("skip", false, target, nil, EffectDeny, nil),
+			NewRule("permit", false, Target{}, traceSelectorExpr{}, EffectPermit, nil),
+		},
+		makeFirstApplicableEffectRCA, nil, nil)
+
+	p.Calculate(ctx)
+	if n := ctx.Trace(); n != nil {
+		t.Errorf("Expected no trace when it's disabled but got %#v", n)
+	}
+
+	ctx.EnableTrace()
+	r := p.Calculate(ctx)
+	if r.Effect != EffectPermit {
+		t.Errorf("Expected %s effect but got %s",
+			EffectNameFromEnum(EffectPermit), EffectNameFromEnum(r.Effect))
+	}
+
+	n := ctx.Trace()
+	if n == nil {
+		t.Fatal("Expected trace but got nothing")
+	}
+
+	if n.Kind != TraceNodePolicy || n.ID != "test" || n.Target != TraceMatch ||
+		n.Algorithm != "FirstApplicableEffect" || n.Effect != "Permit" {
+		t.Errorf("Expected permit of matched \"test\" policy but got %#v", n)
+	}
+
+	if len(n.Children) != 2 {
+		t.Fatalf("Expected two rules in trace but got %d", len(n.Children))
+	}
+
+	skip := n.Children[0]
+	if skip.ID != "skip" || skip.Target != TraceNoMatch || skip.Effect != "NotApplicable" {
+		t.Errorf("Expected not applicable \"skip\" rule but got %#v", skip)
+	}
+
+	permit := n.Children[1]
+	if permit.ID != "permit" || permit.Target != TraceMatch || permit.Condition != TraceTrue ||
+		permit.Effect != "Permit" {
+		t.Errorf("Expected permit of \"permit\" rule but got %#v", permit)
+	}
+
+	if len(permit.Selectors) != 1 {
+		t.Fatalf("Expected single selector call but got %d", len(permit.Selectors))
+	}
+
+	s := permit.Selectors[0]
+	if s.URI != "test:selector" || len(s.Keys) != 1 || s.Keys[0] != "\"test\"" || s.Result != "true" {
+		t.Errorf("Expected selector call with \"test\" key and true result but got %#v", s)
+	}
+}
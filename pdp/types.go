@@ -34,6 +34,10 @@ var (
 	TypeSetOfDomains = newBuiltinType("Set of Domains")
 	// TypeListOfStrings is list of strings data type.
 	TypeListOfStrings = newBuiltinType("List of Strings")
+	// TypeTime is date and time data type.
+	TypeTime = newBuiltinType("Time")
+	// TypeDuration is time duration data type.
+	TypeDuration = newBuiltinType("Duration")
 
 	// BuiltinTypeIDs maps type keys to Type* constants.
 	BuiltinTypes = make(map[string]Type)
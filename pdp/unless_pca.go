@@ -0,0 +1,71 @@
+package pdp
+
+import "encoding/json"
+
+type denyUnlessPermitPCA struct {
+}
+
+func makeDenyUnlessPermitPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return denyUnlessPermitPCAInstance
+}
+
+func (denyUnlessPermitPCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "denyUnlessPermitPCA",
+	})
+}
+
+func (a denyUnlessPermitPCA) execute(policies []Evaluable, ctx *Context) Response {
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	for _, p := range policies {
+		r := p.Calculate(ctx)
+		if r.Effect == EffectPermit {
+			return r
+		}
+
+		if r.Effect == EffectDeny {
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+		}
+	}
+
+	return Response{EffectDeny, nil, obligations, path}
+}
+
+type permitUnlessDenyPCA struct {
+}
+
+func makePermitUnlessDenyPCA(policies []Evaluable, params interface{}) PolicyCombiningAlg {
+	return permitUnlessDenyPCAInstance
+}
+
+func (permitUnlessDenyPCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "permitUnlessDenyPCA",
+	})
+}
+
+func (a permitUnlessDenyPCA) execute(policies []Evaluable, ctx *Context) Response {
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	for _, p := range policies {
+		r := p.Calculate(ctx)
+		if r.Effect == EffectDeny {
+			return r
+		}
+
+		if r.Effect == EffectPermit {
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+		}
+	}
+
+	return Response{EffectPermit, nil, obligations, path}
+}
@@ -0,0 +1,29 @@
+package pdp
+
+import "testing"
+
+func TestUnlessPCA(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	policies := []Evaluable{
+		makeSimplePolicy("first", makeErrorRule("permit", EffectPermit)),
+	}
+	r := makeDenyUnlessPermitPCA(policies, nil).execute(policies, ctx)
+	if r.Effect != EffectDeny {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectDeny], effectNames[r.Effect], r.Status)
+	}
+
+	r = makePermitUnlessDenyPCA(policies, nil).execute(policies, ctx)
+	if r.Effect != EffectPermit {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectPermit], effectNames[r.Effect], r.Status)
+	}
+
+	policies = append(policies, makeSimplePolicy("second", makeSimpleRule("deny", EffectDeny)))
+	r = makePermitUnlessDenyPCA(policies, nil).execute(policies, ctx)
+	if r.Effect != EffectDeny {
+		t.Errorf("Expected %q but got %q (%v)", effectNames[EffectDeny], effectNames[r.Effect], r.Status)
+	}
+}
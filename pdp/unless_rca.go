@@ -0,0 +1,71 @@
+package pdp
+
+import "encoding/json"
+
+type denyUnlessPermitRCA struct {
+}
+
+func makeDenyUnlessPermitRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return denyUnlessPermitRCAInstance
+}
+
+func (denyUnlessPermitRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "denyUnlessPermitRCA",
+	})
+}
+
+func (a denyUnlessPermitRCA) execute(rules []*Rule, ctx *Context) Response {
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	for _, rule := range rules {
+		r := rule.calculate(ctx)
+		if r.Effect == EffectPermit {
+			return r
+		}
+
+		if r.Effect == EffectDeny {
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+		}
+	}
+
+	return Response{EffectDeny, nil, obligations, path}
+}
+
+type permitUnlessDenyRCA struct {
+}
+
+func makePermitUnlessDenyRCA(rules []*Rule, params interface{}) RuleCombiningAlg {
+	return permitUnlessDenyRCAInstance
+}
+
+func (permitUnlessDenyRCA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(algFmt{
+		Type: "permitUnlessDenyRCA",
+	})
+}
+
+func (a permitUnlessDenyRCA) execute(rules []*Rule, ctx *Context) Response {
+	obligations := make([]AttributeAssignment, 0)
+	var path []string
+
+	for _, rule := range rules {
+		r := rule.calculate(ctx)
+		if r.Effect == EffectDeny {
+			return r
+		}
+
+		if r.Effect == EffectPermit {
+			obligations = append(obligations, r.Obligations...)
+			if path == nil {
+				path = r.path
+			}
+		}
+	}
+
+	return Response{EffectPermit, nil, obligations, path}
+}
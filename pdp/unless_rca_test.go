@@ -0,0 +1,52 @@
+package pdp
+
+import "testing"
+
+func TestUnlessRCA(t *testing.T) {
+	ctx, err := NewContext(nil, 0, nil)
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	testCases := []struct {
+		rules            []*Rule
+		denyUnlessPermit int
+		permitUnlessDeny int
+	}{
+		{
+			rules:            []*Rule{},
+			denyUnlessPermit: EffectDeny,
+			permitUnlessDeny: EffectPermit,
+		},
+		{
+			rules: []*Rule{
+				makeSimpleRule("first", EffectDeny),
+				makeSimpleRule("second", EffectPermit),
+			},
+			denyUnlessPermit: EffectPermit,
+			permitUnlessDeny: EffectDeny,
+		},
+		{
+			rules: []*Rule{
+				makeErrorRule("first", EffectPermit),
+				makeErrorRule("second", EffectDeny),
+			},
+			denyUnlessPermit: EffectDeny,
+			permitUnlessDeny: EffectPermit,
+		},
+	}
+
+	for i, tc := range testCases {
+		r := makeDenyUnlessPermitRCA(tc.rules, nil).execute(tc.rules, ctx)
+		if r.Effect != tc.denyUnlessPermit || r.Status != nil {
+			t.Errorf("%d: Expected %q for deny unless permit but got %q (%v)",
+				i, effectNames[tc.denyUnlessPermit], effectNames[r.Effect], r.Status)
+		}
+
+		r = makePermitUnlessDenyRCA(tc.rules, nil).execute(tc.rules, ctx)
+		if r.Effect != tc.permitUnlessDeny || r.Status != nil {
+			t.Errorf("%d: Expected %q for permit unless deny but got %q (%v)",
+				i, effectNames[tc.permitUnlessDeny], effectNames[r.Effect], r.Status)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -104,6 +105,20 @@ func MakeListOfStringsValue(v []string) AttributeValue {
 		v: v}
 }
 
+// MakeTimeValue creates instance of time attribute value.
+func MakeTimeValue(v time.Time) AttributeValue {
+	return AttributeValue{
+		t: TypeTime,
+		v: v}
+}
+
+// MakeDurationValue creates instance of duration attribute value.
+func MakeDurationValue(v time.Duration) AttributeValue {
+	return AttributeValue{
+		t: TypeDuration,
+		v: v}
+}
+
 // MakeFlagsValue8 creates instance of given flags value which fits 8 bits integer.
 func MakeFlagsValue8(v uint8, t Type) AttributeValue {
 	if t, ok := t.(*FlagsType); ok {
@@ -164,12 +179,57 @@ func MakeFlagsValue64(v uint64, t Type) AttributeValue {
 	panic(fmt.Errorf("can't make flags value for type %q", t))
 }
 
+// MakeEnumValue creates instance of given enum value. It returns error if
+// the type isn't an enum type or the value doesn't belong to the type.
+func MakeEnumValue(v string, t Type) (AttributeValue, error) {
+	et, ok := t.(*EnumType)
+	if !ok {
+		return UndefinedValue, newUnknownMetaType(t)
+	}
+
+	if et.GetIndex(v) < 0 {
+		return UndefinedValue, newInvalidEnumValueError(v, t)
+	}
+
+	return AttributeValue{
+		t: t,
+		v: v}, nil
+}
+
+// MakeStructValue creates instance of given struct value. Values of fields
+// should go in order of the type declaration and have the same types as
+// declared fields.
+func MakeStructValue(t Type, fields ...AttributeValue) (AttributeValue, error) {
+	st, ok := t.(*StructType)
+	if !ok {
+		return UndefinedValue, newUnknownMetaType(t)
+	}
+
+	if len(fields) != len(st.b) {
+		return UndefinedValue, newStructValueFieldsNumberError(t, len(st.b), len(fields))
+	}
+
+	for i, f := range st.b {
+		if fields[i].t != f.Type {
+			return UndefinedValue, newStructValueFieldTypeError(t, f.Name, f.Type, fields[i].t)
+		}
+	}
+
+	return AttributeValue{
+		t: t,
+		v: fields}, nil
+}
+
 // MakeValueFromString creates instance of attribute value by given type and
 // string representation. The function performs necessary validation.
 // No covertion defined for undefined type and collection types.
 func MakeValueFromString(t Type, s string) (AttributeValue, error) {
-	if _, ok := t.(*FlagsType); ok {
+	switch t.(type) {
+	case *FlagsType, *StructType:
 		return UndefinedValue, newNotImplementedStringCastError(t)
+
+	case *EnumType:
+		return MakeEnumValue(s, t)
 	}
 
 	switch t {
@@ -229,6 +289,22 @@ func MakeValueFromString(t Type, s string) (AttributeValue, error) {
 		}
 
 		return MakeDomainValue(d), nil
+
+	case TypeTime:
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return UndefinedValue, newInvalidTimeStringCastError(s, err)
+		}
+
+		return MakeTimeValue(tm), nil
+
+	case TypeDuration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return UndefinedValue, newInvalidDurationStringCastError(s, err)
+		}
+
+		return MakeDurationValue(d), nil
 	}
 
 	return UndefinedValue, newUnknownTypeStringCastError(t)
@@ -271,6 +347,23 @@ func (v AttributeValue) describe() string {
 		return fmt.Sprintf("flags<%q>(%s)", t, strings.Join(s, ", "))
 	}
 
+	switch t := v.t.(type) {
+	case *EnumType:
+		return fmt.Sprintf("enum<%q>(%q)", t, v.v.(string))
+
+	case *StructType:
+		var s []string
+		for i, f := range v.v.([]AttributeValue) {
+			s = append(s, fmt.Sprintf("%s: %s", t.b[i].Name, f.describe()))
+			if len(s) > 2 {
+				s[2] = "..."
+				break
+			}
+		}
+
+		return fmt.Sprintf("struct<%q>(%s)", t, strings.Join(s, ", "))
+	}
+
 	switch v.t {
 	case TypeUndefined:
 		return "val(undefined)"
@@ -343,6 +436,12 @@ func (v AttributeValue) describe() string {
 		}
 
 		return fmt.Sprintf("[%s]", strings.Join(s, ", "))
+
+	case TypeTime:
+		return fmt.Sprintf("time(%s)", v.v.(time.Time).Format(time.RFC3339Nano))
+
+	case TypeDuration:
+		return fmt.Sprintf("duration(%s)", v.v.(time.Duration))
 	}
 
 	return "val(unknown type)"
@@ -364,6 +463,22 @@ func (v AttributeValue) flagsTypeCheck() (*FlagsType, error) {
 	return nil, bindError(newAttributeValueFlagsTypeError(v.t), v.describe())
 }
 
+func (v AttributeValue) enum() (string, error) {
+	if _, ok := v.t.(*EnumType); ok {
+		return v.v.(string), nil
+	}
+
+	return "", bindError(newAttributeValueEnumTypeError(v.t), v.describe())
+}
+
+func (v AttributeValue) structTypeCheck() (*StructType, error) {
+	if t, ok := v.t.(*StructType); ok {
+		return t, nil
+	}
+
+	return nil, bindError(newAttributeValueStructTypeError(v.t), v.describe())
+}
+
 func (v AttributeValue) flagsTypeCheckN(n int) error {
 	t, err := v.flagsTypeCheck()
 	if err != nil {
@@ -476,6 +591,24 @@ func (v AttributeValue) listOfStrings() ([]string, error) {
 	return v.v.([]string), nil
 }
 
+func (v AttributeValue) time() (time.Time, error) {
+	err := v.typeCheck(TypeTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return v.v.(time.Time), nil
+}
+
+func (v AttributeValue) duration() (time.Duration, error) {
+	err := v.typeCheck(TypeDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.v.(time.Duration), nil
+}
+
 func (v AttributeValue) flags8() (uint8, error) {
 	err := v.flagsTypeCheckN(8)
 	if err != nil {
@@ -532,6 +665,112 @@ func (v AttributeValue) flags() (uint64, error) {
 	return v.v.(uint64), nil
 }
 
+// GetBoolean returns data of boolean value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetBoolean() (bool, error) {
+	return v.boolean()
+}
+
+// GetString returns data of string value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetString() (string, error) {
+	return v.str()
+}
+
+// GetInteger returns data of integer value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetInteger() (int64, error) {
+	return v.integer()
+}
+
+// GetFloat returns data of float value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetFloat() (float64, error) {
+	return v.float()
+}
+
+// GetAddress returns data of address value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetAddress() (net.IP, error) {
+	return v.address()
+}
+
+// GetNetwork returns data of network value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetNetwork() (*net.IPNet, error) {
+	return v.network()
+}
+
+// GetDomain returns data of domain value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetDomain() (domain.Name, error) {
+	return v.domain()
+}
+
+// GetSetOfStrings returns data of set of strings value. It returns error if
+// the value has different type.
+func (v AttributeValue) GetSetOfStrings() (*strtree.Tree, error) {
+	return v.setOfStrings()
+}
+
+// GetSetOfNetworks returns data of set of networks value. It returns error if
+// the value has different type.
+func (v AttributeValue) GetSetOfNetworks() (*iptree.Tree, error) {
+	return v.setOfNetworks()
+}
+
+// GetSetOfDomains returns data of set of domains value. It returns error if
+// the value has different type.
+func (v AttributeValue) GetSetOfDomains() (*domaintree.Node, error) {
+	return v.setOfDomains()
+}
+
+// GetListOfStrings returns data of list of strings value. It returns error if
+// the value has different type.
+func (v AttributeValue) GetListOfStrings() ([]string, error) {
+	return v.listOfStrings()
+}
+
+// GetTime returns data of time value. It returns error if the value has
+// different type.
+func (v AttributeValue) GetTime() (time.Time, error) {
+	return v.time()
+}
+
+// GetDuration returns data of duration value. It returns error if the value
+// has different type.
+func (v AttributeValue) GetDuration() (time.Duration, error) {
+	return v.duration()
+}
+
+// GetFlags returns data of flags value of any capacity. It returns error if
+// the value isn't a flags value.
+func (v AttributeValue) GetFlags() (uint64, error) {
+	return v.flags()
+}
+
+// GetEnum returns data of enum value. It returns error if the value isn't
+// an enum value.
+func (v AttributeValue) GetEnum() (string, error) {
+	return v.enum()
+}
+
+// GetField returns value of struct field with given name. It returns error
+// if the value isn't a struct value or the struct doesn't have the field.
+func (v AttributeValue) GetField(name string) (AttributeValue, error) {
+	t, err := v.structTypeCheck()
+	if err != nil {
+		return UndefinedValue, err
+	}
+
+	i := t.GetFieldIndex(name)
+	if i < 0 {
+		return UndefinedValue, bindError(newUnknownStructFieldError(t, name), v.describe())
+	}
+
+	return v.v.([]AttributeValue)[i], nil
+}
+
 // Calculate implements Expression interface and returns calculated value
 func (v AttributeValue) Calculate(ctx *Context) (AttributeValue, error) {
 	return v, nil
@@ -566,6 +805,14 @@ func (v AttributeValue) Serialize() (string, error) {
 		return strings.Join(s, ","), nil
 	}
 
+	switch v.t.(type) {
+	case *EnumType:
+		return v.v.(string), nil
+
+	case *StructType:
+		return serializeStruct(v.v.([]AttributeValue))
+	}
+
 	switch v.t {
 	case TypeUndefined:
 		return "", newInvalidTypeSerializationError(v.t)
@@ -602,6 +849,12 @@ func (v AttributeValue) Serialize() (string, error) {
 
 	case TypeListOfStrings:
 		return serializeListOfStrings(v.v.([]string)), nil
+
+	case TypeTime:
+		return v.v.(time.Time).Format(time.RFC3339Nano), nil
+
+	case TypeDuration:
+		return v.v.(time.Duration).String(), nil
 	}
 
 	return "", newUnknownTypeSerializationError(v.t)
@@ -656,22 +909,90 @@ func serializeFlags(v uint64, t *FlagsType) string {
 	return strings.Join(s, ",")
 }
 
+func serializeStruct(v []AttributeValue) (string, error) {
+	s := make([]string, len(v))
+	for i, f := range v {
+		fs, err := f.Serialize()
+		if err != nil {
+			return "", bindErrorf(err, "%d", i+1)
+		}
+
+		s[i] = strconv.QuoteToASCII(fs)
+	}
+
+	return strings.Join(s, ","), nil
+}
+
+// canRebind checks if value of type "from" can be rebound to type "to".
+// In addition to matching types a string can be rebound to an enum type and
+// a struct to other struct type if all its fields can be rebound.
+func canRebind(from, to Type) bool {
+	if from == to {
+		return true
+	}
+
+	switch to := to.(type) {
+	case *EnumType:
+		if from == TypeString {
+			return true
+		}
+
+	case *StructType:
+		st, ok := from.(*StructType)
+		if !ok || len(st.b) != len(to.b) {
+			return false
+		}
+
+		for i, f := range to.b {
+			if !canRebind(st.b[i].Type, f.Type) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return from.Match(to)
+}
+
 // Rebind produces copy of the value with given type if the type matches original value type.
+// Additionally it converts string to enum value if the string belongs to
+// the enum type and struct to struct of other type field by field.
 func (v AttributeValue) Rebind(t Type) (AttributeValue, error) {
 	if v.t == t {
 		return v, nil
 	}
 
-	if !v.t.Match(t) {
+	if !canRebind(v.t, t) {
 		return v, newNotMatchingTypeRebindError(t, v.t)
 	}
 
-	switch t.(type) {
+	switch t := t.(type) {
 	case *FlagsType:
 		return AttributeValue{
 			t: t,
 			v: v.v,
 		}, nil
+
+	case *EnumType:
+		return MakeEnumValue(v.v.(string), t)
+
+	case *StructType:
+		src := v.v.([]AttributeValue)
+		fields := make([]AttributeValue, len(src))
+		for i, f := range src {
+			fv, err := f.Rebind(t.b[i].Type)
+			if err != nil {
+				return v, bindError(err, t.b[i].Name)
+			}
+
+			fields[i] = fv
+		}
+
+		return AttributeValue{
+			t: t,
+			v: fields,
+		}, nil
 	}
 
 	return v, newUnknownMetaType(t)
@@ -472,6 +472,42 @@ func TestMakeValueFromSting(t *testing.T) {
 		t.Errorf("Expected *invalidDomainNameStringCastError but got %T (%s)", err, err)
 	}
 
+	v, err = MakeValueFromString(TypeTime, "2018-06-15T09:30:00-04:00")
+	if err != nil {
+		t.Errorf("Expected time attribute value but got error: %s", err)
+	} else {
+		expDesc := "time(2018-06-15T09:30:00-04:00)"
+		d := v.describe()
+		if d != expDesc {
+			t.Errorf("Expected %q as value description but got %q", expDesc, d)
+		}
+	}
+
+	v, err = MakeValueFromString(TypeTime, "yesterday")
+	if err == nil {
+		t.Errorf("Expected error but got value: %s", v.describe())
+	} else if _, ok := err.(*invalidTimeStringCastError); !ok {
+		t.Errorf("Expected *invalidTimeStringCastError but got %T (%s)", err, err)
+	}
+
+	v, err = MakeValueFromString(TypeDuration, "1h30m")
+	if err != nil {
+		t.Errorf("Expected duration attribute value but got error: %s", err)
+	} else {
+		expDesc := "duration(1h30m0s)"
+		d := v.describe()
+		if d != expDesc {
+			t.Errorf("Expected %q as value description but got %q", expDesc, d)
+		}
+	}
+
+	v, err = MakeValueFromString(TypeDuration, "day")
+	if err == nil {
+		t.Errorf("Expected error but got value: %s", v.describe())
+	} else if _, ok := err.(*invalidDurationStringCastError); !ok {
+		t.Errorf("Expected *invalidDurationStringCastError but got %T (%s)", err, err)
+	}
+
 	ft, err := NewFlagsType("flags", "first", "second", "third")
 	if err != nil {
 		t.Errorf("Expected no error but got %s", err)
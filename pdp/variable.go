@@ -0,0 +1,98 @@
+package pdp
+
+import "fmt"
+
+// Variable represents named expression defined at policy set or policy level.
+// Descendants of the policy set or policy refer to the variable with
+// VariableReference expression. Value of the variable is calculated on first
+// reference and then reused for the same request context. Element variable
+// of any-of or all-of expression has no expression and gets its values from
+// the expression.
+type Variable struct {
+	id string
+	e  Expression
+	t  Type
+}
+
+type variableValue struct {
+	v   AttributeValue
+	err error
+}
+
+// NewVariable creates new instance of variable with given id and expression.
+func NewVariable(ID string, e Expression) *Variable {
+	return &Variable{
+		id: ID,
+		e:  e,
+	}
+}
+
+// GetID returns variable id.
+func (v *Variable) GetID() string {
+	return v.id
+}
+
+// GetResultType returns type of variable expression (or type of elements
+// for element variable).
+func (v *Variable) GetResultType() Type {
+	if v.e == nil {
+		return v.t
+	}
+
+	return v.e.GetResultType()
+}
+
+// VariableReference represents expression which gets value of variable.
+type VariableReference struct {
+	v *Variable
+}
+
+// MakeVariableReference creates expression instance which refers to given
+// variable.
+func MakeVariableReference(v *Variable) VariableReference {
+	return VariableReference{v}
+}
+
+// GetID returns id of the variable.
+func (r VariableReference) GetID() string {
+	return r.v.id
+}
+
+// GetResultType implements Expression interface and returns type of
+// the variable.
+func (r VariableReference) GetResultType() Type {
+	return r.v.GetResultType()
+}
+
+func (r VariableReference) describe() string {
+	return fmt.Sprintf("variable %q", r.v.id)
+}
+
+func (r VariableReference) arguments() []Expression {
+	return []Expression{r.v.e}
+}
+
+// Calculate implements Expression interface and returns value of
+// the variable. The variable expression is calculated only once for given
+// context and any later call gets the same value or error.
+func (r VariableReference) Calculate(ctx *Context) (AttributeValue, error) {
+	if vv, ok := ctx.vars[r.v]; ok {
+		return vv.v, vv.err
+	}
+
+	if r.v.e == nil {
+		return UndefinedValue, bindError(newUnboundElementVariableError(r.v.id), r.describe())
+	}
+
+	v, err := r.v.e.Calculate(ctx)
+	if err != nil {
+		err = bindError(err, r.describe())
+	}
+
+	if ctx.vars == nil {
+		ctx.vars = make(map[*Variable]variableValue)
+	}
+	ctx.vars[r.v] = variableValue{v: v, err: err}
+
+	return v, err
+}
@@ -0,0 +1,66 @@
+package pdp
+
+import "testing"
+
+type countingExpression struct {
+	e Expression
+	n *int
+}
+
+func (c countingExpression) GetResultType() Type {
+	return c.e.GetResultType()
+}
+
+func (c countingExpression) Calculate(ctx *Context) (AttributeValue, error) {
+	*c.n++
+	return c.e.Calculate(ctx)
+}
+
+func TestVariableReference(t *testing.T) {
+	n := 0
+	v := NewVariable("test", countingExpression{e: MakeStringDesignator("s"), n: &n})
+	r := MakeVariableReference(v)
+
+	if r.GetResultType() != TypeString {
+		t.Errorf("Expected %q type but got %q", TypeString, r.GetResultType())
+	}
+
+	if _, ok := CheckExpressionAsTargetArgument(r); !ok {
+		t.Errorf("Expected variable reference to be target compatible")
+	}
+
+	ctx, err := NewContext(nil, 1, func(i int) (string, AttributeValue, error) {
+		return "s", MakeStringValue("example"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected context but got error %s", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		s, err := ctx.calculateStringExpression(r)
+		if err != nil {
+			t.Errorf("Expected no error but got %s", err)
+		} else if s != "example" {
+			t.Errorf("Expected %q but got %q", "example", s)
+		}
+	}
+
+	if n != 1 {
+		t.Errorf("Expected variable to be calculated once but got %d calculations", n)
+	}
+
+	n = 0
+	ctx = &Context{}
+	for i := 0; i < 2; i++ {
+		_, err := r.Calculate(ctx)
+		if err == nil {
+			t.Errorf("Expected *missingAttributeError but got nothing")
+		} else if _, ok := err.(*missingAttributeError); !ok {
+			t.Errorf("Expected *missingAttributeError but got %T (%s)", err, err)
+		}
+	}
+
+	if n != 1 {
+		t.Errorf("Expected variable to be calculated once but got %d calculations", n)
+	}
+}
@@ -30,6 +30,31 @@ func (e *TagError) Error() string {
 	return e.tag
 }
 
+// Version represents tagged policies or content kept in PDP server history.
+// Field Current is true if server uses the version at the moment.
+type Version struct {
+	Tag       string
+	Timestamp time.Time
+	Current   bool
+}
+
+// Decision represents PDP response to a request made on dry run.
+type Decision struct {
+	Effect      string
+	Status      string
+	Obligations []string
+}
+
+// DryRunDiff describes request which gets different decisions from current
+// and uploaded policies or content. Index is position of the request in list
+// of requests evaluated on dry run.
+type DryRunDiff struct {
+	Index     int
+	Request   []string
+	Current   Decision
+	Candidate Decision
+}
+
 // Client structure represents client side of PDP control protocol. It's
 // responsible for establishing connection and uploading data to PDP server.
 type Client struct {
@@ -152,6 +177,56 @@ func (c *Client) Apply(id int32) error {
 	return nil
 }
 
+// ApplyGroup requests server to switch to several recently uploaded policies
+// and content at once. Its ids arguments should be upload ids obtained on
+// previous Upload calls. Server applies all the uploads or none of them. In
+// the latter case the uploads are dropped and should be made again.
+func (c *Client) ApplyGroup(ids ...int32) error {
+	r, err := c.client.ApplyGroup(context.Background(), &pb.UpdateGroup{Ids: ids})
+	if err != nil {
+		return err
+	}
+
+	if r.Status != pb.Response_ACK {
+		return errors.New(r.Details)
+	}
+
+	return nil
+}
+
+// DryRun requests server to evaluate given requests against current and
+// recently uploaded policies or content without applying the upload. Its id
+// argument should be upload id obtained on previous Upload call. Requests
+// should be marshaled as for decision request body. If no requests given
+// server uses recently recorded decision requests. The method returns number
+// of evaluated requests and the requests with different effect or
+// obligations. The upload is dropped by server after dry run so it should be
+// uploaded again to be applied.
+func (c *Client) DryRun(id int32, requests [][]byte) (int, []DryRunDiff, error) {
+	r, err := c.client.DryRun(context.Background(), &pb.DryRunRequest{
+		Id:       id,
+		Requests: requests})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if r.Status != pb.Response_ACK {
+		return 0, nil, errors.New(r.Details)
+	}
+
+	out := make([]DryRunDiff, len(r.Diffs))
+	for i, d := range r.Diffs {
+		out[i] = DryRunDiff{
+			Index:     int(d.Index),
+			Request:   d.Request,
+			Current:   makeDecision(d.Current),
+			Candidate: makeDecision(d.Candidate),
+		}
+	}
+
+	return int(r.Total), out, nil
+}
+
 // NotifyReady set server to 'ready' state -
 // after that server will open service port for serve decision requests
 func (c *Client) NotifyReady() error {
@@ -167,6 +242,85 @@ func (c *Client) NotifyReady() error {
 	return nil
 }
 
+// ListPoliciesVersions returns versions of policies kept in server history.
+// The most recently applied version goes first. Server keeps history only if
+// it has been started with non-zero history size.
+func (c *Client) ListPoliciesVersions() ([]Version, error) {
+	return c.listVersions(&pb.Item{Type: pb.Item_POLICIES})
+}
+
+// ListContentVersions returns versions of content with given id kept in server
+// history. As for policies the most recently applied version goes first.
+func (c *Client) ListContentVersions(id string) ([]Version, error) {
+	return c.listVersions(&pb.Item{
+		Type: pb.Item_CONTENT,
+		Id:   id})
+}
+
+// RollbackPolicies requests server to switch to policies with given tag from
+// its history. The tag should be valid text representation of UUID.
+func (c *Client) RollbackPolicies(tag string) error {
+	return c.rollback(&pb.Item{
+		Type:  pb.Item_POLICIES,
+		ToTag: tag})
+}
+
+// RollbackContent requests server to switch content with given id to version
+// with given tag from its history.
+func (c *Client) RollbackContent(id, tag string) error {
+	return c.rollback(&pb.Item{
+		Type:  pb.Item_CONTENT,
+		ToTag: tag,
+		Id:    id})
+}
+
+func (c *Client) listVersions(item *pb.Item) ([]Version, error) {
+	r, err := c.client.ListVersions(context.Background(), item)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Status != pb.Response_ACK {
+		return nil, errors.New(r.Details)
+	}
+
+	out := make([]Version, len(r.Versions))
+	for i, v := range r.Versions {
+		out[i] = Version{
+			Tag:       v.Tag,
+			Timestamp: time.Unix(0, v.Timestamp),
+			Current:   v.Current,
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Client) rollback(item *pb.Item) error {
+	r, err := c.client.Rollback(context.Background(), item)
+	if err != nil {
+		return err
+	}
+
+	if r.Status != pb.Response_ACK {
+		return errors.New(r.Details)
+	}
+
+	return nil
+}
+
+func makeDecision(d *pb.Decision) Decision {
+	if d == nil {
+		return Decision{}
+	}
+
+	return Decision{
+		Effect:      d.Effect,
+		Status:      d.Status,
+		Obligations: d.Obligations,
+	}
+}
+
 func (c *Client) request(item *pb.Item) (int32, error) {
 	r, err := c.client.Request(context.Background(), item)
 	if err != nil {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp/ast"
+)
+
+const (
+	policyFormatNameYAML = "yaml"
+	policyFormatNameJSON = "json"
+
+	outputFormatNameText = "text"
+	outputFormatNameJSON = "json"
+)
+
+var policyParsers = map[string]ast.Parser{
+	policyFormatNameYAML: ast.NewYAMLParser(),
+	policyFormatNameJSON: ast.NewJSONParser(),
+}
+
+type config struct {
+	policy       string
+	policyParser ast.Parser
+	output       string
+}
+
+var conf config
+
+func init() {
+	flag.StringVar(&conf.policy, "p", "", "policy file to analyze")
+	policyFmt := flag.String("pfmt", policyFormatNameYAML, "policy data format \"yaml\" or \"json\"")
+	flag.StringVar(&conf.output, "o", outputFormatNameText, "warnings output format \"text\" or \"json\"")
+
+	flag.Parse()
+
+	p, ok := policyParsers[strings.ToLower(*policyFmt)]
+	if !ok {
+		log.WithField("format", *policyFmt).Error("unknown policy format")
+		os.Exit(failureExitCode)
+	}
+	conf.policyParser = p
+
+	conf.output = strings.ToLower(conf.output)
+	if conf.output != outputFormatNameText && conf.output != outputFormatNameJSON {
+		log.WithField("format", conf.output).Error("unknown output format")
+		os.Exit(failureExitCode)
+	}
+
+	if len(conf.policy) <= 0 {
+		log.Error("no policy file to analyze")
+		os.Exit(failureExitCode)
+	}
+}
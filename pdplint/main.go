@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp/analyzer"
+)
+
+// Exit codes. The application exits with warningsExitCode if analyzer has
+// found any problem so it can be used as a check before policies upload.
+const (
+	warningsExitCode = 1
+	failureExitCode  = 2
+)
+
+func main() {
+	f, err := os.Open(conf.policy)
+	if err != nil {
+		log.WithError(err).Error("failed to open policy file")
+		os.Exit(failureExitCode)
+	}
+	defer f.Close()
+
+	p, err := conf.policyParser.Unmarshal(f, nil)
+	if err != nil {
+		log.WithError(err).Error("failed to parse policy file")
+		os.Exit(failureExitCode)
+	}
+
+	w := analyzer.Analyze(p)
+	if conf.output == outputFormatNameJSON {
+		if w == nil {
+			w = []analyzer.Warning{}
+		}
+
+		b, err := json.MarshalIndent(w, "", "  ")
+		if err != nil {
+			log.WithError(err).Error("failed to marshal warnings")
+			os.Exit(failureExitCode)
+		}
+
+		fmt.Println(string(b))
+	} else {
+		for _, item := range w {
+			fmt.Println(item)
+		}
+	}
+
+	if len(w) > 0 {
+		os.Exit(warningsExitCode)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"io/ioutil"
 	"math"
 	"os"
 	"strings"
@@ -31,13 +32,25 @@ type config struct {
 	serviceEP           string
 	controlEP           string
 	tracingEP           string
+	tracingType         string
 	healthEP            string
 	profilerEP          string
+	metricsEP           string
 	storageEP           string
+	storageToken        string
 	mem                 server.MemLimits
+	contentMemLimit     int64
 	maxStreams          uint
 	autoResponseSize    bool
 	maxResponseSize     uint
+	decisionPath        string
+	stateDir            string
+	historySize         int
+	recordedRequests    int
+	policySource        string
+	contentSources      stringSet
+	syncInterval        time.Duration
+	sweepInterval       time.Duration
 	memStatsLogPath     string
 	memStatsLogInterval time.Duration
 	memProfDumpPath     string
@@ -67,17 +80,29 @@ func parseCommandLine() {
 	verbose := flag.Int("v", 1, "log verbosity (0 - error, 1 - warn (default), 2 - info, 3 - debug)")
 	flag.StringVar(&conf.policy, "p", "", "policy file to start with")
 	policyFmt := flag.String("pfmt", policyFormatNameYAML, "policy data format \"yaml\" or \"json\"")
-	flag.Var(&conf.content, "j", "JSON content files to start with")
+	flag.Var(&conf.content, "j", "JSON content or binary content snapshot files to start with")
 	flag.StringVar(&conf.serviceEP, "l", ":5555", "listen for decision requests on this address:port")
 	flag.StringVar(&conf.controlEP, "c", ":5554", "listen for policies on this address:port")
-	flag.StringVar(&conf.tracingEP, "t", "", "OpenZipkin tracing endpoint")
+	flag.StringVar(&conf.tracingEP, "t", "", "tracing endpoint (OpenZipkin HTTP URL or OpenTelemetry collector host:port)")
+	flag.StringVar(&conf.tracingType, "tracing-type", "zipkin", "tracing type (\"zipkin\" or \"otlp\")")
 	flag.StringVar(&conf.healthEP, "health", "", "health check endpoint")
 	flag.StringVar(&conf.profilerEP, "pprof", "", "performance profiler endpoint")
+	flag.StringVar(&conf.metricsEP, "metrics", "", "Prometheus metrics endpoint")
 	flag.StringVar(&conf.storageEP, "storage", ":5552", "storage control endpoint")
+	storageTokenFile := flag.String("storage-token-file", "", "file with token to authorize policies modification via storage endpoint (empty - read only)")
 	limit := flag.Uint64("mem-limit", 0, "memory limit in megabytes")
+	contentLimit := flag.Uint64("content-mem-limit", 0, "memory limit for single content in megabytes (0 - no limit)")
 	flag.UintVar(&conf.maxStreams, "max-streams", 0, "maximum number of parallel gRPC streams (0 - use gRPC default)")
 	flag.BoolVar(&conf.autoResponseSize, "auto-response", false, "automatic respose buffer allocation")
 	flag.UintVar(&conf.maxResponseSize, "max-response", 10240, "maximal response size")
+	flag.StringVar(&conf.decisionPath, "decision-path", "", "put decision path to obligation with given id (empty - don't put)")
+	flag.StringVar(&conf.stateDir, "state", "", "directory to persist applied policies and content (empty - don't persist)")
+	flag.IntVar(&conf.historySize, "history", 10, "number of recently applied policies and content versions to keep for rollback (0 - disable)")
+	flag.IntVar(&conf.recordedRequests, "record-requests", 0, "number of recent decision requests to record for dry run (0 - don't record)")
+	flag.StringVar(&conf.policySource, "sync-policy", "", "policy file or HTTP(S) URL to poll for new policies (empty - don't poll)")
+	flag.Var(&conf.contentSources, "sync-content", "JSON content file, directory or HTTP(S) URL to poll for new content")
+	flag.DurationVar(&conf.syncInterval, "sync-interval", 10*time.Second, "interval between polls of -sync-policy and -sync-content sources")
+	flag.DurationVar(&conf.sweepInterval, "content-sweep-interval", time.Minute, "interval between removals of expired content items and keys (0 - don't remove)")
 
 	flag.StringVar(&conf.memStatsLogPath, "mem-stats-log", "mem-stats.log", "file to log memory allocator statistics")
 	flag.DurationVar(&conf.memStatsLogInterval, "mem-stats-interval", -1,
@@ -105,11 +130,24 @@ func parseCommandLine() {
 	}
 	conf.policyParser = p
 
+	if len(*storageTokenFile) > 0 {
+		b, err := ioutil.ReadFile(*storageTokenFile)
+		if err != nil {
+			log.WithError(err).Fatal("can't read storage token")
+		}
+
+		conf.storageToken = strings.TrimSpace(string(b))
+		if len(conf.storageToken) <= 0 {
+			log.WithField("file", *storageTokenFile).Fatal("empty storage token")
+		}
+	}
+
 	mem, err := server.MakeMemLimits(*limit*1024*1024, 80, 70, 30, 30)
 	if err != nil {
 		log.WithError(err).Fatal("wrong memory limits")
 	}
 	conf.mem = mem
+	conf.contentMemLimit = int64(*contentLimit * 1024 * 1024)
 
 	if conf.maxStreams > math.MaxUint32 {
 		log.WithFields(log.Fields{
@@ -132,6 +170,10 @@ func parseCommandLine() {
 		}).Fatal("too tight response size limit")
 	}
 
+	if conf.syncInterval <= 0 {
+		log.WithField("sync-interval", conf.syncInterval).Fatal("sync interval should be positive")
+	}
+
 	if conf.memProfNumGC > math.MaxUint32 {
 		log.WithFields(log.Fields{
 			"mem-prof-gc": conf.memProfNumGC,
@@ -34,12 +34,24 @@ func main() {
 		server.WithControlAt(conf.controlEP),
 		server.WithHealthAt(conf.healthEP),
 		server.WithProfilerAt(conf.profilerEP),
+		server.WithMetricsAt(conf.metricsEP),
 		server.WithStorageAt(conf.storageEP),
+		server.WithStorageToken(conf.storageToken),
 		server.WithTracingAt(conf.tracingEP),
+		server.WithTracingType(conf.tracingType),
 		server.WithMemLimits(conf.mem),
 		server.WithMaxGRPCStreams(uint32(conf.maxStreams)),
 		server.WithAutoResponseSize(conf.autoResponseSize),
 		server.WithMaxResponseSize(uint32(conf.maxResponseSize)),
+		server.WithDecisionPath(conf.decisionPath),
+		server.WithStateDir(conf.stateDir),
+		server.WithHistorySize(conf.historySize),
+		server.WithRecordedRequests(conf.recordedRequests),
+		server.WithPolicySource(conf.policySource),
+		server.WithContentSources(conf.contentSources...),
+		server.WithSyncInterval(conf.syncInterval),
+		server.WithContentMemLimit(conf.contentMemLimit),
+		server.WithContentSweepInterval(conf.sweepInterval),
 		server.WithMemStatsLogging(
 			conf.memStatsLogPath,
 			conf.memStatsLogInterval,
@@ -68,6 +80,16 @@ func main() {
 		logger.WithField("err", err).Error("Failed to load content. Continue with no content...")
 	}
 
+	err = pdp.RestoreState()
+	if err != nil {
+		logger.WithFields(
+			log.Fields{
+				"state": conf.stateDir,
+				"err":   err,
+			},
+		).Error("Failed to restore state. Continue with loaded policy and content...")
+	}
+
 	runtime.GC()
 
 	err = pdp.Serve()
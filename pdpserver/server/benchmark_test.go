@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -870,7 +871,7 @@ func benchmarkRawPolicySet(p *pdp.PolicyStorage, b *testing.B) {
 		c := s.c
 		s.RUnlock()
 
-		r := s.rawValidate(p, c, benchmarkRequests[n%len(benchmarkRequests)].Body)
+		r := s.rawValidate(context.Background(), p, c, benchmarkRequests[n%len(benchmarkRequests)].Body)
 
 		effect, n, err := pdp.UnmarshalResponseToAssignmentsArray(r, a[:])
 		if err != nil {
@@ -910,7 +911,7 @@ func benchmarkRawPolicySetWithAllocator(p *pdp.PolicyStorage, b *testing.B) {
 		c := s.c
 		s.RUnlock()
 
-		r := s.rawValidateWithAllocator(p, c, benchmarkRequests[n%len(benchmarkRequests)].Body, func(n int) ([]byte, error) {
+		r := s.rawValidateWithAllocator(context.Background(), p, c, benchmarkRequests[n%len(benchmarkRequests)].Body, func(n int) ([]byte, error) {
 			if len(buf) < n {
 				buf = make([]byte, n)
 			}
@@ -956,7 +957,7 @@ func benchmarkRawPolicySetToBuffer(p *pdp.PolicyStorage, b *testing.B) {
 		c := s.c
 		s.RUnlock()
 
-		r := s.rawValidateToBuffer(p, c, benchmarkRequests[n%len(benchmarkRequests)].Body, buf[:])
+		r := s.rawValidateToBuffer(context.Background(), p, c, benchmarkRequests[n%len(benchmarkRequests)].Body, buf[:])
 
 		effect, n, err := pdp.UnmarshalResponseToAssignmentsArray(r, a[:])
 		if err != nil {
@@ -13,6 +13,9 @@ import (
 func controlFail(err error) *pb.Response {
 	status := pb.Response_ERROR
 	switch e := err.(type) {
+	case *groupItemApplyError:
+		status = controlFail(e.err).Status
+
 	case *tagCheckError:
 		switch e.err.(type) {
 		case *pdp.UntaggedPolicyModificationError, *pdp.MissingPolicyTagError, *pdp.PolicyTagsNotMatchError, *pdp.UntaggedContentModificationError, *pdp.MissingContentTagError, *pdp.ContentTagsNotMatchError:
@@ -122,6 +125,14 @@ func (s *Server) Upload(stream pb.PDPControl_UploadServer) error {
 		return stream.SendAndClose(controlFail(newUnknownUploadError(id)))
 	}
 
+	if s.metrics != nil {
+		stream = uploadStream{
+			PDPControl_UploadServer: stream,
+			m:                       s.metrics,
+			policy:                  req.policy,
+		}
+	}
+
 	if req.fromTag == nil {
 		if req.policy {
 			err = s.uploadPolicy(id, r, req, stream)
@@ -160,6 +171,10 @@ func (s *Server) Apply(ctx context.Context, in *pb.Update) (*pb.Response, error)
 		res, err = s.applyContent(in.Id, req)
 	}
 
+	if res != nil {
+		s.metrics.observeControl(metricsOpApply, req.policy, res)
+	}
+
 	return res, err
 }
 
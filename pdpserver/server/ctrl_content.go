@@ -1,13 +1,34 @@
 package server
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/infobloxopen/themis/pdp"
 	pb "github.com/infobloxopen/themis/pdp-control"
 	"github.com/infobloxopen/themis/pdp/jcon"
 )
 
+// contentProgressKeys is a number of content keys between log records
+// on content loading progress.
+const contentProgressKeys = 1000000
+
+// unmarshalContent parses content within memory limit and logs progress
+// of loading with given fields.
+func (s *Server) unmarshalContent(r io.Reader, tag *uuid.UUID, fields log.Fields) (*pdp.LocalContent, jcon.Progress, error) {
+	return jcon.UnmarshalStream(r, tag,
+		jcon.WithMemoryLimit(s.opts.contentMemLimit),
+		jcon.WithProgress(func(p jcon.Progress) {
+			s.opts.logger.WithFields(fields).WithFields(log.Fields{
+				"items": p.Items,
+				"keys":  p.Keys,
+				"size":  p.Size}).Info("Loading content")
+		}, contentProgressKeys),
+	)
+}
+
 func (s *Server) contentRequest(id string, fromTag, toTag *uuid.UUID) (int32, error) {
 	if fromTag != nil {
 		s.RLock()
@@ -24,19 +45,36 @@ func (s *Server) contentRequest(id string, fromTag, toTag *uuid.UUID) (int32, er
 }
 
 func (s *Server) uploadContent(id int32, r *streamReader, req *item, stream pb.PDPControl_UploadServer) error {
-	c, err := jcon.Unmarshal(r, req.toTag)
+	tr, u := s.teeState(r)
+	c, p, err := s.unmarshalContent(tr, req.toTag, log.Fields{"id": id, "content": req.id})
 	if err != nil {
+		u.discard()
 		r.skip()
-		return stream.SendAndClose(controlFail(newContentUploadParseError(id, err)))
+		return stream.SendAndClose(withProgress(controlFail(newContentUploadParseError(id, err)), p))
 	}
 
+	s.opts.logger.WithFields(log.Fields{
+		"id":    id,
+		"items": p.Items,
+		"keys":  p.Keys,
+		"size":  p.Size}).Info("Content has been loaded")
+
 	req.c = c
+	req.raw = u
+	u.finish()
 	nid, err := s.q.push(req)
 	if err != nil {
-		return stream.SendAndClose(controlFail(newContentUploadStoreError(id, err)))
+		return stream.SendAndClose(withProgress(controlFail(newContentUploadStoreError(id, err)), p))
 	}
 
-	return stream.SendAndClose(&pb.Response{Status: pb.Response_ACK, Id: nid})
+	return stream.SendAndClose(withProgress(&pb.Response{Status: pb.Response_ACK, Id: nid}, p))
+}
+
+// withProgress puts numbers of loaded content items and keys to the response.
+func withProgress(r *pb.Response, p jcon.Progress) *pb.Response {
+	r.Items = int32(p.Items)
+	r.Keys = p.Keys
+	return r
 }
 
 func (s *Server) uploadContentUpdate(id int32, r *streamReader, req *item, stream pb.PDPControl_UploadServer) error {
@@ -49,16 +87,22 @@ func (s *Server) uploadContentUpdate(id int32, r *streamReader, req *item, strea
 	}
 	s.RUnlock()
 
-	u, err := jcon.UnmarshalUpdate(r, req.id, *req.fromTag, *req.toTag, t.Symbols())
+	tr, raw := s.teeState(r)
+	u, err := jcon.UnmarshalUpdate(tr, req.id, *req.fromTag, *req.toTag, t.Symbols())
 	if err != nil {
+		raw.discard()
 		r.skip()
 		return stream.SendAndClose(controlFail(newContentUpdateParseError(id, req, err)))
 	}
 
+	req.raw = raw
+	raw.finish()
+
 	s.opts.logger.WithField("update", u).Debug("Content update")
 
 	err = t.Apply(u)
 	if err != nil {
+		raw.discard()
 		return stream.SendAndClose(controlFail(newContentUpdateApplicationError(id, req, err)))
 	}
 
@@ -72,6 +116,9 @@ func (s *Server) uploadContentUpdate(id int32, r *streamReader, req *item, strea
 }
 
 func (s *Server) applyContent(id int32, req *item) (*pb.Response, error) {
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
 	if req.c != nil {
 		s.Lock()
 		s.c = s.c.Add(req.c)
@@ -85,27 +132,45 @@ func (s *Server) applyContent(id int32, req *item) (*pb.Response, error) {
 				"tag": req.toTag.String()}).Info("New content has been applied")
 		}
 
+		s.saveState(groupItem{id: id, req: req})
+		s.putContentVersion(req.toTag, req.c)
+
 		return &pb.Response{Status: pb.Response_ACK, Id: id}, nil
 	}
 
 	if req.ct != nil {
 		s.Lock()
+		if _, err := s.c.GetLocalContent(req.id, req.fromTag); err != nil {
+			s.Unlock()
+
+			req.raw.discard()
+			return controlFail(newTagCheckError(err)), nil
+		}
+
 		c, err := req.ct.Commit(s.c)
 		if err != nil {
 			s.Unlock()
 
+			req.raw.discard()
 			return controlFail(newContentTransactionCommitError(id, req, err)), nil
 		}
 
 		s.c = c
 		s.Unlock()
 
+		lc, err := c.GetLocalContent(req.id, req.toTag)
+		if err == nil {
+			s.putContentVersion(req.toTag, lc)
+		}
+
 		s.opts.logger.WithFields(log.Fields{
 			"id":       id,
 			"cid":      req.id,
 			"prev-tag": req.fromTag,
 			"curr-tag": req.toTag}).Info("Content update has been applied")
 
+		s.saveState(groupItem{id: id, req: req})
+
 		return &pb.Response{Status: pb.Response_ACK, Id: id}, nil
 	}
 
@@ -23,12 +23,16 @@ func (s *Server) policyRequest(fromTag, toTag *uuid.UUID) (int32, error) {
 }
 
 func (s *Server) uploadPolicy(id int32, r *streamReader, req *item, stream pb.PDPControl_UploadServer) error {
-	p, err := s.opts.parser.Unmarshal(r, req.toTag)
+	tr, u := s.teeState(r)
+	p, err := s.opts.parser.Unmarshal(tr, req.toTag)
 	if err != nil {
+		u.discard()
 		return stream.SendAndClose(controlFail(newPolicyUploadParseError(id, err)))
 	}
 
 	req.p = p
+	req.raw = u
+	u.finish()
 	nid, err := s.q.push(req)
 	if err != nil {
 		return stream.SendAndClose(controlFail(newPolicyUploadStoreError(id, err)))
@@ -53,15 +57,21 @@ func (s *Server) uploadPolicyUpdate(id int32, r *streamReader, req *item, stream
 	}
 	s.RUnlock()
 
-	u, err := s.opts.parser.UnmarshalUpdate(r, t.Symbols(), *req.fromTag, *req.toTag)
+	tr, raw := s.teeState(r)
+	u, err := s.opts.parser.UnmarshalUpdate(tr, t.Symbols(), *req.fromTag, *req.toTag)
 	if err != nil {
+		raw.discard()
 		return stream.SendAndClose(controlFail(newPolicyUpdateParseError(id, req, err)))
 	}
 
+	req.raw = raw
+	raw.finish()
+
 	s.opts.logger.WithField("update", u).Debug("Policy update")
 
 	err = t.Apply(u)
 	if err != nil {
+		raw.discard()
 		return stream.SendAndClose(controlFail(newPolicyUpdateApplicationError(id, req, err)))
 	}
 
@@ -75,6 +85,9 @@ func (s *Server) uploadPolicyUpdate(id int32, r *streamReader, req *item, stream
 }
 
 func (s *Server) applyPolicy(id int32, req *item) (*pb.Response, error) {
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
 	if req.p != nil {
 		s.Lock()
 		s.p = req.p
@@ -88,12 +101,24 @@ func (s *Server) applyPolicy(id int32, req *item) (*pb.Response, error) {
 				"tag": req.toTag.String()}).Info("New policy has been applied")
 		}
 
+		s.saveState(groupItem{id: id, req: req})
+		s.putPoliciesVersion(req.toTag, req.p)
+
 		return &pb.Response{Status: pb.Response_ACK, Id: id}, nil
 	}
 
 	if req.pt != nil {
+		s.RLock()
+		err := s.p.CheckTag(req.fromTag)
+		s.RUnlock()
+		if err != nil {
+			req.raw.discard()
+			return controlFail(newTagCheckError(err)), nil
+		}
+
 		p, err := req.pt.Commit()
 		if err != nil {
+			req.raw.discard()
 			return controlFail(newPolicyTransactionCommitError(id, req, err)), nil
 		}
 
@@ -106,6 +131,9 @@ func (s *Server) applyPolicy(id int32, req *item) (*pb.Response, error) {
 			"prev-tag": req.fromTag,
 			"curr-tag": req.toTag}).Info("Policy update has been applied")
 
+		s.saveState(groupItem{id: id, req: req})
+		s.putPoliciesVersion(req.toTag, p)
+
 		return &pb.Response{Status: pb.Response_ACK, Id: id}, nil
 	}
 
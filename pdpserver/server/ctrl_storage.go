@@ -1,19 +1,39 @@
 package server
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
 	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pdp/ast/jast"
 )
 
 const (
 	queryCmd          = "query"
+	policiesCmd       = "policies"
 	readonlyMsg       = "This endpoint is read only. Only GET method is allowed"
 	missingStorageMsg = "Server missing policy storage"
+	unauthorizedMsg   = "Missing or invalid authorization token"
+	untaggedMsg       = "Policies have no tag and can't be modified"
+	invalidJSONMsg    = "Request body should contain single JSON value"
+	mismatchIDMsg     = "Entity id doesn't match the last element of path"
+	unknownFormatMsg  = "Unknown output format"
+	jsonFormat        = "json"
+
+	// maxPolicyBodySize limits size of request body with policy entity.
+	maxPolicyBodySize = 16 << 20
 	usage             = `PDP storage traversal API:
 Description: This API displays the ord, id, target, obligation, and algorithm
 information of specific nodes/subtree in the pdp storage tree. The subtree root
@@ -33,7 +53,27 @@ Parameters:
 			E.g.: depth=1 displays the selected root and its children.
             By default, the depth is 0 (only display the selected node).
 
-GET /query/<path>?depth=<depth>`
+    format  Is an optional query string parameter. Value "json" makes API
+            respond with indented JSON document and application/json
+            content type. By default, the subtree is streamed as is.
+
+GET /query/<path>?depth=<depth>&format=<format>
+
+PDP storage modification API:
+Description: This API modifies policies at the path. Each call makes
+an update of current policies and sets new tag to them. The tag is returned
+as JSON object {"tag": "<tag>"}. Requests should have "Authorization: Bearer
+<token>" header with token set on server start.
+
+POST /policies/<path>    Adds policy set, policy or rule from request body
+                         to the item at the path.
+PUT /policies/<path>     Replaces the item at the path by policy set, policy
+                         or rule from request body. Id of the entity must
+                         match the last element of the path.
+DELETE /policies/<path>  Deletes the item at the path.
+
+Request body should be in the same format (YAML or JSON) as policies
+the server loads.`
 )
 
 func handleQuery(w http.ResponseWriter, storage *pdp.PolicyStorage,
@@ -71,6 +111,20 @@ func handleQuery(w http.ResponseWriter, storage *pdp.PolicyStorage,
 		return
 	}
 
+	format := ""
+	if formatOpt, ok := urlQuery["format"]; ok {
+		format = strings.ToLower(formatOpt[0])
+		if format != jsonFormat {
+			http.Error(w, fmt.Sprintf("%s %q", unknownFormatMsg, formatOpt[0]), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if format == jsonFormat {
+		writeJSONNode(w, target, int(depth))
+		return
+	}
+
 	// dump
 	if err = target.MarshalWithDepth(w, int(depth)); err != nil {
 		http.Error(w, strconv.Quote(err.Error()), http.StatusInternalServerError)
@@ -79,17 +133,260 @@ func handleQuery(w http.ResponseWriter, storage *pdp.PolicyStorage,
 	w.WriteHeader(http.StatusOK)
 }
 
+func writeJSONNode(w http.ResponseWriter, target pdp.StorageMarshal, depth int) {
+	b := new(bytes.Buffer)
+	if err := target.MarshalWithDepth(b, depth); err != nil {
+		http.Error(w, strconv.Quote(err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	out := new(bytes.Buffer)
+	if err := json.Indent(out, b.Bytes(), "", "  "); err != nil {
+		http.Error(w, strconv.Quote(err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	out.WriteTo(w)
+}
+
+// makePolicyUpdate builds update document in format of given parser with
+// single command. YAML entity is embedded to the document as is with
+// indentation while JSON entity is checked to be a single JSON value.
+func makePolicyUpdate(isJSON bool, op string, path []string, entity []byte) ([]byte, error) {
+	p, err := json.Marshal(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := new(bytes.Buffer)
+	if isJSON {
+		fmt.Fprintf(b, "[{\"op\": %q, \"path\": %s", op, p)
+		if entity != nil {
+			v, err := getJSONEntity(entity)
+			if err != nil {
+				return nil, err
+			}
+
+			b.WriteString(", \"entity\": ")
+			b.Write(v)
+		}
+		b.WriteString("}]\n")
+
+		return b.Bytes(), nil
+	}
+
+	fmt.Fprintf(b, "- op: %s\n  path: %s\n", op, p)
+	if entity != nil {
+		b.WriteString("  entity:\n")
+		for _, line := range strings.Split(strings.TrimRight(string(entity), "\n"), "\n") {
+			b.WriteString("    ")
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+func getJSONEntity(b []byte) (json.RawMessage, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+
+	var v json.RawMessage
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New(invalidJSONMsg)
+	}
+
+	return v, nil
+}
+
+func (handler *storageHandler) authorized(r *http.Request) bool {
+	token := handler.s.opts.storageToken
+	a := r.Header.Get("Authorization")
+	if !strings.HasPrefix(a, "Bearer ") {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(a[len("Bearer "):]), []byte(token)) == 1
+}
+
+func (handler *storageHandler) handlePolicies(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method == http.MethodGet {
+		handler.s.RLock()
+		storage := handler.s.p
+		handler.s.RUnlock()
+		handleQuery(w, storage, path, r.URL.Query())
+		return
+	}
+
+	if len(handler.s.opts.storageToken) <= 0 {
+		http.Error(w, readonlyMsg, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !handler.authorized(r) {
+		http.Error(w, unauthorizedMsg, http.StatusUnauthorized)
+		return
+	}
+
+	ok := false
+	defer func() {
+		handler.s.metrics.observeOperation(metricsOpModify, true, ok)
+	}()
+
+	var (
+		op     string
+		target []string
+		entity []byte
+	)
+
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPolicyBodySize))
+		if err != nil {
+			http.Error(w, strconv.Quote(err.Error()), http.StatusBadRequest)
+			return
+		}
+
+		op = pdp.UpdateOpNames[pdp.UOAdd]
+		entity = b
+		target = path
+		if r.Method == http.MethodPut && len(path) > 0 {
+			target = path[:len(path)-1]
+		}
+
+	case http.MethodDelete:
+		op = pdp.UpdateOpNames[pdp.UODelete]
+		target = path
+
+	default:
+		http.Error(w, fmt.Sprintf("Method %s isn't allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if len(path) <= 0 {
+		http.Error(w, usage, http.StatusNotFound)
+		return
+	}
+
+	handler.s.ctrlLock.Lock()
+	defer handler.s.ctrlLock.Unlock()
+
+	handler.s.RLock()
+	storage := handler.s.p
+	handler.s.RUnlock()
+
+	if storage == nil {
+		http.Error(w, missingStorageMsg, http.StatusNotFound)
+		return
+	}
+
+	fromTag := storage.GetTag()
+	if fromTag == nil {
+		http.Error(w, untaggedMsg, http.StatusConflict)
+		return
+	}
+
+	_, isJSON := handler.s.opts.parser.(jast.Parser)
+	doc, err := makePolicyUpdate(isJSON, op, target, entity)
+	if err != nil {
+		http.Error(w, strconv.Quote(err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	t, err := storage.NewTransaction(fromTag)
+	if err != nil {
+		http.Error(w, strconv.Quote(err.Error()), http.StatusConflict)
+		return
+	}
+
+	toTag := uuid.New()
+	tr, raw := handler.s.teeState(bytes.NewReader(doc))
+	u, err := handler.s.opts.parser.UnmarshalUpdate(tr, t.Symbols(), *fromTag, toTag)
+	if err != nil {
+		raw.discard()
+		http.Error(w, strconv.Quote(err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if err := t.Apply(u); err != nil {
+		raw.discard()
+		http.Error(w, strconv.Quote(err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	p, err := t.Commit()
+	if err != nil {
+		raw.discard()
+		http.Error(w, strconv.Quote(err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	// add command replaces existing item with the same id so PUT is correct
+	// only if it has made new item at the path
+	if r.Method == http.MethodPut {
+		prev, err := storage.GetAtPath(path)
+		if err != nil {
+			raw.discard()
+			http.Error(w, strconv.Quote(err.Error()), http.StatusNotFound)
+			return
+		}
+
+		if curr, err := p.GetAtPath(path); err != nil || curr == prev {
+			raw.discard()
+			http.Error(w, mismatchIDMsg, http.StatusBadRequest)
+			return
+		}
+	}
+
+	handler.s.Lock()
+	handler.s.p = p
+	handler.s.Unlock()
+	ok = true
+
+	handler.s.opts.logger.WithFields(log.Fields{
+		"method":   r.Method,
+		"path":     strings.Join(path, "/"),
+		"prev-tag": fromTag,
+		"curr-tag": toTag}).Info("Policy modification has been applied")
+
+	req := newPolicyItem(fromTag, &toTag)
+	req.raw = raw
+	handler.s.saveState(groupItem{id: -1, req: req})
+	handler.s.putPoliciesVersion(&toTag, p)
+
+	b, err := json.Marshal(map[string]string{"tag": toTag.String()})
+	if err != nil {
+		http.Error(w, strconv.Quote(err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
 type storageHandler struct {
 	s *Server
 }
 
 func (handler *storageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.FieldsFunc(r.URL.Path, func(c rune) bool { return c == '/' })
+	if len(path) > 0 && path[0] == policiesCmd {
+		handler.handlePolicies(w, r, path[1:])
+		return
+	}
+
 	if r.Method != http.MethodGet {
 		http.Error(w, readonlyMsg, http.StatusMethodNotAllowed)
 		return
 	}
 
-	path := strings.FieldsFunc(r.URL.Path, func(c rune) bool { return c == '/' })
 	if len(path) == 0 {
 		http.Error(w, usage, http.StatusNotFound)
 		return
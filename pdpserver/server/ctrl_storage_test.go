@@ -1,12 +1,21 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
+
 	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+	"github.com/infobloxopen/themis/pdp/ast"
 )
 
 type mockResponseWriter struct {
@@ -74,6 +83,269 @@ func TestHandleQuery(t *testing.T) {
 	w.clear()
 }
 
+func TestHandleQueryJSON(t *testing.T) {
+	s := NewServer()
+	tag := uuid.New()
+	applyTestPolicy(t, s, &tag)
+
+	w := httptest.NewRecorder()
+	(&storageHandler{s}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/query/root?depth=1&format=json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d but got %d (%s)", http.StatusOK, w.Code, w.Body)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected %q content type but got %q", "application/json", ct)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Errorf("Expected JSON document but got %s (%s)", err, w.Body)
+	} else if v["id"] != "root" {
+		t.Errorf("Expected %q node but got %v", "root", v["id"])
+	}
+
+	w = httptest.NewRecorder()
+	(&storageHandler{s}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/query/root?format=xml", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusBadRequest, w.Code, w.Body)
+	}
+}
+
+func TestHandlePolicies(t *testing.T) {
+	s := NewServer(WithStorageToken("secret"), WithHistorySize(5), WithMetricsAt("127.0.0.1:0"))
+	tag := uuid.New()
+	applyTestPolicy(t, s, &tag)
+
+	h := &storageHandler{s}
+	w := storageRequest(h, http.MethodDelete, "/policies/root/deny", "", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusUnauthorized, w.Code, w.Body)
+	}
+
+	w = storageRequest(h, http.MethodDelete, "/policies/root/deny", "wrong", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusUnauthorized, w.Code, w.Body)
+	}
+
+	tag = assertStorageTag(t, s, storageRequest(h, http.MethodPost, "/policies/root", "secret", `# Permit policy
+id: permit
+alg: FirstApplicableEffect
+rules:
+- effect: Permit
+`))
+	assertStorageEffect(t, s, "other", pdp.EffectPermit)
+
+	tag = assertStorageTag(t, s, storageRequest(h, http.MethodPut, "/policies/root/deny", "secret", `{
+  "id": "deny",
+  "alg": "FirstApplicableEffect",
+  "rules": [{"effect": "Permit"}]
+}`))
+	assertStorageEffect(t, s, "deny", pdp.EffectPermit)
+
+	w = storageRequest(h, http.MethodPut, "/policies/root/deny", "secret", `id: other
+alg: FirstApplicableEffect
+rules:
+- effect: Deny
+`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusBadRequest, w.Code, w.Body)
+	}
+
+	if err := s.p.CheckTag(&tag); err != nil {
+		t.Errorf("Expected policies unchanged on failed request but got %s", err)
+	}
+
+	assertStorageTag(t, s, storageRequest(h, http.MethodDelete, "/policies/root/permit", "secret", ""))
+	if _, err := s.p.GetAtPath([]string{"root", "permit"}); err == nil {
+		t.Errorf("Expected deleted policy to be missing")
+	}
+
+	w = storageRequest(h, http.MethodDelete, "/policies/root/missing", "secret", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusBadRequest, w.Code, w.Body)
+	}
+
+	if n := len(s.history.get(true, "")); n != 4 {
+		t.Errorf("Expected 4 versions in history but got %d", n)
+	}
+
+	assertControlMetric(t, s, metricsOpModify, metricsKindPolicies, metricsResultSuccess, 3)
+	assertControlMetric(t, s, metricsOpModify, metricsKindPolicies, metricsResultFailure, 2)
+
+	w = storageRequest(&storageHandler{NewServer()}, http.MethodDelete, "/policies/root/deny", "secret", "")
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusMethodNotAllowed, w.Code, w.Body)
+	}
+}
+
+func TestHandlePoliciesBeforeApply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-storage")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStorageToken("secret"), WithStateDir(dir))
+	tag := uuid.New()
+	applyTestPolicy(t, s, &tag)
+
+	newTag := uuid.New()
+	id := pushTestPolicyUpdate(t, s, &tag, &newTag)
+
+	h := &storageHandler{s}
+	httpTag := assertStorageTag(t, s, storageRequest(h, http.MethodDelete, "/policies/root/deny", "secret", ""))
+
+	res, err := s.Apply(context.Background(), &pb.Update{Id: id})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_TAG_ERROR {
+		t.Errorf("Expected TAG_ERROR for update made before storage modification but got %s (%s)",
+			res.Status, res.Details)
+	}
+
+	if err := s.p.CheckTag(&httpTag); err != nil {
+		t.Errorf("Expected policies with tag %s but got %s", httpTag, err)
+	}
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&httpTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", httpTag, err)
+	}
+}
+
+func TestHandlePoliciesJSON(t *testing.T) {
+	s := NewServer(WithStorageToken("secret"), WithPolicyParser(ast.NewJSONParser()))
+	tag := uuid.New()
+	req := newPolicyItem(nil, &tag)
+	p, err := s.opts.parser.Unmarshal(strings.NewReader(`{
+  "attributes": {"x": "string"},
+  "policies": {
+    "id": "root",
+    "alg": "FirstApplicableEffect",
+    "policies": [{"id": "deny", "alg": "FirstApplicableEffect", "rules": [{"effect": "Deny"}]}]
+  }
+}`), &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.p = p
+	res, err := s.applyPolicy(0, req)
+	assertApplyResponse(t, res, err)
+
+	h := &storageHandler{s}
+	w := storageRequest(h, http.MethodPost, "/policies/root", "secret",
+		`{"id": "permit", "alg": "FirstApplicableEffect", "rules": []}}, {"op": "delete", "path": ["root", "deny"]`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d but got %d (%s)", http.StatusBadRequest, w.Code, w.Body)
+	}
+
+	if _, err := s.p.GetAtPath([]string{"root", "deny"}); err != nil {
+		t.Errorf("Expected policy %q not deleted by injected command but got %s", "deny", err)
+	}
+
+	assertStorageTag(t, s, storageRequest(h, http.MethodPost, "/policies/root", "secret",
+		`{"id": "permit", "alg": "FirstApplicableEffect", "rules": [{"effect": "Permit"}]}`))
+	if _, err := s.p.GetAtPath([]string{"root", "permit"}); err != nil {
+		t.Errorf("Expected added policy %q but got %s", "permit", err)
+	}
+
+	w = storageRequest(h, http.MethodPost, "/policies/root", "secret", strings.Repeat(" ", maxPolicyBodySize+1))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for too large body but got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestMakePolicyUpdate(t *testing.T) {
+	b, err := makePolicyUpdate(true, "add", []string{"root"}, []byte(`{"id": "test"}`))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if !json.Valid(b) {
+		t.Errorf("Expected valid JSON but got %q", b)
+	}
+
+	for _, e := range []string{
+		`{"id": "test"}}, {"op": "delete", "path": ["root"]`,
+		`{"id": "test"} {"id": "other"}`,
+		`{"id": "test"`,
+	} {
+		if b, err := makePolicyUpdate(true, "add", []string{"root"}, []byte(e)); err == nil {
+			t.Errorf("Expected error for %q but got %q", e, b)
+		}
+	}
+
+	b, err = makePolicyUpdate(false, "add", []string{"root"}, []byte("id: test\nrules:\n- effect: Permit\n"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	e := "- op: add\n  path: [\"root\"]\n  entity:\n    id: test\n    rules:\n    - effect: Permit\n"
+	if string(b) != e {
+		t.Errorf("Expected %q but got %q", e, b)
+	}
+}
+
+func storageRequest(h *storageHandler, method, target, token, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if len(token) > 0 {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func assertStorageTag(t *testing.T, s *Server, w *httptest.ResponseRecorder) uuid.UUID {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d but got %d (%s)", http.StatusOK, w.Code, w.Body)
+	}
+
+	var v struct {
+		Tag string `json:"tag"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("Expected JSON with tag but got %s (%s)", err, w.Body)
+	}
+
+	tag, err := uuid.Parse(v.Tag)
+	if err != nil {
+		t.Fatalf("Expected tag but got %s", err)
+	}
+
+	if err := s.p.CheckTag(&tag); err != nil {
+		t.Errorf("Expected policies with returned tag but got %s", err)
+	}
+
+	return tag
+}
+
+func assertStorageEffect(t *testing.T, s *Server, x string, effect int) {
+	t.Helper()
+	ctx, err := pdp.NewContext(nil, 1, func(i int) (string, pdp.AttributeValue, error) {
+		return "x", pdp.MakeStringValue(x), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res := s.p.Root().Calculate(ctx); res.Effect != effect {
+		t.Errorf("Expected %q effect for x=%q but got %q (%v)",
+			pdp.EffectNameFromEnum(effect), x, pdp.EffectNameFromEnum(res.Effect), res.Status)
+	}
+}
+
 func assertEqualWriter(t *testing.T, expect, got mockResponseWriter, failPrefix string) {
 	t.Helper()
 	if expect.statusCode != got.statusCode {
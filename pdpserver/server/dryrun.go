@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+)
+
+// recorder keeps limited number of recent decision requests in a ring buffer.
+type recorder struct {
+	sync.Mutex
+
+	reqs [][]byte
+	next int
+	full bool
+}
+
+func newRecorder(size int) *recorder {
+	return &recorder{
+		reqs: make([][]byte, size),
+	}
+}
+
+func (r *recorder) record(b []byte) {
+	c := make([]byte, len(b))
+	copy(c, b)
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.reqs[r.next] = c
+	r.next++
+	if r.next >= len(r.reqs) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// get returns recorded requests from the oldest to the newest.
+func (r *recorder) get() [][]byte {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.full {
+		out := make([][]byte, r.next)
+		copy(out, r.reqs[:r.next])
+		return out
+	}
+
+	out := make([][]byte, 0, len(r.reqs))
+	out = append(out, r.reqs[r.next:]...)
+	return append(out, r.reqs[:r.next]...)
+}
+
+func (s *Server) recordRequest(b []byte) {
+	if s.recorder != nil {
+		s.recorder.record(b)
+	}
+}
+
+// DryRun is a server handler for gRPC call
+// It evaluates given or recently recorded requests against current and
+// uploaded policies or content and reports requests with different decisions.
+// The upload is dropped after dry run so it should be made again to be applied
+func (s *Server) DryRun(ctx context.Context, in *pb.DryRunRequest) (*pb.DryRunResult, error) {
+	s.opts.logger.Info("Got dry run command")
+
+	req, ok := s.q.pop(in.Id)
+	if !ok {
+		s.opts.logger.WithField("id", in.Id).Error("no such request")
+		return dryRunFail(newUnknownUploadedRequestError(in.Id)), nil
+	}
+	defer req.raw.discard()
+
+	reqs := in.Requests
+	if len(reqs) <= 0 && s.recorder != nil {
+		reqs = s.recorder.get()
+	}
+
+	if len(reqs) <= 0 {
+		return dryRunFail(newMissingDryRunRequestsError()), nil
+	}
+
+	s.RLock()
+	p := s.p
+	c := s.c
+	s.RUnlock()
+
+	cp, cc, err := makeCandidate(in.Id, p, c, req)
+	if err != nil {
+		return dryRunFail(newDryRunCandidateError(in.Id, err)), nil
+	}
+
+	diffs := []*pb.DryRunDiff{}
+	for i, b := range reqs {
+		curr := s.decide(p, c, b)
+		cand := s.decide(cp, cc, b)
+		if sameDecisions(curr, cand) {
+			continue
+		}
+
+		diffs = append(diffs, &pb.DryRunDiff{
+			Index:     int32(i),
+			Request:   describeRequest(b),
+			Current:   curr,
+			Candidate: cand,
+		})
+	}
+
+	s.opts.logger.WithFields(log.Fields{
+		"id":       in.Id,
+		"requests": len(reqs),
+		"diffs":    len(diffs)}).Info("Dry run has been done")
+
+	return &pb.DryRunResult{
+		Status: pb.Response_ACK,
+		Total:  int32(len(reqs)),
+		Diffs:  diffs,
+	}, nil
+}
+
+// makeCandidate returns policies and content storage which server would have
+// after applying given uploaded item.
+func makeCandidate(id int32, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, req *item) (*pdp.PolicyStorage, *pdp.LocalContentStorage, error) {
+	if req.policy {
+		if req.p != nil {
+			return req.p, c, nil
+		}
+
+		if req.pt != nil {
+			cp, err := req.pt.Commit()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return cp, c, nil
+		}
+
+		return nil, nil, newMissingPolicyDataApplyError(id)
+	}
+
+	if req.c != nil {
+		return p, c.Add(req.c), nil
+	}
+
+	if req.ct != nil {
+		cc, err := req.ct.Commit(c)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return p, cc, nil
+	}
+
+	return nil, nil, newMissingContentDataApplyError(id, req.id)
+}
+
+func (s *Server) decide(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte) *pb.Decision {
+	if p == nil {
+		return indeterminateDecision(newMissingPolicyError())
+	}
+
+	ctx, err := s.newContext(context.Background(), p, c, in)
+	if err != nil {
+		return indeterminateDecision(err)
+	}
+
+	r := s.calculate(p, ctx)
+
+	d := &pb.Decision{
+		Effect:      pdp.EffectNameFromEnum(r.Effect),
+		Obligations: make([]string, len(r.Obligations)),
+	}
+
+	if r.Status != nil {
+		d.Status = r.Status.Error()
+	}
+
+	for i, o := range r.Obligations {
+		id, t, v, err := o.Serialize(ctx)
+		if err != nil {
+			d.Obligations[i] = err.Error()
+		} else {
+			d.Obligations[i] = fmt.Sprintf("%s.(%s): %q", id, t, v)
+		}
+	}
+
+	return d
+}
+
+func indeterminateDecision(err error) *pb.Decision {
+	return &pb.Decision{
+		Effect: pdp.EffectNameFromEnum(pdp.EffectIndeterminate),
+		Status: err.Error(),
+	}
+}
+
+func sameDecisions(a, b *pb.Decision) bool {
+	if a.Effect != b.Effect || len(a.Obligations) != len(b.Obligations) {
+		return false
+	}
+
+	for i, o := range a.Obligations {
+		if o != b.Obligations[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeRequest(b []byte) []string {
+	a, err := pdp.UnmarshalRequestAssignments(b)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	out := make([]string, len(a))
+	for i, e := range a {
+		id, t, v, err := e.Serialize(nil)
+		if err != nil {
+			out[i] = err.Error()
+		} else {
+			out[i] = fmt.Sprintf("%s.(%s): %q", id, t, v)
+		}
+	}
+
+	return out
+}
+
+func dryRunFail(err error) *pb.DryRunResult {
+	return &pb.DryRunResult{
+		Status:  pb.Response_ERROR,
+		Details: err.Error(),
+	}
+}
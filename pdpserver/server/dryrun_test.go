@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+	pbs "github.com/infobloxopen/themis/pdp-service"
+)
+
+func TestDryRun(t *testing.T) {
+	s := NewServer(WithRecordedRequests(5))
+
+	tag := uuid.New()
+	applyTestPolicy(t, s, &tag)
+
+	newTag := uuid.New()
+	id := pushTestPolicyUpdate(t, s, &tag, &newTag)
+
+	deny := makeDryRunRequest(t, "deny")
+	other := makeDryRunRequest(t, "other")
+
+	res, err := s.DryRun(context.Background(), &pb.DryRunRequest{Id: id})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ERROR {
+		t.Errorf("Expected ERROR without requests but got %s", res.Status)
+	}
+
+	id = pushTestPolicyUpdate(t, s, &tag, &newTag)
+	res, err = s.DryRun(context.Background(), &pb.DryRunRequest{Id: id, Requests: [][]byte{deny, other}})
+	assertDryRunDiffs(t, res, err, 2, 1)
+
+	if len(res.Diffs) > 0 {
+		d := res.Diffs[0]
+		if d.Current.Effect != pdp.EffectNameFromEnum(pdp.EffectNotApplicable) ||
+			d.Candidate.Effect != pdp.EffectNameFromEnum(pdp.EffectPermit) {
+			t.Errorf("Expected change from %q to %q but got from %q to %q",
+				pdp.EffectNameFromEnum(pdp.EffectNotApplicable), pdp.EffectNameFromEnum(pdp.EffectPermit),
+				d.Current.Effect, d.Candidate.Effect)
+		}
+	}
+
+	if err := s.p.CheckTag(&tag); err != nil {
+		t.Errorf("Expected policies with tag %s after dry run but got %s", tag, err)
+	}
+
+	ar, err := s.Apply(context.Background(), &pb.Update{Id: id})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if ar.Status != pb.Response_ERROR {
+		t.Errorf("Expected ERROR for upload dropped by dry run but got %s", ar.Status)
+	}
+
+	if _, err := s.Validate(context.Background(), &pbs.Msg{Body: deny}); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	err = s.NewValidationStream(&testValidationStream{
+		ctx:  context.Background(),
+		reqs: [][]byte{deny, other},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	id = pushTestPolicyUpdate(t, s, &tag, &newTag)
+	res, err = s.DryRun(context.Background(), &pb.DryRunRequest{Id: id})
+	assertDryRunDiffs(t, res, err, 3, 1)
+
+	s.q.Lock()
+	_, ok := s.q.items[id]
+	s.q.Unlock()
+
+	if ok {
+		t.Errorf("Expected upload %d dropped after dry run", id)
+	}
+
+	id = pushTestPolicyUpdate(t, s, &tag, &newTag)
+	ar, err = s.Apply(context.Background(), &pb.Update{Id: id})
+	assertApplyResponse(t, ar, err)
+
+	if err := s.p.CheckTag(&newTag); err != nil {
+		t.Errorf("Expected policies with tag %s after apply but got %s", newTag, err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := newRecorder(3)
+	for _, s := range []string{"a", "b"} {
+		r.record([]byte(s))
+	}
+
+	assertRecorded(t, r.get(), "a", "b")
+
+	for _, s := range []string{"c", "d", "e"} {
+		r.record([]byte(s))
+	}
+
+	assertRecorded(t, r.get(), "c", "d", "e")
+}
+
+func pushTestPolicyUpdate(t *testing.T, s *Server, fromTag, toTag *uuid.UUID) int32 {
+	req := newPolicyItem(fromTag, toTag)
+	pt, err := s.p.NewTransaction(fromTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u, err := s.opts.parser.UnmarshalUpdate(strings.NewReader(statePolicyUpdate), pt.Symbols(), *fromTag, *toTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := pt.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.pt = pt
+	id, err := s.q.push(req)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	return id
+}
+
+type testValidationStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	reqs [][]byte
+}
+
+func (s *testValidationStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testValidationStream) Recv() (*pbs.Msg, error) {
+	if len(s.reqs) <= 0 {
+		return nil, io.EOF
+	}
+
+	b := s.reqs[0]
+	s.reqs = s.reqs[1:]
+
+	return &pbs.Msg{Body: b}, nil
+}
+
+func (s *testValidationStream) Send(*pbs.Msg) error {
+	return nil
+}
+
+func makeDryRunRequest(t *testing.T, x string) []byte {
+	b, err := pdp.MarshalRequestAssignments([]pdp.AttributeAssignment{
+		pdp.MakeStringAssignment("x", x),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	return b
+}
+
+func assertDryRunDiffs(t *testing.T, res *pb.DryRunResult, err error, total, diffs int) {
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ACK {
+		t.Fatalf("Expected ACK but got %s (%s)", res.Status, res.Details)
+	}
+
+	if int(res.Total) != total {
+		t.Errorf("Expected %d evaluated requests but got %d", total, res.Total)
+	}
+
+	if len(res.Diffs) != diffs {
+		t.Errorf("Expected %d changed decisions but got %d", diffs, len(res.Diffs))
+	}
+}
+
+func assertRecorded(t *testing.T, reqs [][]byte, e ...string) {
+	a := make([]string, len(reqs))
+	for i, b := range reqs {
+		a[i] = string(b)
+	}
+
+	if strings.Join(a, ",") != strings.Join(e, ",") {
+		t.Errorf("Expected %q recorded requests but got %q", e, a)
+	}
+}
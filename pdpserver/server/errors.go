@@ -47,6 +47,31 @@ const (
 	contentTransactionCommitErrorID   = 35
 	unknownUploadedRequestErrorID     = 36
 	unsupportedPolicyFromatErrorID    = 37
+	stateLoadErrorID                  = 38
+	stateSaveErrorID                  = 39
+	statePoliciesRestoreErrorID       = 40
+	stateContentRestoreErrorID        = 41
+	stateUpdateRestoreErrorID         = 42
+	missingStatePoliciesErrorID       = 43
+	missingStateContentErrorID        = 44
+	unknownVersionRequestErrorID      = 45
+	historyDisabledErrorID            = 46
+	missingRollbackTagErrorID         = 47
+	unknownVersionErrorID             = 48
+	missingStateVersionErrorID        = 49
+	missingDryRunRequestsErrorID      = 50
+	dryRunCandidateErrorID            = 51
+	missingStateDataErrorID           = 52
+	stateUpdatesLimitErrorID          = 53
+	stateRollbackErrorID              = 54
+	stateUpdateTagErrorID             = 55
+	emptyGroupApplyErrorID            = 56
+	duplicateGroupPoliciesErrorID     = 57
+	duplicateGroupContentErrorID      = 58
+	groupItemApplyErrorID             = 59
+	sourceFetchErrorID                = 60
+	sourcePolicyCountErrorID          = 61
+	sourceParseErrorID                = 62
 )
 
 type externalError struct {
@@ -672,3 +697,386 @@ func newUnsupportedPolicyFromatError(format string) *unsupportedPolicyFromatErro
 func (e *unsupportedPolicyFromatError) Error() string {
 	return e.errorf("The %s policy format is unsupported. Must be YAML or JSON", e.format)
 }
+
+type stateLoadError struct {
+	errorLink
+	path string
+	err  error
+}
+
+func newStateLoadError(path string, err error) *stateLoadError {
+	return &stateLoadError{
+		errorLink: errorLink{id: stateLoadErrorID},
+		path:      path,
+		err:       err}
+}
+
+func (e *stateLoadError) Error() string {
+	return e.errorf("Failed to load state from %q: %s", e.path, e.err)
+}
+
+type stateSaveError struct {
+	errorLink
+	path string
+	err  error
+}
+
+func newStateSaveError(path string, err error) *stateSaveError {
+	return &stateSaveError{
+		errorLink: errorLink{id: stateSaveErrorID},
+		path:      path,
+		err:       err}
+}
+
+func (e *stateSaveError) Error() string {
+	return e.errorf("Failed to save state to %q: %s", e.path, e.err)
+}
+
+type statePoliciesRestoreError struct {
+	errorLink
+	err error
+}
+
+func newStatePoliciesRestoreError(err error) *statePoliciesRestoreError {
+	return &statePoliciesRestoreError{
+		errorLink: errorLink{id: statePoliciesRestoreErrorID},
+		err:       err}
+}
+
+func (e *statePoliciesRestoreError) Error() string {
+	return e.errorf("Failed to restore policies: %s", e.err)
+}
+
+type stateContentRestoreError struct {
+	errorLink
+	id  string
+	err error
+}
+
+func newStateContentRestoreError(id string, err error) *stateContentRestoreError {
+	return &stateContentRestoreError{
+		errorLink: errorLink{id: stateContentRestoreErrorID},
+		id:        id,
+		err:       err}
+}
+
+func (e *stateContentRestoreError) Error() string {
+	return e.errorf("Failed to restore content %q: %s", e.id, e.err)
+}
+
+type stateUpdateRestoreError struct {
+	errorLink
+	idx int
+	err error
+}
+
+func newStateUpdateRestoreError(idx int, err error) *stateUpdateRestoreError {
+	return &stateUpdateRestoreError{
+		errorLink: errorLink{id: stateUpdateRestoreErrorID},
+		idx:       idx,
+		err:       err}
+}
+
+func (e *stateUpdateRestoreError) Error() string {
+	return e.errorf("Failed to restore update %d: %s", e.idx, e.err)
+}
+
+type missingStatePoliciesError struct {
+	errorLink
+}
+
+func newMissingStatePoliciesError() *missingStatePoliciesError {
+	return &missingStatePoliciesError{
+		errorLink: errorLink{id: missingStatePoliciesErrorID}}
+}
+
+func (e *missingStatePoliciesError) Error() string {
+	return e.errorf("Can't save policy update without policies")
+}
+
+type missingStateContentError struct {
+	errorLink
+	id string
+}
+
+func newMissingStateContentError(id string) *missingStateContentError {
+	return &missingStateContentError{
+		errorLink: errorLink{id: missingStateContentErrorID},
+		id:        id}
+}
+
+func (e *missingStateContentError) Error() string {
+	return e.errorf("Can't save update without content %q", e.id)
+}
+
+type unknownVersionRequestError struct {
+	errorLink
+	t control.Item_DataType
+}
+
+func newUnknownVersionRequestError(t control.Item_DataType) *unknownVersionRequestError {
+	return &unknownVersionRequestError{
+		errorLink: errorLink{id: unknownVersionRequestErrorID},
+		t:         t}
+}
+
+func (e *unknownVersionRequestError) Error() string {
+	return e.errorf("Unknown version request type: %d", e.t)
+}
+
+type historyDisabledError struct {
+	errorLink
+}
+
+func newHistoryDisabledError() *historyDisabledError {
+	return &historyDisabledError{
+		errorLink: errorLink{id: historyDisabledErrorID}}
+}
+
+func (e *historyDisabledError) Error() string {
+	return e.errorf("Version history is disabled")
+}
+
+type missingRollbackTagError struct {
+	errorLink
+}
+
+func newMissingRollbackTagError() *missingRollbackTagError {
+	return &missingRollbackTagError{
+		errorLink: errorLink{id: missingRollbackTagErrorID}}
+}
+
+func (e *missingRollbackTagError) Error() string {
+	return e.errorf("Missing tag to roll back to")
+}
+
+type unknownVersionError struct {
+	errorLink
+	tag string
+}
+
+func newUnknownVersionError(tag string) *unknownVersionError {
+	return &unknownVersionError{
+		errorLink: errorLink{id: unknownVersionErrorID},
+		tag:       tag}
+}
+
+func (e *unknownVersionError) Error() string {
+	return e.errorf("No version with tag %q in history", e.tag)
+}
+
+type missingStateVersionError struct {
+	errorLink
+	tag string
+}
+
+func newMissingStateVersionError(tag string) *missingStateVersionError {
+	return &missingStateVersionError{
+		errorLink: errorLink{id: missingStateVersionErrorID},
+		tag:       tag}
+}
+
+func (e *missingStateVersionError) Error() string {
+	return e.errorf("Can't roll back saved state to %q", e.tag)
+}
+
+type missingDryRunRequestsError struct {
+	errorLink
+}
+
+func newMissingDryRunRequestsError() *missingDryRunRequestsError {
+	return &missingDryRunRequestsError{
+		errorLink: errorLink{id: missingDryRunRequestsErrorID}}
+}
+
+func (e *missingDryRunRequestsError) Error() string {
+	return e.errorf("No requests to dry run")
+}
+
+type dryRunCandidateError struct {
+	errorLink
+	id  int32
+	err error
+}
+
+func newDryRunCandidateError(id int32, err error) *dryRunCandidateError {
+	return &dryRunCandidateError{
+		errorLink: errorLink{id: dryRunCandidateErrorID},
+		id:        id,
+		err:       err}
+}
+
+func (e *dryRunCandidateError) Error() string {
+	return e.errorf("Can't make candidate for upload %d: %s", e.id, e.err)
+}
+
+type missingStateDataError struct {
+	errorLink
+}
+
+func newMissingStateDataError() *missingStateDataError {
+	return &missingStateDataError{
+		errorLink: errorLink{id: missingStateDataErrorID}}
+}
+
+func (e *missingStateDataError) Error() string {
+	return e.errorf("Missing data of upload to save")
+}
+
+type stateUpdatesLimitError struct {
+	errorLink
+	limit int
+}
+
+func newStateUpdatesLimitError(limit int) *stateUpdatesLimitError {
+	return &stateUpdatesLimitError{
+		errorLink: errorLink{id: stateUpdatesLimitErrorID},
+		limit:     limit}
+}
+
+func (e *stateUpdatesLimitError) Error() string {
+	return e.errorf("Too many updates after the last upload (limit %d). Data is dropped from state until the next upload", e.limit)
+}
+
+type stateRollbackError struct {
+	errorLink
+	tag string
+	err error
+}
+
+func newStateRollbackError(tag string, err error) *stateRollbackError {
+	return &stateRollbackError{
+		errorLink: errorLink{id: stateRollbackErrorID},
+		tag:       tag,
+		err:       err}
+}
+
+func (e *stateRollbackError) Error() string {
+	return e.errorf("Can't roll back to %q as saved state can't follow: %s", e.tag, e.err)
+}
+
+type stateUpdateTagError struct {
+	errorLink
+	from string
+	last string
+}
+
+func newStateUpdateTagError(from, last string) *stateUpdateTagError {
+	return &stateUpdateTagError{
+		errorLink: errorLink{id: stateUpdateTagErrorID},
+		from:      from,
+		last:      last}
+}
+
+func (e *stateUpdateTagError) Error() string {
+	return e.errorf("Can't save update from %q to state with %q. Data is dropped from state until the next upload", e.from, e.last)
+}
+
+type emptyGroupApplyError struct {
+	errorLink
+}
+
+func newEmptyGroupApplyError() *emptyGroupApplyError {
+	return &emptyGroupApplyError{
+		errorLink: errorLink{id: emptyGroupApplyErrorID}}
+}
+
+func (e *emptyGroupApplyError) Error() string {
+	return e.errorf("Nothing to apply in group")
+}
+
+type duplicateGroupPoliciesError struct {
+	errorLink
+}
+
+func newDuplicateGroupPoliciesError() *duplicateGroupPoliciesError {
+	return &duplicateGroupPoliciesError{
+		errorLink: errorLink{id: duplicateGroupPoliciesErrorID}}
+}
+
+func (e *duplicateGroupPoliciesError) Error() string {
+	return e.errorf("Group can contain only one policies upload")
+}
+
+type duplicateGroupContentError struct {
+	errorLink
+	id string
+}
+
+func newDuplicateGroupContentError(id string) *duplicateGroupContentError {
+	return &duplicateGroupContentError{
+		errorLink: errorLink{id: duplicateGroupContentErrorID},
+		id:        id}
+}
+
+func (e *duplicateGroupContentError) Error() string {
+	return e.errorf("Group can contain only one upload of content %q", e.id)
+}
+
+type groupItemApplyError struct {
+	errorLink
+	id  int32
+	err error
+}
+
+func newGroupItemApplyError(id int32, err error) *groupItemApplyError {
+	return &groupItemApplyError{
+		errorLink: errorLink{id: groupItemApplyErrorID},
+		id:        id,
+		err:       err}
+}
+
+func (e *groupItemApplyError) Error() string {
+	return e.errorf("Can't apply upload %d in group: %s", e.id, e.err)
+}
+
+type sourceFetchError struct {
+	errorLink
+	loc string
+	err error
+}
+
+func newSourceFetchError(loc string, err error) *sourceFetchError {
+	return &sourceFetchError{
+		errorLink: errorLink{id: sourceFetchErrorID},
+		loc:       loc,
+		err:       err}
+}
+
+func (e *sourceFetchError) Error() string {
+	return e.errorf("Can't get data from %q: %s", e.loc, e.err)
+}
+
+type sourcePolicyCountError struct {
+	errorLink
+	loc   string
+	count int
+}
+
+func newSourcePolicyCountError(loc string, count int) *sourcePolicyCountError {
+	return &sourcePolicyCountError{
+		errorLink: errorLink{id: sourcePolicyCountErrorID},
+		loc:       loc,
+		count:     count}
+}
+
+func (e *sourcePolicyCountError) Error() string {
+	return e.errorf("Expected exactly one policies document at %q but got %d", e.loc, e.count)
+}
+
+type sourceParseError struct {
+	errorLink
+	name string
+	err  error
+}
+
+func newSourceParseError(name string, err error) *sourceParseError {
+	return &sourceParseError{
+		errorLink: errorLink{id: sourceParseErrorID},
+		name:      name,
+		err:       err}
+}
+
+func (e *sourceParseError) Error() string {
+	return e.errorf("Can't parse %q: %s", e.name, e.err)
+}
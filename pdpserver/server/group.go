@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+)
+
+type groupItem struct {
+	id  int32
+	req *item
+}
+
+// ApplyGroup is a server handler for gRPC call
+// It applies several uploads of policies and content at once. PDP switches
+// to all of them together or rejects the whole group if any upload fails
+func (s *Server) ApplyGroup(ctx context.Context, in *pb.UpdateGroup) (*pb.Response, error) {
+	s.opts.logger.WithField("ids", in.Ids).Info("Got group apply command")
+
+	if len(in.Ids) <= 0 {
+		return controlFail(newEmptyGroupApplyError()), nil
+	}
+
+	g := make([]groupItem, 0, len(in.Ids))
+	for _, id := range in.Ids {
+		req, ok := s.q.pop(id)
+		if !ok {
+			s.opts.logger.WithField("id", id).Error("no such request")
+			discardGroup(g)
+			return s.observeGroup(g, controlFail(newUnknownUploadedRequestError(id))), nil
+		}
+
+		g = append(g, groupItem{id: id, req: req})
+	}
+
+	if err := checkGroup(g); err != nil {
+		discardGroup(g)
+		return s.observeGroup(g, controlFail(err)), nil
+	}
+
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
+	s.Lock()
+	p, c, err := s.commitGroup(g)
+	if err != nil {
+		s.Unlock()
+
+		discardGroup(g)
+		return s.observeGroup(g, controlFail(err)), nil
+	}
+
+	s.p = p
+	s.c = c
+	s.Unlock()
+
+	for _, gi := range g {
+		req := gi.req
+		if req.policy {
+			s.opts.logger.WithFields(log.Fields{
+				"id":       gi.id,
+				"prev-tag": req.fromTag,
+				"curr-tag": req.toTag}).Info("Policy has been applied in group")
+
+			s.putPoliciesVersion(req.toTag, p)
+			continue
+		}
+
+		cid := req.contentID()
+
+		s.opts.logger.WithFields(log.Fields{
+			"id":       gi.id,
+			"cid":      cid,
+			"prev-tag": req.fromTag,
+			"curr-tag": req.toTag}).Info("Content has been applied in group")
+
+		if req.c != nil {
+			s.putContentVersion(req.toTag, req.c)
+		} else if lc, err := c.GetLocalContent(cid, req.toTag); err == nil {
+			s.putContentVersion(req.toTag, lc)
+		}
+	}
+
+	s.saveState(g...)
+
+	return s.observeGroup(g, &pb.Response{Status: pb.Response_ACK}), nil
+}
+
+// observeGroup counts result of group apply for each its item and returns
+// the result.
+func (s *Server) observeGroup(g []groupItem, r *pb.Response) *pb.Response {
+	for _, gi := range g {
+		s.metrics.observeControl(metricsOpApply, gi.req.policy, r)
+	}
+
+	return r
+}
+
+func discardGroup(g []groupItem) {
+	for _, gi := range g {
+		gi.req.raw.discard()
+	}
+}
+
+func checkGroup(g []groupItem) error {
+	policies := false
+	content := make(map[string]struct{}, len(g))
+	for _, gi := range g {
+		req := gi.req
+		if req.policy {
+			if req.p == nil && req.pt == nil {
+				return newGroupItemApplyError(gi.id, newMissingPolicyDataApplyError(gi.id))
+			}
+
+			if policies {
+				return newDuplicateGroupPoliciesError()
+			}
+
+			policies = true
+			continue
+		}
+
+		if req.c == nil && req.ct == nil {
+			return newGroupItemApplyError(gi.id, newMissingContentDataApplyError(gi.id, req.id))
+		}
+
+		cid := req.contentID()
+		if _, ok := content[cid]; ok {
+			return newDuplicateGroupContentError(cid)
+		}
+
+		content[cid] = struct{}{}
+	}
+
+	return nil
+}
+
+// commitGroup makes new policies and content storages with all group items
+// applied. It expects server to be locked and leaves current storages intact.
+func (s *Server) commitGroup(g []groupItem) (*pdp.PolicyStorage, *pdp.LocalContentStorage, error) {
+	p := s.p
+	c := s.c
+	for _, gi := range g {
+		req := gi.req
+		if req.policy {
+			if req.p != nil {
+				p = req.p
+				continue
+			}
+
+			if err := p.CheckTag(req.fromTag); err != nil {
+				return nil, nil, newGroupItemApplyError(gi.id, newTagCheckError(err))
+			}
+
+			np, err := req.pt.Commit()
+			if err != nil {
+				return nil, nil, newGroupItemApplyError(gi.id, newPolicyTransactionCommitError(gi.id, req, err))
+			}
+
+			p = np
+			continue
+		}
+
+		if req.c != nil {
+			c = c.Add(req.c)
+			continue
+		}
+
+		if _, err := c.GetLocalContent(req.id, req.fromTag); err != nil {
+			return nil, nil, newGroupItemApplyError(gi.id, newTagCheckError(err))
+		}
+
+		nc, err := req.ct.Commit(c)
+		if err != nil {
+			return nil, nil, newGroupItemApplyError(gi.id, newContentTransactionCommitError(gi.id, req, err))
+		}
+
+		c = nc
+	}
+
+	return p, c, nil
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	pb "github.com/infobloxopen/themis/pdp-control"
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+func TestApplyGroup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir), WithHistorySize(2))
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	cTag := uuid.New()
+	applyTestContent(t, s, &cTag)
+
+	pNewTag := uuid.New()
+	cNewTag := uuid.New()
+	res, err := s.ApplyGroup(context.Background(), &pb.UpdateGroup{
+		Ids: []int32{
+			pushTestGroupItem(t, s, newTestPolicyUpdateItem(t, s, &pTag, &pNewTag)),
+			pushTestGroupItem(t, s, newTestContentUpdateItem(t, s, &cTag, &cNewTag)),
+		},
+	})
+	assertApplyResponse(t, res, err)
+
+	if err := s.p.CheckTag(&pNewTag); err != nil {
+		t.Errorf("Expected policies with tag %s but got %s", pNewTag, err)
+	}
+
+	if _, err := s.c.GetLocalContent("content", &cNewTag); err != nil {
+		t.Errorf("Expected content with tag %s but got %s", cNewTag, err)
+	}
+
+	vs, err := s.ListVersions(context.Background(), &pb.Item{Type: pb.Item_POLICIES})
+	assertVersions(t, vs, err, pNewTag, pTag)
+
+	vs, err = s.ListVersions(context.Background(), &pb.Item{Type: pb.Item_CONTENT, Id: "content"})
+	assertVersions(t, vs, err, cNewTag, cTag)
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&pNewTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", pNewTag, err)
+	}
+
+	if _, err := r.c.GetLocalContent("content", &cNewTag); err != nil {
+		t.Errorf("Expected restored content with tag %s but got %s", cNewTag, err)
+	}
+}
+
+func TestApplyGroupReject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir))
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	cTag := uuid.New()
+	applyTestContent(t, s, &cTag)
+
+	res, err := s.ApplyGroup(context.Background(), &pb.UpdateGroup{})
+	assertGroupFail(t, res, err, pb.Response_ERROR)
+
+	pNewTag := uuid.New()
+	cNewTag := uuid.New()
+	pID := pushTestGroupItem(t, s, newTestPolicyUpdateItem(t, s, &pTag, &pNewTag))
+	res, err = s.ApplyGroup(context.Background(), &pb.UpdateGroup{
+		Ids: []int32{
+			pID,
+			pushTestGroupItem(t, s, newTestPolicyUpdateItem(t, s, &pTag, &pNewTag)),
+		},
+	})
+	assertGroupFail(t, res, err, pb.Response_ERROR)
+
+	if _, ok := s.q.pop(pID); ok {
+		t.Errorf("Expected item %d to be dropped from queue after rejected group", pID)
+	}
+
+	cID := pushTestGroupItem(t, s, newTestContentUpdateItem(t, s, &cTag, &cNewTag))
+	res, err = s.ApplyGroup(context.Background(), &pb.UpdateGroup{
+		Ids: []int32{
+			cID,
+			pushTestGroupItem(t, s, newTestContentUpdateItem(t, s, &cTag, &cNewTag)),
+		},
+	})
+	assertGroupFail(t, res, err, pb.Response_ERROR)
+
+	pID = pushTestGroupItem(t, s, newTestPolicyUpdateItem(t, s, &pTag, &pNewTag))
+	cID = pushTestGroupItem(t, s, newTestContentUpdateItem(t, s, &cTag, &cNewTag))
+
+	cOtherTag := uuid.New()
+	applyTestContentUpdate(t, s, &cTag, &cOtherTag)
+
+	res, err = s.ApplyGroup(context.Background(), &pb.UpdateGroup{Ids: []int32{pID, cID}})
+	assertGroupFail(t, res, err, pb.Response_TAG_ERROR)
+
+	if err := s.p.CheckTag(&pTag); err != nil {
+		t.Errorf("Expected policies with tag %s but got %s", pTag, err)
+	}
+
+	if _, err := s.c.GetLocalContent("content", &cOtherTag); err != nil {
+		t.Errorf("Expected content with tag %s but got %s", cOtherTag, err)
+	}
+
+	assertStateFiles(t, s.state, 3)
+}
+
+func TestApplyGroupStateSaveFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir))
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	cTag := uuid.New()
+	applyTestContent(t, s, &cTag)
+
+	b, err := ioutil.ReadFile(s.state.path)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	// Directory in place of state file makes any save fail.
+	if err := os.Remove(s.state.path); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.state.path, "busy"), 0755); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	pNewTag := uuid.New()
+	cNewTag := uuid.New()
+	res, err := s.ApplyGroup(context.Background(), &pb.UpdateGroup{
+		Ids: []int32{
+			pushTestGroupItem(t, s, newTestPolicyUpdateItem(t, s, &pTag, &pNewTag)),
+			pushTestGroupItem(t, s, newTestContentUpdateItem(t, s, &cTag, &cNewTag)),
+		},
+	})
+	assertApplyResponse(t, res, err)
+
+	if d := s.state.snap.Policies; d == nil || d.lastTag() != pTag.String() {
+		t.Errorf("Expected policies with tag %s in state but got %#v", pTag, d)
+	}
+
+	if d := s.state.snap.Content["content"]; d == nil || d.lastTag() != cTag.String() {
+		t.Errorf("Expected content with tag %s in state but got %#v", cTag, d)
+	}
+
+	assertStateFiles(t, s.state, 2)
+
+	if err := os.RemoveAll(s.state.path); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := ioutil.WriteFile(s.state.path, b, 0644); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&pTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", pTag, err)
+	}
+
+	if _, err := r.c.GetLocalContent("content", &cTag); err != nil {
+		t.Errorf("Expected restored content with tag %s but got %s", cTag, err)
+	}
+}
+
+func newTestPolicyUpdateItem(t *testing.T, s *Server, fromTag, toTag *uuid.UUID) *item {
+	req := newPolicyItem(fromTag, toTag)
+	tr, raw := s.teeState(strings.NewReader(statePolicyUpdate))
+
+	pt, err := s.p.NewTransaction(fromTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u, err := s.opts.parser.UnmarshalUpdate(tr, pt.Symbols(), *fromTag, *toTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := pt.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.pt = pt
+	req.raw = raw
+	raw.finish()
+
+	return req
+}
+
+func newTestContentUpdateItem(t *testing.T, s *Server, fromTag, toTag *uuid.UUID) *item {
+	req := newContentItem("content", fromTag, toTag)
+	tr, raw := s.teeState(strings.NewReader(stateContentUpdate))
+
+	ct, err := s.c.NewTransaction("content", fromTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u, err := jcon.UnmarshalUpdate(tr, "content", *fromTag, *toTag, ct.Symbols())
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := ct.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.ct = ct
+	req.raw = raw
+	raw.finish()
+
+	return req
+}
+
+func pushTestGroupItem(t *testing.T, s *Server, req *item) int32 {
+	id, err := s.q.push(req)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	return id
+}
+
+func assertGroupFail(t *testing.T, res *pb.Response, err error, status pb.Response_Status) {
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != status {
+		t.Errorf("Expected %s but got %s (%s)", status, res.Status, res.Details)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+)
+
+// version represents tagged policies or content applied to PDP.
+type version struct {
+	tag uuid.UUID
+	ts  time.Time
+	p   *pdp.PolicyStorage
+	c   *pdp.LocalContent
+}
+
+// history keeps limited number of recently applied versions of policies and
+// each content. Versions are ordered from the oldest to the newest.
+type history struct {
+	sync.Mutex
+
+	size int
+	p    []*version
+	c    map[string][]*version
+}
+
+func newHistory(size int) *history {
+	return &history{
+		size: size,
+		c:    make(map[string][]*version),
+	}
+}
+
+func (h *history) push(vs []*version, v *version) []*version {
+	out := make([]*version, 0, len(vs)+1)
+	for _, item := range vs {
+		if item.tag != v.tag {
+			out = append(out, item)
+		}
+	}
+
+	out = append(out, v)
+	if len(out) > h.size {
+		out = out[len(out)-h.size:]
+	}
+
+	return out
+}
+
+func (h *history) putPolicies(tag *uuid.UUID, p *pdp.PolicyStorage) {
+	if tag == nil {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	h.p = h.push(h.p, &version{
+		tag: *tag,
+		ts:  time.Now(),
+		p:   p,
+	})
+}
+
+func (h *history) putContent(id string, tag *uuid.UUID, c *pdp.LocalContent) {
+	if tag == nil {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	h.c[id] = h.push(h.c[id], &version{
+		tag: *tag,
+		ts:  time.Now(),
+		c:   c,
+	})
+}
+
+// replaceContent replaces content of version with given tag if the version
+// is in the history.
+func (h *history) replaceContent(id string, tag uuid.UUID, c *pdp.LocalContent) {
+	h.Lock()
+	defer h.Unlock()
+
+	for i, v := range h.c[id] {
+		if v.tag == tag {
+			h.c[id][i] = &version{
+				tag: v.tag,
+				ts:  v.ts,
+				c:   c,
+			}
+		}
+	}
+}
+
+func (h *history) get(policy bool, id string) []*version {
+	h.Lock()
+	defer h.Unlock()
+
+	vs := h.p
+	if !policy {
+		vs = h.c[id]
+	}
+
+	out := make([]*version, len(vs))
+	copy(out, vs)
+
+	return out
+}
+
+func (h *history) find(policy bool, id string, tag uuid.UUID) (*version, bool) {
+	for _, v := range h.get(policy, id) {
+		if v.tag == tag {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *Server) putPoliciesVersion(tag *uuid.UUID, p *pdp.PolicyStorage) {
+	if s.history != nil {
+		s.history.putPolicies(tag, p)
+	}
+}
+
+func (s *Server) putContentVersion(tag *uuid.UUID, c *pdp.LocalContent) {
+	if s.history != nil {
+		s.history.putContent(c.GetID(), tag, c)
+	}
+}
+
+// ListVersions is a server handler for gRPC call
+// It returns versions of policies or content kept in history
+func (s *Server) ListVersions(ctx context.Context, in *pb.Item) (*pb.VersionList, error) {
+	s.opts.logger.Info("Got list versions request")
+
+	if in.Type != pb.Item_POLICIES && in.Type != pb.Item_CONTENT {
+		return versionsFail(newUnknownVersionRequestError(in.Type)), nil
+	}
+
+	if s.history == nil {
+		return versionsFail(newHistoryDisabledError()), nil
+	}
+
+	s.RLock()
+	p := s.p
+	c := s.c
+	s.RUnlock()
+
+	policy := in.Type == pb.Item_POLICIES
+	vs := s.history.get(policy, in.Id)
+	out := make([]*pb.Version, len(vs))
+	for i, v := range vs {
+		tag := v.tag
+		current := false
+		if policy {
+			current = p.CheckTag(&tag) == nil
+		} else {
+			_, err := c.GetLocalContent(in.Id, &tag)
+			current = err == nil
+		}
+
+		out[len(vs)-1-i] = &pb.Version{
+			Tag:       tag.String(),
+			Timestamp: v.ts.UnixNano(),
+			Current:   current,
+		}
+	}
+
+	return &pb.VersionList{Status: pb.Response_ACK, Versions: out}, nil
+}
+
+// Rollback is a server handler for gRPC call
+// It switches policies or content to a version from history
+func (s *Server) Rollback(ctx context.Context, in *pb.Item) (*pb.Response, error) {
+	s.opts.logger.Info("Got rollback request")
+
+	if in.Type != pb.Item_POLICIES && in.Type != pb.Item_CONTENT {
+		return controlFail(newUnknownVersionRequestError(in.Type)), nil
+	}
+
+	if s.history == nil {
+		return controlFail(newHistoryDisabledError()), nil
+	}
+
+	tag, err := newTag(in.ToTag)
+	if err != nil {
+		return controlFail(newInvalidToTagError(in.ToTag, err)), nil
+	}
+
+	if tag == nil {
+		return controlFail(newMissingRollbackTagError()), nil
+	}
+
+	policy := in.Type == pb.Item_POLICIES
+	v, ok := s.history.find(policy, in.Id, *tag)
+	if !ok {
+		return controlFail(newUnknownVersionError(in.ToTag)), nil
+	}
+
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
+	if err := s.saveRollback(policy, in.Id, tag); err != nil {
+		return controlFail(newStateRollbackError(in.ToTag, err)), nil
+	}
+
+	s.Lock()
+	if policy {
+		s.p = v.p
+	} else {
+		s.c = s.c.Add(v.c)
+	}
+	s.Unlock()
+
+	if policy {
+		s.opts.logger.WithField("tag", in.ToTag).Info("Policy has been rolled back")
+	} else {
+		s.opts.logger.WithFields(log.Fields{
+			"cid": in.Id,
+			"tag": in.ToTag}).Info("Content has been rolled back")
+	}
+
+	return &pb.Response{Status: pb.Response_ACK}, nil
+}
+
+func versionsFail(err error) *pb.VersionList {
+	return &pb.VersionList{
+		Status:  pb.Response_ERROR,
+		Details: err.Error(),
+	}
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+
+	pb "github.com/infobloxopen/themis/pdp-control"
+)
+
+func TestHistoryRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-history")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithHistorySize(10), WithStateDir(dir))
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	pNewTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &pTag, &pNewTag)
+
+	cTag := uuid.New()
+	applyTestContent(t, s, &cTag)
+
+	cNewTag := uuid.New()
+	applyTestContentUpdate(t, s, &cTag, &cNewTag)
+
+	vs, err := s.ListVersions(context.Background(), &pb.Item{Type: pb.Item_POLICIES})
+	assertVersions(t, vs, err, pNewTag, pTag)
+
+	vs, err = s.ListVersions(context.Background(), &pb.Item{Type: pb.Item_CONTENT, Id: "content"})
+	assertVersions(t, vs, err, cNewTag, cTag)
+
+	res, err := s.Rollback(context.Background(), &pb.Item{Type: pb.Item_POLICIES, ToTag: pTag.String()})
+	assertApplyResponse(t, res, err)
+
+	if err := s.p.CheckTag(&pTag); err != nil {
+		t.Errorf("Expected policies with tag %s after rollback but got %s", pTag, err)
+	}
+
+	res, err = s.Rollback(context.Background(), &pb.Item{Type: pb.Item_CONTENT, Id: "content", ToTag: cTag.String()})
+	assertApplyResponse(t, res, err)
+
+	if _, err := s.c.Get("content", "second"); err == nil {
+		t.Errorf("Expected no content item from rolled back update")
+	}
+
+	vs, err = s.ListVersions(context.Background(), &pb.Item{Type: pb.Item_POLICIES})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if len(vs.Versions) != 2 {
+		t.Errorf("Expected 2 versions after rollback but got %d", len(vs.Versions))
+	} else if vs.Versions[0].Current || !vs.Versions[1].Current {
+		t.Errorf("Expected only %s as current version but got %v", pTag, vs.Versions)
+	}
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&pTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s after rollback but got %s", pTag, err)
+	}
+
+	if _, err := r.c.GetLocalContent("content", &cTag); err != nil {
+		t.Errorf("Expected restored content with tag %s after rollback but got %s", cTag, err)
+	}
+
+	res, err = s.Rollback(context.Background(), &pb.Item{Type: pb.Item_POLICIES, ToTag: uuid.New().String()})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ERROR {
+		t.Errorf("Expected ERROR for unknown version but got %s", res.Status)
+	}
+
+	res, err = r.Rollback(context.Background(), &pb.Item{Type: pb.Item_POLICIES, ToTag: pTag.String()})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ERROR {
+		t.Errorf("Expected ERROR for disabled history but got %s", res.Status)
+	}
+}
+
+func TestHistoryRollbackBeforeStateUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-history")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithHistorySize(10), WithStateDir(dir))
+
+	firstTag := uuid.New()
+	applyTestPolicy(t, s, &firstTag)
+
+	middleTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &firstTag, &middleTag)
+
+	lastTag := uuid.New()
+	applyTestPolicy(t, s, &lastTag)
+
+	res, err := s.Rollback(context.Background(), &pb.Item{Type: pb.Item_POLICIES, ToTag: middleTag.String()})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ERROR {
+		t.Errorf("Expected ERROR for version saved state can't follow but got %s", res.Status)
+	}
+
+	if err := s.p.CheckTag(&lastTag); err != nil {
+		t.Errorf("Expected policies with tag %s after rejected rollback but got %s", lastTag, err)
+	}
+
+	newTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &lastTag, &newTag)
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&newTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", newTag, err)
+	}
+
+	m := NewServer(WithHistorySize(10))
+	applyTestPolicy(t, m, &firstTag)
+	applyTestPolicyUpdate(t, m, &firstTag, &middleTag)
+	applyTestPolicy(t, m, &lastTag)
+
+	res, err = m.Rollback(context.Background(), &pb.Item{Type: pb.Item_POLICIES, ToTag: middleTag.String()})
+	assertApplyResponse(t, res, err)
+
+	if err := m.p.CheckTag(&middleTag); err != nil {
+		t.Errorf("Expected policies with tag %s after rollback without state but got %s", middleTag, err)
+	}
+}
+
+func TestHistorySize(t *testing.T) {
+	h := newHistory(2)
+
+	tags := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, tag := range tags {
+		tag := tag
+		h.putPolicies(&tag, nil)
+	}
+
+	h.putPolicies(nil, nil)
+
+	vs := h.get(true, "")
+	if len(vs) != 2 {
+		t.Fatalf("Expected 2 versions but got %d", len(vs))
+	}
+
+	if vs[0].tag != tags[1] || vs[1].tag != tags[2] {
+		t.Errorf("Expected %s and %s but got %s and %s", tags[1], tags[2], vs[0].tag, vs[1].tag)
+	}
+
+	h.putPolicies(&tags[1], nil)
+	vs = h.get(true, "")
+	if len(vs) != 2 || vs[0].tag != tags[2] || vs[1].tag != tags[1] {
+		t.Errorf("Expected %s moved to the end of history", tags[1])
+	}
+}
+
+func assertVersions(t *testing.T, vs *pb.VersionList, err error, tags ...uuid.UUID) {
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if vs.Status != pb.Response_ACK {
+		t.Fatalf("Expected ACK but got %s (%s)", vs.Status, vs.Details)
+	}
+
+	if len(vs.Versions) != len(tags) {
+		t.Fatalf("Expected %d versions but got %d", len(tags), len(vs.Versions))
+	}
+
+	for i, tag := range tags {
+		if vs.Versions[i].Tag != tag.String() {
+			t.Errorf("Expected %s at %d but got %s", tag, i, vs.Versions[i].Tag)
+		}
+
+		if current := i == 0; vs.Versions[i].Current != current {
+			t.Errorf("Expected current=%v for %s but got %v", current, tag, vs.Versions[i].Current)
+		}
+	}
+}
@@ -98,6 +98,7 @@ func (s *Server) checkMemory(c *MemLimits) {
 	} else {
 		s.softMemWarn = nil
 	}
+	s.metrics.setMemWarning("soft", s.softMemWarn != nil)
 
 	limit := float64(c.limit)
 	if total > 0.1*limit && float64(m.HeapInuse-m.HeapAlloc)/total >= c.frag {
@@ -120,6 +121,7 @@ func (s *Server) checkMemory(c *MemLimits) {
 	} else {
 		s.fragMemWarn = nil
 	}
+	s.metrics.setMemWarning("frag", s.fragMemWarn != nil)
 
 	if total > 0.1*limit && (total-float64(m.HeapAlloc))/total >= c.back {
 		if s.backMemWarn == nil {
@@ -139,6 +141,7 @@ func (s *Server) checkMemory(c *MemLimits) {
 	} else {
 		s.backMemWarn = nil
 	}
+	s.metrics.setMemWarning("back", s.backMemWarn != nil)
 }
 
 func (s *Server) memoryChecker() {
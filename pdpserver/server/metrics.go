@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+	"github.com/infobloxopen/themis/pdp/selector/pip"
+	"github.com/infobloxopen/themis/pip/client"
+)
+
+const (
+	metricsNamespace = "themis"
+	metricsSubsystem = "pdp"
+
+	metricsPathUnary  = "unary"
+	metricsPathStream = "stream"
+
+	metricsOpUpload = "upload"
+	metricsOpApply  = "apply"
+	metricsOpModify = "modify"
+	metricsOpSync   = "sync"
+
+	metricsKindPolicies = "policies"
+	metricsKindContent  = "content"
+
+	metricsResultSuccess = "success"
+	metricsResultFailure = "failure"
+)
+
+// metrics keeps Prometheus collectors of the server. All methods of nil
+// metrics do nothing so server doesn't need to check if metrics are enabled.
+type metrics struct {
+	registry *prometheus.Registry
+
+	decisions  []prometheus.Counter
+	validation *prometheus.HistogramVec
+	overflows  prometheus.Counter
+	control    *prometheus.CounterVec
+
+	pipRequests prometheus.Histogram
+	pipErrors   prometheus.Counter
+	pipHits     prometheus.Counter
+
+	memWarnings *prometheus.GaugeVec
+}
+
+func newMetrics(s *Server) *metrics {
+	m := &metrics{
+		registry:  prometheus.NewRegistry(),
+		decisions: make([]prometheus.Counter, pdp.EffectIndeterminateDP+1),
+
+		validation: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "validation_duration_seconds",
+			Help:      "Time spent to evaluate decision request by unary or stream path.",
+			Buckets:   []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"path"}),
+
+		overflows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "response_overflows_total",
+			Help:      "Count of responses which status or obligations haven't fit response buffer.",
+		}),
+
+		control: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "control_operations_total",
+			Help:      "Count of policies and content uploads, applies, storage endpoint modifications and syncs by result.",
+		}, []string{"operation", "kind", "result"}),
+
+		pipRequests: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "pip_request_duration_seconds",
+			Help:      "Time spent by PIP selector requests including ones served from cache.",
+			Buckets:   []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}),
+
+		pipErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "pip_request_errors_total",
+			Help:      "Count of failed PIP selector requests.",
+		}),
+
+		pipHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "pip_cache_hits_total",
+			Help:      "Count of PIP selector requests served from cache.",
+		}),
+
+		memWarnings: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "memory_warning",
+			Help:      "Is 1 while memory usage is above soft limit or amount of unused or fragmented memory is above its threshold.",
+		}, []string{"kind"}),
+	}
+
+	decisions := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "decisions_total",
+		Help:      "Count of decisions by effect.",
+	}, []string{"effect"})
+	for i := range m.decisions {
+		m.decisions[i] = decisions.WithLabelValues(pdp.EffectNameFromEnum(i))
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		decisions,
+		m.validation,
+		m.overflows,
+		m.control,
+		m.pipRequests,
+		m.pipErrors,
+		m.pipHits,
+		m.memWarnings,
+		&stateCollector{s},
+	)
+
+	return m
+}
+
+// pipContext returns copy of given request context which makes PIP
+// selectors report requests and cache hits to the metrics.
+func (m *metrics) pipContext(ctx context.Context) context.Context {
+	if m == nil {
+		return ctx
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = pip.NewContextWithCallHandler(ctx, m.observePIPRequest)
+	return client.NewContextWithCacheHitHandler(ctx, m.observePIPCacheHit)
+}
+
+func (m *metrics) observePIPRequest(d time.Duration, err error) {
+	m.pipRequests.Observe(d.Seconds())
+	if err != nil {
+		m.pipErrors.Inc()
+	}
+}
+
+func (m *metrics) observePIPCacheHit(path string, args []pdp.AttributeValue, v pdp.AttributeValue, err error) {
+	m.pipHits.Inc()
+}
+
+func (m *metrics) observeValidation(path string, start time.Time, out []byte) {
+	if m == nil {
+		return
+	}
+
+	m.validation.WithLabelValues(path).Observe(time.Since(start).Seconds())
+
+	effect, overflow := pdp.InspectResponse(out)
+	if effect >= 0 && effect < len(m.decisions) {
+		m.decisions[effect].Inc()
+	}
+
+	if overflow {
+		m.overflows.Inc()
+	}
+}
+
+func (m *metrics) observeControl(op string, policy bool, r *pb.Response) {
+	m.observeOperation(op, policy, r.Status == pb.Response_ACK)
+}
+
+// observeOperation counts result of operation on policies or content made
+// by control protocol, storage endpoint or sync.
+func (m *metrics) observeOperation(op string, policy bool, ok bool) {
+	if m == nil {
+		return
+	}
+
+	kind := metricsKindContent
+	if policy {
+		kind = metricsKindPolicies
+	}
+
+	result := metricsResultFailure
+	if ok {
+		result = metricsResultSuccess
+	}
+
+	m.control.WithLabelValues(op, kind, result).Inc()
+}
+
+func (m *metrics) setMemWarning(kind string, on bool) {
+	if m == nil {
+		return
+	}
+
+	v := 0.
+	if on {
+		v = 1.
+	}
+
+	m.memWarnings.WithLabelValues(kind).Set(v)
+}
+
+func (s *Server) listenMetrics() error {
+	if len(s.opts.metrics) <= 0 {
+		return nil
+	}
+
+	s.opts.logger.WithField("address", s.opts.metrics).Info("Opening metrics port")
+	ln, err := net.Listen("tcp", s.opts.metrics)
+	if err != nil {
+		return err
+	}
+
+	s.metricsCtrl = ln
+	return nil
+}
+
+// uploadStream counts result of upload sent by control protocol handler.
+type uploadStream struct {
+	pb.PDPControl_UploadServer
+
+	m      *metrics
+	policy bool
+}
+
+func (s uploadStream) SendAndClose(r *pb.Response) error {
+	s.m.observeControl(metricsOpUpload, s.policy, r)
+	return s.PDPControl_UploadServer.SendAndClose(r)
+}
+
+// stateCollector exports tags of current policies and content and memory
+// limits of the server.
+type stateCollector struct {
+	s *Server
+}
+
+var (
+	policiesTagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "policies_tag_info"),
+		"Tag of current policies (empty for untagged policies).",
+		[]string{"tag"}, nil,
+	)
+
+	contentTagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "content_tag_info"),
+		"Tag of current content by content id (empty for untagged content).",
+		[]string{"id", "tag"}, nil,
+	)
+
+	memLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "memory_limit_bytes"),
+		"Memory limit and levels of the limit at which server exits (reset) or warns (soft).",
+		[]string{"level"}, nil,
+	)
+
+	memThresholdDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "memory_threshold_ratio"),
+		"Ratios of unused (back) or fragmented (frag) memory at which server warns.",
+		[]string{"kind"}, nil,
+	)
+)
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- policiesTagDesc
+	ch <- contentTagDesc
+	ch <- memLimitDesc
+	ch <- memThresholdDesc
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.s.RLock()
+	p := c.s.p
+	cs := c.s.c
+	c.s.RUnlock()
+
+	if p != nil {
+		ch <- prometheus.MustNewConstMetric(policiesTagDesc, prometheus.GaugeValue, 1, tagLabel(p.GetTag()))
+	}
+
+	if cs != nil {
+		for _, lc := range cs.Contents() {
+			ch <- prometheus.MustNewConstMetric(contentTagDesc, prometheus.GaugeValue, 1, lc.GetID(), tagLabel(lc.GetTag()))
+		}
+	}
+
+	if l := c.s.opts.memLimits; l != nil {
+		ch <- prometheus.MustNewConstMetric(memLimitDesc, prometheus.GaugeValue, float64(l.limit), "limit")
+		ch <- prometheus.MustNewConstMetric(memLimitDesc, prometheus.GaugeValue, l.reset, "reset")
+		ch <- prometheus.MustNewConstMetric(memLimitDesc, prometheus.GaugeValue, l.soft, "soft")
+		ch <- prometheus.MustNewConstMetric(memThresholdDesc, prometheus.GaugeValue, l.back, "back")
+		ch <- prometheus.MustNewConstMetric(memThresholdDesc, prometheus.GaugeValue, l.frag, "frag")
+	}
+}
+
+func tagLabel(tag *uuid.UUID) string {
+	if tag == nil {
+		return ""
+	}
+
+	return tag.String()
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-service"
+	"github.com/infobloxopen/themis/pdp/ast"
+)
+
+func TestMetrics(t *testing.T) {
+	p, err := ast.NewYAMLParser().Unmarshal(strings.NewReader(decisionPathTestPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := NewServer(WithMetricsAt("127.0.0.1:0"), WithDecisionPath("path"), WithMaxResponseSize(30))
+	s.p = p
+
+	b, err := pdp.MarshalRequestAssignments([]pdp.AttributeAssignment{
+		pdp.MakeStringAssignment("x", "test"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, err := s.Validate(nil, &pb.Msg{Body: b}); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, err := s.Validate(nil, &pb.Msg{Body: b, Explain: true}); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, err := s.Validate(nil, &pb.Msg{Body: []byte{}}); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	mfs, err := s.metrics.registry.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	assertMetric(t, mfs, "themis_pdp_decisions_total", "effect", "Permit", 2)
+	assertMetric(t, mfs, "themis_pdp_decisions_total", "effect", "Indeterminate", 1)
+	assertMetric(t, mfs, "themis_pdp_response_overflows_total", "", "", 2)
+	assertMetric(t, mfs, "themis_pdp_validation_duration_seconds", "path", "unary", 3)
+	assertMetric(t, mfs, "themis_pdp_policies_tag_info", "tag", "", 1)
+}
+
+func assertMetric(t *testing.T, mfs []*dto.MetricFamily, name, label, value string, e float64) {
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+
+		for _, m := range mf.GetMetric() {
+			if len(label) > 0 && !hasLabel(m, label, value) {
+				continue
+			}
+
+			var v float64
+			switch {
+			case m.Counter != nil:
+				v = m.Counter.GetValue()
+
+			case m.Gauge != nil:
+				v = m.Gauge.GetValue()
+
+			case m.Histogram != nil:
+				v = float64(m.Histogram.GetSampleCount())
+			}
+
+			if v != e {
+				t.Errorf("Expected %v for %s{%s=%q} but got %v", e, name, label, value, v)
+			}
+
+			return
+		}
+	}
+
+	t.Errorf("Expected %s{%s=%q} but got nothing", name, label, value)
+}
+
+func assertControlMetric(t *testing.T, s *Server, op, kind, result string, e float64) {
+	m := new(dto.Metric)
+	if err := s.metrics.control.WithLabelValues(op, kind, result).Write(m); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if v := m.GetCounter().GetValue(); v != e {
+		t.Errorf("Expected %v for %s of %s with %s but got %v", e, op, kind, result, v)
+	}
+}
+
+func hasLabel(m *dto.Metric, name, value string) bool {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue() == value
+		}
+	}
+
+	return false
+}
@@ -21,6 +21,8 @@ type item struct {
 
 	c  *pdp.LocalContent
 	ct *pdp.LocalContentStorageTransaction
+
+	raw *stateUpload
 }
 
 type queue struct {
@@ -51,6 +53,16 @@ func newContentItem(id string, fromTag, toTag *uuid.UUID) *item {
 		toTag:   toTag}
 }
 
+// contentID returns id of content the item uploads. Content id of full
+// upload comes from its data.
+func (v *item) contentID() string {
+	if v.c != nil {
+		return v.c.GetID()
+	}
+
+	return v.id
+}
+
 func (q *queue) push(v *item) (int32, error) {
 	q.Lock()
 	defer q.Unlock()
@@ -19,10 +19,11 @@ import (
 	pb "github.com/infobloxopen/themis/pdp-service"
 	pbs "github.com/infobloxopen/themis/pdp-service"
 	"github.com/infobloxopen/themis/pdp/ast"
-	"github.com/infobloxopen/themis/pdp/jcon"
+	"github.com/infobloxopen/themis/pdp/selector/pip"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	ot "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -104,6 +105,13 @@ func WithProfilerAt(addr string) Option {
 	}
 }
 
+// WithMetricsAt returns a Option which sets endpoint for Prometheus metrics
+func WithMetricsAt(addr string) Option {
+	return func(o *options) {
+		o.metrics = addr
+	}
+}
+
 // WithStorageAt returns a Option which sets storage endpoint
 func WithStorageAt(addr string) Option {
 	return func(o *options) {
@@ -111,6 +119,13 @@ func WithStorageAt(addr string) Option {
 	}
 }
 
+// WithStorageToken returns a Option which enables policies modification via storage endpoint. Requests to modify policies should be authorized with given token. Empty token keeps the endpoint read only.
+func WithStorageToken(token string) Option {
+	return func(o *options) {
+		o.storageToken = token
+	}
+}
+
 // WithTracingAt returns a Option which sets tracing endpoint
 func WithTracingAt(addr string) Option {
 	return func(o *options) {
@@ -118,6 +133,13 @@ func WithTracingAt(addr string) Option {
 	}
 }
 
+// WithTracingType returns a Option which sets tracing type ("zipkin" or "otlp")
+func WithTracingType(t string) Option {
+	return func(o *options) {
+		o.tracingType = t
+	}
+}
+
 // WithMaxGRPCStreams returns a Option which sets maximum gRPC streams count
 func WithMaxGRPCStreams(limit uint32) Option {
 	return func(o *options) {
@@ -163,24 +185,103 @@ func WithMemProfDumping(path string, numGC uint32, delay time.Duration) Option {
 	}
 }
 
-const memStatsCheckInterval = 100 * time.Millisecond
+// WithDecisionPath returns an Option which makes server add decision path to obligations of permit and deny responses. The path is a list of strings attribute with given id. It contains ids of policy sets and policies enclosing the deciding rule from the root to the rule itself. Empty id disables the path.
+func WithDecisionPath(id string) Option {
+	return func(o *options) {
+		o.decisionPath = id
+	}
+}
+
+// WithStateDir returns an Option which makes server persist policies and content to given directory. After each successfully applied policies or content upload or update server saves the data along with its tags to the directory. Data of each upload and update is stored in its own file. Server.RestoreState loads saved data on start so incremental updates can continue after restart. Empty path disables persistence.
+func WithStateDir(path string) Option {
+	return func(o *options) {
+		o.stateDir = path
+	}
+}
+
+// WithHistorySize returns an Option which makes server keep given number of recently applied versions of policies and of each content. Server.ListVersions and Server.Rollback use the history to list versions and switch back to any of them. Only tagged policies and content are kept. Zero size disables the history.
+func WithHistorySize(n int) Option {
+	return func(o *options) {
+		o.historySize = n
+	}
+}
+
+// WithRecordedRequests returns an Option which makes server record given number of recent decision requests. Server.DryRun evaluates recorded requests against uploaded policies or content if PAP doesn't supply its own requests. Zero number disables recording.
+func WithRecordedRequests(n int) Option {
+	return func(o *options) {
+		o.recordedRequests = n
+	}
+}
+
+// WithPolicySource returns an Option which makes server poll given file or HTTP(S) URL for policies. Server loads new policies when data at the location changes and replaces current policies with them. Empty location disables polling.
+func WithPolicySource(loc string) Option {
+	return func(o *options) {
+		o.policySource = loc
+	}
+}
+
+// WithContentSources returns an Option which makes server poll given files, directories or HTTP(S) URLs for content. Server loads content from all the sources when any of them changes and replaces all current content with it. Policies from policy source (see WithPolicySource) and content are replaced at once if both have changed.
+func WithContentSources(locs ...string) Option {
+	return func(o *options) {
+		o.contentSources = append(o.contentSources, locs...)
+	}
+}
+
+// WithSyncInterval returns an Option which sets interval between polls of policies and content sources (see WithPolicySource and WithContentSources).
+func WithSyncInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.syncInterval = d
+	}
+}
+
+// WithContentMemLimit returns an Option which makes server reject content which is estimated to take more than given number of bytes as soon as the limit is reached during parsing. Zero limit disables the check.
+func WithContentMemLimit(n int64) Option {
+	return func(o *options) {
+		o.contentMemLimit = n
+	}
+}
+
+// WithContentSweepInterval returns an Option which makes server periodically remove content items and map entries which have expired. Expired entries are treated as missing by lookups anyway, sweeping frees memory they take. Content keeps its tag after sweeping. Zero interval disables sweeping.
+func WithContentSweepInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.contentSweepInterval = d
+	}
+}
+
+const (
+	memStatsCheckInterval = 100 * time.Millisecond
+	defSyncInterval       = 10 * time.Second
+)
 
 type options struct {
 	grpcOpts []grpc.ServerOption
 
-	logger    *log.Logger
-	parser    ast.Parser
-	service   string
-	control   string
-	health    string
-	profiler  string
-	storage   string
-	tracing   string
-	memLimits *MemLimits
-	streams   uint32
+	logger       *log.Logger
+	parser       ast.Parser
+	service      string
+	control      string
+	health       string
+	profiler     string
+	metrics      string
+	storage      string
+	storageToken string
+	tracing      string
+	tracingType  string
+	memLimits    *MemLimits
+	streams      uint32
 
 	autoResponseSize bool
 	maxResponseSize  uint32
+	decisionPath     string
+	stateDir         string
+	historySize      int
+	recordedRequests int
+	policySource     string
+	contentSources   []string
+	syncInterval     time.Duration
+	contentMemLimit  int64
+
+	contentSweepInterval time.Duration
 
 	memStatsLogPath     string
 	memStatsLogInterval time.Duration
@@ -205,13 +306,24 @@ type Server struct {
 	control     transport
 	health      transport
 	profiler    net.Listener
+	metricsCtrl net.Listener
 	storageCtrl net.Listener
 
 	q *queue
 
+	// ctrlLock serializes changes of policies and content made by control
+	// protocol, storage API and rollback. It keeps tag checks valid until
+	// the change is applied and makes state saved in the same order.
+	ctrlLock sync.Mutex
+
 	p *pdp.PolicyStorage
 	c *pdp.LocalContentStorage
 
+	state    *state
+	history  *history
+	recorder *recorder
+	syncer   *syncer
+
 	softMemWarn *time.Time
 	backMemWarn *time.Time
 	fragMemWarn *time.Time
@@ -219,6 +331,10 @@ type Server struct {
 	memProfBaseDumpDone chan uint32
 
 	pool bytePool
+
+	metrics *metrics
+
+	tracer ot.Tracer
 }
 
 // NewServer returns new Server instance
@@ -228,6 +344,8 @@ func NewServer(opts ...Option) *Server {
 		service:             ":5555",
 		memStatsLogInterval: -1 * time.Second,
 		maxResponseSize:     10240,
+		syncInterval:        defSyncInterval,
+		tracingType:         "zipkin",
 	}
 
 	for _, opt := range opts {
@@ -257,6 +375,26 @@ func NewServer(opts ...Option) *Server {
 		pool:                pool,
 	}
 
+	if len(o.stateDir) > 0 {
+		s.state = newState(o.stateDir)
+	}
+
+	if o.historySize > 0 {
+		s.history = newHistory(o.historySize)
+	}
+
+	if o.recordedRequests > 0 {
+		s.recorder = newRecorder(o.recordedRequests)
+	}
+
+	if len(o.policySource) > 0 || len(o.contentSources) > 0 {
+		s.syncer = newSyncer(o.policySource, o.contentSources)
+	}
+
+	if len(o.metrics) > 0 {
+		s.metrics = newMetrics(s)
+	}
+
 	o.logger.Info("Creating service protocol handler")
 
 	requests := grpc.NewServer(s.configureRequests()...)
@@ -328,7 +466,7 @@ func (s *Server) LoadContent(paths []string) error {
 			defer f.Close()
 
 			s.opts.logger.WithField("content", path).Info("Parsing content")
-			item, err := jcon.Unmarshal(f, nil)
+			item, _, err := s.unmarshalContent(f, nil, log.Fields{"content": path})
 			if err != nil {
 				return err
 			}
@@ -356,7 +494,7 @@ func (s *Server) ReadContent(readers ...io.Reader) error {
 	items := []*pdp.LocalContent{}
 	for _, r := range readers {
 		s.opts.logger.Info("Parsing content")
-		item, err := jcon.Unmarshal(r, nil)
+		item, _, err := s.unmarshalContent(r, nil, log.Fields{})
 		if err != nil {
 			return err
 		}
@@ -447,10 +585,13 @@ func (s *Server) configureRequests() []grpc.ServerOption {
 	}
 
 	if len(s.opts.tracing) > 0 {
-		tracer, err := initTracing("zipkin", s.opts.tracing)
+		tracer, err := initTracing(s.opts.tracingType, s.opts.tracing)
 		if err != nil {
 			s.opts.logger.WithFields(log.Fields{"err": err}).Warning("Cannot initialize tracing.")
 		} else {
+			s.tracer = tracer
+			pip.SetTracer(tracer)
+
 			onlyIfParent := func(parentSpanCtx ot.SpanContext, method string, req, resp interface{}) bool {
 				return parentSpanCtx != nil
 			}
@@ -502,6 +643,14 @@ func (s *Server) Serve() error {
 
 	s.flushErrors()
 
+	syncingDone := make(chan struct{})
+	go s.syncing(syncingDone)
+	defer close(syncingDone)
+
+	sweepingDone := make(chan struct{})
+	go s.sweeping(sweepingDone)
+	defer close(sweepingDone)
+
 	if err := s.listenControl(); err != nil {
 		return err
 	}
@@ -512,6 +661,10 @@ func (s *Server) Serve() error {
 		return err
 	}
 
+	if err := s.listenMetrics(); err != nil {
+		return err
+	}
+
 	if err := s.listenStorage(); err != nil {
 		return err
 	}
@@ -519,6 +672,13 @@ func (s *Server) Serve() error {
 	if s.health.iface != nil {
 		healthMux := http.NewServeMux()
 		healthMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+			if err := s.syncError(); err != nil {
+				s.opts.logger.Debug("Health check responding with sync error")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				io.WriteString(w, err.Error())
+				return
+			}
+
 			s.opts.logger.Debug("Health check responding with OK")
 			io.WriteString(w, "OK")
 		})
@@ -546,6 +706,21 @@ func (s *Server) Serve() error {
 		}(s.profiler)
 	}
 
+	if s.metricsCtrl != nil {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}))
+
+		metricsServer := &http.Server{Handler: metricsMux}
+		defer func() {
+			s.metricsCtrl.Close()
+			s.metricsCtrl = nil
+		}()
+
+		go func(l net.Listener) {
+			s.errCh <- metricsServer.Serve(l)
+		}(s.metricsCtrl)
+	}
+
 	if s.storageCtrl != nil {
 		storageServer := &http.Server{Handler: &storageHandler{s}}
 		defer func() {
@@ -564,14 +739,18 @@ func (s *Server) Serve() error {
 
 	go s.memoryChecker()
 
-	if s.p != nil {
+	s.RLock()
+	p := s.p
+	s.RUnlock()
+
+	if p != nil {
 		// We already have policy info applied; supplied from local files,
 		// pointed to by CLI options.
 		go s.startOnce.Do(func() {
 			s.errCh <- s.serveRequests()
 		})
 	} else {
-		if s.control.iface == nil {
+		if s.control.iface == nil && s.syncer == nil {
 			return fmt.Errorf("nothing to server - no policies provided and no control endpoint specified")
 		}
 
@@ -593,6 +772,7 @@ func (s *Server) Serve() error {
 
 	err := <-s.errCh
 	s.flushErrors()
+	s.closeTracer()
 	return err
 }
 
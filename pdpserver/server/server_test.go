@@ -104,3 +104,15 @@ func TestValidate_hook_context(t *testing.T) {
 	}
 
 }
+
+func TestReadContentMemLimit(t *testing.T) {
+	s := NewServer()
+	if err := s.ReadContent(strings.NewReader(stateContent)); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s = NewServer(WithContentMemLimit(int64(len(stateContent)/2)))
+	if err := s.ReadContent(strings.NewReader(stateContent)); err == nil {
+		t.Errorf("Expected memory limit error but got nothing")
+	}
+}
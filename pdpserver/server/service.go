@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -11,11 +13,21 @@ import (
 	pb "github.com/infobloxopen/themis/pdp-service"
 )
 
-func (s *Server) newContext(c *pdp.LocalContentStorage, in []byte) (*pdp.Context, error) {
-	ctx, err := pdp.NewContextFromBytes(c, in)
+// newContext makes evaluation context for given request. Request context rc
+// is passed to information providers (for example to trace PIP calls) along
+// with handlers which count PIP requests if metrics are enabled.
+// The context collects decision path only if decision path option is set or
+// policies use the path in obligations.
+func (s *Server) newContext(rc context.Context, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte) (*pdp.Context, error) {
+	ctx, err := pdp.NewContextFromBytesWithSymbols(c, in, p.Symbols())
 	if err != nil {
 		return nil, newContextCreationError(err)
 	}
+	ctx.SetRequestContext(s.metrics.pipContext(rc))
+
+	if len(s.opts.decisionPath) > 0 || p.UsesDecisionPath() {
+		ctx.EnableDecisionPath()
+	}
 
 	return ctx, nil
 }
@@ -47,12 +59,27 @@ func makeFailureResponseWithBuffer(b []byte, err error) []byte {
 	return b[:n]
 }
 
-func (s *Server) rawValidate(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte) []byte {
+// calculate evaluates policies for given context. If decision path option
+// is set it adds the path to obligations of permit or deny response.
+func (s *Server) calculate(p *pdp.PolicyStorage, ctx *pdp.Context) pdp.Response {
+	r := p.Root().Calculate(ctx)
+	ctx.SetDecision(r)
+
+	if len(s.opts.decisionPath) > 0 && (r.Effect == pdp.EffectPermit || r.Effect == pdp.EffectDeny) {
+		o := make([]pdp.AttributeAssignment, len(r.Obligations), len(r.Obligations)+1)
+		copy(o, r.Obligations)
+		r.Obligations = append(o, pdp.MakeListOfStringsAssignment(s.opts.decisionPath, r.DecisionPath()))
+	}
+
+	return r
+}
+
+func (s *Server) rawValidate(rc context.Context, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte) []byte {
 	if p == nil {
 		return makeFailureResponse(newMissingPolicyError())
 	}
 
-	ctx, err := s.newContext(c, in)
+	ctx, err := s.newContext(rc, p, c, in)
 	if err != nil {
 		return makeFailureResponse(err)
 	}
@@ -61,7 +88,7 @@ func (s *Server) rawValidate(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, i
 		s.opts.logger.WithField("context", ctx).Debug("Request context")
 	}
 
-	r := p.Root().Calculate(ctx)
+	r := s.calculate(p, ctx)
 
 	if s.opts.logger.Level >= log.DebugLevel {
 		s.opts.logger.WithFields(log.Fields{
@@ -82,12 +109,12 @@ func (s *Server) rawValidate(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, i
 	return out
 }
 
-func (s *Server) rawValidateWithAllocator(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte, f func(n int) ([]byte, error)) []byte {
+func (s *Server) rawValidateWithAllocator(rc context.Context, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte, f func(n int) ([]byte, error)) []byte {
 	if p == nil {
 		return makeFailureResponseWithAllocator(f, newMissingPolicyError())
 	}
 
-	ctx, err := s.newContext(c, in)
+	ctx, err := s.newContext(rc, p, c, in)
 	if err != nil {
 		return makeFailureResponseWithAllocator(f, err)
 	}
@@ -96,7 +123,7 @@ func (s *Server) rawValidateWithAllocator(p *pdp.PolicyStorage, c *pdp.LocalCont
 		s.opts.logger.WithField("context", ctx).Debug("Request context")
 	}
 
-	r := p.Root().Calculate(ctx)
+	r := s.calculate(p, ctx)
 
 	if s.opts.logger.Level >= log.DebugLevel {
 		s.opts.logger.WithFields(log.Fields{
@@ -117,12 +144,12 @@ func (s *Server) rawValidateWithAllocator(p *pdp.PolicyStorage, c *pdp.LocalCont
 	return out
 }
 
-func (s *Server) rawValidateToBuffer(p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte, out []byte) []byte {
+func (s *Server) rawValidateToBuffer(rc context.Context, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte, out []byte) []byte {
 	if p == nil {
 		return makeFailureResponseWithBuffer(out, newMissingPolicyError())
 	}
 
-	ctx, err := s.newContext(c, in)
+	ctx, err := s.newContext(rc, p, c, in)
 	if err != nil {
 		return makeFailureResponseWithBuffer(out, err)
 	}
@@ -131,7 +158,7 @@ func (s *Server) rawValidateToBuffer(p *pdp.PolicyStorage, c *pdp.LocalContentSt
 		s.opts.logger.WithField("context", ctx).Debug("Request context")
 	}
 
-	r := p.Root().Calculate(ctx)
+	r := s.calculate(p, ctx)
 
 	if s.opts.logger.Level >= log.DebugLevel {
 		s.opts.logger.WithFields(log.Fields{
@@ -152,6 +179,34 @@ func (s *Server) rawValidateToBuffer(p *pdp.PolicyStorage, c *pdp.LocalContentSt
 	return out[:n]
 }
 
+// rawExplain evaluates request as rawValidate does and additionally returns
+// decision trace in JSON format.
+func (s *Server) rawExplain(rc context.Context, p *pdp.PolicyStorage, c *pdp.LocalContentStorage, in []byte) ([]byte, []byte) {
+	if p == nil {
+		return makeFailureResponse(newMissingPolicyError()), nil
+	}
+
+	ctx, err := s.newContext(rc, p, c, in)
+	if err != nil {
+		return makeFailureResponse(err), nil
+	}
+
+	ctx.EnableTrace()
+	r := s.calculate(p, ctx)
+
+	out, err := r.Marshal(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	trace, err := json.Marshal(ctx.Trace())
+	if err != nil {
+		panic(err)
+	}
+
+	return out, trace
+}
+
 // Validate is a server handler for gRPC call
 // It handles PDP decision requests
 // Return variables are named, so they can be passed to validate
@@ -174,18 +229,29 @@ func (s *Server) Validate(ctx context.Context, in *pb.Msg) (*pb.Msg, error) {
 		}
 	}()
 
+	s.recordRequest(in.Body)
+
+	start := time.Now()
 	s.RLock()
 	p := s.p
 	c := s.c
 	s.RUnlock()
 
+	if in.Explain {
+		msg.Body, msg.Trace = s.rawExplain(ctx, p, c, in.Body)
+		s.metrics.observeValidation(metricsPathUnary, start, msg.Body)
+		return msg, err
+	}
+
 	if s.opts.autoResponseSize {
-		msg.Body = s.rawValidate(p, c, in.Body)
+		msg.Body = s.rawValidate(ctx, p, c, in.Body)
+		s.metrics.observeValidation(metricsPathUnary, start, msg.Body)
 		return msg, err
 	}
 
 	b := s.pool.Get()
-	msg.Body = s.rawValidateToBuffer(p, c, in.Body, b)
+	msg.Body = s.rawValidateToBuffer(ctx, p, c, in.Body, b)
+	s.metrics.observeValidation(metricsPathUnary, start, msg.Body)
 	s.pool.Put(b)
 
 	return msg, err
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-service"
+	"github.com/infobloxopen/themis/pdp/ast"
+)
+
+const decisionPathTestPolicy = `# Policy for decision path test
+attributes:
+  x: string
+
+policies:
+  id: Root
+  alg: FirstApplicableEffect
+  policies:
+  - id: Policy
+    alg: FirstApplicableEffect
+    rules:
+    - id: Permit
+      effect: Permit
+      obligations:
+      - x:
+         val:
+           type: string
+           content: test
+`
+
+func TestValidateWithDecisionPath(t *testing.T) {
+	p, err := ast.NewYAMLParser().Unmarshal(strings.NewReader(decisionPathTestPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := NewServer(WithDecisionPath("path"))
+	s.p = p
+
+	b := make([]byte, 128)
+	n, err := pdp.MarshalRequestAssignmentsToBuffer(b, []pdp.AttributeAssignment{
+		pdp.MakeStringAssignment("x", "test"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	r, err := s.Validate(nil, &pb.Msg{Body: b[:n]})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	var a [2]pdp.AttributeAssignment
+	effect, n, err := pdp.UnmarshalResponseToAssignmentsArray(r.Body, a[:])
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if effect != pdp.EffectPermit {
+		t.Errorf("Expected %s effect but got %s",
+			pdp.EffectNameFromEnum(pdp.EffectPermit), pdp.EffectNameFromEnum(effect))
+	}
+
+	pdp.AssertAttributeAssignments(t, "TestValidateWithDecisionPath", a[:n],
+		pdp.MakeStringAssignment("x", "test"),
+		pdp.MakeListOfStringsAssignment("path", []string{"Root", "Policy", "Permit"}),
+	)
+}
+
+func TestValidateWithExplain(t *testing.T) {
+	p, err := ast.NewYAMLParser().Unmarshal(strings.NewReader(decisionPathTestPolicy), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := NewServer()
+	s.p = p
+
+	b, err := pdp.MarshalRequestAssignments([]pdp.AttributeAssignment{
+		pdp.MakeStringAssignment("x", "test"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	r, err := s.Validate(nil, &pb.Msg{Body: b})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if len(r.Trace) > 0 {
+		t.Errorf("Expected no trace without explain flag but got %s", r.Trace)
+	}
+
+	r, err = s.Validate(nil, &pb.Msg{Body: b, Explain: true})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	effect, _, err := pdp.UnmarshalResponseAssignments(r.Body)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if effect != pdp.EffectPermit {
+		t.Errorf("Expected %s effect but got %s",
+			pdp.EffectNameFromEnum(pdp.EffectPermit), pdp.EffectNameFromEnum(effect))
+	}
+
+	var trace pdp.TraceNode
+	if err := json.Unmarshal(r.Trace, &trace); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if trace.ID != "Root" || trace.Effect != "Permit" || len(trace.Children) != 1 {
+		t.Errorf("Expected permit of \"Root\" with single child but got %s", r.Trace)
+	}
+}
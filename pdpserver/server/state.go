@@ -0,0 +1,789 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+const (
+	stateFileName    = "state.json"
+	stateDataDir     = "data"
+	stateDataPattern = "doc-"
+
+	// maxStateUpdates limits number of updates saved after the last full
+	// upload of policies or content. Document which gets more updates is
+	// dropped from the state until the next full upload.
+	maxStateUpdates = 256
+)
+
+// state keeps documents of policies and content applied to PDP along with
+// their tags. Each document refers to data of the last full upload and all
+// updates applied after it. The data is stored in separate files under data
+// directory while the state file holds only tags and names of the files. The
+// state file is replaced as a whole so policies and content on disk always
+// match each other.
+type state struct {
+	sync.Mutex
+
+	path string
+	data string
+	snap stateSnapshot
+}
+
+type stateSnapshot struct {
+	Policies *stateDocument            `json:"policies,omitempty"`
+	Content  map[string]*stateDocument `json:"content,omitempty"`
+}
+
+type stateDocument struct {
+	Tag     string        `json:"tag,omitempty"`
+	File    string        `json:"file"`
+	Updates []stateUpdate `json:"updates,omitempty"`
+}
+
+type stateUpdate struct {
+	FromTag string `json:"from"`
+	ToTag   string `json:"to"`
+	File    string `json:"file"`
+}
+
+func newState(dir string) *state {
+	return &state{
+		path: filepath.Join(dir, stateFileName),
+		data: filepath.Join(dir, stateDataDir),
+		snap: stateSnapshot{
+			Content: make(map[string]*stateDocument),
+		},
+	}
+}
+
+func tagToString(tag *uuid.UUID) string {
+	if tag == nil {
+		return ""
+	}
+
+	return tag.String()
+}
+
+// stateUpload spools raw data of an upload to a file in data directory so
+// the data doesn't stay in memory until the upload is applied.
+type stateUpload struct {
+	f    *os.File
+	path string
+	err  error
+}
+
+func (st *state) newUpload() *stateUpload {
+	if err := os.MkdirAll(st.data, 0755); err != nil {
+		return &stateUpload{err: err}
+	}
+
+	f, err := ioutil.TempFile(st.data, stateDataPattern)
+	if err != nil {
+		return &stateUpload{err: err}
+	}
+
+	return &stateUpload{
+		f:    f,
+		path: f.Name(),
+	}
+}
+
+// Write implements io.Writer. It never fails so a problem with state
+// directory doesn't break the upload itself. The problem is reported when
+// the upload is saved.
+func (u *stateUpload) Write(b []byte) (int, error) {
+	if u.f != nil && u.err == nil {
+		_, u.err = u.f.Write(b)
+	}
+
+	return len(b), nil
+}
+
+// finish flushes spooled data to disk.
+func (u *stateUpload) finish() {
+	if u == nil || u.f == nil {
+		return
+	}
+
+	if u.err == nil {
+		u.err = u.f.Sync()
+	}
+
+	if err := u.f.Close(); u.err == nil {
+		u.err = err
+	}
+
+	u.f = nil
+}
+
+// discard removes spooled data of an upload which isn't going to be saved.
+func (u *stateUpload) discard() {
+	if u == nil {
+		return
+	}
+
+	u.finish()
+	if len(u.path) > 0 {
+		os.Remove(u.path)
+	}
+}
+
+func (u *stateUpload) name() (string, error) {
+	if u == nil {
+		return "", newMissingStateDataError()
+	}
+
+	u.finish()
+	if u.err != nil {
+		return "", u.err
+	}
+
+	return filepath.Base(u.path), nil
+}
+
+func (st *state) load() (*stateSnapshot, error) {
+	b, err := ioutil.ReadFile(st.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, newStateLoadError(st.path, err)
+	}
+
+	snap := &stateSnapshot{}
+	if err := json.Unmarshal(b, snap); err != nil {
+		return nil, newStateLoadError(st.path, err)
+	}
+
+	if snap.Content == nil {
+		snap.Content = make(map[string]*stateDocument)
+	}
+
+	return snap, nil
+}
+
+func (st *state) open(name string) (*os.File, error) {
+	return os.Open(filepath.Join(st.data, name))
+}
+
+// cleanup removes files from data directory which aren't referenced by
+// given snapshot. Those are left by uploads which never have been applied.
+func (st *state) cleanup(snap *stateSnapshot) {
+	names, err := ioutil.ReadDir(st.data)
+	if err != nil {
+		return
+	}
+
+	used := make(map[string]struct{})
+	for _, d := range snap.documents() {
+		for _, name := range d.files() {
+			used[name] = struct{}{}
+		}
+	}
+
+	for _, fi := range names {
+		if _, ok := used[fi.Name()]; !ok {
+			os.Remove(filepath.Join(st.data, fi.Name()))
+		}
+	}
+}
+
+func (st *state) remove(names ...string) {
+	for _, name := range names {
+		os.Remove(filepath.Join(st.data, name))
+	}
+}
+
+// save writes state to temporary file and then renames it to state file.
+// Caller should hold the lock.
+func (st *state) save() error {
+	b, err := json.Marshal(st.snap)
+	if err != nil {
+		return newStateSaveError(st.path, err)
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(st.path), stateFileName+".")
+	if err != nil {
+		return newStateSaveError(st.path, err)
+	}
+
+	tmp := f.Name()
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return newStateSaveError(st.path, err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return newStateSaveError(st.path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return newStateSaveError(st.path, err)
+	}
+
+	if err := os.Rename(tmp, st.path); err != nil {
+		os.Remove(tmp)
+		return newStateSaveError(st.path, err)
+	}
+
+	return nil
+}
+
+func (snap *stateSnapshot) documents() []*stateDocument {
+	out := make([]*stateDocument, 0, len(snap.Content)+1)
+	if snap.Policies != nil {
+		out = append(out, snap.Policies)
+	}
+
+	for _, d := range snap.Content {
+		out = append(out, d)
+	}
+
+	return out
+}
+
+// get returns document of policies or content with given id. Caller should
+// hold the lock.
+func (st *state) get(policy bool, id string) *stateDocument {
+	if policy {
+		return st.snap.Policies
+	}
+
+	return st.snap.Content[id]
+}
+
+// set replaces document of policies or content with given id. Nil document
+// removes it from the state. Caller should hold the lock.
+func (st *state) set(policy bool, id string, d *stateDocument) {
+	if policy {
+		st.snap.Policies = d
+		return
+	}
+
+	if d == nil {
+		delete(st.snap.Content, id)
+		return
+	}
+
+	st.snap.Content[id] = d
+}
+
+// stateChange is a change of policies or content document prepared to be
+// saved together with other changes by a single update of the state file.
+type stateChange struct {
+	policy bool
+	id     string
+
+	// d is a new document. Nil document removes policies or content from
+	// the state.
+	d *stateDocument
+	// u is an upload to discard if the state can't be saved.
+	u *stateUpload
+	// obsolete lists data files to remove after the state is saved.
+	obsolete []string
+	// err is a reason to remove the document.
+	err error
+}
+
+// commit applies all changes and saves the state once. It restores previous
+// documents and discards uploads if the state can't be saved. Otherwise it
+// removes data files which aren't referred by the state anymore. Caller
+// should hold the lock.
+func (st *state) commit(changes ...*stateChange) error {
+	prev := make([]*stateDocument, len(changes))
+	for i, c := range changes {
+		prev[i] = st.get(c.policy, c.id)
+		st.set(c.policy, c.id, c.d)
+	}
+
+	if err := st.save(); err != nil {
+		for i := len(changes) - 1; i >= 0; i-- {
+			c := changes[i]
+			st.set(c.policy, c.id, prev[i])
+			if c.u != nil {
+				c.u.discard()
+			}
+		}
+
+		return err
+	}
+
+	for _, c := range changes {
+		st.remove(c.obsolete...)
+	}
+
+	return nil
+}
+
+func (st *state) missingDocumentError(policy bool, id string) error {
+	if policy {
+		return newMissingStatePoliciesError()
+	}
+
+	return newMissingStateContentError(id)
+}
+
+// prepare makes change which saves given applied upload of policies
+// or content. Caller should hold the lock.
+func (st *state) prepare(policy bool, id string, req *item) (*stateChange, error) {
+	if req.p != nil || req.c != nil {
+		return st.prepareDocument(policy, id, req.toTag, req.raw)
+	}
+
+	return st.prepareUpdate(policy, id, req.fromTag, req.toTag, req.raw)
+}
+
+func (st *state) prepareDocument(policy bool, id string, tag *uuid.UUID, u *stateUpload) (*stateChange, error) {
+	name, err := u.name()
+	if err != nil {
+		u.discard()
+		return nil, newStateSaveError(st.path, err)
+	}
+
+	c := &stateChange{
+		policy: policy,
+		id:     id,
+		d: &stateDocument{
+			Tag:  tagToString(tag),
+			File: name,
+		},
+		u: u,
+	}
+
+	if prev := st.get(policy, id); prev != nil {
+		c.obsolete = prev.files()
+	}
+
+	return c, nil
+}
+
+func (st *state) prepareUpdate(policy bool, id string, fromTag, toTag *uuid.UUID, u *stateUpload) (*stateChange, error) {
+	d := st.get(policy, id)
+	if d == nil {
+		u.discard()
+		return nil, st.missingDocumentError(policy, id)
+	}
+
+	if last := d.lastTag(); last != tagToString(fromTag) {
+		u.discard()
+		return prepareDrop(policy, id, d, newStateUpdateTagError(tagToString(fromTag), last)), nil
+	}
+
+	if len(d.Updates) >= maxStateUpdates {
+		u.discard()
+		return prepareDrop(policy, id, d, newStateUpdatesLimitError(maxStateUpdates)), nil
+	}
+
+	name, err := u.name()
+	if err != nil {
+		u.discard()
+		return prepareDrop(policy, id, d, newStateSaveError(st.path, err)), nil
+	}
+
+	updates := make([]stateUpdate, len(d.Updates), len(d.Updates)+1)
+	copy(updates, d.Updates)
+	return &stateChange{
+		policy: policy,
+		id:     id,
+		d: &stateDocument{
+			Tag:  d.Tag,
+			File: d.File,
+			Updates: append(updates, stateUpdate{
+				FromTag: tagToString(fromTag),
+				ToTag:   tagToString(toTag),
+				File:    name,
+			}),
+		},
+		u: u,
+	}, nil
+}
+
+// prepareDrop makes change which removes document that can't follow changes
+// applied to PDP anymore so restored state doesn't get outdated data. Nil
+// reason means the document has been removed from PDP.
+func prepareDrop(policy bool, id string, d *stateDocument, reason error) *stateChange {
+	return &stateChange{
+		policy:   policy,
+		id:       id,
+		obsolete: d.files(),
+		err:      reason,
+	}
+}
+
+// files returns names of all data files of the document.
+func (d *stateDocument) files() []string {
+	out := make([]string, 0, len(d.Updates)+1)
+	out = append(out, d.File)
+	for _, u := range d.Updates {
+		out = append(out, u.File)
+	}
+
+	return out
+}
+
+// rollback returns document without updates applied after given tag. It
+// returns false if the tag can't be found.
+func (d *stateDocument) rollback(tag string) (*stateDocument, bool) {
+	if d.Tag == tag {
+		return &stateDocument{
+			Tag:  d.Tag,
+			File: d.File,
+		}, true
+	}
+
+	for i, u := range d.Updates {
+		if u.ToTag == tag {
+			updates := make([]stateUpdate, i+1)
+			copy(updates, d.Updates)
+
+			return &stateDocument{
+				Tag:     d.Tag,
+				File:    d.File,
+				Updates: updates,
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
+// lastTag returns tag of the document after all its updates.
+func (d *stateDocument) lastTag() string {
+	if n := len(d.Updates); n > 0 {
+		return d.Updates[n-1].ToTag
+	}
+
+	return d.Tag
+}
+
+// rollback drops saved updates applied after given tag. It does nothing if
+// the state has no document of policies or content with given id. It returns
+// an error if the document doesn't have the tag. Then the state can't follow
+// the rollback and the rollback should be rejected.
+func (st *state) rollback(policy bool, id string, tag *uuid.UUID) error {
+	st.Lock()
+	defer st.Unlock()
+
+	d := st.get(policy, id)
+	if d == nil {
+		return nil
+	}
+
+	nd, ok := d.rollback(tagToString(tag))
+	if !ok {
+		return newMissingStateVersionError(tagToString(tag))
+	}
+
+	obsolete := make([]string, 0, len(d.Updates)-len(nd.Updates))
+	for _, u := range d.Updates[len(nd.Updates):] {
+		obsolete = append(obsolete, u.File)
+	}
+
+	return st.commit(&stateChange{
+		policy:   policy,
+		id:       id,
+		d:        nd,
+		obsolete: obsolete,
+	})
+}
+
+// teeState returns reader which copies all data read from r to a file in
+// state directory if server persists its state. Otherwise it returns r and
+// nil upload.
+func (s *Server) teeState(r io.Reader) (io.Reader, *stateUpload) {
+	if s.state == nil {
+		return r, nil
+	}
+
+	u := s.state.newUpload()
+	return io.TeeReader(r, u), u
+}
+
+// saveState saves applied uploads of policies and content with a single
+// update of the state file so a crash can't leave only some of them on disk.
+func (s *Server) saveState(g ...groupItem) {
+	s.commitState(g, nil)
+}
+
+// commitState saves applied uploads and removes documents of content with
+// given ids with a single update of the state file.
+func (s *Server) commitState(g []groupItem, removed []string) {
+	if s.state == nil {
+		return
+	}
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	changes := make([]*stateChange, 0, len(g)+len(removed))
+	saved := make([]groupItem, 0, len(g))
+	for _, gi := range g {
+		c, err := s.state.prepare(gi.req.policy, gi.req.contentID(), gi.req)
+		if err != nil {
+			s.logStateError(gi, err)
+			continue
+		}
+
+		changes = append(changes, c)
+		saved = append(saved, gi)
+	}
+
+	for _, id := range removed {
+		if d := s.state.get(false, id); d != nil {
+			changes = append(changes, prepareDrop(false, id, d, nil))
+		}
+	}
+
+	if len(changes) <= 0 {
+		return
+	}
+
+	if err := s.state.commit(changes...); err != nil {
+		for _, gi := range saved {
+			s.logStateError(gi, err)
+		}
+
+		return
+	}
+
+	for i, c := range changes[:len(saved)] {
+		if c.err != nil {
+			s.logStateError(saved[i], c.err)
+		}
+	}
+}
+
+func (s *Server) logStateError(gi groupItem, err error) {
+	if gi.req.policy {
+		s.opts.logger.WithFields(log.Fields{
+			"id":  gi.id,
+			"err": err}).Error("Failed to save policies")
+		return
+	}
+
+	s.opts.logger.WithFields(log.Fields{
+		"id":  gi.id,
+		"cid": gi.req.contentID(),
+		"err": err}).Error("Failed to save content")
+}
+
+// saveRollback rolls back saved state before the rollback is applied to
+// PDP so state on disk always matches policies and content in memory.
+func (s *Server) saveRollback(policy bool, cid string, tag *uuid.UUID) error {
+	if s.state == nil {
+		return nil
+	}
+
+	return s.state.rollback(policy, cid, tag)
+}
+
+// RestoreState loads policies and content saved to state directory
+// (see WithStateDir) by previous run of the server. Restored policies replace
+// policies loaded by LoadPolicies or ReadPolicies and restored content
+// replaces loaded content with the same id. The method does nothing if
+// the server doesn't persist its state or there is no saved state yet.
+func (s *Server) RestoreState() error {
+	if s.state == nil {
+		return nil
+	}
+
+	s.opts.logger.WithField("state", s.state.path).Info("Restoring state")
+	snap, err := s.state.load()
+	if err != nil {
+		return err
+	}
+
+	if snap == nil {
+		s.opts.logger.WithField("state", s.state.path).Info("No state to restore")
+		return nil
+	}
+
+	s.state.cleanup(snap)
+
+	p := s.p
+	if snap.Policies != nil {
+		p, err = s.restorePolicies(snap.Policies)
+		if err != nil {
+			return newStatePoliciesRestoreError(err)
+		}
+	}
+
+	c := s.c
+	for id, d := range snap.Content {
+		c, err = s.restoreContent(c, id, d)
+		if err != nil {
+			return newStateContentRestoreError(id, err)
+		}
+	}
+
+	s.Lock()
+	s.p = p
+	s.c = c
+	s.Unlock()
+
+	s.state.Lock()
+	s.state.snap = *snap
+	s.state.Unlock()
+
+	if snap.Policies != nil {
+		if tag, err := newTag(snap.Policies.lastTag()); err == nil {
+			s.putPoliciesVersion(tag, p)
+		}
+	}
+
+	for id, d := range snap.Content {
+		tag, err := newTag(d.lastTag())
+		if err != nil || tag == nil {
+			continue
+		}
+
+		if lc, err := c.GetLocalContent(id, tag); err == nil {
+			s.putContentVersion(tag, lc)
+		}
+	}
+
+	return nil
+}
+
+func parseStateTags(u stateUpdate) (uuid.UUID, uuid.UUID, error) {
+	fromTag, err := uuid.Parse(u.FromTag)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, newInvalidFromTagError(u.FromTag, err)
+	}
+
+	toTag, err := uuid.Parse(u.ToTag)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, newInvalidToTagError(u.ToTag, err)
+	}
+
+	return fromTag, toTag, nil
+}
+
+func (s *Server) restorePolicies(d *stateDocument) (*pdp.PolicyStorage, error) {
+	tag, err := newTag(d.Tag)
+	if err != nil {
+		return nil, newInvalidToTagError(d.Tag, err)
+	}
+
+	f, err := s.state.open(d.File)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.opts.parser.Unmarshal(f, tag)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, u := range d.Updates {
+		p, err = s.restorePoliciesUpdate(p, u)
+		if err != nil {
+			return nil, newStateUpdateRestoreError(i, err)
+		}
+	}
+
+	return p, nil
+}
+
+func (s *Server) restorePoliciesUpdate(p *pdp.PolicyStorage, u stateUpdate) (*pdp.PolicyStorage, error) {
+	fromTag, toTag, err := parseStateTags(u)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := p.NewTransaction(&fromTag)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := s.state.open(u.File)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pu, err := s.opts.parser.UnmarshalUpdate(f, t.Symbols(), fromTag, toTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Apply(pu); err != nil {
+		return nil, err
+	}
+
+	return t.Commit()
+}
+
+func (s *Server) restoreContent(c *pdp.LocalContentStorage, id string, d *stateDocument) (*pdp.LocalContentStorage, error) {
+	tag, err := newTag(d.Tag)
+	if err != nil {
+		return nil, newInvalidToTagError(d.Tag, err)
+	}
+
+	f, err := s.state.open(d.File)
+	if err != nil {
+		return nil, err
+	}
+
+	lc, _, err := s.unmarshalContent(f, tag, log.Fields{"content": d.File})
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c = c.Add(lc)
+	for i, u := range d.Updates {
+		c, err = s.restoreContentUpdate(c, id, u)
+		if err != nil {
+			return nil, newStateUpdateRestoreError(i, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (s *Server) restoreContentUpdate(c *pdp.LocalContentStorage, id string, u stateUpdate) (*pdp.LocalContentStorage, error) {
+	fromTag, toTag, err := parseStateTags(u)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := c.NewTransaction(id, &fromTag)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := s.state.open(u.File)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cu, err := jcon.UnmarshalUpdate(f, id, fromTag, toTag, t.Symbols())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Apply(cu); err != nil {
+		return nil, err
+	}
+
+	return t.Commit(c)
+}
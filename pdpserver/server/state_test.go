@@ -0,0 +1,315 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-control"
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+const (
+	statePolicy = `# Policy to persist
+attributes:
+  x: string
+policies:
+  id: root
+  alg: FirstApplicableEffect
+  policies:
+  - id: deny
+    alg: FirstApplicableEffect
+    target:
+    - equal:
+      - attr: x
+      - val:
+          type: string
+          content: deny
+    rules:
+    - effect: Deny
+`
+
+	statePolicyUpdate = `# Update to persist
+- op: add
+  path:
+  - root
+  entity:
+    id: permit
+    alg: FirstApplicableEffect
+    rules:
+    - effect: Permit
+`
+
+	stateContent = `{
+  "ID": "content",
+  "Items": {
+    "value": {
+      "type": "string",
+      "data": "first"
+    }
+  }
+}`
+
+	stateContentUpdate = `[
+  {
+    "op": "Add",
+    "path": ["second"],
+    "entity": {
+      "type": "string",
+      "data": "second"
+    }
+  }
+]`
+)
+
+func TestStateRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir))
+	if err := s.RestoreState(); err != nil {
+		t.Fatalf("Expected no error for empty state but got %s", err)
+	}
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	pNewTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &pTag, &pNewTag)
+
+	cTag := uuid.New()
+	applyTestContent(t, s, &cTag)
+
+	cNewTag := uuid.New()
+	applyTestContentUpdate(t, s, &cTag, &cNewTag)
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := r.p.CheckTag(&pNewTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", pNewTag, err)
+	}
+
+	ctx, err := pdp.NewContext(nil, 1, func(i int) (string, pdp.AttributeValue, error) {
+		return "x", pdp.MakeStringValue("other"), nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res := r.p.Root().Calculate(ctx); res.Effect != pdp.EffectPermit {
+		t.Errorf("Expected %q effect from restored policy update but got %q (%v)",
+			pdp.EffectNameFromEnum(pdp.EffectPermit), pdp.EffectNameFromEnum(res.Effect), res.Status)
+	}
+
+	if _, err := r.c.GetLocalContent("content", &cNewTag); err != nil {
+		t.Errorf("Expected restored content with tag %s but got %s", cNewTag, err)
+	}
+
+	if _, err := r.c.Get("content", "second"); err != nil {
+		t.Errorf("Expected content item from restored content update but got %s", err)
+	}
+
+	if err := ioutil.WriteFile(r.state.path, []byte("{"), 0644); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	err = NewServer(WithStateDir(dir)).RestoreState()
+	if err == nil {
+		t.Errorf("Expected *stateLoadError but got nothing")
+	} else if _, ok := err.(*stateLoadError); !ok {
+		t.Errorf("Expected *stateLoadError but got %T (%s)", err, err)
+	}
+}
+
+func TestStateFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir))
+
+	pTag := uuid.New()
+	applyTestPolicy(t, s, &pTag)
+
+	pNewTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &pTag, &pNewTag)
+	assertStateFiles(t, s.state, 2)
+
+	b, err := ioutil.ReadFile(s.state.path)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if strings.Contains(string(b), "FirstApplicableEffect") {
+		t.Errorf("Expected only tags and file names in state file but got %s", b)
+	}
+
+	pTag = uuid.New()
+	applyTestPolicy(t, s, &pTag)
+	assertStateFiles(t, s.state, 1)
+
+	tr, u := s.teeState(strings.NewReader(statePolicy))
+	if _, err := ioutil.ReadAll(tr); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	u.finish()
+	assertStateFiles(t, s.state, 2)
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	assertStateFiles(t, r.state, 1)
+
+	if err := r.p.CheckTag(&pTag); err != nil {
+		t.Errorf("Expected restored policies with tag %s but got %s", pTag, err)
+	}
+}
+
+func TestStateUpdatesLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-state")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer(WithStateDir(dir))
+
+	tag := uuid.New()
+	applyTestPolicy(t, s, &tag)
+
+	for i := 0; i < maxStateUpdates; i++ {
+		newTag := uuid.New()
+		applyTestPolicyUpdate(t, s, &tag, &newTag)
+		tag = newTag
+	}
+	assertStateFiles(t, s.state, maxStateUpdates+1)
+
+	newTag := uuid.New()
+	applyTestPolicyUpdate(t, s, &tag, &newTag)
+	assertStateFiles(t, s.state, 0)
+
+	if s.state.snap.Policies != nil {
+		t.Errorf("Expected policies dropped from state but got %#v", s.state.snap.Policies)
+	}
+
+	r := NewServer(WithStateDir(dir))
+	if err := r.RestoreState(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if r.p != nil {
+		t.Errorf("Expected no restored policies but got %s", r.p.GetTag())
+	}
+}
+
+func applyTestPolicy(t *testing.T, s *Server, tag *uuid.UUID) {
+	req := newPolicyItem(nil, tag)
+	tr, raw := s.teeState(strings.NewReader(statePolicy))
+
+	p, err := s.opts.parser.Unmarshal(tr, tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.p = p
+	req.raw = raw
+	res, err := s.applyPolicy(0, req)
+	assertApplyResponse(t, res, err)
+}
+
+func applyTestPolicyUpdate(t *testing.T, s *Server, fromTag, toTag *uuid.UUID) {
+	req := newPolicyItem(fromTag, toTag)
+	tr, raw := s.teeState(strings.NewReader(statePolicyUpdate))
+
+	pt, err := s.p.NewTransaction(fromTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u, err := s.opts.parser.UnmarshalUpdate(tr, pt.Symbols(), *fromTag, *toTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := pt.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.pt = pt
+	req.raw = raw
+	res, err := s.applyPolicy(1, req)
+	assertApplyResponse(t, res, err)
+}
+
+func applyTestContent(t *testing.T, s *Server, tag *uuid.UUID) {
+	req := newContentItem("content", nil, tag)
+	tr, raw := s.teeState(strings.NewReader(stateContent))
+
+	c, err := jcon.Unmarshal(tr, tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.c = c
+	req.raw = raw
+	res, err := s.applyContent(2, req)
+	assertApplyResponse(t, res, err)
+}
+
+func applyTestContentUpdate(t *testing.T, s *Server, fromTag, toTag *uuid.UUID) {
+	req := newContentItem("content", fromTag, toTag)
+	tr, raw := s.teeState(strings.NewReader(stateContentUpdate))
+
+	ct, err := s.c.NewTransaction("content", fromTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	u, err := jcon.UnmarshalUpdate(tr, "content", *fromTag, *toTag, ct.Symbols())
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if err := ct.Apply(u); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.ct = ct
+	req.raw = raw
+	res, err := s.applyContent(3, req)
+	assertApplyResponse(t, res, err)
+}
+
+func assertApplyResponse(t *testing.T, res *pb.Response, err error) {
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if res.Status != pb.Response_ACK {
+		t.Fatalf("Expected ACK but got %s (%s)", res.Status, res.Details)
+	}
+}
+
+func assertStateFiles(t *testing.T, st *state, n int) {
+	fis, err := ioutil.ReadDir(st.data)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if len(fis) != n {
+		t.Errorf("Expected %d data files in state but got %d", n, len(fis))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -41,22 +42,37 @@ func (s *Server) NewValidationStream(stream pb.PDP_NewValidationStreamServer) er
 			return err
 		}
 
+		s.recordRequest(in.Body)
+
+		start := time.Now()
 		s.RLock()
 		p := s.p
 		c := s.c
 		s.RUnlock()
 
-		if s.opts.autoResponseSize {
-			err = stream.Send(&pb.Msg{Body: s.rawValidateWithAllocator(p, c, in.Body, func(n int) ([]byte, error) {
+		span, rc := s.startMessageSpan(ctx, in.Header)
+
+		out := &pb.Msg{}
+		if in.Explain {
+			out.Body, out.Trace = s.rawExplain(rc, p, c, in.Body)
+		} else if s.opts.autoResponseSize {
+			out.Body = s.rawValidateWithAllocator(rc, p, c, in.Body, func(n int) ([]byte, error) {
 				if len(buffer) < n {
 					buffer = make([]byte, n)
 				}
 
 				return buffer, nil
-			})})
+			})
 		} else {
-			err = stream.Send(&pb.Msg{Body: s.rawValidateToBuffer(p, c, in.Body, buffer)})
+			out.Body = s.rawValidateToBuffer(rc, p, c, in.Body, buffer)
 		}
+
+		s.metrics.observeValidation(metricsPathStream, start, out.Body)
+		err = stream.Send(out)
+		if span != nil {
+			span.Finish()
+		}
+
 		if err != nil {
 			s.opts.logger.WithFields(log.Fields{
 				"id":  sID,
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// sweeping periodically removes expired items and map entries from content
+// (see WithContentSweepInterval).
+func (s *Server) sweeping(done <-chan struct{}) {
+	if s.opts.contentSweepInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.opts.contentSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			s.sweepContent(time.Now())
+		}
+	}
+}
+
+// sweepContent replaces current content with a copy which doesn't have items
+// and map entries expired at given time. Content keeps its tag so PAP can
+// send updates for it as usual. The history gets the copy as well to let
+// memory taken by expired entries go.
+func (s *Server) sweepContent(now time.Time) int {
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
+	s.RLock()
+	c := s.c
+	s.RUnlock()
+
+	c, n := c.Sweep(now)
+	if n <= 0 {
+		return 0
+	}
+
+	s.Lock()
+	s.c = c
+	s.Unlock()
+
+	if s.history != nil {
+		for _, lc := range c.Contents() {
+			if tag := lc.GetTag(); tag != nil {
+				s.history.replaceContent(lc.GetID(), *tag, lc)
+			}
+		}
+	}
+
+	s.opts.logger.WithFields(log.Fields{"entries": n}).Info("Expired content has been swept")
+	return n
+}
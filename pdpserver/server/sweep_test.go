@@ -0,0 +1,64 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+const sweepTestContent = `{
+  "ID": "content",
+  "Items": {
+    "value": {
+      "type": "string",
+      "data": "first"
+    },
+    "stale": {
+      "type": "string",
+      "expires": "2000-01-01T00:00:00Z",
+      "data": "old"
+    }
+  }
+}`
+
+func TestSweepContent(t *testing.T) {
+	s := NewServer(WithHistorySize(10))
+
+	cTag := uuid.New()
+	c, err := jcon.Unmarshal(strings.NewReader(sweepTestContent), &cTag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req := newContentItem("content", nil, &cTag)
+	req.c = c
+	res, err := s.applyContent(1, req)
+	assertApplyResponse(t, res, err)
+
+	if n := s.sweepContent(time.Now()); n != 1 {
+		t.Errorf("Expected 1 swept entry but got %d", n)
+	}
+
+	if _, err := s.c.Get("content", "stale"); err == nil {
+		t.Errorf("Expected no expired content item after sweep")
+	}
+
+	if _, err := s.c.Get("content", "value"); err != nil {
+		t.Errorf("Expected content item after sweep but got %s", err)
+	}
+
+	if n := s.sweepContent(time.Now()); n != 0 {
+		t.Errorf("Expected nothing to sweep but got %d entries", n)
+	}
+
+	cNewTag := uuid.New()
+	applyTestContentUpdate(t, s, &cTag, &cNewTag)
+
+	if _, err := s.c.Get("content", "second"); err != nil {
+		t.Errorf("Expected content item from update after sweep but got %s", err)
+	}
+}
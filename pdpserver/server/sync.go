@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/source"
+)
+
+// syncer keeps sources which server polls for policies and content along
+// with result of the last poll.
+type syncer struct {
+	sync.Mutex
+
+	policy  *source.Source
+	content []*source.Source
+
+	// ids holds ids of content loaded by the last sync. Content which
+	// disappears from sources is removed from PDP.
+	ids map[string]struct{}
+
+	err error
+}
+
+func newSyncer(policy string, content []string) *syncer {
+	s := &syncer{}
+	if len(policy) > 0 {
+		s.policy = source.New(policy)
+	}
+
+	for _, loc := range content {
+		s.content = append(s.content, source.New(loc))
+	}
+
+	return s
+}
+
+func (s *syncer) sources() []*source.Source {
+	if s.policy == nil {
+		return s.content
+	}
+
+	return append([]*source.Source{s.policy}, s.content...)
+}
+
+func (s *syncer) setError(err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.err = err
+}
+
+func (s *syncer) getError() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.err
+}
+
+func (s *Server) syncError() error {
+	if s.syncer == nil {
+		return nil
+	}
+
+	return s.syncer.getError()
+}
+
+func (s *Server) syncing(done <-chan struct{}) {
+	if s.syncer == nil {
+		return
+	}
+
+	s.syncSources()
+
+	ticker := time.NewTicker(s.opts.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			s.syncSources()
+		}
+	}
+}
+
+// syncSources polls all sources and if any of them has changed loads data
+// from all of them. New policies and content replace current ones at once
+// while content with other ids uploaded by PAP stays. Each synced document
+// gets new tag and goes to state and history as a full upload. Nothing is
+// replaced if any source fails.
+func (s *Server) syncSources() {
+	err := s.loadSources()
+	if err != nil {
+		s.opts.logger.WithError(err).Error("Failed to sync policies and content")
+	}
+
+	s.syncer.setError(err)
+}
+
+func (s *Server) loadSources() error {
+	srcs := s.syncer.sources()
+	docs := make([][]source.Document, len(srcs))
+	changed := false
+	for i, src := range srcs {
+		d, err := src.Fetch()
+		if err != nil {
+			return newSourceFetchError(src.String(), err)
+		}
+
+		docs[i] = d
+		changed = changed || d != nil
+	}
+
+	if !changed {
+		return nil
+	}
+
+	ok := false
+	defer func() {
+		if s.syncer.policy != nil {
+			s.metrics.observeOperation(metricsOpSync, true, ok)
+		}
+
+		if len(s.syncer.content) > 0 {
+			s.metrics.observeOperation(metricsOpSync, false, ok)
+		}
+	}()
+
+	for i, src := range srcs {
+		if docs[i] != nil {
+			continue
+		}
+
+		d, err := src.Get()
+		if err != nil {
+			return newSourceFetchError(src.String(), err)
+		}
+
+		docs[i] = d
+	}
+
+	var (
+		p *pdp.PolicyStorage
+		g []groupItem
+	)
+
+	if s.syncer.policy != nil {
+		if len(docs[0]) != 1 {
+			return newSourcePolicyCountError(s.syncer.policy.String(), len(docs[0]))
+		}
+
+		d := docs[0][0]
+		s.opts.logger.WithField("policy", d.Name).Info("Parsing synced policy")
+
+		req := newPolicyItem(nil, newSyncTag())
+		r, u := s.teeState(bytes.NewReader(d.Data))
+		req.raw = u
+
+		var err error
+		p, err = s.opts.parser.Unmarshal(r, req.toTag)
+		if err != nil {
+			discardSynced(g)
+			u.discard()
+			return newSourceParseError(d.Name, err)
+		}
+
+		req.p = p
+		g = append(g, groupItem{req: req})
+		docs = docs[1:]
+	}
+
+	items := []*pdp.LocalContent{}
+	ids := make(map[string]struct{})
+	for _, ds := range docs {
+		for _, d := range ds {
+			s.opts.logger.WithField("content", d.Name).Info("Parsing synced content")
+
+			req := newContentItem("", nil, newSyncTag())
+			r, u := s.teeState(bytes.NewReader(d.Data))
+			req.raw = u
+
+			c, _, err := s.unmarshalContent(r, req.toTag, log.Fields{"content": d.Name})
+			if err != nil {
+				discardSynced(g)
+				u.discard()
+				return newSourceParseError(d.Name, err)
+			}
+
+			req.c = c
+			g = append(g, groupItem{req: req})
+			items = append(items, c)
+			ids[c.GetID()] = struct{}{}
+		}
+	}
+
+	s.ctrlLock.Lock()
+	defer s.ctrlLock.Unlock()
+
+	var removed []string
+	s.Lock()
+	if p != nil {
+		s.p = p
+	}
+
+	if len(s.syncer.content) > 0 {
+		for _, c := range items {
+			s.c = s.c.Add(c)
+		}
+
+		for id := range s.syncer.ids {
+			if _, ok := ids[id]; !ok {
+				s.c = s.c.Delete(id)
+				removed = append(removed, id)
+			}
+		}
+
+		s.syncer.ids = ids
+	}
+	s.Unlock()
+
+	s.commitState(g, removed)
+	for _, gi := range g {
+		if gi.req.policy {
+			s.putPoliciesVersion(gi.req.toTag, gi.req.p)
+		} else {
+			s.putContentVersion(gi.req.toTag, gi.req.c)
+		}
+	}
+
+	for _, src := range srcs {
+		src.Commit()
+	}
+
+	ok = true
+
+	s.opts.logger.WithFields(log.Fields{
+		"policy":  p != nil,
+		"content": len(items),
+	}).Info("Synced policies and content have been applied")
+
+	if p != nil {
+		go s.startOnce.Do(func() {
+			s.errCh <- s.serveRequests()
+		})
+	}
+
+	return nil
+}
+
+func newSyncTag() *uuid.UUID {
+	tag := uuid.New()
+	return &tag
+}
+
+// discardSynced removes spooled data of synced documents which aren't going
+// to be applied.
+func discardSynced(g []groupItem) {
+	for _, gi := range g {
+		gi.req.raw.discard()
+	}
+}
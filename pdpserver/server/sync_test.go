@@ -0,0 +1,208 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/infobloxopen/themis/pdp/jcon"
+)
+
+func TestSyncSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-sync")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	policy := filepath.Join(dir, "policy.yaml")
+	content := filepath.Join(dir, "content")
+	if err := os.Mkdir(content, 0755); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	writeTestSource(t, policy, statePolicy)
+	writeTestSource(t, filepath.Join(content, "content.json"), stateContent)
+
+	s := NewServer(WithPolicySource(policy), WithContentSources(content), WithMetricsAt("127.0.0.1:0"))
+	// Don't start serving decision requests after policies are loaded.
+	s.startOnce.Do(func() {})
+
+	s.syncSources()
+	if err := s.syncError(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s.p == nil {
+		t.Fatal("Expected synced policies but got nothing")
+	}
+
+	if _, err := s.c.Get("content", "value"); err != nil {
+		t.Errorf("Expected synced content but got %s", err)
+	}
+
+	p := s.p
+	s.syncSources()
+	if s.p != p {
+		t.Error("Expected policies to stay the same when sources haven't changed")
+	}
+
+	writeTestSource(t, filepath.Join(content, "content.json"), "{")
+	s.syncSources()
+	if err := s.syncError(); err == nil {
+		t.Error("Expected error for broken content but got nothing")
+	}
+
+	if s.p != p {
+		t.Error("Expected policies to stay the same when content is broken")
+	}
+
+	if _, err := s.c.Get("content", "value"); err != nil {
+		t.Errorf("Expected previous content but got %s", err)
+	}
+
+	if err := os.Remove(filepath.Join(content, "content.json")); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s.syncSources()
+	if err := s.syncError(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if s.p == p {
+		t.Error("Expected policies to be reloaded with content")
+	}
+
+	if _, err := s.c.Get("content", "value"); err == nil {
+		t.Error("Expected no content after its file has been removed")
+	}
+
+	assertControlMetric(t, s, metricsOpSync, metricsKindPolicies, metricsResultSuccess, 2)
+	assertControlMetric(t, s, metricsOpSync, metricsKindContent, metricsResultSuccess, 2)
+	assertControlMetric(t, s, metricsOpSync, metricsKindContent, metricsResultFailure, 1)
+}
+
+func TestSyncSourcesStateAndHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pdpserver-sync")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	policy := filepath.Join(dir, "policy.yaml")
+	content := filepath.Join(dir, "content")
+	if err := os.Mkdir(content, 0755); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	writeTestSource(t, policy, statePolicy)
+	writeTestSource(t, filepath.Join(content, "content.json"), stateContent)
+
+	s := NewServer(
+		WithPolicySource(policy),
+		WithContentSources(content),
+		WithStateDir(filepath.Join(dir, "state")),
+		WithHistorySize(10),
+	)
+	s.startOnce.Do(func() {})
+
+	s.syncSources()
+	if err := s.syncError(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	pvs := s.history.get(true, "")
+	if len(pvs) != 1 {
+		t.Fatalf("Expected one synced version of policies but got %d", len(pvs))
+	}
+
+	if err := s.p.CheckTag(&pvs[0].tag); err != nil {
+		t.Errorf("Expected synced policies with tag from history but got %s", err)
+	}
+
+	cvs := s.history.get(false, "content")
+	if len(cvs) != 1 {
+		t.Fatalf("Expected one synced version of content but got %d", len(cvs))
+	}
+
+	if _, err := s.c.GetLocalContent("content", &cvs[0].tag); err != nil {
+		t.Errorf("Expected synced content with tag from history but got %s", err)
+	}
+
+	if d := s.state.get(true, ""); d == nil || d.Tag != pvs[0].tag.String() {
+		t.Errorf("Expected synced policies in state with tag %s but got %#v", pvs[0].tag, d)
+	}
+
+	if d := s.state.get(false, "content"); d == nil || d.Tag != cvs[0].tag.String() {
+		t.Errorf("Expected synced content in state with tag %s but got %#v", cvs[0].tag, d)
+	}
+
+	tag := uuid.New()
+	req := newContentItem("uploaded", nil, &tag)
+	tr, raw := s.teeState(strings.NewReader(strings.Replace(stateContent, `"content"`, `"uploaded"`, 1)))
+	req.c, err = jcon.Unmarshal(tr, &tag)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	req.raw = raw
+	res, err := s.applyContent(1, req)
+	assertApplyResponse(t, res, err)
+
+	writeTestSource(t, filepath.Join(content, "content.json"), strings.Replace(stateContent, "first", "second", 1))
+	s.syncSources()
+	if err := s.syncError(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, err := s.c.GetLocalContent("uploaded", &tag); err != nil {
+		t.Errorf("Expected uploaded content after sync but got %s", err)
+	}
+
+	if n := len(s.history.get(false, "content")); n != 2 {
+		t.Errorf("Expected two synced versions of content but got %d", n)
+	}
+
+	if err := os.Remove(filepath.Join(content, "content.json")); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s.syncSources()
+	if err := s.syncError(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, err := s.c.Get("content", "value"); err == nil {
+		t.Error("Expected no synced content after its file has been removed")
+	}
+
+	if d := s.state.get(false, "content"); d != nil {
+		t.Errorf("Expected no removed content in state but got %#v", d)
+	}
+
+	if _, err := s.c.GetLocalContent("uploaded", &tag); err != nil {
+		t.Errorf("Expected uploaded content after sync but got %s", err)
+	}
+
+	if d := s.state.get(false, "uploaded"); d == nil {
+		t.Error("Expected uploaded content in state but got nothing")
+	}
+}
+
+func writeTestSource(t *testing.T, path, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	// Make sure modification time differs from previous write.
+	mt := time.Now().Add(time.Duration(len(data)) * time.Second)
+	if err := os.Chtimes(path, mt, mt); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+}
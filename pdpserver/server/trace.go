@@ -1,12 +1,17 @@
 package server
 
 import (
+	"context"
+	"io"
 	"strings"
 
 	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
 	zipkinot "github.com/openzipkin-contrib/zipkin-go-opentracing"
 	zipkin "github.com/openzipkin/zipkin-go"
 	zipkinhttp "github.com/openzipkin/zipkin-go/reporter/http"
+
+	"github.com/infobloxopen/themis/tracing"
 )
 
 func initTracing(tracingType, tracingEP string) (ot.Tracer, error) {
@@ -20,7 +25,19 @@ func initTracing(tracingType, tracingEP string) (ot.Tracer, error) {
 
 	case "zipkin":
 		return setupZipkin(tracingEP)
+
+	case "otlp":
+		return setupOTLP(tracingEP)
+	}
+}
+
+func setupOTLP(tracingEP string) (ot.Tracer, error) {
+	tracer, err := tracing.NewOTLPTracer("PDP", tracingEP)
+	if err != nil {
+		return nil, err
 	}
+
+	return tracer, nil
 }
 
 func setupZipkin(tracingEP string) (ot.Tracer, error) {
@@ -38,3 +55,32 @@ func setupZipkin(tracingEP string) (ot.Tracer, error) {
 	}
 	return zipkinot.Wrap(tracer), nil
 }
+
+// closeTracer sends remaining spans if tracer supports that.
+func (s *Server) closeTracer() {
+	c, ok := s.tracer.(io.Closer)
+	if !ok {
+		return
+	}
+
+	if err := c.Close(); err != nil {
+		s.opts.logger.WithField("err", err).Warning("Failed to close tracer")
+	}
+}
+
+// startMessageSpan starts span for a stream message which carries span
+// context in its header. It returns nil span and the same context if tracing
+// is off or the message has no span context.
+func (s *Server) startMessageSpan(ctx context.Context, h map[string]string) (ot.Span, context.Context) {
+	if s.tracer == nil || len(h) <= 0 {
+		return nil, ctx
+	}
+
+	sc, err := s.tracer.Extract(ot.TextMap, ot.TextMapCarrier(h))
+	if err != nil {
+		return nil, ctx
+	}
+
+	span := s.tracer.StartSpan("Validate", ext.RPCServerOption(sc))
+	return span, ot.ContextWithSpan(ctx, span)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/infobloxopen/themis/tracing"
+)
+
+func TestStartMessageSpan(t *testing.T) {
+	c, err := tracing.NewCollector("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer c.Stop()
+
+	tr, err := initTracing("otlp", c.Addr())
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	s := NewServer()
+	if span, _ := s.startMessageSpan(context.Background(), nil); span != nil {
+		t.Errorf("Expected no span without tracer but got %#v", span)
+	}
+
+	s.tracer = tr
+	if span, _ := s.startMessageSpan(context.Background(), nil); span != nil {
+		t.Errorf("Expected no span without header but got %#v", span)
+	}
+
+	parent := tr.StartSpan("parent")
+	h := ot.TextMapCarrier{}
+	if err := tr.Inject(parent.Context(), ot.TextMap, h); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	span, ctx := s.startMessageSpan(context.Background(), h)
+	if span == nil {
+		t.Fatal("Expected span but got nothing")
+	}
+
+	if ot.SpanFromContext(ctx) != span {
+		t.Errorf("Expected span in context but got %#v", ot.SpanFromContext(ctx))
+	}
+
+	span.Finish()
+	parent.Finish()
+	s.closeTracer()
+
+	spans := c.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans but got %#v", spans)
+	}
+
+	v, p := spans[0], spans[1]
+	if v.Name != "Validate" || v.Service != "PDP" || v.ParentSpanID != p.SpanID {
+		t.Errorf("Expected PDP \"Validate\" span as child of %#v but got %#v", p, v)
+	}
+}
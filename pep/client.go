@@ -65,6 +65,13 @@ var (
 // types of fields allow assignment if there is no field with appropriate
 // name and type response attribute silently dropped. The same as for marshaling
 // `pdp` key can control unmarshaling.
+//
+// Decision trace
+//
+// To get explanation of a decision pass Msg structure with Explain flag
+// as "in" argument and pointer to Msg as "out". Server puts decision trace
+// in JSON format to Trace field of the response. Such requests bypass
+// response cache.
 type Client interface {
 	// Connect establishes connection to given PDP server. It ignores address
 	// parameter if balancer is provided.
@@ -78,6 +85,17 @@ type Client interface {
 	Validate(in, out interface{}) error
 }
 
+// ContextClient is a Client which accepts context of the request. Clients
+// created by NewClient implement the interface.
+type ContextClient interface {
+	Client
+
+	// ValidateContext is the same as Validate but accepts context
+	// of the request. If tracer is set and the context carries a span,
+	// the span becomes parent of the request span on client and server.
+	ValidateContext(ctx context.Context, in, out interface{}) error
+}
+
 // An Option sets such options as balancer, tracer and number of streams.
 type Option func(*options)
 
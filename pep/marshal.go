@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -55,6 +56,8 @@ var (
 	iptreeType      = reflect.TypeOf((*iptree.Tree)(nil))
 	domaintreeType  = reflect.TypeOf((*domaintree.Node)(nil))
 	stringsType     = reflect.TypeOf([]string(nil))
+	timeType        = reflect.TypeOf(time.Time{})
+	durationType    = reflect.TypeOf(time.Duration(0))
 
 	attrTypeByType = map[reflect.Type]pdp.Type{
 		boolType:        pdp.TypeBoolean,
@@ -79,6 +82,8 @@ var (
 		iptreeType:      pdp.TypeSetOfNetworks,
 		domaintreeType:  pdp.TypeSetOfDomains,
 		stringsType:     pdp.TypeListOfStrings,
+		timeType:        pdp.TypeTime,
+		durationType:    pdp.TypeDuration,
 	}
 
 	attrTypeByTag = map[string]pdp.Type{
@@ -93,6 +98,8 @@ var (
 		pdp.TypeSetOfNetworks.GetKey(): pdp.TypeSetOfNetworks,
 		pdp.TypeSetOfDomains.GetKey():  pdp.TypeSetOfDomains,
 		pdp.TypeListOfStrings.GetKey(): pdp.TypeListOfStrings,
+		pdp.TypeTime.GetKey():          pdp.TypeTime,
+		pdp.TypeDuration.GetKey():      pdp.TypeDuration,
 	}
 
 	typeByAttrType = map[pdp.Type]map[reflect.Type]struct{}{
@@ -141,6 +148,13 @@ var (
 		pdp.TypeListOfStrings: {
 			stringsType: {},
 		},
+		pdp.TypeTime: {
+			timeType: {},
+		},
+		pdp.TypeDuration: {
+			durationType: {},
+			int64Type:    {},
+		},
 	}
 
 	typeByTag = map[string]map[reflect.Type]struct{}{}
@@ -154,6 +168,7 @@ type reqFieldInfo struct {
 
 type reqFieldsInfo struct {
 	fields []reqFieldInfo
+	addr   bool
 	err    error
 }
 
@@ -203,7 +218,7 @@ func makeTaggedFieldsInfo(fields []reflect.StructField, typeName string) reqFiel
 		out = append(out, reqFieldInfo{i, tag, at})
 	}
 
-	return reqFieldsInfo{fields: out}
+	return reqFieldsInfo{fields: out, addr: needsAddressableValue(out)}
 }
 
 func makeUntaggedFieldsInfo(fields []reflect.StructField) reqFieldsInfo {
@@ -222,7 +237,30 @@ func makeUntaggedFieldsInfo(fields []reflect.StructField) reqFieldsInfo {
 		out = append(out, reqFieldInfo{i, name, t})
 	}
 
-	return reqFieldsInfo{fields: out}
+	return reqFieldsInfo{fields: out, addr: needsAddressableValue(out)}
+}
+
+// needsAddressableValue checks if any of fields can be read only from
+// addressable structure (time.Time has no exported fields so unexported
+// field of the type can't be read from a copy of the structure).
+func needsAddressableValue(fields []reqFieldInfo) bool {
+	for _, f := range fields {
+		if f.at == pdp.TypeTime {
+			return true
+		}
+	}
+
+	return false
+}
+
+func makeAddressable(v reflect.Value, info reqFieldsInfo) reflect.Value {
+	if !info.addr || v.CanAddr() {
+		return v
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
 }
 
 var (
@@ -365,6 +403,7 @@ func marshalStruct(v reflect.Value, info reqFieldsInfo) ([]byte, error) {
 		return nil, info.err
 	}
 
+	v = makeAddressable(v, info)
 	return pdp.MarshalRequestReflection(len(info.fields), func(i int) (string, pdp.Type, reflect.Value, error) {
 		f := info.fields[i]
 		return f.tag, f.at, v.Field(f.idx), nil
@@ -376,6 +415,7 @@ func marshalStructToBuffer(v reflect.Value, info reqFieldsInfo, b []byte) (int,
 		return 0, info.err
 	}
 
+	v = makeAddressable(v, info)
 	return pdp.MarshalRequestReflectionToBuffer(b, len(info.fields), func(i int) (string, pdp.Type, reflect.Value, error) {
 		f := info.fields[i]
 		return f.tag, f.at, v.Field(f.idx), nil
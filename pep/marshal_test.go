@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/go-trees/domaintree"
@@ -54,6 +55,13 @@ type TestTaggedStruct struct {
 	strlist  []string         `pdp:"ls,list of strings"`
 }
 
+type TestTimeStruct struct {
+	Time     time.Time     `pdp:""`
+	issued   time.Time     `pdp:"issued"`
+	lifetime time.Duration `pdp:"lifetime"`
+	timeout  int64         `pdp:"timeout,duration"`
+}
+
 type TestInvalidStruct1 struct {
 	String string `pdp:",address"`
 }
@@ -178,6 +186,44 @@ func TestMarshalTaggedStruct(t *testing.T) {
 	)
 }
 
+func TestMarshalTimeStruct(t *testing.T) {
+	tm := time.Date(2018, 6, 15, 9, 30, 0, 0, time.UTC)
+	m, err := makeRequest(TestTimeStruct{
+		Time:     tm,
+		issued:   tm.Add(-time.Hour),
+		lifetime: 24 * time.Hour,
+		timeout:  int64(time.Second),
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+
+	a, err := pdp.UnmarshalRequestAssignments(m.Body)
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+
+	e := []string{
+		"Time.time.2018-06-15T09:30:00Z",
+		"issued.time.2018-06-15T08:30:00Z",
+		"lifetime.duration.24h0m0s",
+		"timeout.duration.1s",
+	}
+
+	if len(a) != len(e) {
+		t.Fatalf("expected %d attributes but got %d", len(e), len(a))
+	}
+
+	for i, a := range a {
+		id, tp, v, err := a.Serialize(nil)
+		if err != nil {
+			t.Errorf("expected no error for %d but got %s", i, err)
+		} else if s := strings.Join([]string{id, tp, v}, "."); s != e[i] {
+			t.Errorf("expected %q for %d but got %q", e[i], i, s)
+		}
+	}
+}
+
 func TestMarshalInvalidStructs(t *testing.T) {
 	b, err := marshalValue(reflect.ValueOf(TestInvalidStruct1{}))
 	if err == nil {
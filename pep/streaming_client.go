@@ -1,11 +1,14 @@
 package pep
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 
 	"github.com/allegro/bigcache/v2"
 	pb "github.com/infobloxopen/themis/pdp-service"
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
 )
 
 const (
@@ -111,6 +114,10 @@ func (c *streamingClient) Close() {
 }
 
 func (c *streamingClient) Validate(in, out interface{}) error {
+	return c.ValidateContext(context.Background(), in, out)
+}
+
+func (c *streamingClient) ValidateContext(ctx context.Context, in, out interface{}) error {
 	var (
 		m   pb.Msg
 		err error
@@ -134,7 +141,7 @@ func (c *streamingClient) Validate(in, out interface{}) error {
 		return err
 	}
 
-	if c.cache != nil {
+	if c.cache != nil && !m.Explain {
 		var b []byte
 		if b, err = c.cache.Get(string(m.Body)); err == nil {
 			err = fillResponse(pb.Msg{Body: b}, out)
@@ -149,6 +156,10 @@ func (c *streamingClient) Validate(in, out interface{}) error {
 		}
 	}
 
+	if span := c.startSpan(ctx, &m); span != nil {
+		defer span.Finish()
+	}
+
 	for atomic.LoadUint32(c.state) == scsConnected {
 		if !c.crp.check() {
 			c.crp.tryStart()
@@ -160,7 +171,7 @@ func (c *streamingClient) Validate(in, out interface{}) error {
 		for i := 0; i < len(c.conns); i++ {
 			r, err := c.validate(&m)
 			if err == nil {
-				if c.cache != nil {
+				if c.cache != nil && !m.Explain {
 					c.cache.Set(string(m.Body), r.Body)
 				}
 
@@ -237,3 +248,26 @@ func (c *streamingClient) makeHotSpotValidator() validator {
 		return r, err
 	}
 }
+
+// startSpan starts span for the request if client has tracer and given
+// context carries parent span. It puts span context to header of the message
+// so server can continue the trace.
+func (c *streamingClient) startSpan(ctx context.Context, m *pb.Msg) ot.Span {
+	if c.opts.tracer == nil {
+		return nil
+	}
+
+	parent := ot.SpanFromContext(ctx)
+	if parent == nil {
+		return nil
+	}
+
+	span := c.opts.tracer.StartSpan("Validate", ot.ChildOf(parent.Context()), ext.SpanKindRPCClient)
+
+	h := ot.TextMapCarrier{}
+	if err := c.opts.tracer.Inject(span.Context(), ot.TextMap, h); err == nil {
+		m.Header = h
+	}
+
+	return span
+}
@@ -144,6 +144,10 @@ func (c *unaryClient) Close() {
 }
 
 func (c *unaryClient) Validate(in, out interface{}) error {
+	return c.ValidateContext(context.Background(), in, out)
+}
+
+func (c *unaryClient) ValidateContext(rctx context.Context, in, out interface{}) error {
 	c.lock.RLock()
 	uc := c.client
 	c.lock.RUnlock()
@@ -175,7 +179,7 @@ func (c *unaryClient) Validate(in, out interface{}) error {
 		return err
 	}
 
-	if c.cache != nil {
+	if c.cache != nil && !req.Explain {
 		var b []byte
 		if b, err = c.cache.Get(string(req.Body)); err == nil {
 			err = fillResponse(pb.Msg{Body: b}, out)
@@ -195,6 +199,10 @@ func (c *unaryClient) Validate(in, out interface{}) error {
 		ctx = context.Background()
 	}
 
+	if span := ot.SpanFromContext(rctx); span != nil {
+		ctx = ot.ContextWithSpan(ctx, span)
+	}
+
 	if c.opts.connTimeout > 0 {
 		var cancelFn context.CancelFunc
 		ctx, cancelFn = context.WithTimeout(ctx, c.opts.connTimeout)
@@ -206,7 +214,7 @@ func (c *unaryClient) Validate(in, out interface{}) error {
 		return err
 	}
 
-	if c.cache != nil {
+	if c.cache != nil && !req.Explain {
 		c.cache.Set(string(req.Body), res.Body)
 	}
 
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/infobloxopen/go-trees/domain"
 	"github.com/infobloxopen/themis/pdp"
@@ -110,7 +111,9 @@ var marshallers = map[pdp.Type]attributeMarshaller{
 	pdp.TypeAddress:       addressMarshaller,
 	pdp.TypeNetwork:       networkMarshaller,
 	pdp.TypeDomain:        domainMarshaller,
-	pdp.TypeListOfStrings: listOfStringsMarshaller}
+	pdp.TypeListOfStrings: listOfStringsMarshaller,
+	pdp.TypeTime:          timeMarshaller,
+	pdp.TypeDuration:      durationMarshaller}
 
 func makeAttribute(name string, value interface{}, symbols map[string]pdp.Type) (pdp.AttributeAssignment, error) {
 	t, ok := symbols[name]
@@ -304,6 +307,38 @@ func domainMarshaller(value interface{}) (pdp.AttributeValue, error) {
 	return pdp.MakeDomainValue(d), nil
 }
 
+func timeMarshaller(value interface{}) (pdp.AttributeValue, error) {
+	switch value := value.(type) {
+	case time.Time:
+		return pdp.MakeTimeValue(value), nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return pdp.UndefinedValue, fmt.Errorf("can't marshal \"%s\" as time", value)
+		}
+
+		return pdp.MakeTimeValue(t), nil
+	}
+
+	return pdp.UndefinedValue, fmt.Errorf("can't marshal %T as time", value)
+}
+
+func durationMarshaller(value interface{}) (pdp.AttributeValue, error) {
+	switch value := value.(type) {
+	case time.Duration:
+		return pdp.MakeDurationValue(value), nil
+	case string:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return pdp.UndefinedValue, fmt.Errorf("can't marshal \"%s\" as duration", value)
+		}
+
+		return pdp.MakeDurationValue(d), nil
+	}
+
+	return pdp.UndefinedValue, fmt.Errorf("can't marshal %T as duration", value)
+}
+
 func listOfStringsMarshaller(value interface{}) (pdp.AttributeValue, error) {
 	v, ok := value.([]interface{})
 	if !ok {
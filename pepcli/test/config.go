@@ -9,7 +9,8 @@ import (
 )
 
 type config struct {
-	sort bool
+	sort    bool
+	explain bool
 }
 
 var testFlagSet = flag.NewFlagSet(Name, flag.ExitOnError)
@@ -20,6 +21,7 @@ func FlagsParser(args []string) interface{} {
 
 	testFlagSet.Usage = usage
 	testFlagSet.BoolVar(&conf.sort, "sort", false, "sort lists of strings in returned obligations")
+	testFlagSet.BoolVar(&conf.explain, "explain", false, "request decision trace and dump it with response")
 	testFlagSet.Parse(args)
 
 	count := testFlagSet.NArg()
@@ -2,6 +2,7 @@
 package test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/infobloxopen/themis/pdp"
+	pb "github.com/infobloxopen/themis/pdp-service"
 	"github.com/infobloxopen/themis/pep"
 
 	"encoding/json"
@@ -54,15 +56,15 @@ func Exec(addr string, opts []pep.Option, maxRequestSize, maxResponseObligations
 	res := pdp.Response{}
 	for i := 0; i < n; i++ {
 		idx := i % len(reqs)
-		req := reqs[idx]
+		req := &reqs[idx]
 
 		res.Obligations = obligations
-		err := c.Validate(req, &res)
+		trace, err := validate(c, req, &res, v.(config).explain)
 		if err != nil {
 			return fmt.Errorf("can't send request %d (%d): %s", idx, i, err)
 		}
 
-		err = dump(res, f, v.(config).sort)
+		err = dump(res, trace, f, v.(config).sort)
 		if err != nil {
 			return fmt.Errorf("can't dump response for reqiest %d (%d): %s", idx, i, err)
 		}
@@ -71,9 +73,36 @@ func Exec(addr string, opts []pep.Option, maxRequestSize, maxResponseObligations
 	return nil
 }
 
+// validate sends request to PDP server. With explain flag it requests
+// decision trace and returns it in JSON format.
+func validate(c pep.Client, req *pb.Msg, res *pdp.Response, explain bool) ([]byte, error) {
+	if !explain {
+		return nil, c.Validate(req, res)
+	}
+
+	var out pb.Msg
+	if err := c.Validate(&pb.Msg{Body: req.Body, Explain: true}, &out); err != nil {
+		return nil, err
+	}
+
+	effect, n, err := pdp.UnmarshalResponseToAssignmentsArray(out.Body, res.Obligations)
+	if err != nil {
+		if _, ok := err.(*pdp.ResponseServerError); !ok {
+			return nil, err
+		}
+	}
+
+	res.Effect = effect
+	res.Status = err
+	res.Obligations = res.Obligations[:n]
+
+	return out.Trace, nil
+}
+
 // dump prints the pdp response to the writer; if the boolean s is set to true, dump will
-// sort the list of strings pdp return value for deterministic automated testing
-func dump(r pdp.Response, f io.Writer, s bool) error {
+// sort the list of strings pdp return value for deterministic automated testing.
+// Decision trace if any is printed as indented JSON
+func dump(r pdp.Response, trace []byte, f io.Writer, s bool) error {
 	lines := []string{fmt.Sprintf("- effect: %s", pdp.EffectNameFromEnum(r.Effect))}
 	if r.Status != nil {
 		lines = append(lines, fmt.Sprintf("  reason: %q", r.Status))
@@ -104,6 +133,15 @@ func dump(r pdp.Response, f io.Writer, s bool) error {
 		lines = append(lines, "")
 	}
 
+	if len(trace) > 0 {
+		var b bytes.Buffer
+		if err := json.Indent(&b, trace, "    ", "  "); err != nil {
+			return fmt.Errorf("can't format decision trace: %s", err)
+		}
+
+		lines = append(lines[:len(lines)-1], "  trace:", "    "+b.String(), "")
+	}
+
 	_, err := fmt.Fprintf(f, "%s\n", strings.Join(lines, "\n"))
 	return err
 }
@@ -2,6 +2,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"math"
 	"net"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/allegro/bigcache/v2"
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
 
 	"github.com/infobloxopen/themis/pdp"
 )
@@ -35,6 +38,11 @@ type Client interface {
 
 	// Get requests information from PIP.
 	Get(path string, args []pdp.AttributeValue) (pdp.AttributeValue, error)
+
+	// GetContext requests information from PIP as Get does. If client has
+	// tracer and given context carries a span, the request gets child span
+	// which is passed to PIP server.
+	GetContext(ctx context.Context, path string, args []pdp.AttributeValue) (pdp.AttributeValue, error)
 }
 
 // NewClient creates client instance.
@@ -117,9 +125,22 @@ func (c *client) Close() {
 }
 
 func (c *client) Get(path string, args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	return c.GetContext(context.Background(), path, args)
+}
+
+func (c *client) GetContext(ctx context.Context, path string, args []pdp.AttributeValue) (pdp.AttributeValue, error) {
+	span := c.startSpan(ctx, path)
+	if span != nil {
+		defer span.Finish()
+	}
+
 	for atomic.LoadUint32(c.state) == pipClientConnected {
-		v, ok, err := c.tryGet(path, args)
+		v, ok, err := c.tryGet(ctx, span, path, args)
 		if !ok || err == nil {
+			if span != nil && err != nil {
+				ext.Error.Set(span, true)
+			}
+
 			return v, err
 		}
 	}
@@ -127,7 +148,7 @@ func (c *client) Get(path string, args []pdp.AttributeValue) (pdp.AttributeValue
 	return pdp.UndefinedValue, ErrNotConnected
 }
 
-func (c *client) tryGet(path string, args []pdp.AttributeValue) (pdp.AttributeValue, bool, error) {
+func (c *client) tryGet(ctx context.Context, span ot.Span, path string, args []pdp.AttributeValue) (pdp.AttributeValue, bool, error) {
 	conn := c.p.get()
 	if conn == nil {
 		return pdp.UndefinedValue, false, ErrNotConnected
@@ -141,11 +162,16 @@ func (c *client) tryGet(path string, args []pdp.AttributeValue) (pdp.AttributeVa
 		}
 	}()
 
-	n, err := pdp.MarshalInfoRequest(b.b, path, args)
+	off, err := c.putSpan(b.b, span)
 	if err != nil {
 		return pdp.UndefinedValue, false, err
 	}
-	b.b = b.b[:n]
+
+	n, err := pdp.MarshalInfoRequest(b.b[off:], path, args)
+	if err != nil {
+		return pdp.UndefinedValue, false, err
+	}
+	b.b = b.b[:off+n]
 
 	var (
 		key   string
@@ -154,7 +180,7 @@ func (c *client) tryGet(path string, args []pdp.AttributeValue) (pdp.AttributeVa
 
 	if c.cache != nil {
 		cache = c.cache
-		key = string(b.b)
+		key = string(b.b[off:])
 
 		var out []byte
 		if out, err = cache.Get(key); err == nil {
@@ -168,6 +194,14 @@ func (c *client) tryGet(path string, args []pdp.AttributeValue) (pdp.AttributeVa
 				c.opts.onCache(path, args, v, err)
 			}
 
+			if h := cacheHitHandlerFromContext(ctx); h != nil {
+				h(path, args, v, err)
+			}
+
+			if span != nil {
+				span.SetTag("pip.cache", true)
+			}
+
 			return v, false, nil
 		}
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -210,7 +211,7 @@ func TestClientCloseWithDifferentRequests(t *testing.T) {
 	var err1 error
 	go func() {
 		defer wg.Done()
-		_, _, err1 = c.tryGet("test", []pdp.AttributeValue{
+		_, _, err1 = c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{
 			pdp.MakeStringValue("test"),
 		})
 	}()
@@ -221,7 +222,7 @@ func TestClientCloseWithDifferentRequests(t *testing.T) {
 	var err2 error
 	go func() {
 		defer wg.Done()
-		_, _, err2 = c.tryGet("test", []pdp.AttributeValue{
+		_, _, err2 = c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{
 			pdp.MakeStringValue("a"),
 		})
 	}()
@@ -232,7 +233,7 @@ func TestClientCloseWithDifferentRequests(t *testing.T) {
 	var err3 error
 	go func() {
 		defer wg.Done()
-		_, _, err3 = c.tryGet("test", []pdp.AttributeValue{
+		_, _, err3 = c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{
 			pdp.MakeStringValue("b"),
 		})
 	}()
@@ -279,7 +280,7 @@ func TestClientTryGet(t *testing.T) {
 	if err := c.Connect(); assert.NoError(t, err) {
 		defer c.Close()
 
-		v, ok, err := c.tryGet("test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		v, ok, err := c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
 		assert.Equal(t, pdp.MakeStringValue("test"), v)
 		assert.False(t, ok)
 		assert.NoError(t, err)
@@ -304,25 +305,38 @@ func TestClientTryGetWithCache(t *testing.T) {
 
 		assert.Zero(t, c.cache.Len())
 
-		v, ok, err := c.tryGet("test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		v, ok, err := c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
 		assert.Equal(t, pdp.MakeStringValue("test"), v)
 		assert.False(t, ok)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, c.cache.Len())
 		assert.Zero(t, hits)
 
-		v, ok, err = c.tryGet("test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		v, ok, err = c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
 		assert.Equal(t, pdp.MakeStringValue("test"), v)
 		assert.False(t, ok)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, c.cache.Len())
 		assert.Equal(t, 1, hits)
+
+		ctxHits := 0
+		ctx := NewContextWithCacheHitHandler(context.Background(),
+			func(string, []pdp.AttributeValue, pdp.AttributeValue, error) {
+				ctxHits++
+			},
+		)
+
+		v, err = c.GetContext(ctx, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		assert.Equal(t, pdp.MakeStringValue("test"), v)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, hits)
+		assert.Equal(t, 1, ctxHits)
 	}
 }
 
 func TestClientTryGettErrNotConnected(t *testing.T) {
 	c := NewClient().(*client)
-	_, ok, err := c.tryGet("test", nil)
+	_, ok, err := c.tryGet(context.Background(), nil, "test", nil)
 	assert.False(t, ok)
 	assert.Equal(t, ErrNotConnected, err)
 }
@@ -337,7 +351,7 @@ func TestClientTryGetMarshallingError(t *testing.T) {
 	if err := c.Connect(); assert.NoError(t, err) {
 		defer c.Close()
 
-		_, ok, err := c.tryGet("test", []pdp.AttributeValue{
+		_, ok, err := c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{
 			pdp.UndefinedValue,
 		})
 		assert.False(t, ok)
@@ -357,7 +371,7 @@ func TestClientTryGetResponseServerError(t *testing.T) {
 	if err := c.Connect(); assert.NoError(t, err) {
 		defer c.Close()
 
-		_, ok, err := c.tryGet("test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		_, ok, err := c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
 		assert.False(t, ok)
 		if assert.Error(t, err) {
 			assert.IsType(t, &pdp.ResponseServerError{}, err)
@@ -377,7 +391,7 @@ func TestClientTryGetBadResponse(t *testing.T) {
 	if err := c.Connect(); assert.NoError(t, err) {
 		defer c.Close()
 
-		_, ok, err := c.tryGet("test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		_, ok, err := c.tryGet(context.Background(), nil, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
 		assert.True(t, ok)
 		assert.Error(t, err)
 	}
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"math"
 	"net"
 	"time"
 
+	ot "github.com/opentracing/opentracing-go"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -180,6 +182,21 @@ func WithCacheHitHandler(h CacheHitHandler) Option {
 	}
 }
 
+type cacheHitHandlerKey struct{}
+
+// NewContextWithCacheHitHandler returns copy of given context which carries
+// handler for cache hits. Client calls the handler on cache hits of requests
+// made by GetContext with the context in addition to handler set
+// by WithCacheHitHandler.
+func NewContextWithCacheHitHandler(ctx context.Context, h CacheHitHandler) context.Context {
+	return context.WithValue(ctx, cacheHitHandlerKey{}, h)
+}
+
+func cacheHitHandlerFromContext(ctx context.Context) CacheHitHandler {
+	h, _ := ctx.Value(cacheHitHandlerKey{}).(CacheHitHandler)
+	return h
+}
+
 // WithConnTimeout returns an Option which sets connection timeout.
 func WithConnTimeout(d time.Duration) Option {
 	return func(o *options) {
@@ -224,6 +241,15 @@ func WithResponseCheckInterval(d time.Duration) Option {
 	}
 }
 
+// WithTracer returns an Option which sets OpenTracing tracer. With the tracer
+// GetContext makes span for each request which has parent span in given
+// context and passes the span to PIP server.
+func WithTracer(t ot.Tracer) Option {
+	return func(o *options) {
+		o.tracer = t
+	}
+}
+
 type options struct {
 	maxSize            int
 	maxQueue           int
@@ -242,6 +268,7 @@ type options struct {
 	timeout            time.Duration
 	termInt            time.Duration
 	k8sClientMaker     func() (kubernetes.Interface, error)
+	tracer             ot.Tracer
 
 	net  string
 	addr string
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// Trace header precedes information request in a message when client passes
+// span context to PIP server. The header starts with traceHeaderMark which
+// never matches request version. It's followed by size of the rest
+// of the header and a sequence of key-value pairs of strings. Each string
+// is prefixed with its size.
+const (
+	traceHeaderMark      = math.MaxUint16
+	traceHeaderSizeBytes = 2
+)
+
+var errTraceHeaderOverflow = errors.New("trace header doesn't fit request buffer")
+
+func putTraceHeader(b []byte, h ot.TextMapCarrier) (int, error) {
+	size := 0
+	for k, v := range h {
+		size += 2*traceHeaderSizeBytes + len(k) + len(v)
+	}
+
+	if size > math.MaxUint16 || len(b) < 2*traceHeaderSizeBytes+size {
+		return 0, errTraceHeaderOverflow
+	}
+
+	binary.LittleEndian.PutUint16(b, traceHeaderMark)
+	binary.LittleEndian.PutUint16(b[traceHeaderSizeBytes:], uint16(size))
+	off := 2 * traceHeaderSizeBytes
+
+	for k, v := range h {
+		off += putTraceHeaderString(b[off:], k)
+		off += putTraceHeaderString(b[off:], v)
+	}
+
+	return off, nil
+}
+
+func putTraceHeaderString(b []byte, s string) int {
+	binary.LittleEndian.PutUint16(b, uint16(len(s)))
+	return traceHeaderSizeBytes + copy(b[traceHeaderSizeBytes:], s)
+}
+
+// startSpan starts span for PIP request if client has tracer and there is
+// a span in given context. It returns nil otherwise.
+func (c *client) startSpan(ctx context.Context, path string) ot.Span {
+	if c.opts.tracer == nil {
+		return nil
+	}
+
+	parent := ot.SpanFromContext(ctx)
+	if parent == nil {
+		return nil
+	}
+
+	return c.opts.tracer.StartSpan("PIP request",
+		ot.ChildOf(parent.Context()),
+		ext.SpanKindRPCClient,
+		ot.Tag{Key: "pip.path", Value: path},
+	)
+}
+
+// putSpan writes trace header for given span to the buffer. It writes nothing
+// for nil span or if tracer fails to inject span context.
+func (c *client) putSpan(b []byte, span ot.Span) (int, error) {
+	if span == nil {
+		return 0, nil
+	}
+
+	h := ot.TextMapCarrier{}
+	if err := c.opts.tracer.Inject(span.Context(), ot.TextMap, h); err != nil || len(h) <= 0 {
+		return 0, nil
+	}
+
+	return putTraceHeader(b, h)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/pip/server"
+	"github.com/infobloxopen/themis/tracing"
+)
+
+func TestPutTraceHeader(t *testing.T) {
+	var b [20]byte
+
+	n, err := putTraceHeader(b[:], ot.TextMapCarrier{"key": "value"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte{
+			0xff, 0xff, 12, 0,
+			3, 0, 'k', 'e', 'y',
+			5, 0, 'v', 'a', 'l', 'u', 'e',
+		}, b[:n])
+	}
+
+	_, err = putTraceHeader(b[:10], ot.TextMapCarrier{"key": "value"})
+	assert.Equal(t, errTraceHeaderOverflow, err)
+}
+
+func TestClientGetContextWithTracer(t *testing.T) {
+	col, err := tracing.NewCollector("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "failed to start collector")
+	}
+	defer col.Stop()
+
+	st, err := tracing.NewOTLPTracer("PIP", col.Addr())
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "failed to create server tracer")
+	}
+
+	ct, err := tracing.NewOTLPTracer("PDP", col.Addr())
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "failed to create client tracer")
+	}
+
+	s := newTestServerForClient(t,
+		server.WithHandler(testServerForClientHandler),
+		server.WithTracer(st),
+	)
+	defer s.stop(t)
+
+	c := NewClient(WithTracer(ct))
+	if err := c.Connect(); assert.NoError(t, err) {
+		defer c.Close()
+
+		parent := ct.StartSpan("Validate")
+		ctx := ot.ContextWithSpan(context.Background(), parent)
+
+		v, err := c.GetContext(ctx, "test", []pdp.AttributeValue{pdp.MakeStringValue("test")})
+		assert.Equal(t, pdp.MakeStringValue("test"), v)
+		assert.NoError(t, err)
+
+		parent.Finish()
+	}
+
+	assert.NoError(t, ct.Close())
+	assert.NoError(t, st.Close())
+
+	spans := map[string]tracing.Span{}
+	for _, span := range col.Spans() {
+		spans[span.Service+"/"+span.Name] = span
+	}
+
+	parent, ok := spans["PDP/Validate"]
+	if !assert.True(t, ok, "missing parent span in %#v", spans) {
+		return
+	}
+
+	req, ok := spans["PDP/PIP request"]
+	if assert.True(t, ok, "missing client span in %#v", spans) {
+		assert.Equal(t, parent.TraceID, req.TraceID)
+		assert.Equal(t, parent.SpanID, req.ParentSpanID)
+		assert.Equal(t, "test", req.Attributes["pip.path"])
+
+		srv, ok := spans["PIP/PIP request"]
+		if assert.True(t, ok, "missing server span in %#v", spans) {
+			assert.Equal(t, parent.TraceID, srv.TraceID)
+			assert.Equal(t, req.SpanID, srv.ParentSpanID)
+		}
+	}
+}
@@ -19,6 +19,12 @@ type config struct {
 	maxArgs    int
 	writeInt   time.Duration
 	workers    int
+	sync       string
+	syncInt    time.Duration
+	sweepInt   time.Duration
+	health     string
+	tracing    string
+	memLimit   int64
 }
 
 const (
@@ -45,7 +51,7 @@ func init() {
 	flag.StringVar(&conf.addr, "a", "", "address to listen at "+
 		"(default for \"tcp*\" - localhost:5600, default for \"unix\" - /var/run/pip.socket)")
 	flag.StringVar(&conf.ctrl, "c", "", "address for control (unavailable for \"unix\")")
-	flag.StringVar(&conf.content, "j", "", "path to JCon file to load at startup")
+	flag.StringVar(&conf.content, "j", "", "path to JCon file or binary content snapshot to load at startup")
 	flag.IntVar(&conf.maxConn, "max-connections", 0, "limit on number of simultaneous connections "+
 		"(defailt - no limit)")
 	flag.IntVar(&conf.bufSize, "buffer-size", 1024*1024, "input/output buffer size")
@@ -54,6 +60,13 @@ func init() {
 	flag.DurationVar(&conf.writeInt, "write-interval", 50*time.Microsecond,
 		"interval to wait for responses if output buffer isn't full")
 	flag.IntVar(&conf.workers, "w", 100, "number of workers per connection")
+	flag.StringVar(&conf.sync, "sync", "", "JCon file, directory or HTTP(S) URL to poll for content (empty - don't poll)")
+	flag.DurationVar(&conf.syncInt, "sync-interval", 10*time.Second, "interval between polls of -sync source")
+	flag.DurationVar(&conf.sweepInt, "content-sweep-interval", time.Minute, "interval between removals of expired content items and keys (0 - don't remove)")
+	flag.StringVar(&conf.health, "health", "", "health check endpoint (empty - disabled)")
+	flag.StringVar(&conf.tracing, "t", "", "OpenTelemetry collector endpoint to send request spans to "+
+		"(host:port of OTLP/gRPC receiver, empty - disabled)")
+	memLimit := flag.Uint64("content-mem-limit", 0, "memory limit for single content in megabytes (0 - no limit)")
 
 	flag.Parse()
 
@@ -70,6 +83,12 @@ func init() {
 		}
 	}
 
+	conf.memLimit = int64(*memLimit * 1024 * 1024)
+
+	if conf.syncInt <= 0 {
+		log.WithField("sync-interval", conf.syncInt).Fatal("sync interval should be positive")
+	}
+
 	if len(conf.ctrl) > 0 && netID == netUnix {
 		log.WithField("control", conf.ctrl).Info("control address set for \"unix\" network. ignoring...")
 		conf.ctrl = ""
@@ -11,6 +11,7 @@ import (
 
 	"github.com/infobloxopen/themis/pdp"
 	pb "github.com/infobloxopen/themis/pdp-control"
+	"github.com/infobloxopen/themis/pdp/jcon"
 )
 
 func (s *srv) Request(ctx context.Context, in *pb.Item) (*pb.Response, error) {
@@ -79,10 +80,10 @@ func (s *srv) Upload(stream pb.PDPControl_UploadServer) error {
 	}()
 
 	if err = u.upload(newStreamReader(stream, chunk)); err != nil {
-		return stream.SendAndClose(ctrlError(err.Error()))
+		return stream.SendAndClose(ctrlProgress(ctrlError(err.Error()), u.p))
 	}
 
-	return stream.SendAndClose(ctrlAckID(id))
+	return stream.SendAndClose(ctrlProgress(ctrlAckID(id), u.p))
 }
 
 func (s *srv) Apply(ctx context.Context, in *pb.Update) (*pb.Response, error) {
@@ -149,6 +150,28 @@ func (s *srv) NotifyReady(context.Context, *pb.Empty) (*pb.Response, error) {
 	return ctrlAck(), nil
 }
 
+func (s *srv) ListVersions(context.Context, *pb.Item) (*pb.VersionList, error) {
+	return &pb.VersionList{
+		Status:  pb.Response_ERROR,
+		Details: "version history isn't supported",
+	}, nil
+}
+
+func (s *srv) Rollback(context.Context, *pb.Item) (*pb.Response, error) {
+	return ctrlError("version history isn't supported"), nil
+}
+
+func (s *srv) DryRun(context.Context, *pb.DryRunRequest) (*pb.DryRunResult, error) {
+	return &pb.DryRunResult{
+		Status:  pb.Response_ERROR,
+		Details: "dry run isn't supported",
+	}, nil
+}
+
+func (s *srv) ApplyGroup(context.Context, *pb.UpdateGroup) (*pb.Response, error) {
+	return ctrlError("group apply isn't supported"), nil
+}
+
 func (s *srv) contentRequest(id string, fromTag, toTag *uuid.UUID) (int32, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -201,6 +224,12 @@ func ctrlTagErrorf(f string, args ...interface{}) *pb.Response {
 	return ctrlTagError(fmt.Sprintf(f, args...))
 }
 
+func ctrlProgress(r *pb.Response, p jcon.Progress) *pb.Response {
+	r.Items = int32(p.Items)
+	r.Keys = p.Keys
+	return r
+}
+
 func ctrlTagError(s string) *pb.Response {
 	return ctrlStatusError(pb.Response_TAG_ERROR, s)
 }
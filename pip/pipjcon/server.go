@@ -2,6 +2,7 @@ package main
 
 import (
 	"net"
+	"net/http"
 	"os"
 	"sync"
 
@@ -10,8 +11,8 @@ import (
 
 	"github.com/infobloxopen/themis/pdp"
 	pb "github.com/infobloxopen/themis/pdp-control"
-	"github.com/infobloxopen/themis/pdp/jcon"
 	"github.com/infobloxopen/themis/pip/server"
+	"github.com/infobloxopen/themis/tracing"
 )
 
 //go:generate bash -c "mkdir -p $GOPATH/src/github.com/infobloxopen/themis/pdp-control && protoc -I $GOPATH/src/github.com/infobloxopen/themis/proto/ $GOPATH/src/github.com/infobloxopen/themis/proto/control.proto --go_out=plugins=grpc:$GOPATH/src/github.com/infobloxopen/themis/pdp-control && ls $GOPATH/src/github.com/infobloxopen/themis/pdp-control"
@@ -28,6 +29,14 @@ type srv struct {
 	uIdx int32
 	u    *update
 
+	syncErr  error
+	syncDone chan struct{}
+	hs       *http.Server
+
+	sweepDone chan struct{}
+
+	tracer *tracing.Tracer
+
 	once *sync.Once
 }
 
@@ -49,7 +58,7 @@ func (s *srv) load() {
 		defer f.Close()
 
 		log.WithField("content", conf.content).Info("parsing content")
-		item, err := jcon.Unmarshal(f, nil)
+		item, _, err := unmarshalContent(f, nil, log.Fields{"content": conf.content})
 		if err != nil {
 			log.WithError(err).Fatal("failed to parse content")
 		}
@@ -61,25 +70,38 @@ func (s *srv) load() {
 }
 
 func (s *srv) start() {
+	s.startTracing()
+	s.startHealth()
 	s.startCtrl()
+	s.startSync()
+	s.startSweep()
 
 	s.RLock()
 	sc := s.sc
 	s.RUnlock()
 
-	if sc == nil || len(conf.content) > 0 {
+	if sc == nil || len(conf.content) > 0 || len(conf.sync) > 0 {
 		s.once.Do(s.startSrv)
 	}
 }
 
 func (s *srv) stop() {
+	s.stopSync()
+	s.stopSweep()
+
 	s.Lock()
 	ss := s.ss
 	s.ss = nil
 	sc := s.sc
 	s.sc = nil
+	hs := s.hs
+	s.hs = nil
 	s.Unlock()
 
+	if hs != nil {
+		hs.Close()
+	}
+
 	if ss != nil {
 		if err := ss.Stop(); err != nil {
 			log.WithError(err).Fatal("failed to stop service")
@@ -89,13 +111,15 @@ func (s *srv) stop() {
 	if sc != nil {
 		sc.Stop()
 	}
+
+	s.stopTracing()
 }
 
 func (s *srv) startSrv() {
 	s.Lock()
 	defer s.Unlock()
 
-	s.ss = server.NewServer(
+	opts := []server.Option{
 		server.WithNetwork(conf.net),
 		server.WithAddress(conf.addr),
 		server.WithMaxConnections(conf.maxConn),
@@ -114,7 +138,13 @@ func (s *srv) startSrv() {
 		server.WithWriteInterval(conf.writeInt),
 		server.WithWorkers(conf.workers),
 		server.WithHandler(s.handler),
-	)
+	}
+
+	if s.tracer != nil {
+		opts = append(opts, server.WithTracer(s.tracer))
+	}
+
+	s.ss = server.NewServer(opts...)
 
 	log.WithFields(log.Fields{
 		"network": conf.net,
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func (s *srv) startSweep() {
+	if conf.sweepInt <= 0 {
+		return
+	}
+
+	done := make(chan struct{})
+
+	s.Lock()
+	s.sweepDone = done
+	s.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(conf.sweepInt)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case <-ticker.C:
+				s.sweepContent(time.Now())
+			}
+		}
+	}()
+}
+
+func (s *srv) stopSweep() {
+	s.Lock()
+	done := s.sweepDone
+	s.sweepDone = nil
+	s.Unlock()
+
+	if done != nil {
+		close(done)
+	}
+}
+
+// sweepContent removes content items and keys expired at given time. Sweeping
+// goes without lock so if content changes meanwhile the result is dropped and
+// expired entries are removed on the next run.
+func (s *srv) sweepContent(now time.Time) {
+	s.RLock()
+	c := s.c
+	s.RUnlock()
+
+	sc, n := c.Sweep(now)
+	if n <= 0 {
+		return
+	}
+
+	s.Lock()
+	ok := s.c == c
+	if ok {
+		s.c = sc
+	}
+	s.Unlock()
+
+	if ok {
+		log.WithField("entries", n).Info("expired content has been swept")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/pdp"
+	"github.com/infobloxopen/themis/source"
+)
+
+func (s *srv) startSync() {
+	if len(conf.sync) <= 0 {
+		return
+	}
+
+	src := source.New(conf.sync)
+	s.syncContent(src)
+
+	done := make(chan struct{})
+
+	s.Lock()
+	s.syncDone = done
+	s.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(conf.syncInt)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case <-ticker.C:
+				s.syncContent(src)
+			}
+		}
+	}()
+}
+
+func (s *srv) stopSync() {
+	s.Lock()
+	done := s.syncDone
+	s.syncDone = nil
+	s.Unlock()
+
+	if done != nil {
+		close(done)
+	}
+}
+
+// syncContent loads content from given source if it has changed and
+// replaces all current content with it. Current content stays if any file
+// of the source can't be parsed.
+func (s *srv) syncContent(src *source.Source) {
+	err := s.loadSource(src)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"source": src,
+			"err":    err,
+		}).Error("failed to sync content")
+	}
+
+	s.Lock()
+	s.syncErr = err
+	s.Unlock()
+}
+
+func (s *srv) loadSource(src *source.Source) error {
+	docs, err := src.Fetch()
+	if err != nil {
+		return err
+	}
+
+	if docs == nil {
+		return nil
+	}
+
+	items := make([]*pdp.LocalContent, len(docs))
+	for i, d := range docs {
+		log.WithField("content", d.Name).Info("parsing synced content")
+		items[i], _, err = unmarshalContent(bytes.NewReader(d.Data), nil, log.Fields{"content": d.Name})
+		if err != nil {
+			return fmt.Errorf("can't parse %q: %s", d.Name, err)
+		}
+	}
+
+	s.Lock()
+	s.c = pdp.NewLocalContentStorage(items)
+	s.Unlock()
+
+	src.Commit()
+	log.WithField("content", len(items)).Info("synced content has been applied")
+
+	return nil
+}
+
+func (s *srv) startHealth() {
+	if len(conf.health) <= 0 {
+		return
+	}
+
+	log.WithField("address", conf.health).Info("opening health check port")
+	ln, err := net.Listen("tcp", conf.health)
+	if err != nil {
+		log.WithError(err).Fatal("failed to open health check port")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		s.RLock()
+		err := s.syncErr
+		s.RUnlock()
+
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, err.Error())
+			return
+		}
+
+		io.WriteString(w, "OK")
+	})
+
+	hs := &http.Server{Handler: mux}
+
+	s.Lock()
+	s.hs = hs
+	s.Unlock()
+
+	go func() {
+		if err := hs.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Fatal("failed to start health check")
+		}
+	}()
+}
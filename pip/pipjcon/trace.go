@@ -0,0 +1,37 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infobloxopen/themis/tracing"
+)
+
+func (s *srv) startTracing() {
+	if len(conf.tracing) <= 0 {
+		return
+	}
+
+	log.WithField("endpoint", conf.tracing).Info("starting tracing")
+	t, err := tracing.NewOTLPTracer("PIP", conf.tracing)
+	if err != nil {
+		log.WithError(err).Warning("failed to initialize tracing")
+		return
+	}
+
+	s.Lock()
+	s.tracer = t
+	s.Unlock()
+}
+
+func (s *srv) stopTracing() {
+	s.Lock()
+	t := s.tracer
+	s.tracer = nil
+	s.Unlock()
+
+	if t != nil {
+		if err := t.Close(); err != nil {
+			log.WithError(err).Error("failed to stop tracing")
+		}
+	}
+}
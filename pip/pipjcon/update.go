@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -11,6 +12,10 @@ import (
 	"github.com/infobloxopen/themis/pdp/jcon"
 )
 
+// contentProgressKeys is a number of content keys between log records
+// on content loading progress.
+const contentProgressKeys = 1000000
+
 var (
 	errUpdateIdxOverflow = errors.New("update index overflow")
 	errNoActiveUpdate    = errors.New("no active update")
@@ -25,6 +30,7 @@ type update struct {
 
 	c *pdp.LocalContent
 	t *pdp.LocalContentStorageTransaction
+	p jcon.Progress
 }
 
 func newUpdate(id string, fromTag, toTag *uuid.UUID, t *pdp.LocalContentStorageTransaction) *update {
@@ -66,17 +72,35 @@ func (u *update) upload(r *streamReader) error {
 }
 
 func (u *update) uploadSnapshot(r *streamReader) error {
-	c, err := jcon.Unmarshal(r, u.toTag)
+	c, p, err := unmarshalContent(r, u.toTag, log.Fields{"ctn-id": u.id})
+	u.p = p
 	if err != nil {
 		r.skip()
 		return err
 	}
 
 	u.c = c
-	log.WithField("size", r.size).Info("stream has been read and parsed as snapshot")
+	log.WithFields(log.Fields{
+		"size":  r.size,
+		"items": p.Items,
+		"keys":  p.Keys}).Info("stream has been read and parsed as snapshot")
 	return nil
 }
 
+// unmarshalContent parses content within memory limit and logs progress
+// of loading with given fields.
+func unmarshalContent(r io.Reader, tag *uuid.UUID, fields log.Fields) (*pdp.LocalContent, jcon.Progress, error) {
+	return jcon.UnmarshalStream(r, tag,
+		jcon.WithMemoryLimit(conf.memLimit),
+		jcon.WithProgress(func(p jcon.Progress) {
+			log.WithFields(fields).WithFields(log.Fields{
+				"items": p.Items,
+				"keys":  p.Keys,
+				"size":  p.Size}).Info("loading content")
+		}, contentProgressKeys),
+	)
+}
+
 func (u *update) uploadDiff(r *streamReader) error {
 	d, err := jcon.UnmarshalUpdate(r, u.id, *u.fromTag, *u.toTag, u.t.Symbols())
 	if err != nil {
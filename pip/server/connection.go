@@ -14,7 +14,7 @@ func (s *Server) handle(wg *sync.WaitGroup, c connWithErrHandler, idx int) {
 	msgs := makePool(s.opts.workers+1, s.opts.maxMsgSize)
 
 	in := startReader(c, msgs, s.opts.bufSize)
-	out := startWorkers(in, s.opts.workers, s.opts.handler)
+	out := startWorkers(in, s.opts.workers, withTraceHeader(s.opts.handler, s.opts.tracer))
 	write(c, out, msgs, s.opts.bufSize, s.opts.writeInt)
 }
 
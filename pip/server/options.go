@@ -4,6 +4,8 @@ import (
 	"math"
 	"net"
 	"time"
+
+	ot "github.com/opentracing/opentracing-go"
 )
 
 // Option configures how we set up PIP server.
@@ -95,6 +97,13 @@ func WithHandler(f ServiceHandler) Option {
 	}
 }
 
+// WithTracer returns an Option which sets OpenTracing tracer. With the tracer server makes span for each request which comes with span context from client.
+func WithTracer(t ot.Tracer) Option {
+	return func(o *options) {
+		o.tracer = t
+	}
+}
+
 type options struct {
 	net        string
 	addr       string
@@ -105,6 +114,7 @@ type options struct {
 	writeInt   time.Duration
 	workers    int
 	handler    func([]byte) []byte
+	tracer     ot.Tracer
 }
 
 const (
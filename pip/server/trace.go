@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/binary"
+	"math"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// Trace header may precede information request in a message (after request
+// id). It's put by client to pass span context and starts with
+// traceHeaderMark which never matches request version. The mark is followed
+// by size of the rest of the header and a sequence of key-value pairs
+// of strings. Each string is prefixed with its size.
+const (
+	traceHeaderMark      = math.MaxUint16
+	traceHeaderSizeBytes = 2
+
+	reqIDBytes = 4
+)
+
+// withTraceHeader wraps service handler to strip trace header from messages.
+// If tracer is given the handler gets called within span which is child
+// of the span from the header. The span only measures time of the handler
+// call. ServiceHandler gets just message bytes so the handler can't see
+// the span and can't add tags to it or start child spans.
+func withTraceHeader(f ServiceHandler, t ot.Tracer) ServiceHandler {
+	return func(b []byte) []byte {
+		h, ok := stripTraceHeader(b)
+		if !ok {
+			return f(b)
+		}
+
+		b = b[:len(b)-h.size]
+		if t == nil {
+			return f(b)
+		}
+
+		sc, err := t.Extract(ot.TextMap, h.m)
+		if err != nil {
+			return f(b)
+		}
+
+		span := t.StartSpan("PIP request", ext.RPCServerOption(sc))
+		defer span.Finish()
+
+		return f(b)
+	}
+}
+
+type traceHeader struct {
+	size int
+	m    ot.TextMapCarrier
+}
+
+// stripTraceHeader removes trace header from given message moving rest
+// of the message to the place of the header. It returns the header if any.
+// Caller should cut the message by header size.
+func stripTraceHeader(b []byte) (traceHeader, bool) {
+	if len(b) < reqIDBytes+2*traceHeaderSizeBytes {
+		return traceHeader{}, false
+	}
+
+	in := b[reqIDBytes:]
+	if binary.LittleEndian.Uint16(in) != traceHeaderMark {
+		return traceHeader{}, false
+	}
+
+	size := int(binary.LittleEndian.Uint16(in[traceHeaderSizeBytes:]))
+	if len(in) < 2*traceHeaderSizeBytes+size {
+		return traceHeader{}, false
+	}
+
+	m := ot.TextMapCarrier{}
+	pairs := in[2*traceHeaderSizeBytes : 2*traceHeaderSizeBytes+size]
+	for len(pairs) > 0 {
+		k, n, ok := getTraceHeaderString(pairs)
+		if !ok {
+			break
+		}
+		pairs = pairs[n:]
+
+		v, n, ok := getTraceHeaderString(pairs)
+		if !ok {
+			break
+		}
+		pairs = pairs[n:]
+
+		m[k] = v
+	}
+
+	size += 2 * traceHeaderSizeBytes
+	copy(in, in[size:])
+
+	return traceHeader{size: size, m: m}, true
+}
+
+func getTraceHeaderString(b []byte) (string, int, bool) {
+	if len(b) < traceHeaderSizeBytes {
+		return "", 0, false
+	}
+
+	n := traceHeaderSizeBytes + int(binary.LittleEndian.Uint16(b))
+	if len(b) < n {
+		return "", 0, false
+	}
+
+	return string(b[traceHeaderSizeBytes:n]), n, true
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStripTraceHeader(t *testing.T) {
+	b := []byte{
+		1, 0, 0, 0,
+		0xff, 0xff, 12, 0,
+		3, 0, 'k', 'e', 'y',
+		5, 0, 'v', 'a', 'l', 'u', 'e',
+		1, 0, 0, 0,
+	}
+
+	h, ok := stripTraceHeader(b)
+	if !ok {
+		t.Fatalf("Expected trace header but got nothing")
+	}
+
+	if h.size != 16 {
+		t.Errorf("Expected header of 16 bytes but got %d", h.size)
+	}
+
+	if v := h.m["key"]; len(h.m) != 1 || v != "value" {
+		t.Errorf("Expected {\"key\": \"value\"} but got %#v", h.m)
+	}
+
+	e := []byte{1, 0, 0, 0, 1, 0, 0, 0}
+	if b = b[:len(b)-h.size]; !bytes.Equal(b, e) {
+		t.Errorf("Expected %#v message after header stripping but got %#v", e, b)
+	}
+
+	if _, ok := stripTraceHeader(e); ok {
+		t.Errorf("Expected no trace header in %#v", e)
+	}
+
+	e = []byte{1, 0, 0, 0, 0xff, 0xff, 12, 0, 3, 0}
+	if _, ok := stripTraceHeader(e); ok {
+		t.Errorf("Expected no trace header in truncated message %#v", e)
+	}
+}
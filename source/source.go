@@ -0,0 +1,193 @@
+// Package source implements polling of local files, directories and
+// HTTP(S) URLs for new versions of policies and content.
+package source
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Document is data of a single file or HTTP response got from a source.
+type Document struct {
+	Name string
+	Data []byte
+}
+
+// Source polls given location for changes. The location can be a path to
+// a file, a path to a directory or an HTTP(S) URL. For a directory the source
+// gets all regular files in it except hidden ones. For an URL it makes
+// conditional requests with ETag and Last-Modified values of the previous
+// response.
+type Source struct {
+	loc    string
+	client *http.Client
+
+	last version
+	next version
+}
+
+// version identifies data got from source. For an URL it keeps ETag and
+// Last-Modified headers of response along with hash of its body (in case
+// server ignores conditional headers). For a path it keeps names, sizes and
+// modification times of files.
+type version struct {
+	etag     string
+	modified string
+	data     string
+}
+
+// New creates a Source for given location.
+func New(loc string) *Source {
+	s := &Source{loc: loc}
+	if isURL(loc) {
+		s.client = &http.Client{Timeout: time.Minute}
+	}
+
+	return s
+}
+
+// String returns location of the source.
+func (s *Source) String() string {
+	return s.loc
+}
+
+// Fetch returns documents from the source if they have changed since last
+// commit. It returns nil slice if nothing has changed.
+func (s *Source) Fetch() ([]Document, error) {
+	return s.fetch(s.last)
+}
+
+// Get returns documents from the source regardless of changes.
+func (s *Source) Get() ([]Document, error) {
+	return s.fetch(version{})
+}
+
+// Commit makes the source remember version of documents returned by
+// the latest Fetch or Get call. Following Fetch calls return documents only
+// if they differ from the version.
+func (s *Source) Commit() {
+	s.last = s.next
+}
+
+func (s *Source) fetch(last version) ([]Document, error) {
+	if s.client != nil {
+		return s.fetchURL(last)
+	}
+
+	return s.fetchPath(last)
+}
+
+func (s *Source) fetchURL(last version) ([]Document, error) {
+	req, err := http.NewRequest(http.MethodGet, s.loc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(last.etag) > 0 {
+		req.Header.Set("If-None-Match", last.etag)
+	}
+
+	if len(last.modified) > 0 {
+		req.Header.Set("If-Modified-Since", last.modified)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from %s: %s", s.loc, res.Status)
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	next := version{
+		etag:     res.Header.Get("ETag"),
+		modified: res.Header.Get("Last-Modified"),
+		data:     fmt.Sprintf("%x", sha256.Sum256(b)),
+	}
+	if next.data == last.data {
+		return nil, nil
+	}
+
+	s.next = next
+	return []Document{{Name: s.loc, Data: b}}, nil
+}
+
+func (s *Source) fetchPath(last version) ([]Document, error) {
+	fi, err := os.Stat(s.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{s.loc}
+	fis := []os.FileInfo{fi}
+	if fi.IsDir() {
+		names, fis, err = readDir(s.loc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sig strings.Builder
+	for i, fi := range fis {
+		fmt.Fprintf(&sig, "%s %d %d\n", names[i], fi.Size(), fi.ModTime().UnixNano())
+	}
+
+	next := version{data: sig.String()}
+	if next == last {
+		return nil, nil
+	}
+
+	docs := make([]Document, len(names))
+	for i, name := range names {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		docs[i] = Document{Name: name, Data: b}
+	}
+
+	s.next = next
+	return docs, nil
+}
+
+func readDir(dir string) ([]string, []os.FileInfo, error) {
+	all, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(all))
+	fis := make([]os.FileInfo, 0, len(all))
+	for _, fi := range all {
+		if !fi.Mode().IsRegular() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+
+		names = append(names, filepath.Join(dir, fi.Name()))
+		fis = append(fis, fi)
+	}
+
+	return names, fis, nil
+}
+
+func isURL(loc string) bool {
+	return strings.HasPrefix(loc, "http://") || strings.HasPrefix(loc, "https://")
+}
@@ -0,0 +1,143 @@
+package source
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSourceDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, filepath.Join(dir, "b.json"), "second")
+	writeTestFile(t, filepath.Join(dir, "a.json"), "first")
+	writeTestFile(t, filepath.Join(dir, ".a.json.swp"), "hidden")
+
+	s := New(dir)
+	docs, err := s.Fetch()
+	assertDocuments(t, docs, err, "first", "second")
+
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err, "first", "second")
+
+	s.Commit()
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err)
+
+	docs, err = s.Get()
+	assertDocuments(t, docs, err, "first", "second")
+
+	writeTestFile(t, filepath.Join(dir, "a.json"), "changed")
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err, "changed", "second")
+
+	s.Commit()
+	if err := os.Remove(filepath.Join(dir, "b.json")); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err, "changed")
+}
+
+func TestSourceFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "policy.yaml")
+	s := New(path)
+	if docs, err := s.Fetch(); err == nil {
+		t.Errorf("Expected error for missing file but got %d documents", len(docs))
+	}
+
+	writeTestFile(t, path, "policy")
+	docs, err := s.Fetch()
+	assertDocuments(t, docs, err, "policy")
+
+	s.Commit()
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err)
+}
+
+func TestSourceURL(t *testing.T) {
+	data := "first"
+	etag := `"1"`
+	modified := time.Now().UTC().Format(http.TimeFormat)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag && r.Header.Get("If-Modified-Since") == modified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if len(etag) > 0 {
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Last-Modified", modified)
+		w.Write([]byte(data))
+	}))
+	defer ts.Close()
+
+	s := New(ts.URL)
+	docs, err := s.Fetch()
+	assertDocuments(t, docs, err, "first")
+
+	s.Commit()
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err)
+
+	data = "second"
+	etag = `"2"`
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err, "second")
+
+	s.Commit()
+	etag = ""
+	docs, err = s.Fetch()
+	assertDocuments(t, docs, err)
+
+	s = New(ts.URL + "/missing\x7f")
+	if docs, err := s.Fetch(); err == nil {
+		t.Errorf("Expected error for invalid URL but got %d documents", len(docs))
+	}
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	// Make sure modification time differs from previous write.
+	mt := time.Now().Add(time.Duration(len(data)) * time.Second)
+	if err := os.Chtimes(path, mt, mt); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+}
+
+func assertDocuments(t *testing.T, docs []Document, err error, e ...string) {
+	if err != nil {
+		t.Errorf("Expected no error but got %s", err)
+		return
+	}
+
+	if len(docs) != len(e) {
+		t.Errorf("Expected %d documents but got %d", len(e), len(docs))
+		return
+	}
+
+	for i, d := range docs {
+		if string(d.Data) != e[i] {
+			t.Errorf("Expected %q at %d but got %q", e[i], i, d.Data)
+		}
+	}
+}
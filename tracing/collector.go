@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+)
+
+// Span is a span received by Collector.
+type Span struct {
+	Service      string
+	Name         string
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Attributes   map[string]string
+}
+
+// Collector is a local stand-in for OpenTelemetry collector. It accepts spans
+// over OTLP/gRPC and keeps them in memory. It's intended for tests
+// and debugging.
+type Collector struct {
+	ln net.Listener
+	s  *grpc.Server
+
+	lock  sync.Mutex
+	spans []Span
+}
+
+// NewCollector creates collector listening on given address (use
+// "127.0.0.1:0" to get any free port) and starts serving export requests.
+func NewCollector(addr string) (*Collector, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collector{
+		ln: ln,
+		s:  grpc.NewServer(),
+	}
+	c.s.RegisterService(&traceServiceDesc, c)
+
+	go c.s.Serve(ln)
+
+	return c, nil
+}
+
+// Addr returns address collector listens on.
+func (c *Collector) Addr() string {
+	return c.ln.Addr().String()
+}
+
+// Stop terminates collector.
+func (c *Collector) Stop() {
+	c.s.Stop()
+}
+
+// Spans returns all spans received by collector so far.
+func (c *Collector) Spans() []Span {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	out := make([]Span, len(c.spans))
+	copy(out, c.spans)
+
+	return out
+}
+
+// traceService is a server of OTLP trace service.
+type traceService interface {
+	export(req *exportRequest)
+}
+
+var traceServiceDesc = grpc.ServiceDesc{
+	ServiceName: traceServiceName,
+	HandlerType: (*traceService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: traceExportMethod,
+			Handler:    traceExportHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func traceExportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(exportRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+
+	srv.(traceService).export(req)
+	return new(exportResponse), nil
+}
+
+func (c *Collector) export(req *exportRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, rs := range req.spans {
+		service := ""
+		for _, kv := range rs.GetResource().GetAttributes() {
+			if kv.GetKey() == "service.name" {
+				service = anyValueString(kv.GetValue())
+			}
+		}
+
+		for _, ils := range rs.GetInstrumentationLibrarySpans() {
+			for _, s := range ils.GetSpans() {
+				attrs := make(map[string]string, len(s.GetAttributes()))
+				for _, kv := range s.GetAttributes() {
+					attrs[kv.GetKey()] = anyValueString(kv.GetValue())
+				}
+
+				c.spans = append(c.spans, Span{
+					Service:      service,
+					Name:         s.GetName(),
+					TraceID:      hex.EncodeToString(s.GetTraceId()),
+					SpanID:       hex.EncodeToString(s.GetSpanId()),
+					ParentSpanID: hex.EncodeToString(s.GetParentSpanId()),
+					Attributes:   attrs,
+				})
+			}
+		}
+	}
+}
+
+func anyValueString(v *commonpb.AnyValue) string {
+	switch v := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+
+	case *commonpb.AnyValue_BoolValue:
+		return fmt.Sprintf("%t", v.BoolValue)
+
+	case *commonpb.AnyValue_IntValue:
+		return fmt.Sprintf("%d", v.IntValue)
+
+	case *commonpb.AnyValue_DoubleValue:
+		return fmt.Sprintf("%g", v.DoubleValue)
+	}
+
+	return ""
+}
@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP trace service is defined by OpenTelemetry protocol. Generated gRPC
+// stubs of the service require newer gRPC than CoreDNS plugin can use so
+// the package calls the service directly with messages which marshal
+// themselves.
+const (
+	traceServiceName   = "opentelemetry.proto.collector.trace.v1.TraceService"
+	traceExportMethod  = "Export"
+	traceExportFullRPC = "/" + traceServiceName + "/" + traceExportMethod
+)
+
+// otlpClient sends spans to OTLP/gRPC receiver. It implements
+// otlptrace.Client interface.
+type otlpClient struct {
+	endpoint string
+	conn     *grpc.ClientConn
+}
+
+func (c *otlpClient) Start(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, c.endpoint, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+
+	c.conn = conn
+	return nil
+}
+
+func (c *otlpClient) Stop(ctx context.Context) error {
+	return c.conn.Close()
+}
+
+func (c *otlpClient) UploadTraces(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	return c.conn.Invoke(ctx, traceExportFullRPC, &exportRequest{spans: spans}, new(exportResponse))
+}
+
+// exportRequest is ExportTraceServiceRequest message of OTLP trace service.
+// It has single repeated field of resource spans.
+type exportRequest struct {
+	spans []*tracepb.ResourceSpans
+}
+
+func (r *exportRequest) Reset()         { r.spans = nil }
+func (r *exportRequest) String() string { return fmt.Sprintf("%d resource spans", len(r.spans)) }
+func (r *exportRequest) ProtoMessage()  {}
+
+func (r *exportRequest) Marshal() ([]byte, error) {
+	var out []byte
+	for _, rs := range r.spans {
+		b, err := proto.Marshal(rs)
+		if err != nil {
+			return nil, err
+		}
+
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendBytes(out, b)
+	}
+
+	return out, nil
+}
+
+func (r *exportRequest) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if num != 1 || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		rs := new(tracepb.ResourceSpans)
+		if err := proto.Unmarshal(v, rs); err != nil {
+			return err
+		}
+
+		r.spans = append(r.spans, rs)
+	}
+
+	return nil
+}
+
+// exportResponse is empty ExportTraceServiceResponse message of OTLP trace
+// service.
+type exportResponse struct{}
+
+func (r *exportResponse) Reset()                   {}
+func (r *exportResponse) String() string           { return "" }
+func (r *exportResponse) ProtoMessage()            {}
+func (r *exportResponse) Marshal() ([]byte, error) { return nil, nil }
+func (r *exportResponse) Unmarshal(b []byte) error { return nil }
@@ -0,0 +1,109 @@
+// Package tracing implements OpenTracing tracer which sends spans
+// to OpenTelemetry collector over OTLP/gRPC.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	ot "github.com/opentracing/opentracing-go"
+	otbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Tracer is an OpenTracing tracer backed by OpenTelemetry SDK. It can be used
+// anywhere OpenTracing tracer is expected (for example with PEP WithTracer
+// option). The tracer propagates span context in W3C Trace Context format
+// with any text map or HTTP headers carrier.
+type Tracer struct {
+	*otbridge.BridgeTracer
+
+	p *sdktrace.TracerProvider
+}
+
+// NewOTLPTracer creates tracer which sends spans of given service to
+// OpenTelemetry collector at given endpoint (host:port of OTLP/gRPC receiver).
+func NewOTLPTracer(service, endpoint string) (*Tracer, error) {
+	exp, err := otlptrace.New(context.Background(), &otlpClient{endpoint: endpoint})
+	if err != nil {
+		return nil, err
+	}
+
+	p := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(service))),
+	)
+
+	b, _ := otbridge.NewTracerPair(p.Tracer("github.com/infobloxopen/themis/tracing"))
+	b.SetTextMapPropagator(propagation.TraceContext{})
+
+	return &Tracer{
+		BridgeTracer: b,
+		p:            p,
+	}, nil
+}
+
+// Close sends all finished spans to collector and stops the tracer.
+func (t *Tracer) Close() error {
+	return t.p.Shutdown(context.Background())
+}
+
+// Inject implements opentracing.Tracer interface. In addition to
+// opentracing.HTTPHeadersCarrier supported by OpenTelemetry bridge it accepts
+// any opentracing.TextMapWriter for opentracing.HTTPHeaders
+// and opentracing.TextMap formats.
+func (t *Tracer) Inject(sc ot.SpanContext, format interface{}, carrier interface{}) error {
+	if _, ok := carrier.(ot.HTTPHeadersCarrier); ok || !isTextFormat(format) {
+		return t.BridgeTracer.Inject(sc, format, carrier)
+	}
+
+	w, ok := carrier.(ot.TextMapWriter)
+	if !ok {
+		return ot.ErrInvalidCarrier
+	}
+
+	h := http.Header{}
+	if err := t.BridgeTracer.Inject(sc, ot.HTTPHeaders, ot.HTTPHeadersCarrier(h)); err != nil {
+		return err
+	}
+
+	for k, v := range h {
+		if len(v) > 0 {
+			w.Set(k, v[0])
+		}
+	}
+
+	return nil
+}
+
+// Extract implements opentracing.Tracer interface. Similarly to Inject it
+// accepts any opentracing.TextMapReader for text formats.
+func (t *Tracer) Extract(format interface{}, carrier interface{}) (ot.SpanContext, error) {
+	if _, ok := carrier.(ot.HTTPHeadersCarrier); ok || !isTextFormat(format) {
+		return t.BridgeTracer.Extract(format, carrier)
+	}
+
+	r, ok := carrier.(ot.TextMapReader)
+	if !ok {
+		return nil, ot.ErrInvalidCarrier
+	}
+
+	h := http.Header{}
+	if err := r.ForeachKey(func(k, v string) error {
+		h.Set(k, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return t.BridgeTracer.Extract(ot.HTTPHeaders, ot.HTTPHeadersCarrier(h))
+}
+
+func isTextFormat(format interface{}) bool {
+	f, ok := format.(ot.BuiltinFormat)
+	return ok && (f == ot.HTTPHeaders || f == ot.TextMap)
+}
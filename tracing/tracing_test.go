@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"testing"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+func TestOTLPTracer(t *testing.T) {
+	c, err := NewCollector("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	defer c.Stop()
+
+	tr, err := NewOTLPTracer("test", c.Addr())
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	parent := tr.StartSpan("parent")
+
+	carrier := ot.TextMapCarrier{}
+	if err := tr.Inject(parent.Context(), ot.TextMap, carrier); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	if _, ok := carrier["Traceparent"]; !ok {
+		t.Errorf("Expected traceparent in carrier but got %#v", carrier)
+	}
+
+	sc, err := tr.Extract(ot.TextMap, carrier)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	child := tr.StartSpan("child", ext.RPCServerOption(sc))
+	child.SetTag("path", "test")
+	child.Finish()
+	parent.Finish()
+
+	if err := tr.Close(); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+
+	spans := c.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans but got %#v", spans)
+	}
+
+	s, p := spans[0], spans[1]
+	if s.Name != "child" || p.Name != "parent" {
+		t.Fatalf("Expected \"child\" and \"parent\" spans but got %q and %q", s.Name, p.Name)
+	}
+
+	if s.Service != "test" {
+		t.Errorf("Expected \"test\" service but got %q", s.Service)
+	}
+
+	if s.TraceID != p.TraceID || s.ParentSpanID != p.SpanID {
+		t.Errorf("Expected %q as child of %q but got %#v", s.Name, p.Name, s)
+	}
+
+	if v := s.Attributes["path"]; v != "test" {
+		t.Errorf("Expected \"test\" path attribute but got %q", v)
+	}
+}